@@ -1,6 +1,7 @@
 // Code generated for package assets by go-bindata DO NOT EDIT. (@generated)
 // sources:
 // templates/cloudformation/iam_user_osdCcsAdmin.json
+// templates/cloudformation/rosa_network.json
 package assets
 
 import (
@@ -83,6 +84,1404 @@ func templatesCloudformationIam_user_osdccsadminJson() (*asset, error) {
 	return a, nil
 }
 
+var _templatesCloudformationRosa_networkJson = []byte(`{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Description": "Network for Red Hat OpenShift Service on AWS clusters, created by 'rosa create network'",
+  "Parameters": {
+    "Name": {
+      "Type": "String",
+      "Description": "Name used as prefix of the names of the resources"
+    },
+    "VpcCidr": {
+      "Type": "String",
+      "Default": "10.0.0.0/16",
+      "Description": "CIDR block of the VPC"
+    },
+    "SubnetCidrBits": {
+      "Type": "Number",
+      "Default": "13",
+      "Description": "Number of host bits of the CIDR blocks of the subnets"
+    },
+    "AvailabilityZoneCount": {
+      "Type": "Number",
+      "Default": "1",
+      "AllowedValues": [
+        "1",
+        "2",
+        "3"
+      ],
+      "Description": "Number of availability zones with subnets"
+    },
+    "Private": {
+      "Type": "String",
+      "Default": "false",
+      "AllowedValues": [
+        "true",
+        "false"
+      ],
+      "Description": "Create only private subnets, without internet gateway and NAT gateways"
+    },
+    "PrivateLinkEndpoints": {
+      "Type": "String",
+      "Default": "false",
+      "AllowedValues": [
+        "true",
+        "false"
+      ],
+      "Description": "Create VPC endpoints for the AWS services used by the clusters"
+    }
+  },
+  "Conditions": {
+    "Public": {
+      "Fn::Equals": [
+        {
+          "Ref": "Private"
+        },
+        "false"
+      ]
+    },
+    "HasAZ2": {
+      "Fn::Not": [
+        {
+          "Fn::Equals": [
+            {
+              "Ref": "AvailabilityZoneCount"
+            },
+            "1"
+          ]
+        }
+      ]
+    },
+    "HasAZ3": {
+      "Fn::Equals": [
+        {
+          "Ref": "AvailabilityZoneCount"
+        },
+        "3"
+      ]
+    },
+    "PublicAZ2": {
+      "Fn::And": [
+        {
+          "Condition": "Public"
+        },
+        {
+          "Condition": "HasAZ2"
+        }
+      ]
+    },
+    "PublicAZ3": {
+      "Fn::And": [
+        {
+          "Condition": "Public"
+        },
+        {
+          "Condition": "HasAZ3"
+        }
+      ]
+    },
+    "Endpoints": {
+      "Fn::Equals": [
+        {
+          "Ref": "PrivateLinkEndpoints"
+        },
+        "true"
+      ]
+    }
+  },
+  "Resources": {
+    "VPC": {
+      "Type": "AWS::EC2::VPC",
+      "Properties": {
+        "CidrBlock": {
+          "Ref": "VpcCidr"
+        },
+        "EnableDnsSupport": true,
+        "EnableDnsHostnames": true,
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "vpc"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "InternetGateway": {
+      "Type": "AWS::EC2::InternetGateway",
+      "Condition": "Public",
+      "Properties": {
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "igw"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "InternetGatewayAttachment": {
+      "Type": "AWS::EC2::VPCGatewayAttachment",
+      "Condition": "Public",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "InternetGatewayId": {
+          "Ref": "InternetGateway"
+        }
+      }
+    },
+    "PublicRouteTable": {
+      "Type": "AWS::EC2::RouteTable",
+      "Condition": "Public",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "public"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PublicRoute": {
+      "Type": "AWS::EC2::Route",
+      "Condition": "Public",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "GatewayId": {
+          "Ref": "InternetGateway"
+        }
+      }
+    },
+    "PrivateSubnet1": {
+      "Type": "AWS::EC2::Subnet",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            0,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetCidrBits"
+                }
+              ]
+            }
+          ]
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            0,
+            {
+              "Fn::GetAZs": ""
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "private",
+                  {
+                    "Fn::Select": [
+                      0,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/internal-elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PrivateRouteTable1": {
+      "Type": "AWS::EC2::RouteTable",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "private",
+                  {
+                    "Fn::Select": [
+                      0,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateSubnetRouteTableAssociation1": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PrivateSubnet1"
+        },
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable1"
+        }
+      }
+    },
+    "PublicSubnet1": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "Public",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            3,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetCidrBits"
+                }
+              ]
+            }
+          ]
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            0,
+            {
+              "Fn::GetAZs": ""
+            }
+          ]
+        },
+        "MapPublicIpOnLaunch": true,
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "public",
+                  {
+                    "Fn::Select": [
+                      0,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PublicSubnetRouteTableAssociation1": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "Public",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PublicSubnet1"
+        },
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        }
+      }
+    },
+    "NatGatewayEIP1": {
+      "Type": "AWS::EC2::EIP",
+      "Condition": "Public",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "Domain": "vpc",
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "nat",
+                  {
+                    "Fn::Select": [
+                      0,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "NatGateway1": {
+      "Type": "AWS::EC2::NatGateway",
+      "Condition": "Public",
+      "Properties": {
+        "AllocationId": {
+          "Fn::GetAtt": [
+            "NatGatewayEIP1",
+            "AllocationId"
+          ]
+        },
+        "SubnetId": {
+          "Ref": "PublicSubnet1"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "nat",
+                  {
+                    "Fn::Select": [
+                      0,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateRoute1": {
+      "Type": "AWS::EC2::Route",
+      "Condition": "Public",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable1"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "NatGatewayId": {
+          "Ref": "NatGateway1"
+        }
+      }
+    },
+    "PrivateSubnet2": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "HasAZ2",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            1,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetCidrBits"
+                }
+              ]
+            }
+          ]
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            1,
+            {
+              "Fn::GetAZs": ""
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "private",
+                  {
+                    "Fn::Select": [
+                      1,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/internal-elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PrivateRouteTable2": {
+      "Type": "AWS::EC2::RouteTable",
+      "Condition": "HasAZ2",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "private",
+                  {
+                    "Fn::Select": [
+                      1,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateSubnetRouteTableAssociation2": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "HasAZ2",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PrivateSubnet2"
+        },
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable2"
+        }
+      }
+    },
+    "PublicSubnet2": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "PublicAZ2",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            4,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetCidrBits"
+                }
+              ]
+            }
+          ]
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            1,
+            {
+              "Fn::GetAZs": ""
+            }
+          ]
+        },
+        "MapPublicIpOnLaunch": true,
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "public",
+                  {
+                    "Fn::Select": [
+                      1,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PublicSubnetRouteTableAssociation2": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "PublicAZ2",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PublicSubnet2"
+        },
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        }
+      }
+    },
+    "NatGatewayEIP2": {
+      "Type": "AWS::EC2::EIP",
+      "Condition": "PublicAZ2",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "Domain": "vpc",
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "nat",
+                  {
+                    "Fn::Select": [
+                      1,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "NatGateway2": {
+      "Type": "AWS::EC2::NatGateway",
+      "Condition": "PublicAZ2",
+      "Properties": {
+        "AllocationId": {
+          "Fn::GetAtt": [
+            "NatGatewayEIP2",
+            "AllocationId"
+          ]
+        },
+        "SubnetId": {
+          "Ref": "PublicSubnet2"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "nat",
+                  {
+                    "Fn::Select": [
+                      1,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateRoute2": {
+      "Type": "AWS::EC2::Route",
+      "Condition": "PublicAZ2",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable2"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "NatGatewayId": {
+          "Ref": "NatGateway2"
+        }
+      }
+    },
+    "PrivateSubnet3": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "HasAZ3",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            2,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetCidrBits"
+                }
+              ]
+            }
+          ]
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            2,
+            {
+              "Fn::GetAZs": ""
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "private",
+                  {
+                    "Fn::Select": [
+                      2,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/internal-elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PrivateRouteTable3": {
+      "Type": "AWS::EC2::RouteTable",
+      "Condition": "HasAZ3",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "private",
+                  {
+                    "Fn::Select": [
+                      2,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateSubnetRouteTableAssociation3": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "HasAZ3",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PrivateSubnet3"
+        },
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable3"
+        }
+      }
+    },
+    "PublicSubnet3": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "PublicAZ3",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            5,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetCidrBits"
+                }
+              ]
+            }
+          ]
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            2,
+            {
+              "Fn::GetAZs": ""
+            }
+          ]
+        },
+        "MapPublicIpOnLaunch": true,
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "public",
+                  {
+                    "Fn::Select": [
+                      2,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PublicSubnetRouteTableAssociation3": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "PublicAZ3",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PublicSubnet3"
+        },
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        }
+      }
+    },
+    "NatGatewayEIP3": {
+      "Type": "AWS::EC2::EIP",
+      "Condition": "PublicAZ3",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "Domain": "vpc",
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "nat",
+                  {
+                    "Fn::Select": [
+                      2,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "NatGateway3": {
+      "Type": "AWS::EC2::NatGateway",
+      "Condition": "PublicAZ3",
+      "Properties": {
+        "AllocationId": {
+          "Fn::GetAtt": [
+            "NatGatewayEIP3",
+            "AllocationId"
+          ]
+        },
+        "SubnetId": {
+          "Ref": "PublicSubnet3"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "nat",
+                  {
+                    "Fn::Select": [
+                      2,
+                      {
+                        "Fn::GetAZs": ""
+                      }
+                    ]
+                  }
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateRoute3": {
+      "Type": "AWS::EC2::Route",
+      "Condition": "PublicAZ3",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable3"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "NatGatewayId": {
+          "Ref": "NatGateway3"
+        }
+      }
+    },
+    "EndpointSecurityGroup": {
+      "Type": "AWS::EC2::SecurityGroup",
+      "Condition": "Endpoints",
+      "Properties": {
+        "GroupDescription": "Allows HTTPS from the VPC to the VPC endpoints",
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "SecurityGroupIngress": [
+          {
+            "IpProtocol": "tcp",
+            "FromPort": 443,
+            "ToPort": 443,
+            "CidrIp": {
+              "Ref": "VpcCidr"
+            }
+          }
+        ],
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "Name"
+                  },
+                  "endpoints"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "S3Endpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Condition": "Endpoints",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "VpcEndpointType": "Gateway",
+        "ServiceName": {
+          "Fn::Sub": "com.amazonaws.${AWS::Region}.s3"
+        },
+        "RouteTableIds": [
+          {
+            "Ref": "PrivateRouteTable1"
+          },
+          {
+            "Fn::If": [
+              "HasAZ2",
+              {
+                "Ref": "PrivateRouteTable2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAZ3",
+              {
+                "Ref": "PrivateRouteTable3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ]
+      }
+    },
+    "STSEndpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Condition": "Endpoints",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "VpcEndpointType": "Interface",
+        "ServiceName": {
+          "Fn::Sub": "com.amazonaws.${AWS::Region}.sts"
+        },
+        "PrivateDnsEnabled": true,
+        "SubnetIds": [
+          {
+            "Ref": "PrivateSubnet1"
+          },
+          {
+            "Fn::If": [
+              "HasAZ2",
+              {
+                "Ref": "PrivateSubnet2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAZ3",
+              {
+                "Ref": "PrivateSubnet3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ],
+        "SecurityGroupIds": [
+          {
+            "Ref": "EndpointSecurityGroup"
+          }
+        ]
+      }
+    },
+    "EC2Endpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Condition": "Endpoints",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "VpcEndpointType": "Interface",
+        "ServiceName": {
+          "Fn::Sub": "com.amazonaws.${AWS::Region}.ec2"
+        },
+        "PrivateDnsEnabled": true,
+        "SubnetIds": [
+          {
+            "Ref": "PrivateSubnet1"
+          },
+          {
+            "Fn::If": [
+              "HasAZ2",
+              {
+                "Ref": "PrivateSubnet2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAZ3",
+              {
+                "Ref": "PrivateSubnet3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ],
+        "SecurityGroupIds": [
+          {
+            "Ref": "EndpointSecurityGroup"
+          }
+        ]
+      }
+    },
+    "ElasticLoadBalancingEndpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Condition": "Endpoints",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "VpcEndpointType": "Interface",
+        "ServiceName": {
+          "Fn::Sub": "com.amazonaws.${AWS::Region}.elasticloadbalancing"
+        },
+        "PrivateDnsEnabled": true,
+        "SubnetIds": [
+          {
+            "Ref": "PrivateSubnet1"
+          },
+          {
+            "Fn::If": [
+              "HasAZ2",
+              {
+                "Ref": "PrivateSubnet2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAZ3",
+              {
+                "Ref": "PrivateSubnet3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ],
+        "SecurityGroupIds": [
+          {
+            "Ref": "EndpointSecurityGroup"
+          }
+        ]
+      }
+    },
+    "ECRAPIEndpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Condition": "Endpoints",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "VpcEndpointType": "Interface",
+        "ServiceName": {
+          "Fn::Sub": "com.amazonaws.${AWS::Region}.ecr.api"
+        },
+        "PrivateDnsEnabled": true,
+        "SubnetIds": [
+          {
+            "Ref": "PrivateSubnet1"
+          },
+          {
+            "Fn::If": [
+              "HasAZ2",
+              {
+                "Ref": "PrivateSubnet2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAZ3",
+              {
+                "Ref": "PrivateSubnet3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ],
+        "SecurityGroupIds": [
+          {
+            "Ref": "EndpointSecurityGroup"
+          }
+        ]
+      }
+    },
+    "ECRDockerEndpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Condition": "Endpoints",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "VpcEndpointType": "Interface",
+        "ServiceName": {
+          "Fn::Sub": "com.amazonaws.${AWS::Region}.ecr.dkr"
+        },
+        "PrivateDnsEnabled": true,
+        "SubnetIds": [
+          {
+            "Ref": "PrivateSubnet1"
+          },
+          {
+            "Fn::If": [
+              "HasAZ2",
+              {
+                "Ref": "PrivateSubnet2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAZ3",
+              {
+                "Ref": "PrivateSubnet3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ],
+        "SecurityGroupIds": [
+          {
+            "Ref": "EndpointSecurityGroup"
+          }
+        ]
+      }
+    }
+  },
+  "Outputs": {
+    "VpcId": {
+      "Description": "Identifier of the VPC",
+      "Value": {
+        "Ref": "VPC"
+      }
+    },
+    "PrivateSubnetIds": {
+      "Description": "Identifiers of the private subnets",
+      "Value": {
+        "Fn::Join": [
+          ",",
+          [
+            {
+              "Ref": "PrivateSubnet1"
+            },
+            {
+              "Fn::If": [
+                "HasAZ2",
+                {
+                  "Ref": "PrivateSubnet2"
+                },
+                {
+                  "Ref": "AWS::NoValue"
+                }
+              ]
+            },
+            {
+              "Fn::If": [
+                "HasAZ3",
+                {
+                  "Ref": "PrivateSubnet3"
+                },
+                {
+                  "Ref": "AWS::NoValue"
+                }
+              ]
+            }
+          ]
+        ]
+      }
+    },
+    "PublicSubnetIds": {
+      "Description": "Identifiers of the public subnets",
+      "Condition": "Public",
+      "Value": {
+        "Fn::Join": [
+          ",",
+          [
+            {
+              "Ref": "PublicSubnet1"
+            },
+            {
+              "Fn::If": [
+                "HasAZ2",
+                {
+                  "Ref": "PublicSubnet2"
+                },
+                {
+                  "Ref": "AWS::NoValue"
+                }
+              ]
+            },
+            {
+              "Fn::If": [
+                "HasAZ3",
+                {
+                  "Ref": "PublicSubnet3"
+                },
+                {
+                  "Ref": "AWS::NoValue"
+                }
+              ]
+            }
+          ]
+        ]
+      }
+    }
+  }
+}
+`)
+
+func templatesCloudformationRosa_networkJsonBytes() ([]byte, error) {
+	return _templatesCloudformationRosa_networkJson, nil
+}
+
+func templatesCloudformationRosa_networkJson() (*asset, error) {
+	bytes, err := templatesCloudformationRosa_networkJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cloudformation/rosa_network.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -136,6 +1535,7 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"templates/cloudformation/iam_user_osdCcsAdmin.json": templatesCloudformationIam_user_osdccsadminJson,
+	"templates/cloudformation/rosa_network.json":         templatesCloudformationRosa_networkJson,
 }
 
 // AssetDir returns the file names below a certain
@@ -182,6 +1582,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
 		"cloudformation": &bintree{nil, map[string]*bintree{
 			"iam_user_osdCcsAdmin.json": &bintree{templatesCloudformationIam_user_osdccsadminJson, map[string]*bintree{}},
+			"rosa_network.json":         &bintree{templatesCloudformationRosa_networkJson, map[string]*bintree{}},
 		}},
 	}},
 }}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/apply"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "apply"
+	short = "Apply a configuration of cluster resources"
+	long  = "Create, update and delete the machine pools, identity providers, ingresses and kubelet " +
+		"configs of a cluster so that they match the given file. The changes are shown before they " +
+		"are applied."
+	example = `  # Apply the resources in the file to cluster "mycluster"
+  rosa apply -f cluster-resources.yaml --cluster=mycluster
+
+  # Also delete the resources of the cluster that are not in the file
+  rosa apply -f cluster-resources.yaml --prune`
+
+	fileFlag  = "file"
+	pruneFlag = "prune"
+)
+
+type ApplyOptions struct {
+	File  string
+	Prune bool
+}
+
+func NewApplyCommand() *cobra.Command {
+	options := &ApplyOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), ApplyRunner(options)),
+		Args:    cobra.NoArgs,
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVarP(
+		&options.File,
+		fileFlag,
+		"f",
+		"",
+		"Path to a YAML or JSON file with the desired cluster resources.",
+	)
+	cmd.MarkFlagRequired(fileFlag)
+	flags.BoolVar(
+		&options.Prune,
+		pruneFlag,
+		false,
+		"Delete the resources of the cluster that are not in the file. Only the kinds of resources "+
+			"listed in the file are affected.",
+	)
+	ocm.AddOptionalClusterFlag(cmd)
+	confirm.AddFlag(flags)
+	return cmd
+}
+
+func ApplyRunner(options *ApplyOptions) rosa.CommandRunner {
+	return func(ctx context.Context, r *rosa.Runtime, command *cobra.Command, _ []string) error {
+		resources, err := apply.Load(options.File)
+		if err != nil {
+			return err
+		}
+
+		// The cluster given on the command line takes precedence over the one in the file
+		if resources.Cluster != "" && !command.Flags().Changed("cluster") {
+			ocm.SetClusterKey(resources.Cluster)
+		}
+		clusterKey := r.GetClusterKey()
+		cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+		if err != nil {
+			return err
+		}
+		if cluster.State() != cmv1.ClusterStateReady {
+			return fmt.Errorf("Cluster '%s' is not yet ready. Current state is '%s'", clusterKey, cluster.State())
+		}
+
+		plan, err := apply.BuildPlan(ctx, apply.NewResourceKinds(r.OCMClient, cluster), resources, options.Prune)
+		if err != nil {
+			return err
+		}
+		if plan.Empty() {
+			r.Reporter.Infof("Cluster '%s' already matches the resources in '%s'", clusterKey, options.File)
+			return nil
+		}
+
+		r.Reporter.Infof("The following changes will be applied to cluster '%s':", clusterKey)
+		fmt.Print(plan)
+		if !cluster.Hypershift().Enabled() && plan.HasKind(apply.KubeletConfigKind) {
+			r.Reporter.Warnf("Changing the KubeletConfig of a classic cluster causes the worker nodes to reboot")
+		}
+		if !confirm.Prompt(false, "Apply the changes to cluster '%s'?", clusterKey) {
+			return nil
+		}
+
+		err = plan.Execute(ctx, r.Reporter)
+		if err != nil {
+			return err
+		}
+		r.Reporter.Infof("Successfully applied the resources in '%s' to cluster '%s'", options.File, clusterKey)
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package apply
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/testing"
+
+	. "github.com/openshift/rosa/pkg/test"
+)
+
+const resourcesFile = `
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterResources
+cluster: cluster
+machinePools:
+- id: workers
+  replicas: 3
+`
+
+var _ = Describe("rosa apply", func() {
+
+	It("Correctly builds the command", func() {
+		cmd := NewApplyCommand()
+		Expect(cmd).NotTo(BeNil())
+
+		Expect(cmd.Use).To(Equal(use))
+		Expect(cmd.Short).To(Equal(short))
+		Expect(cmd.Long).To(Equal(long))
+		Expect(cmd.Example).To(Equal(example))
+		Expect(cmd.Args).NotTo(BeNil())
+		Expect(cmd.Run).NotTo(BeNil())
+
+		Expect(cmd.Flags().Lookup(fileFlag)).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup(pruneFlag)).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("cluster")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("yes")).NotTo(BeNil())
+	})
+
+	Context("Apply Runner", func() {
+		var t *TestingRuntime
+		var file string
+
+		BeforeEach(func() {
+			t = NewTestRuntime()
+			file = filepath.Join(GinkgoT().TempDir(), "resources.yaml")
+			Expect(os.WriteFile(file, []byte(resourcesFile), 0600)).To(Succeed())
+		})
+
+		It("Returns an error if the cluster is not ready", func() {
+			cluster := MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.State(cmv1.ClusterStateInstalling)
+			})
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, FormatClusterList([]*cmv1.Cluster{cluster})))
+
+			cmd := NewApplyCommand()
+			runner := ApplyRunner(&ApplyOptions{File: file})
+			err := runner(context.Background(), t.RosaRuntime, cmd, nil)
+			Expect(err).To(MatchError("Cluster 'cluster' is not yet ready. Current state is 'installing'"))
+		})
+
+		It("Updates the machine pools that differ from the file", func() {
+			cluster := MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.State(cmv1.ClusterStateReady)
+			})
+			machinePool, err := cmv1.NewMachinePool().ID("workers").Replicas(2).Build()
+			Expect(err).NotTo(HaveOccurred())
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, FormatClusterList([]*cmv1.Cluster{cluster})))
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, FormatMachinePoolList([]*cmv1.MachinePool{machinePool})))
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, FormatResource(machinePool)))
+
+			cmd := NewApplyCommand()
+			Expect(cmd.Flags().Set("yes", "true")).To(Succeed())
+			DeferCleanup(cmd.Flags().Set, "yes", "false")
+			runner := ApplyRunner(&ApplyOptions{File: file})
+			Expect(t.StdOutReader.Record()).To(Succeed())
+			err = runner(context.Background(), t.RosaRuntime, cmd, nil)
+			out, readErr := t.StdOutReader.Read()
+			Expect(readErr).NotTo(HaveOccurred())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("~ machinepool/workers (replicas)"))
+			Expect(out).To(ContainSubstring("Plan: 0 to create, 1 to update, 0 to delete."))
+
+			requests := t.ApiServer.ReceivedRequests()
+			Expect(requests).To(HaveLen(3))
+			Expect(requests[2].Method).To(Equal(http.MethodPatch))
+			Expect(requests[2].URL.Path).To(Equal(MockClusterHREF + "/machine_pools/workers"))
+		})
+	})
+})
@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clear
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use     = "clear"
+	short   = "Clear the cache of OCM reference data"
+	long    = "Remove the cached OCM reference data, or only the data of the given kind."
+	example = `  # Remove all the cached data
+  rosa cache clear
+
+  # Remove only the cached versions
+  rosa cache clear --kind=versions`
+
+	kindFlag = "kind"
+)
+
+// Kinds are the kinds of cached data that can be cleared.
+var Kinds = []string{
+	cache.VersionsKind,
+	cache.RegionsKind,
+	cache.MachineTypesKind,
+	cache.GatesKind,
+	cache.PoliciesKind,
+}
+
+type ClearOptions struct {
+	Kind string
+}
+
+func NewClearCommand() *cobra.Command {
+	options := &ClearOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), ClearRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(
+		&options.Kind,
+		kindFlag,
+		"",
+		fmt.Sprintf("Kind of data to remove, one of '%s'.", strings.Join(Kinds, "', '")),
+	)
+	cmd.RegisterFlagCompletionFunc(kindFlag, func(*cobra.Command, []string, string) ([]string,
+		cobra.ShellCompDirective) {
+		return Kinds, cobra.ShellCompDirectiveDefault
+	})
+	return cmd
+}
+
+func ClearRunner(options *ClearOptions) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		if options.Kind == "" {
+			err := cache.Clear()
+			if err != nil {
+				return err
+			}
+			r.Reporter.Infof("Cache cleared")
+			return nil
+		}
+
+		if !slices.Contains(Kinds, options.Kind) {
+			return fmt.Errorf("Unknown kind '%s', expected one of '%s'", options.Kind, strings.Join(Kinds, "', '"))
+		}
+		service, err := cache.NewRosaCacheService()
+		if err != nil {
+			return err
+		}
+		var keys []string
+		for key := range service.Items() {
+			if strings.HasPrefix(key, options.Kind+":") {
+				keys = append(keys, key)
+			}
+		}
+		err = service.Delete(keys...)
+		if err != nil {
+			return err
+		}
+		r.Reporter.Infof("Removed %d cached entries of kind '%s'", len(keys), options.Kind)
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/cache/clear"
+	"github.com/openshift/rosa/cmd/cache/info"
+)
+
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache of OCM reference data",
+		Long: "Manage the cache of the OCM reference data, like versions, regions, machine types, version " +
+			"gates and policies, that is kept on disk to avoid requesting it repeatedly. Use the " +
+			"'--no-cache' option of any command to ignore it.",
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(clear.NewClearCommand())
+	cmd.AddCommand(info.NewInfoCommand())
+	return cmd
+}
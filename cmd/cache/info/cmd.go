@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package info
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "info"
+	short = "Show the cache of OCM reference data"
+	long  = "Show the location of the cache file and the cached OCM reference data, with the time when " +
+		"it expires. Expired data that has an ETag or Last-Modified validator is kept, so that it can be " +
+		"revalidated with OCM instead of downloaded again."
+)
+
+var Writer io.Writer = os.Stdout
+
+func NewInfoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Args:  cobra.NoArgs,
+		Run:   rosa.DefaultRunner(rosa.DefaultRuntime(), InfoRunner()),
+	}
+}
+
+func InfoRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		path, err := cache.Path()
+		if err != nil {
+			return err
+		}
+		service, err := cache.NewRosaCacheService()
+		if err != nil {
+			return err
+		}
+		return PrintInfo(Writer, path, service.Items())
+	}
+}
+
+// PrintInfo writes the location of the cache file and a table of the cached items.
+func PrintInfo(writer io.Writer, path string, items map[string]cache.Item) error {
+	fmt.Fprintf(writer, "Location: %s\n", path)
+	if len(items) == 0 {
+		fmt.Fprintf(writer, "The cache is empty\n")
+		return nil
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(writer)
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "KIND\tKEY\tSIZE\tSTORED\tEXPIRES\tREVALIDATION\n")
+	for _, key := range keys {
+		item := items[key]
+		typed, ok := item.Object.(cache.TypedItem)
+		if !ok {
+			// Items saved by the version check of the rosa command itself:
+			fmt.Fprintf(table, "%s\t\t\t\t%s\t\n", key, formatTime(item.Expiration))
+			continue
+		}
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			typed.Kind,
+			strings.TrimPrefix(key, typed.Kind+":"),
+			len(typed.Data),
+			formatTime(typed.Stored),
+			formatTime(typed.Expires),
+			revalidation(typed.Validator),
+		)
+	}
+	return table.Flush()
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Local().Format(time.RFC3339)
+}
+
+func revalidation(validator cache.Validator) string {
+	switch {
+	case validator.ETag != "":
+		return "etag"
+	case validator.LastModified != "":
+		return "last-modified"
+	}
+	return "none"
+}
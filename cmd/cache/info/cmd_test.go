@@ -0,0 +1,40 @@
+package info
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/cache"
+)
+
+var _ = Describe("Cache info", func() {
+	It("prints an empty cache", func() {
+		var buffer bytes.Buffer
+		Expect(PrintInfo(&buffer, "/tmp/ocm-cache.gob", nil)).To(Succeed())
+		Expect(buffer.String()).To(Equal("Location: /tmp/ocm-cache.gob\nThe cache is empty\n"))
+	})
+
+	It("prints the cached items", func() {
+		stored := time.Now()
+		items := map[string]cache.Item{
+			"versions:https://api.openshift.com|stable": {
+				Object: cache.TypedItem{
+					Kind:      cache.VersionsKind,
+					Data:      []byte("[]"),
+					Validator: cache.Validator{ETag: `"v1"`},
+					Stored:    stored,
+					Expires:   stored.Add(cache.VersionsTTL),
+				},
+			},
+			cache.VersionCacheKey: {Object: []string{"1.2.38"}},
+		}
+		var buffer bytes.Buffer
+		Expect(PrintInfo(&buffer, "/tmp/ocm-cache.gob", items)).To(Succeed())
+		Expect(buffer.String()).To(MatchRegexp(`KIND\s+KEY\s+SIZE\s+STORED\s+EXPIRES\s+REVALIDATION`))
+		Expect(buffer.String()).To(MatchRegexp(`versions\s+https://api.openshift.com\|stable\s+2\s+\S+\s+\S+\s+etag`))
+		Expect(buffer.String()).To(ContainSubstring(cache.VersionCacheKey))
+	})
+})
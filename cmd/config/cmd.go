@@ -22,8 +22,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/config/deletecontext"
 	"github.com/openshift/rosa/cmd/config/get"
+	"github.com/openshift/rosa/cmd/config/getcontexts"
 	"github.com/openshift/rosa/cmd/config/set"
+	"github.com/openshift/rosa/cmd/config/setcontext"
+	"github.com/openshift/rosa/cmd/config/usecontext"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/properties"
 )
@@ -54,6 +58,12 @@ following backends are supported for the keyring:
 - Windows: wincred
 
 Available Keyrings on your OS: %s
+
+The configuration can contain named contexts, each with its own URL, credentials, FedRAMP setting and
+default AWS profile and region, like the contexts of a kubeconfig file. Contexts are created with
+"rosa config set-context", selected with "rosa config use-context" or with the '--context' option of
+any command, and listed with "rosa config get-contexts". The variables above are those of the selected
+context, if any.
 `, loc, strings.Join(config.ConfigVarDocs(), "\n"), properties.KeyringEnvKey, strings.Join(config.GetKeyrings(), ", "))
 }
 
@@ -66,6 +76,10 @@ func NewConfigCommand() *cobra.Command {
 	}
 	Cmd.AddCommand(get.Cmd)
 	Cmd.AddCommand(set.Cmd)
+	Cmd.AddCommand(getcontexts.Cmd)
+	Cmd.AddCommand(usecontext.Cmd)
+	Cmd.AddCommand(setcontext.Cmd)
+	Cmd.AddCommand(deletecontext.Cmd)
 	return Cmd
 }
 
@@ -11,7 +11,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/openshift/rosa/cmd/config/get"
+	"github.com/openshift/rosa/cmd/config/getcontexts"
 	"github.com/openshift/rosa/cmd/config/set"
+	"github.com/openshift/rosa/cmd/config/setcontext"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/test"
 )
@@ -151,6 +153,41 @@ var _ = Describe("Run Command", Ordered, func() {
 		})
 	})
 
+	When("Contexts are used", Ordered, func() {
+		BeforeAll(func() {
+			buf = new(bytes.Buffer)
+			getcontexts.Writer = buf
+			tmpdir, err = os.MkdirTemp("/tmp", ".ocm-config-*")
+			Expect(err).To(BeNil())
+			os.Setenv("OCM_CONFIG", tmpdir+"/ocm_config.json")
+		})
+
+		AfterAll(func() {
+			os.Setenv("OCM_CONFIG", "")
+		})
+
+		It("Sets and prints contexts", func() {
+			cmd := setcontext.NewConfigSetContextCommand()
+			Expect(cmd.ParseFlags([]string{"--url=staging", "--aws-profile=orgA", "--aws-region=us-east-1"})).
+				To(Succeed())
+			Expect(setcontext.SetContext(cmd, "stage-orgA")).To(Succeed())
+			Expect(config.UseContext("stage-orgA")).To(Succeed())
+
+			cmd = setcontext.NewConfigSetContextCommand()
+			Expect(cmd.ParseFlags([]string{"--aws-region=us-west-2"})).To(Succeed())
+			Expect(setcontext.SetContext(cmd, "stage-orgA")).To(Succeed())
+
+			_, context, err := config.CurrentContext()
+			Expect(err).To(BeNil())
+			Expect(context.URL).To(Equal("https://api.stage.openshift.com"))
+			Expect(context.AWSProfile).To(Equal("orgA"))
+			Expect(context.AWSRegion).To(Equal("us-west-2"))
+
+			Expect(getcontexts.PrintContexts()).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`\*\s+stage-orgA\s+https://api.stage.openshift.com\s+orgA\s+us-west-2`))
+		})
+	})
+
 	When("Config file doesn't exist", func() {
 		AfterEach(func() {
 			os.Setenv("OCM_CONFIG", "")
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletecontext
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = NewConfigDeleteContextCommand()
+
+func NewConfigDeleteContextCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete-context NAME",
+		Short: "Deletes a context and its credentials",
+		Long:  "Deletes a context of the configuration, including its credentials.",
+		Args:  cobra.ExactArgs(1),
+		Run:   run,
+	}
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+
+	err := config.DeleteContext(argv[0])
+	if err != nil {
+		r.Reporter.Errorf(fmt.Sprintf("Can't delete context: %v", err))
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	r.Reporter.Infof("Deleted context '%s'", argv[0])
+}
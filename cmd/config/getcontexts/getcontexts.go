@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getcontexts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var (
+	Writer io.Writer = os.Stdout
+)
+
+var Cmd = NewConfigGetContextsCommand()
+
+func NewConfigGetContextsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "Lists the contexts of the configuration",
+		Long: "Lists the contexts of the configuration. The current context, or the one selected " +
+			"with the '--context' option, is marked with an asterisk.",
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	err := PrintContexts()
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+}
+
+func PrintContexts() error {
+	contexts, current, err := config.LoadContexts()
+	if err != nil {
+		return fmt.Errorf("can't load config: %v", err)
+	}
+
+	writer := tabwriter.NewWriter(Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CURRENT\tNAME\tURL\tAWS PROFILE\tAWS REGION\tFEDRAMP\tCREDENTIALS\n")
+	for _, name := range config.ContextNames(contexts) {
+		context := contexts[name]
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		credentials := "file"
+		if context.Keyring != "" {
+			credentials = fmt.Sprintf("keyring (%s)", context.Keyring)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%v\t%s\n", marker, name, context.URL, context.AWSProfile,
+			context.AWSRegion, context.FedRAMP, credentials)
+	}
+	return writer.Flush()
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -47,7 +48,7 @@ func run(_ *cobra.Command, argv []string) {
 	err := SaveConfig(argv[0], argv[1])
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
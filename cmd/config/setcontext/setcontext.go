@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package setcontext
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift-online/ocm-sdk-go/authentication/securestore"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/fedramp"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	urlFlag        = "url"
+	awsProfileFlag = "aws-profile"
+	awsRegionFlag  = "aws-region"
+	fedrampFlag    = "fedramp"
+	keyringFlag    = "keyring"
+)
+
+var args struct {
+	url        string
+	awsProfile string
+	awsRegion  string
+	fedramp    bool
+	keyring    string
+}
+
+var Cmd = NewConfigSetContextCommand()
+
+func NewConfigSetContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-context NAME",
+		Short: "Creates a context or changes its settings",
+		Long: "Creates a context of the configuration, or changes the settings of an existing one. " +
+			"Log in with the '--context' option to store the credentials of the context.",
+		Example: `  # Create a context for an organization in the staging environment
+  rosa config set-context stage-orgA --url=staging --aws-profile=orgA --aws-region=us-east-1
+
+  # Log in to the new context and make it the current one
+  rosa login --context=stage-orgA --token=...
+  rosa config use-context stage-orgA
+
+  # Store the credentials of a context in the OS keyring
+  rosa config set-context prod-orgB --url=production --keyring=secret-service`,
+		Args: cobra.ExactArgs(1),
+		Run:  run,
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&args.url,
+		urlFlag,
+		"",
+		fmt.Sprintf("URL of the API gateway. The value can also be an alias: %v.", ocm.ValidOCMUrlAliases()),
+	)
+	flags.StringVar(
+		&args.awsProfile,
+		awsProfileFlag,
+		"",
+		"AWS profile used by default with this context.",
+	)
+	flags.StringVar(
+		&args.awsRegion,
+		awsRegionFlag,
+		"",
+		"AWS region used by default with this context.",
+	)
+	flags.BoolVar(
+		&args.fedramp,
+		fedrampFlag,
+		false,
+		"Indicates that the context is a FedRAMP environment.",
+	)
+	flags.StringVar(
+		&args.keyring,
+		keyringFlag,
+		"",
+		fmt.Sprintf("OS keyring where the credentials of the context are stored, instead of the "+
+			"configuration file. Available keyrings: %v.", config.GetKeyrings()),
+	)
+	return cmd
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+
+	err := SetContext(cmd, argv[0])
+	if err != nil {
+		r.Reporter.Errorf(fmt.Sprintf("Can't set context: %v", err))
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	r.Reporter.Infof("Context '%s' saved", argv[0])
+}
+
+func SetContext(cmd *cobra.Command, name string) error {
+	flags := cmd.Flags()
+	if flags.Changed(keyringFlag) && args.keyring != "" {
+		err := securestore.ValidateBackend(args.keyring)
+		if err != nil {
+			return err
+		}
+	}
+	return config.SetContext(name, func(context *config.NamedContext) {
+		if flags.Changed(fedrampFlag) {
+			context.FedRAMP = args.fedramp
+		}
+		if flags.Changed(urlFlag) {
+			urlAliases := ocm.URLAliases
+			if context.FedRAMP {
+				urlAliases = fedramp.URLAliases
+			}
+			context.URL = args.url
+			if url, ok := urlAliases[args.url]; ok {
+				context.URL = url
+			}
+		}
+		if flags.Changed(awsProfileFlag) {
+			context.AWSProfile = args.awsProfile
+		}
+		if flags.Changed(awsRegionFlag) {
+			context.AWSRegion = args.awsRegion
+		}
+		if flags.Changed(keyringFlag) {
+			context.Keyring = args.keyring
+		}
+	})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usecontext
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = NewConfigUseContextCommand()
+
+func NewConfigUseContextCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context NAME",
+		Short: "Sets the current context",
+		Long: "Sets the context used by the commands that don't have a '--context' option and " +
+			"aren't run with the ROSA_CONTEXT environment variable.",
+		Example: `  # Use the credentials and AWS settings of the "stage-orgA" context
+  rosa config use-context stage-orgA`,
+		Args: cobra.ExactArgs(1),
+		Run:  run,
+	}
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+
+	err := config.UseContext(argv[0])
+	if err != nil {
+		r.Reporter.Errorf(fmt.Sprintf("Can't use context: %v", err))
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	r.Reporter.Infof("Switched to context '%s'", argv[0])
+}
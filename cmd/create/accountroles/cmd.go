@@ -26,9 +26,12 @@ import (
 	"github.com/openshift/rosa/cmd/verify/oc"
 	"github.com/openshift/rosa/cmd/verify/quota"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/iac"
+	"github.com/openshift/rosa/pkg/drift"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -53,7 +56,10 @@ var Cmd = &cobra.Command{
   rosa create account-roles
 
   # Create account roles with a specific permissions boundary
-  rosa create account-roles --permissions-boundary arn:aws:iam::123456789012:policy/perm-boundary`,
+  rosa create account-roles --permissions-boundary arn:aws:iam::123456789012:policy/perm-boundary
+
+  # Output a Terraform module that creates the account roles, instead of 'aws' commands
+  rosa create account-roles --mode manual --format terraform --prefix myprefix --hosted-cp > account-roles.tf`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -134,6 +140,7 @@ func init() {
 	)
 
 	interactive.AddModeFlag(Cmd)
+	iac.AddFormatFlag(Cmd)
 
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
@@ -145,7 +152,12 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	format, mode, err := iac.GetFormat(cmd, mode)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// If necessary, call `login` as part of `init`. We do this before
@@ -154,7 +166,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = login.Call(cmd, argv, r.Reporter)
 	if err != nil {
 		r.Reporter.Errorf("Failed to login to OCM: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.WithOCM()
 	defer r.Cleanup()
@@ -162,7 +174,7 @@ func run(cmd *cobra.Command, argv []string) {
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	managedPolicies := args.managed
@@ -213,7 +225,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		r.OCMClient.LogEvent("ROSAInitCredentialsFailed", nil)
 		r.Reporter.Errorf("Error validating AWS credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if !ok {
 		r.OCMClient.LogEvent("ROSAInitCredentialsInvalid", nil)
@@ -244,7 +256,7 @@ func run(cmd *cobra.Command, argv []string) {
 	policyVersion, err := r.OCMClient.GetPolicyVersion(version, channelGroup)
 	if err != nil {
 		r.Reporter.Errorf("Error getting version: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	r.Reporter.Debugf("Creating account roles compatible with OpenShift versions up to %s", policyVersion)
@@ -263,7 +275,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
@@ -291,7 +303,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -299,7 +311,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -315,7 +327,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -329,7 +341,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -341,7 +353,7 @@ func run(cmd *cobra.Command, argv []string) {
 	policies, err := r.OCMClient.GetPolicies("AccountRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	createClassic := args.classic
@@ -356,7 +368,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		isClassicValueSet = true
 	}
@@ -371,7 +383,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		isHostedCPValueSet = true
 	}
@@ -385,6 +397,13 @@ func run(cmd *cobra.Command, argv []string) {
 	input := buildRolesCreationInput(prefix, permissionsBoundary, r.Creator.AccountID, env, policies,
 		policyVersion, path)
 
+	preflight := &drift.Preflight{
+		AWSClient: r.AWSClient,
+		Partition: r.Creator.Partition,
+		Policies:  policies,
+	}
+	preflight.Warn(r.Reporter, permissionsBoundary, rolesCreator.getPolicyKeys())
+
 	switch mode {
 	case interactive.ModeAuto:
 		err = rolesCreator.createRoles(r, input)
@@ -408,6 +427,24 @@ func run(cmd *cobra.Command, argv []string) {
 			ocm.Version:  policyVersion,
 		})
 	case interactive.ModeManual:
+		if format != "" {
+			template := iac.NewTemplate("ROSA account roles and policies")
+			err = rolesCreator.buildTemplate(r, input, template)
+			if err == nil {
+				err = template.Write(os.Stdout, format)
+			}
+			if err != nil {
+				r.Reporter.Errorf("There was an error generating the %s output: %s", format, err)
+				r.OCMClient.LogEvent("ROSACreateAccountRolesModeManual", map[string]string{
+					ocm.Response: ocm.Failure,
+				})
+				os.Exit(reporter.Code(err).ExitCode())
+			}
+			r.OCMClient.LogEvent("ROSACreateAccountRolesModeManual", map[string]string{
+				ocm.Version: policyVersion,
+			})
+			return
+		}
 		err = aws.GenerateAccountRolePolicyFiles(r.Reporter, env, policies, rolesCreator.skipPermissionFiles(),
 			rolesCreator.getAccountRolesMap(), r.Creator.Partition)
 		if err != nil {
@@ -415,12 +452,12 @@ func run(cmd *cobra.Command, argv []string) {
 			r.OCMClient.LogEvent("ROSACreateAccountRolesModeManual", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		err = rolesCreator.printCommands(r, input)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
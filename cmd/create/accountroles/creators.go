@@ -8,6 +8,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/iac"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -16,8 +17,10 @@ type creator interface {
 	createRoles(*rosa.Runtime, *accountRolesCreationInput) error
 	getRoleTags(string, *accountRolesCreationInput) map[string]string
 	printCommands(*rosa.Runtime, *accountRolesCreationInput) error
+	buildTemplate(*rosa.Runtime, *accountRolesCreationInput, *iac.Template) error
 	skipPermissionFiles() bool
 	getAccountRolesMap() map[string]aws.AccountRole
+	getPolicyKeys() []string
 }
 
 func initCreator(r *rosa.Runtime, managedPolicies bool, classic bool, hostedCP bool, isClassicValueSet bool,
@@ -34,9 +37,11 @@ func initCreator(r *rosa.Runtime, managedPolicies bool, classic bool, hostedCP b
 
 	// If the user didn't select topologies (default flow creates both), or selected both topologies
 	if !isClassicValueSet && !isHostedCPValueSet || hostedCP && classic {
-		r.Reporter.Infof("By default, the create account-roles command creates two sets of account roles, " +
-			"one for classic ROSA clusters, and one for Hosted Control Plane clusters." +
-			"\nIn order to create a single set, please set one of the following flags: --classic or --hosted-cp")
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("By default, the create account-roles command creates two sets of account roles, " +
+				"one for classic ROSA clusters, and one for Hosted Control Plane clusters." +
+				"\nIn order to create a single set, please set one of the following flags: --classic or --hosted-cp")
+		}
 		return &doubleRolesCreator{}, true
 	}
 
@@ -150,6 +155,26 @@ func (mp *managedPoliciesCreator) printCommands(r *rosa.Runtime, input *accountR
 	return nil
 }
 
+func (mp *managedPoliciesCreator) buildTemplate(r *rosa.Runtime, input *accountRolesCreationInput,
+	template *iac.Template) error {
+	for file, role := range aws.AccountRoles {
+		accRole := buildTemplateRole(r, common.GetRoleName(input.prefix, role.Name), file,
+			mp.getRoleTags(file, input), input)
+
+		policyKeys := aws.GetAccountRolePolicyKeys(file)
+		for _, policyKey := range policyKeys {
+			policyARN, err := aws.GetManagedPolicyARN(input.policies, policyKey)
+			if err != nil {
+				return err
+			}
+			accRole.PolicyARNs = append(accRole.PolicyARNs, policyARN)
+		}
+		template.AddRole(accRole)
+	}
+
+	return nil
+}
+
 func (mp *managedPoliciesCreator) getRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
 	tagsList := getBaseRoleTags(roleType, input)
 	tagsList[common.ManagedPolicies] = tags.True
@@ -165,6 +190,14 @@ func (mp *managedPoliciesCreator) getAccountRolesMap() map[string]aws.AccountRol
 	return aws.AccountRoles
 }
 
+func (mp *managedPoliciesCreator) getPolicyKeys() []string {
+	keys := []string{}
+	for file := range aws.AccountRoles {
+		keys = append(keys, aws.GetAccountRolePolicyKeys(file)...)
+	}
+	return keys
+}
+
 type unmanagedPoliciesCreator struct{}
 
 func (up *unmanagedPoliciesCreator) createRoles(r *rosa.Runtime, input *accountRolesCreationInput) error {
@@ -211,6 +244,29 @@ func (up *unmanagedPoliciesCreator) printCommands(r *rosa.Runtime, input *accoun
 	return nil
 }
 
+func (up *unmanagedPoliciesCreator) buildTemplate(r *rosa.Runtime, input *accountRolesCreationInput,
+	template *iac.Template) error {
+	for file, role := range aws.AccountRoles {
+		accRoleName := common.GetRoleName(input.prefix, role.Name)
+		iamTags := up.getRoleTags(file, input)
+
+		policyName := aws.GetPolicyName(accRoleName)
+		template.AddPolicy(&iac.Policy{
+			Name: policyName,
+			Path: input.path,
+			Document: iac.Document(aws.GetPolicyDetails(input.policies,
+				fmt.Sprintf("sts_%s_permission_policy", file))),
+			Tags: iamTags,
+		})
+
+		accRole := buildTemplateRole(r, accRoleName, file, iamTags, input)
+		accRole.Policies = []string{policyName}
+		template.AddRole(accRole)
+	}
+
+	return nil
+}
+
 func (up *unmanagedPoliciesCreator) getRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
 	return getBaseRoleTags(roleType, input)
 }
@@ -223,6 +279,14 @@ func (up *unmanagedPoliciesCreator) getAccountRolesMap() map[string]aws.AccountR
 	return aws.AccountRoles
 }
 
+func (up *unmanagedPoliciesCreator) getPolicyKeys() []string {
+	keys := []string{}
+	for file := range aws.AccountRoles {
+		keys = append(keys, fmt.Sprintf("sts_%s_permission_policy", file))
+	}
+	return keys
+}
+
 type doubleRolesCreator struct{}
 
 func (db *doubleRolesCreator) createRoles(r *rosa.Runtime, input *accountRolesCreationInput) error {
@@ -251,6 +315,18 @@ func (db *doubleRolesCreator) printCommands(r *rosa.Runtime, input *accountRoles
 	return hcpCreator.printCommands(r, input)
 }
 
+func (db *doubleRolesCreator) buildTemplate(r *rosa.Runtime, input *accountRolesCreationInput,
+	template *iac.Template) error {
+	unmanagedCreator := unmanagedPoliciesCreator{}
+	err := unmanagedCreator.buildTemplate(r, input, template)
+	if err != nil {
+		return err
+	}
+
+	hcpCreator := hcpManagedPoliciesCreator{}
+	return hcpCreator.buildTemplate(r, input, template)
+}
+
 // getRoleTags is not needed, but here to satisfy the interface
 func (db *doubleRolesCreator) getRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
 	return nil
@@ -264,6 +340,12 @@ func (db *doubleRolesCreator) getAccountRolesMap() map[string]aws.AccountRole {
 	return aws.AccountRoles
 }
 
+func (db *doubleRolesCreator) getPolicyKeys() []string {
+	unmanagedCreator := unmanagedPoliciesCreator{}
+	hcpCreator := hcpManagedPoliciesCreator{}
+	return append(unmanagedCreator.getPolicyKeys(), hcpCreator.getPolicyKeys()...)
+}
+
 func createRoleUnmanagedPolicy(r *rosa.Runtime, input *accountRolesCreationInput, accRoleName string,
 	assumeRolePolicy string, tagsList map[string]string, filename string) error {
 	r.Reporter.Debugf("Creating role '%s'", accRoleName)
@@ -361,6 +443,24 @@ func (hcp *hcpManagedPoliciesCreator) printCommands(r *rosa.Runtime, input *acco
 	return nil
 }
 
+func (hcp *hcpManagedPoliciesCreator) buildTemplate(r *rosa.Runtime, input *accountRolesCreationInput,
+	template *iac.Template) error {
+	for file, role := range aws.HCPAccountRoles {
+		accRole := buildTemplateRole(r, common.GetRoleName(input.prefix, role.Name), file,
+			hcp.getRoleTags(file, input), input)
+
+		policyKey := fmt.Sprintf("sts_hcp_%s_permission_policy", file)
+		policyARN, err := aws.GetManagedPolicyARN(input.policies, policyKey)
+		if err != nil {
+			return err
+		}
+		accRole.PolicyARNs = []string{policyARN}
+		template.AddRole(accRole)
+	}
+
+	return nil
+}
+
 func (hcp *hcpManagedPoliciesCreator) getRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
 	tagsList := getBaseRoleTags(roleType, input)
 	tagsList[common.ManagedPolicies] = tags.True
@@ -377,6 +477,14 @@ func (hcp *hcpManagedPoliciesCreator) getAccountRolesMap() map[string]aws.Accoun
 	return aws.HCPAccountRoles
 }
 
+func (hcp *hcpManagedPoliciesCreator) getPolicyKeys() []string {
+	keys := []string{}
+	for file := range aws.HCPAccountRoles {
+		keys = append(keys, fmt.Sprintf("sts_hcp_%s_permission_policy", file))
+	}
+	return keys
+}
+
 func getBaseRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
 	return map[string]string{
 		common.OpenShiftVersion: input.defaultPolicyVersion,
@@ -398,6 +506,17 @@ func buildCreateRoleCommand(accRoleName string, file string, iamTags map[string]
 		Build()
 }
 
+func buildTemplateRole(r *rosa.Runtime, accRoleName string, file string, iamTags map[string]string,
+	input *accountRolesCreationInput) *iac.Role {
+	return &iac.Role{
+		Name:                accRoleName,
+		Path:                input.path,
+		PermissionsBoundary: input.permissionsBoundary,
+		AssumeRolePolicy:    iac.Document(getAssumeRolePolicy(r.Creator.Partition, file, input)),
+		Tags:                iamTags,
+	}
+}
+
 func buildCreatePolicyCommand(policyName string, policyDocument string, iamTags map[string]string, path string) string {
 	return awscb.NewIAMCommandBuilder().
 		SetCommand(awscb.CreatePolicy).
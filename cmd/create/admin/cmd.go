@@ -28,6 +28,7 @@ import (
 	"github.com/openshift/rosa/pkg/object"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -113,7 +114,7 @@ func run(_ *cobra.Command, _ []string) {
 	err = passwordValidator.PasswordValidator(password)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Add admin user to the cluster-admins group:
@@ -128,13 +129,13 @@ func run(_ *cobra.Command, _ []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed to add user '%s' to cluster '%s': %s",
 			ClusterAdminUsername, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	existingIdp, err := FindClusterAdminIDP(cluster, r)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if existingIdp == nil {
 		// No ClusterAdmin IDP exists, create an Htpasswd IDP
@@ -197,7 +198,7 @@ func run(_ *cobra.Command, _ []string) {
 		err = output.Print(outputObject)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		return
 	}
@@ -248,7 +249,7 @@ func FindIDPWithAdmin(cluster *cmv1.Cluster, r *rosa.Runtime) (
 			r.Reporter.Debugf("user list %s: %v", item.Name(), itemUserList)
 			if err != nil {
 				r.Reporter.Errorf("Failed to get user list of the HTPasswd IDP of '%s: %s': %v", item.Name(), r.ClusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			if HasClusterAdmin(itemUserList) {
 				return item, itemUserList, nil
@@ -25,6 +25,7 @@ import (
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -83,7 +84,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed getting autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if autoscaler != nil {
@@ -103,21 +104,21 @@ func run(cmd *cobra.Command, _ []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed creating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	autoscalerConfig, err := clusterautoscaler.CreateAutoscalerConfig(autoscalerArgs)
 	if err != nil {
 		r.Reporter.Errorf("Failed creating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	_, err = r.OCMClient.CreateClusterAutoscaler(cluster.ID(), autoscalerConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed creating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	r.Reporter.Infof("Successfully created autoscaler configuration for cluster '%s'", cluster.ID())
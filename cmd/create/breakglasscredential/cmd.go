@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/rosa/pkg/externalauthprovider"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -42,7 +43,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
@@ -48,6 +48,8 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
+	"github.com/openshift/rosa/pkg/clusterspec"
+	"github.com/openshift/rosa/pkg/drift"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
@@ -97,6 +99,8 @@ var args struct {
 
 	// Simulate creating a cluster
 	dryRun bool
+	// Load options from a cluster spec file
+	fromFile string
 	// Create a fake cluster with no AWS resources
 	fakeCluster bool
 	// Set custom properties in cluster spec
@@ -237,7 +241,10 @@ func makeCmd() *cobra.Command {
   rosa create cluster --cluster-name=mycluster
 
   # Create a cluster in the us-east-2 region
-  rosa create cluster --cluster-name=mycluster --region=us-east-2`,
+  rosa create cluster --cluster-name=mycluster --region=us-east-2
+
+  # Create a cluster from a spec file, overriding the name given in the file
+  rosa create cluster --from-file=cluster.yaml --cluster-name=mycluster`,
 		Run:  run,
 		Args: cobra.NoArgs,
 	}
@@ -480,14 +487,18 @@ func initFlags(cmd *cobra.Command) {
 		"kms-key-arn",
 		"",
 		"The key ARN is the Amazon Resource Name (ARN) of a CMK. It is a unique, "+
-			"fully qualified identifier for the CMK. A key ARN includes the AWS account, Region, and the key ID.")
+			"fully qualified identifier for the CMK. A key ARN includes the AWS account, Region, and the key ID. "+
+			"The key policy has to allow the roles of the cluster to use the key, the missing statements are "+
+			"added with '--mode auto'.")
 
 	flags.StringVar(&args.etcdEncryptionKmsARN,
 		"etcd-encryption-kms-arn",
 		"",
 		"The etcd encryption kms key ARN is the key used to encrypt etcd. "+
 			"If set it will override etcd-encryption flag to true. It is a unique, "+
-			"fully qualified identifier for the CMK. A key ARN includes the AWS account, Region, and the key ID.")
+			"fully qualified identifier for the CMK. A key ARN includes the AWS account, Region, and the key ID. "+
+			"The key policy has to allow the roles of the cluster to use the key, the missing statements are "+
+			"added with '--mode auto'.")
 
 	flags.StringVar(
 		&args.expirationTime,
@@ -671,6 +682,14 @@ func initFlags(cmd *cobra.Command) {
 		"Simulate creating the cluster.",
 	)
 
+	flags.StringVar(
+		&args.fromFile,
+		clusterspec.FromFileFlag,
+		"",
+		"Path to a YAML or JSON cluster spec file with the options used to create the cluster. "+
+			"Options given on the command line take precedence over the values in the file.",
+	)
+
 	flags.BoolVar(
 		&args.fakeCluster,
 		"fake-cluster",
@@ -854,6 +873,16 @@ func networkTypeCompletion(cmd *cobra.Command, args []string, toComplete string)
 }
 
 func run(cmd *cobra.Command, _ []string) {
+	// The spec file is validated before connecting to any service, so that mistakes in the
+	// file are reported without requiring credentials
+	if args.fromFile != "" {
+		err := clusterspec.ApplyFile(args.fromFile, cmd.Flags())
+		if err != nil {
+			reporter.CreateReporter().Errorf("Failed to load cluster spec '%s': %v", args.fromFile, err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
@@ -861,7 +890,7 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	for _, val := range userSpecifiedAutoscalerValues {
@@ -889,7 +918,7 @@ func run(cmd *cobra.Command, _ []string) {
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		r.Reporter.Errorf("Unable to get IAM credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	shardPinningEnabled := false
@@ -939,7 +968,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid cluster name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -968,7 +997,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid domain prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1003,7 +1032,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --hosted-cp value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1011,7 +1040,7 @@ func run(cmd *cobra.Command, _ []string) {
 		techPreviewMsg, err := r.OCMClient.GetTechnologyPreviewMessage(ocm.HcpProduct, time.Now())
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if techPreviewMsg != "" {
 			r.Reporter.Infof(techPreviewMsg)
@@ -1039,13 +1068,13 @@ func run(cmd *cobra.Command, _ []string) {
 		err = passwordValidator.PasswordValidator(clusterAdminPassword)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if clusterAdminUser != "" {
 			err = idp.UsernameValidator(clusterAdminUser)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		} else {
 			clusterAdminUser = admin.ClusterAdminUsername
@@ -1061,7 +1090,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if isClusterAdmin {
 			//clusterAdminUser = idp.GetIdpUserNameFromPrompt(cmd, r, "cluster-admin-user", clusterAdminUser, true)
@@ -1072,7 +1101,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			if !isCustomAdminPassword {
 				clusterAdminPassword, err = idputils.GenerateRandomPassword()
@@ -1100,7 +1129,7 @@ func run(cmd *cobra.Command, _ []string) {
 		isHcpBillingTechPreview, err := r.OCMClient.IsTechnologyPreview(ocm.HcpBillingAccount, time.Now())
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if !isHcpBillingTechPreview {
@@ -1114,7 +1143,7 @@ func run(cmd *cobra.Command, _ []string) {
 			cloudAccounts, err := r.OCMClient.GetBillingAccounts()
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 
 			billingAccounts := ocm.GenerateBillingAccountsList(cloudAccounts)
@@ -1144,7 +1173,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 					if err != nil {
 						r.Reporter.Errorf("Expected a valid billing account: '%s'", err)
-						os.Exit(1)
+						os.Exit(reporter.Code(err).ExitCode())
 					}
 
 					billingAccount = aws.ParseOption(billingAccount)
@@ -1153,7 +1182,7 @@ func run(cmd *cobra.Command, _ []string) {
 				err := validateBillingAccount(billingAccount)
 				if err != nil {
 					r.Reporter.Errorf("%v", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 
 				// Get contract info
@@ -1217,7 +1246,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --sts value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		isIAM = !isSTS
 	}
@@ -1235,7 +1264,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1265,7 +1294,7 @@ func run(cmd *cobra.Command, _ []string) {
 	defaultVersion, versionList, err := versions.GetVersionList(r, channelGroup, isSTS, isHostedCP, isHostedCP, true)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if version == "" {
 		version = defaultVersion
@@ -1280,13 +1309,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OpenShift version: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	version, err = r.OCMClient.ValidateVersion(version, versionList, channelGroup, isSTS, isHostedCP)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid OpenShift version: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if err := r.OCMClient.IsVersionCloseToEol(ocm.CloseToEolDays, version, channelGroup); err != nil {
 		r.Reporter.Warnf("%v", err)
@@ -1309,16 +1338,16 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid http tokens value : %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if err = ocm.ValidateHttpTokensValue(httpTokens); err != nil {
 		r.Reporter.Errorf("Expected a valid http tokens value : %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if err := ocm.ValidateHttpTokensVersion(ocm.GetVersionMinor(version), httpTokens); err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// warn if mode is used for non sts cluster
@@ -1364,7 +1393,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		if err != nil {
 			r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if len(roleARNs) > 1 {
@@ -1403,7 +1432,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid role ARN: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 			}
 		} else if len(roleARNs) == 1 {
@@ -1427,7 +1456,7 @@ func run(cmd *cobra.Command, _ []string) {
 			hostedCPPolicies, err := awsClient.HasHostedCPPolicies(roleARN)
 			if err != nil {
 				r.Reporter.Errorf("Failed to determine if cluster has hosted CP policies: %v", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			hasRoles = true
 			for roleType, role := range aws.AccountRoles {
@@ -1446,7 +1475,7 @@ func run(cmd *cobra.Command, _ []string) {
 				}
 				if err != nil {
 					r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				selectedARN := ""
 				expectedResourceIDForAccRole, rolePrefix, err := getExpectedResourceIDForAccRole(
@@ -1465,7 +1494,7 @@ func run(cmd *cobra.Command, _ []string) {
 					resourceId, err := aws.GetResourceIdFromARN(rARN)
 					if err != nil {
 						r.Reporter.Errorf("Failed to get resource ID from arn. %s", err)
-						os.Exit(1)
+						os.Exit(reporter.Code(err).ExitCode())
 					}
 					lowerCaseResourceIdToCheck := strings.ToLower(resourceId)
 					if lowerCaseResourceIdToCheck == expectedResourceIDForAccRole {
@@ -1514,7 +1543,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1522,7 +1551,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(roleARN)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		isSTS = true
 	}
@@ -1543,7 +1572,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid External ID: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1563,18 +1592,18 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if supportRoleARN != "" {
 		err = aws.ARNValidator(supportRoleARN)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Support Role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	} else if roleARN != "" {
 		r.Reporter.Errorf("Support Role ARN is required: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Instance IAM Roles
@@ -1595,18 +1624,18 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid control plane IAM role ARN: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		if controlPlaneRoleARN != "" {
 			err = aws.ARNValidator(controlPlaneRoleARN)
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid control plane instance IAM role ARN: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		} else if roleARN != "" {
 			r.Reporter.Errorf("Control plane instance IAM role ARN is required: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1627,18 +1656,18 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid worker IAM role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if workerRoleARN != "" {
 		err = aws.ARNValidator(workerRoleARN)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid worker instance IAM role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	} else if roleARN != "" {
 		r.Reporter.Errorf("Worker instance IAM role ARN is required: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// combine role arns to list
@@ -1652,32 +1681,32 @@ func run(cmd *cobra.Command, _ []string) {
 	managedPolicies, err := awsClient.HasManagedPolicies(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	// check if role has hosted cp policy via AWS tag value
 	hostedCPPolicies, err := awsClient.HasHostedCPPolicies(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has hosted CP policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if managedPolicies {
 		rolePrefix, err := getAccountRolePrefix(hostedCPPolicies, roleARN, aws.InstallerAccountRole)
 		if err != nil {
 			r.Reporter.Errorf("Failed to find prefix from account role: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		err = roles.ValidateAccountRolesManagedPolicies(r, rolePrefix, hostedCPPolicies)
 		if err != nil {
 			r.Reporter.Errorf("Failed while validating account roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	} else {
 		err = roles.ValidateUnmanagedAccountRoles(roleARNs, awsClient, version)
 		if err != nil {
 			r.Reporter.Errorf("Failed while validating account roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1703,12 +1732,12 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a prefix for the operator IAM roles: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		if len(operatorRolesPrefix) == 0 {
 			r.Reporter.Errorf("Expected a prefix for the operator IAM roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if len(operatorRolesPrefix) > 32 {
 			r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
@@ -1722,12 +1751,12 @@ func run(cmd *cobra.Command, _ []string) {
 		credRequests, err := r.OCMClient.GetAllCredRequests()
 		if err != nil {
 			r.Reporter.Errorf("Error getting operator credential request from OCM %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		operatorRoles, err = r.AWSClient.GetOperatorRolesFromAccountByPrefix(operatorRolesPrefix, credRequests)
 		if err != nil {
 			r.Reporter.Errorf("There was a problem retrieving the Operator Roles from AWS: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1736,12 +1765,12 @@ func run(cmd *cobra.Command, _ []string) {
 		credRequests, err := r.OCMClient.GetCredRequests(isHostedCP)
 		if err != nil {
 			r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		accRolesPrefix, err := getAccountRolePrefix(hostedCPPolicies, roleARN, aws.InstallerAccountRole)
 		if err != nil {
 			r.Reporter.Errorf("Failed to find prefix from account role: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if expectedOperatorRolePath != "" && !output.HasFlag() && r.Reporter.IsTerminal() {
 			r.Reporter.Infof("ARN path '%s' detected. This ARN path will be used for subsequent"+
@@ -1754,7 +1783,7 @@ func run(cmd *cobra.Command, _ []string) {
 				isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(version), operator.MinVersion())
 				if err != nil {
 					r.Reporter.Errorf("Error validating operator role '%s' version %s", operator.Name(), err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				if !isSupported {
 					continue
@@ -1795,20 +1824,20 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			if !oidcConfig.Reusable() {
 				r.Reporter.Errorf("%v", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			} else {
 				err = ocm.ValidateOperatorRolesMatchOidcProvider(r.Reporter, awsClient, computedOperatorIamRoleList,
 					oidcConfig.IssuerUrl(), ocm.GetVersionMinor(version), expectedOperatorRolePath, managedPolicies, true)
 				if err != nil {
 					r.Reporter.Errorf("%v", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 			}
 		}
 		err = validateUniqueIamRoleArnsForStsCluster(roleARNs, computedOperatorIamRoleList)
 		if err != nil {
 			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1827,7 +1856,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of tags: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if len(tagsInput) > 0 {
 			_tags = strings.Split(tagsInput, ",")
@@ -1836,7 +1865,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if len(_tags) > 0 {
 		if err := aws.UserTagValidator(_tags); err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		delim := aws.GetTagsDelimiter(_tags)
 		for _, tag := range _tags {
@@ -1855,7 +1884,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid multi-AZ value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -1873,7 +1902,7 @@ func run(cmd *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	// Filter regions by OCP version for displaying in interactive mode
 	var versionFilter string
@@ -1886,7 +1915,7 @@ func run(cmd *cobra.Command, _ []string) {
 		awsClient, isHostedCP, shardPinningEnabled)
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if region == "" {
 		r.Reporter.Errorf("Expected a valid AWS region")
@@ -1906,7 +1935,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid AWS region: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if supportsMultiAZ, found := regionAZ[region]; found {
@@ -1926,7 +1955,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create awsClient: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.AWSClient = awsClient
 
@@ -1949,7 +1978,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid private-link value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	} else if (privateLink || (isSTS && private)) && !fedramp.Enabled() && !isPrivateHostedCP {
 		// do not prompt users for privatelink if it is private hosted cluster
@@ -1976,7 +2005,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid private value: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		} else if private {
 			r.Reporter.Warnf("You are choosing to make your cluster private. %s", privateWarning)
@@ -2032,7 +2061,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2049,7 +2078,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	// Pod CIDR:
@@ -2065,7 +2094,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2088,7 +2117,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2107,7 +2136,7 @@ func run(cmd *cobra.Command, _ []string) {
 		initialSubnets, err := getInitialValidSubnets(awsClient, subnetIDs, r.Reporter)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get the list of subnets: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if subnetsProvided {
 			useExistingVPC = true
@@ -2126,7 +2155,7 @@ func run(cmd *cobra.Command, _ []string) {
 		subnets, filterError = filterCidrRangeSubnets(initialSubnets, machineNetwork, serviceNetwork, r)
 		if filterError != nil {
 			r.Reporter.Errorf("%s", filterError)
-			os.Exit(1)
+			os.Exit(reporter.Code(filterError).ExitCode())
 		}
 		if privateLink {
 			subnets = filterPrivateSubnets(subnets, r)
@@ -2210,7 +2239,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected valid subnet IDs: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			for i, subnet := range subnetIDs {
 				subnetIDs[i] = aws.ParseOption(subnet)
@@ -2229,7 +2258,7 @@ func run(cmd *cobra.Command, _ []string) {
 			}
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 
@@ -2268,19 +2297,19 @@ func run(cmd *cobra.Command, _ []string) {
 			privateHostedZoneID, err = getPrivateHostedZoneID(cmd, privateHostedZoneID)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 
 			sharedVPCRoleARN, err = getSharedVpcRoleArn(cmd, sharedVPCRoleARN)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 
 			baseDomain, err = getBaseDomain(r, cmd, baseDomain)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -2301,20 +2330,20 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for select-availability-zones: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 
 			if selectAvailabilityZones {
 				optionsAvailabilityZones, err := awsClient.DescribeAvailabilityZones()
 				if err != nil {
 					r.Reporter.Errorf("Failed to get the list of the availability zone: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 
 				availabilityZones, err = selectAvailabilityZonesInteractively(cmd, optionsAvailabilityZones, multiAZ)
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 			}
 		}
@@ -2323,7 +2352,7 @@ func run(cmd *cobra.Command, _ []string) {
 			err = validateAvailabilityZones(multiAZ, availabilityZones, awsClient)
 			if err != nil {
 				r.Reporter.Errorf(fmt.Sprintf("%s", err))
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -2343,7 +2372,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for enable-customer-managed-key: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2359,14 +2388,14 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for kms-key-arn: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
 	err = kmsArnRegexpValidator.ValidateKMSKeyARN(&kmsKeyARN)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid value for kms-key-arn: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Compute node instance type:
@@ -2375,7 +2404,7 @@ func run(cmd *cobra.Command, _ []string) {
 		awsClient)
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if computeMachineType == "" {
 		computeMachineType = defaultComputeMachineType
@@ -2389,13 +2418,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid machine type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	err = computeMachineTypeList.ValidateMachineType(computeMachineType, multiAZ)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid machine type: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	isAutoscalingSet := cmd.Flags().Changed("enable-autoscaling")
@@ -2412,7 +2441,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for enable-autoscaling: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2449,13 +2478,13 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid number of min replicas: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		err = minReplicaValidator(multiAZ, isHostedCP, privateSubnetsCount)(minReplicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if interactive.Enabled() || !isMaxReplicasSet {
@@ -2470,13 +2499,13 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid number of max replicas: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		err = maxReplicaValidator(multiAZ, minReplicas, isHostedCP, privateSubnetsCount)(maxReplicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if isHostedCP {
@@ -2489,7 +2518,7 @@ func run(cmd *cobra.Command, _ []string) {
 				cmd.Flags(), clusterAutoscalerFlagsPrefix, true, autoscalerArgs)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -2518,13 +2547,13 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid number of compute nodes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		err = minReplicaValidator(multiAZ, isHostedCP, privateSubnetsCount)(computeNodes)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2541,20 +2570,20 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	labelMap, err := mpHelpers.ParseLabels(labels)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	isVersionCompatibleComputeSgIds, err := versions.IsGreaterThanOrEqual(
 		version, ocm.MinVersionForAdditionalComputeSecurityGroupIdsDay1)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking version compatibility: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	additionalComputeSecurityGroupIds := args.additionalComputeSecurityGroupIds
 	getSecurityGroups(r, cmd, isVersionCompatibleComputeSgIds,
@@ -2575,13 +2604,13 @@ func run(cmd *cobra.Command, _ []string) {
 	expiration, err := validateExpiration()
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Network Type:
 	if err := validateNetworkType(args.networkType); err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if cmd.Flags().Changed("network-type") && interactive.Enabled() {
 		args.networkType, err = interactive.GetOption(interactive.Input{
@@ -2592,7 +2621,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid network type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2612,20 +2641,20 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid host prefix value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	err = hostPrefixValidator(hostPrefix)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	machinePoolRootDisk, err := getMachinePoolRootDisk(r, cmd, version,
 		isHostedCP, defaultMachinePoolRootDiskSize)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// No CNI
@@ -2646,7 +2675,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for no CNI: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2663,7 +2692,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid FIPS value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2687,7 +2716,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid etcd-encryption value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if fips {
@@ -2711,7 +2740,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for etcd-encryption-kms-arn: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2724,6 +2753,35 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	// Validate the KMS keys and their key policies. The statements of the roles that will only be
+	// created with the cluster are added to the key policies after creating them:
+	kmsKeyRoleList := kmsKeyRoles{}
+	if isSTS {
+		kmsKeyRoleList = kmsKeyRoles{
+			installer:     roleARN,
+			support:       supportRoleARN,
+			controlPlane:  controlPlaneRoleARN,
+			worker:        workerRoleARN,
+			operatorRoles: computedOperatorIamRoleList,
+		}
+	}
+	pendingKMSKeyGrants := map[string][]drift.KeyGrant{}
+	for _, key := range []struct{ flag, arn string }{
+		{flag: "kms-key-arn", arn: kmsKeyARN},
+		{flag: "etcd-encryption-kms-arn", arn: etcdEncryptionKmsARN},
+	} {
+		if key.arn == "" {
+			continue
+		}
+		pending, err := validateKMSKey(r.Reporter, awsClient, r.Creator.AccountID, mode, key.flag, key.arn, region,
+			kmsKeyGrants(key.flag, kmsKeyRoleList), args.dryRun)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		pendingKMSKeyGrants[key.arn] = append(pendingKMSKeyGrants[key.arn], pending...)
+	}
+
 	disableWorkloadMonitoring := args.disableWorkloadMonitoring
 	if interactive.Enabled() {
 		disableWorkloadMonitoring, err = interactive.GetBool(interactive.Input{
@@ -2733,7 +2791,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid disable-workload-monitoring value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2747,7 +2805,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid proxy-enabled value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2762,13 +2820,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid http proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	err = ocm.ValidateHTTPProxy(httpProxy)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if enableProxy && interactive.Enabled() {
@@ -2782,13 +2840,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid https proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	err = interactive.IsURL(httpsProxy)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if enableProxy && interactive.Enabled() {
@@ -2803,7 +2861,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of no proxy domains/CIDR's: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		noProxySlice = helper.HandleEmptyStringOnSlice(strings.Split(noProxyInput, ","))
 	}
@@ -2818,7 +2876,7 @@ func run(cmd *cobra.Command, _ []string) {
 			err := aws.UserNoProxyValidator(domain)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -2839,13 +2897,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid additional trust bundle file name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	err = ocm.ValidateAdditionalTrustBundle(additionalTrustBundleFile)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Get certificate contents
@@ -2854,7 +2912,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cert, err := os.ReadFile(additionalTrustBundleFile)
 		if err != nil {
 			r.Reporter.Errorf("Failed to read additional trust bundle file: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		additionalTrustBundle = new(string)
 		*additionalTrustBundle = string(cert)
@@ -2879,14 +2937,14 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for Additional Allowed Principal ARNs: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		additionalAllowedPrincipals = helper.HandleEmptyStringOnSlice(strings.Split(aapInputs, ","))
 	}
 	if len(additionalAllowedPrincipals) > 0 {
 		if err := roles.ValidateAdditionalAllowedPrincipals(additionalAllowedPrincipals); err != nil {
 			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -2906,7 +2964,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if requestAuditLogForwarding {
 
@@ -2924,7 +2982,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for audit-log-arn: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		} else {
 			auditLogRoleARN = ""
@@ -2940,7 +2998,7 @@ func run(cmd *cobra.Command, _ []string) {
 		version, ocm.MinVersionForManagedIngressV2)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking version compatibility: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if ingress.IsDefaultIngressSetViaCLI(cmd.Flags()) {
 		if isHostedCP {
@@ -2951,7 +3009,7 @@ func run(cmd *cobra.Command, _ []string) {
 			formattedVersion, err := versions.FormatMajorMinorPatch(ocm.MinVersionForManagedIngressV2)
 			if err != nil {
 				r.Reporter.Errorf(versions.MajorMinorPatchFormattedErrorOutput, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			r.Reporter.Errorf(
 				"Updating default ingress settings is not supported for versions prior to '%s'",
@@ -2991,14 +3049,14 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			routeSelector = routeSelectorArg
 		}
 		routeSelectors, err = ingress.GetRouteSelector(routeSelector)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if cmd.Flags().Changed(ingress.DefaultIngressExcludedNamespacesFlag) {
@@ -3015,7 +3073,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			excludedNamespaces = excludedNamespacesArg
 		}
@@ -3042,7 +3100,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid Wildcard Policy: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				wildcardPolicy = wildcardPolicyArg
 			}
@@ -3071,7 +3129,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid Namespace Ownership Policy: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				namespaceOwnershipPolicy = namespaceOwnershipPolicyArg
 			}
@@ -3175,7 +3233,7 @@ func run(cmd *cobra.Command, _ []string) {
 		autoscalerConfig, err := clusterautoscaler.CreateAutoscalerConfig(clusterAutoscaler)
 		if err != nil {
 			r.Reporter.Errorf("Failed creating autoscaler configuration: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		clusterConfig.AutoscalerConfig = autoscalerConfig
@@ -3207,7 +3265,7 @@ func run(cmd *cobra.Command, _ []string) {
 	clusterConfig, err = clusterConfigFor(r.Reporter, clusterConfig, awsCreator, awsClient)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if !output.HasFlag() || r.Reporter.IsTerminal() {
@@ -3223,7 +3281,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if !clusterConfig.IsSTS {
 		if err := r.OCMClient.EnsureNoPendingClusters(awsCreator); err != nil {
 			r.Reporter.Errorf("%v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -3234,7 +3292,7 @@ func run(cmd *cobra.Command, _ []string) {
 		} else {
 			r.Reporter.Errorf("Failed to create cluster: %s", err)
 		}
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if args.dryRun {
@@ -3261,6 +3319,12 @@ func run(cmd *cobra.Command, _ []string) {
 				r.Reporter.Infof("Preparing to create operator roles.")
 			}
 			operatorroles.Cmd.Run(operatorroles.Cmd, []string{clusterName, mode, permissionsBoundary})
+			for keyARN, grants := range pendingKMSKeyGrants {
+				err = addKMSKeyGrants(r.Reporter, awsClient, keyARN, grants)
+				if err != nil {
+					r.Reporter.Warnf("%v", err)
+				}
+			}
 			if !output.HasFlag() || r.Reporter.IsTerminal() {
 				r.Reporter.Infof("Preparing to create OIDC Provider.")
 			}
@@ -3282,7 +3346,7 @@ func run(cmd *cobra.Command, _ []string) {
 					r.Reporter.Debugf("Failed to verify if OIDC provider exists: %s", err)
 				} else {
 					r.Reporter.Errorf("Failed to verify if OIDC provider exists: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 			}
 			if !oidcProviderExists {
@@ -3436,7 +3500,7 @@ func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, is
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			isOidcConfig = _isOidcConfig
 		}
@@ -3461,7 +3525,7 @@ func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, is
 	oidcConfig, err := r.OCMClient.GetOidcConfig(oidcConfigId)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", oidcConfigId, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	return oidcConfig
 }
@@ -3472,7 +3536,7 @@ func filterPrivateSubnets(initialSubnets []ec2types.Subnet, r *rosa.Runtime) []e
 	publicSubnetMap, err := r.AWSClient.FetchPublicSubnetMap(initialSubnets)
 	if err != nil {
 		r.Reporter.Errorf("Unable to check if subnet have an IGW: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	for _, subnet := range initialSubnets {
 		skip := false
@@ -4078,7 +4142,7 @@ func getSecurityGroups(r *rosa.Runtime, cmd *cobra.Command, isVersionCompatibleC
 			)
 			if err != nil {
 				r.Reporter.Errorf(versions.MajorMinorPatchFormattedErrorOutput, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			r.Reporter.Errorf("Parameter '%s' is not supported prior to version '%s'",
 				securitygroups.SgKindFlagMap[kind], formattedVersion)
@@ -4115,7 +4179,7 @@ func getMachinePoolRootDisk(r *rosa.Runtime, cmd *cobra.Command, version string,
 		formattedVersion, err := versions.FormatMajorMinorPatch(ocm.MinVersionForMachinePoolRootDisk)
 		if err != nil {
 			r.Reporter.Errorf(versions.MajorMinorPatchFormattedErrorOutput, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		return nil, fmt.Errorf(
 			"Updating Worker disk size is not supported for versions prior to '%s'",
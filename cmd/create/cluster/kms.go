@@ -0,0 +1,211 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/drift"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+var (
+	kmsKeyUseActions = []string{
+		"kms:Encrypt",
+		"kms:Decrypt",
+		"kms:ReEncrypt*",
+		"kms:GenerateDataKey*",
+		"kms:DescribeKey",
+	}
+	kmsKeyUseAndGrantActions = append(append([]string{}, kmsKeyUseActions...), "kms:CreateGrant")
+	kmsKeyDescribeActions    = []string{"kms:DescribeKey"}
+)
+
+// kmsKeyOperatorActions are the actions that the operator roles, identified by the namespace and
+// name of their credentials request, need on the key of the '--kms-key-arn' and
+// '--etcd-encryption-kms-arn' flags.
+var kmsKeyOperatorActions = map[string]map[string][]string{
+	"kms-key-arn": {
+		"openshift-cluster-csi-drivers/ebs-cloud-credentials": kmsKeyUseAndGrantActions,
+		"openshift-machine-api/aws-cloud-credentials":         kmsKeyUseAndGrantActions,
+		"kube-system/capa-controller-manager":                 kmsKeyUseAndGrantActions,
+		"kube-system/control-plane-operator": {
+			"kms:CreateGrant",
+			"kms:DescribeKey",
+			"kms:GenerateDataKeyWithoutPlaintext",
+		},
+	},
+	"etcd-encryption-kms-arn": {
+		"kube-system/kms-provider": {
+			"kms:Encrypt",
+			"kms:Decrypt",
+			"kms:DescribeKey",
+		},
+		"kube-system/kube-controller-manager": kmsKeyDescribeActions,
+	},
+}
+
+// kmsKeyRoles are the roles of the cluster that may need to use its KMS keys.
+type kmsKeyRoles struct {
+	installer     string
+	support       string
+	controlPlane  string
+	worker        string
+	operatorRoles []ocm.OperatorIAMRole
+}
+
+// kmsKeyGrants returns the actions that the roles of the cluster need on the key of the given flag.
+func kmsKeyGrants(flag string, roles kmsKeyRoles) []drift.KeyGrant {
+	grants := []drift.KeyGrant{}
+	add := func(name string, roleARN string, actions []string) {
+		if roleARN == "" || len(actions) == 0 {
+			return
+		}
+		grants = append(grants, drift.KeyGrant{
+			Sid:     fmt.Sprintf("ROSA %s", name),
+			RoleARN: roleARN,
+			Actions: actions,
+		})
+	}
+	if flag == "etcd-encryption-kms-arn" {
+		add("installer role", roles.installer, []string{
+			"kms:CreateGrant",
+			"kms:DescribeKey",
+			"kms:GenerateDataKeyWithoutPlaintext",
+		})
+		add("support role", roles.support, kmsKeyDescribeActions)
+	} else {
+		add("installer role", roles.installer, kmsKeyUseAndGrantActions)
+		add("support role", roles.support, kmsKeyDescribeActions)
+		add("control plane role", roles.controlPlane, kmsKeyUseAndGrantActions)
+		add("worker role", roles.worker, kmsKeyUseAndGrantActions)
+	}
+	for _, role := range roles.operatorRoles {
+		operator := fmt.Sprintf("%s/%s", role.Namespace, role.Name)
+		add(fmt.Sprintf("operator role %s", operator), role.RoleARN, kmsKeyOperatorActions[flag][operator])
+	}
+	return grants
+}
+
+// validateKMSKey checks that the KMS key of the given flag is an enabled symmetric key in the region
+// of the cluster, and that its key policy allows the roles of the cluster to use it. In auto mode
+// the missing statements are added to the key policy, otherwise they are printed so that the user
+// can add them. It returns the grants of the roles that don't exist yet, that can only be added to
+// the key policy once the roles are created.
+func validateKMSKey(r *reporter.Object, awsClient aws.Client, accountID string, mode string,
+	flag string, keyARN string, region string, grants []drift.KeyGrant, dryRun bool) ([]drift.KeyGrant, error) {
+	parsed, err := arn.Parse(keyARN)
+	if err != nil {
+		return nil, fmt.Errorf("Expected a valid value for %s: %v", flag, err)
+	}
+	if parsed.Region != region {
+		return nil, fmt.Errorf("KMS key '%s' of '--%s' is in region '%s', it has to be in the region of "+
+			"the cluster '%s'", keyARN, flag, parsed.Region, region)
+	}
+	if parsed.AccountID != accountID {
+		r.Warnf("KMS key '%s' of '--%s' belongs to AWS account '%s', make sure that its key policy allows "+
+			"the roles of the cluster to use it", keyARN, flag, parsed.AccountID)
+		return nil, nil
+	}
+
+	// Failing to read the key doesn't mean that the cluster can't use it, the user may lack the
+	// permissions, so the checks are skipped:
+	metadata, err := awsClient.DescribeKMSKey(keyARN)
+	if err != nil {
+		r.Warnf("Skipping the validation of KMS key '%s': %v", keyARN, err)
+		return nil, nil
+	}
+	if metadata.KeyState != kmstypes.KeyStateEnabled {
+		return nil, fmt.Errorf("KMS key '%s' of '--%s' is in state '%s', it has to be '%s'",
+			keyARN, flag, metadata.KeyState, kmstypes.KeyStateEnabled)
+	}
+	if metadata.KeySpec != kmstypes.KeySpecSymmetricDefault ||
+		metadata.KeyUsage != kmstypes.KeyUsageTypeEncryptDecrypt {
+		return nil, fmt.Errorf("KMS key '%s' of '--%s' has to be a symmetric encryption key", keyARN, flag)
+	}
+	if metadata.KeyManager != kmstypes.KeyManagerTypeCustomer {
+		return nil, fmt.Errorf("KMS key '%s' of '--%s' has to be a customer managed key", keyARN, flag)
+	}
+	if len(grants) == 0 {
+		return nil, nil
+	}
+
+	policy, err := awsClient.GetKMSKeyPolicy(keyARN)
+	if err != nil {
+		r.Warnf("Skipping the validation of the key policy of KMS key '%s': %v", keyARN, err)
+		return nil, nil
+	}
+	missing, err := drift.MissingKeyGrants(policy, grants)
+	if err != nil {
+		r.Warnf("Skipping the validation of the key policy of KMS key '%s': %v", keyARN, err)
+		return nil, nil
+	}
+	if len(missing) == 0 {
+		r.Debugf("The key policy of KMS key '%s' allows the roles of the cluster to use it", keyARN)
+		return nil, nil
+	}
+
+	if mode != interactive.ModeAuto || dryRun {
+		r.Warnf("The key policy of KMS key '%s' doesn't allow the roles of the cluster to use it. Add "+
+			"the following statements to it, or use '--mode auto' to add them automatically:\n%s",
+			keyARN, drift.KeyGrantStatements(missing))
+		return nil, nil
+	}
+	existing := []drift.KeyGrant{}
+	pending := []drift.KeyGrant{}
+	for _, grant := range missing {
+		roleName, err := aws.GetResourceIdFromARN(grant.RoleARN)
+		if err != nil {
+			return nil, err
+		}
+		exists, _, err := awsClient.CheckRoleExists(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if role '%s' exists: %v", grant.RoleARN, err)
+		}
+		if exists {
+			existing = append(existing, grant)
+		} else {
+			pending = append(pending, grant)
+		}
+	}
+	err = addKMSKeyGrants(r, awsClient, keyARN, existing)
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// addKMSKeyGrants adds to the key policy of the KMS key the statements of the given grants.
+func addKMSKeyGrants(r *reporter.Object, awsClient aws.Client, keyARN string, grants []drift.KeyGrant) error {
+	if len(grants) == 0 {
+		return nil
+	}
+	// The policy is read again because it may have changed since it was validated:
+	policy, err := awsClient.GetKMSKeyPolicy(keyARN)
+	if err != nil {
+		return fmt.Errorf("Failed to get the key policy of KMS key '%s': %v", keyARN, err)
+	}
+	missing, err := drift.MissingKeyGrants(policy, grants)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the key policy of KMS key '%s': %v", keyARN, err)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	policy, err = drift.AddKeyGrants(policy, missing)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the key policy of KMS key '%s': %v", keyARN, err)
+	}
+	err = awsClient.PutKMSKeyPolicy(keyARN, policy)
+	if err != nil {
+		return fmt.Errorf("Failed to update the key policy of KMS key '%s': %v", keyARN, err)
+	}
+	for _, grant := range missing {
+		r.Infof("Allowed role '%s' to use KMS key '%s'", grant.RoleARN, keyARN)
+	}
+	return nil
+}
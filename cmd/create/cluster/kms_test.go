@@ -0,0 +1,124 @@
+package cluster
+
+import (
+	"go.uber.org/mock/gomock"
+
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	mock "github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/drift"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var _ = Describe("KMS keys", func() {
+	const (
+		accountID = "123456789012"
+		keyARN    = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+		installer = "arn:aws:iam::123456789012:role/test-Installer-Role"
+		worker    = "arn:aws:iam::123456789012:role/test-Worker-Role"
+		csiDriver = "arn:aws:iam::123456789012:role/test-openshift-cluster-csi-drivers-ebs-cloud-credentia"
+	)
+
+	roles := kmsKeyRoles{
+		installer: installer,
+		worker:    worker,
+		operatorRoles: []ocm.OperatorIAMRole{
+			{Name: "ebs-cloud-credentials", Namespace: "openshift-cluster-csi-drivers", RoleARN: csiDriver},
+			{Name: "cloud-credentials", Namespace: "openshift-ingress-operator",
+				RoleARN: "arn:aws:iam::123456789012:role/test-openshift-ingress-operator-cloud-credentials"},
+		},
+	}
+
+	Context("kmsKeyGrants", func() {
+		It("Returns the grants of the roles that use the EBS key", func() {
+			grants := kmsKeyGrants("kms-key-arn", roles)
+			Expect(grants).To(HaveLen(3))
+			Expect(grants[0].RoleARN).To(Equal(installer))
+			Expect(grants[0].Actions).To(ContainElement("kms:CreateGrant"))
+			Expect(grants[1].RoleARN).To(Equal(worker))
+			Expect(grants[2].Sid).To(Equal("ROSA operator role openshift-cluster-csi-drivers/ebs-cloud-credentials"))
+			Expect(grants[2].RoleARN).To(Equal(csiDriver))
+		})
+
+		It("Returns the grants of the roles that use the etcd key", func() {
+			grants := kmsKeyGrants("etcd-encryption-kms-arn", roles)
+			Expect(grants).To(Equal([]drift.KeyGrant{{
+				Sid:     "ROSA installer role",
+				RoleARN: installer,
+				Actions: []string{"kms:CreateGrant", "kms:DescribeKey", "kms:GenerateDataKeyWithoutPlaintext"},
+			}}))
+		})
+	})
+
+	Context("validateKMSKey", func() {
+		var (
+			r          *rosa.Runtime
+			mockClient *mock.MockClient
+			grants     []drift.KeyGrant
+		)
+
+		enabledKey := &kmstypes.KeyMetadata{
+			KeyState:   kmstypes.KeyStateEnabled,
+			KeySpec:    kmstypes.KeySpecSymmetricDefault,
+			KeyUsage:   kmstypes.KeyUsageTypeEncryptDecrypt,
+			KeyManager: kmstypes.KeyManagerTypeCustomer,
+		}
+
+		BeforeEach(func() {
+			r = rosa.NewRuntime()
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockClient = mock.NewMockClient(mockCtrl)
+			grants = []drift.KeyGrant{
+				{Sid: "ROSA installer role", RoleARN: installer, Actions: []string{"kms:DescribeKey"}},
+				{Sid: "ROSA operator role", RoleARN: csiDriver, Actions: []string{"kms:DescribeKey"}},
+			}
+		})
+
+		It("Fails with keys in other regions", func() {
+			_, err := validateKMSKey(r.Reporter, mockClient, accountID, interactive.ModeAuto, "kms-key-arn", keyARN,
+				"us-west-2", grants, false)
+			Expect(err).To(MatchError(ContainSubstring("it has to be in the region of the cluster 'us-west-2'")))
+		})
+
+		It("Fails with disabled keys", func() {
+			mockClient.EXPECT().DescribeKMSKey(keyARN).Return(&kmstypes.KeyMetadata{
+				KeyState: kmstypes.KeyStatePendingDeletion,
+			}, nil)
+			_, err := validateKMSKey(r.Reporter, mockClient, accountID, interactive.ModeAuto, "kms-key-arn", keyARN,
+				"us-east-1", grants, false)
+			Expect(err).To(MatchError(ContainSubstring("is in state 'PendingDeletion'")))
+		})
+
+		It("Doesn't change the key policy in manual mode", func() {
+			mockClient.EXPECT().DescribeKMSKey(keyARN).Return(enabledKey, nil)
+			mockClient.EXPECT().GetKMSKeyPolicy(keyARN).Return(`{"Statement": []}`, nil)
+			pending, err := validateKMSKey(r.Reporter, mockClient, accountID, interactive.ModeManual, "kms-key-arn",
+				keyARN, "us-east-1", grants, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeEmpty())
+		})
+
+		It("Adds the statements of the existing roles in auto mode", func() {
+			mockClient.EXPECT().DescribeKMSKey(keyARN).Return(enabledKey, nil)
+			mockClient.EXPECT().GetKMSKeyPolicy(keyARN).Return(`{"Statement": []}`, nil).Times(2)
+			mockClient.EXPECT().CheckRoleExists("test-Installer-Role").Return(true, installer, nil)
+			mockClient.EXPECT().CheckRoleExists("test-openshift-cluster-csi-drivers-ebs-cloud-credentia").
+				Return(false, "", nil)
+			mockClient.EXPECT().PutKMSKeyPolicy(keyARN, gomock.Any()).DoAndReturn(
+				func(_ string, policy string) error {
+					missing, err := drift.MissingKeyGrants(policy, grants)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(missing).To(Equal(grants[1:]))
+					return nil
+				})
+			pending, err := validateKMSKey(r.Reporter, mockClient, accountID, interactive.ModeAuto, "kms-key-arn",
+				keyARN, "us-east-1", grants, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(Equal(grants[1:]))
+		})
+	})
+})
@@ -26,9 +26,12 @@ import (
 	"github.com/openshift/rosa/cmd/create/cluster"
 	"github.com/openshift/rosa/cmd/create/dnsdomains"
 	"github.com/openshift/rosa/cmd/create/externalauthprovider"
+	"github.com/openshift/rosa/cmd/create/hibernationschedule"
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/cmd/create/kubeletconfig"
 	"github.com/openshift/rosa/cmd/create/machinepool"
+	"github.com/openshift/rosa/cmd/create/maintenancewindow"
+	"github.com/openshift/rosa/cmd/create/network"
 	"github.com/openshift/rosa/cmd/create/ocmrole"
 	"github.com/openshift/rosa/cmd/create/oidcconfig"
 	"github.com/openshift/rosa/cmd/create/oidcprovider"
@@ -66,6 +69,9 @@ func init() {
 	kubeletConfig := kubeletconfig.NewCreateKubeletConfigCommand()
 	Cmd.AddCommand(kubeletConfig)
 	Cmd.AddCommand(externalauthprovider.Cmd)
+	Cmd.AddCommand(network.Cmd)
+	Cmd.AddCommand(hibernationschedule.NewCreateHibernationScheduleCommand())
+	Cmd.AddCommand(maintenancewindow.NewCreateMaintenanceWindowCommand())
 	Cmd.AddCommand(breakglasscredential.Cmd)
 
 	flags := Cmd.PersistentFlags()
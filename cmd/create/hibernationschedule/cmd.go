@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "hibernation-schedule"
+	short = "Create a schedule to hibernate and resume a cluster"
+	long  = "Create a schedule that hibernates a cluster, and optionally resumes it, following cron " +
+		"expressions. The schedules are saved locally and performed by 'rosa schedule run', that has to " +
+		"be kept running, or be run periodically with cron or systemd. A cluster has at most one " +
+		"schedule, creating another one replaces it. Hibernation is a Technical Preview feature subject " +
+		"to the terms listed in https://access.redhat.com/articles/7012966"
+	example = `  # Hibernate cluster 'mycluster' on weekday evenings and resume it on weekday mornings
+  rosa create hibernation-schedule -c mycluster --cron "0 20 * * 1-5" --resume-cron "0 7 * * 1-5" \
+    --timezone Europe/Berlin
+
+  # Hibernate cluster 'mycluster' every night unless it has an upgrade pending
+  rosa create hibernation-schedule -c mycluster --cron "0 22 * * *" --skip-if-upgrade-pending`
+)
+
+var aliases = []string{"hibernationschedule"}
+
+type options struct {
+	cron                 string
+	resumeCron           string
+	timezone             string
+	skipIfUpgradePending bool
+}
+
+func NewCreateHibernationScheduleCommand() *cobra.Command {
+	options := &options{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCMAndAWS(), CreateHibernationScheduleRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	ocm.AddClusterFlag(cmd)
+	flags.StringVar(
+		&options.cron,
+		"cron",
+		"",
+		"Cron expression of the times to hibernate the cluster, for example '0 20 * * 1-5'.",
+	)
+	flags.StringVar(
+		&options.resumeCron,
+		"resume-cron",
+		"",
+		"Cron expression of the times to resume the cluster. If not set the cluster has to be resumed "+
+			"with 'rosa resume cluster'.",
+	)
+	flags.StringVar(
+		&options.timezone,
+		"timezone",
+		hibernation.DefaultTimezone,
+		"Time zone of the cron expressions, for example 'Europe/Berlin'.",
+	)
+	flags.BoolVar(
+		&options.skipIfUpgradePending,
+		"skip-if-upgrade-pending",
+		false,
+		"Don't hibernate the cluster while it has an upgrade scheduled or in progress.",
+	)
+	confirm.AddFlag(flags)
+	return cmd
+}
+
+func CreateHibernationScheduleRunner(options *options) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		cluster, err := r.OCMClient.GetCluster(r.GetClusterKey(), r.Creator)
+		if err != nil {
+			return err
+		}
+		if cluster.Hypershift().Enabled() {
+			return fmt.Errorf("Hibernation isn't supported for Hosted Control Plane clusters")
+		}
+		if options.cron == "" {
+			return fmt.Errorf("Expected a cron expression to hibernate the cluster with '--cron'")
+		}
+
+		now := time.Now()
+		schedule := &hibernation.Schedule{
+			ClusterID:            cluster.ID(),
+			ClusterName:          cluster.Name(),
+			HibernateCron:        options.cron,
+			ResumeCron:           options.resumeCron,
+			Timezone:             options.timezone,
+			SkipIfUpgradePending: options.skipIfUpgradePending,
+			CreatedAt:            now,
+		}
+		err = schedule.Validate()
+		if err != nil {
+			return err
+		}
+
+		store, err := hibernation.Load()
+		if err != nil {
+			return err
+		}
+		if store.Get(cluster.ID()) != nil &&
+			!confirm.Prompt(true, "Replace the hibernation schedule of cluster '%s'?", cluster.Name()) {
+			return nil
+		}
+		store.Set(schedule)
+		err = store.Save()
+		if err != nil {
+			return fmt.Errorf("Failed to save the hibernation schedule: %v", err)
+		}
+
+		r.Reporter.Infof("Created hibernation schedule of cluster '%s'", cluster.Name())
+		r.Reporter.Infof("The cluster will be hibernated next at %s",
+			schedule.Next(hibernation.ActionHibernate, now).Format(time.RFC3339))
+		if schedule.ResumeCron != "" {
+			r.Reporter.Infof("The cluster will be resumed next at %s",
+				schedule.Next(hibernation.ActionResume, now).Format(time.RFC3339))
+		}
+		r.Reporter.Infof("The schedules are performed by 'rosa schedule run', keep it running or run " +
+			"'rosa schedule run --export cron' to run it periodically")
+		return nil
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -79,7 +80,7 @@ func createHTPasswdIDP(cmd *cobra.Command,
 			if err != nil {
 				r.Reporter.Errorf(
 					"Failed to add a user to the HTPasswd IDP of cluster '%s': %v", clusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			r.Reporter.Infof("User '%s' added", username)
 		}
@@ -132,7 +133,7 @@ func getUserList(cmd *cobra.Command, r *rosa.Runtime) (userList map[string]strin
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --from-file value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -142,7 +143,7 @@ func getUserList(cmd *cobra.Command, r *rosa.Runtime) (userList map[string]strin
 		if err != nil {
 			r.Reporter.Errorf(
 				"Failed to load Htpasswd file '%s': %v", htpasswdFile, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		//password in htpasswd are already and do not need to be hashed again in CS
 		hashed = true
@@ -165,7 +166,7 @@ func getUserList(cmd *cobra.Command, r *rosa.Runtime) (userList map[string]strin
 			err := validateHtUsernameAndPassword(u, p)
 			if err != nil {
 				r.Reporter.Errorf(err.Error())
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			userList[u] = p
 		}
@@ -179,7 +180,7 @@ func getUserList(cmd *cobra.Command, r *rosa.Runtime) (userList map[string]strin
 		err := validateHtUsernameAndPassword(args.htpasswdUsername, args.htpasswdPassword)
 		if err != nil {
 			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		userList[args.htpasswdUsername] = args.htpasswdPassword
 		return
@@ -253,7 +254,7 @@ func exitHTPasswdCreate(format, clusterKey string, err error, r *rosa.Runtime) {
 	r.Reporter.Errorf("Failed to create IDP for cluster '%s': %v",
 		clusterKey,
 		fmt.Errorf(format, err))
-	os.Exit(1)
+	os.Exit(reporter.Code(err).ExitCode())
 }
 
 func UsernameValidator(val interface{}) error {
@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/properties"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -269,7 +270,7 @@ func run(cmd *cobra.Command, _ []string) {
 		_, err := mpHelpers.ParseLabels(args.Labels)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -282,7 +283,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create awsClient: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	service := machinepool.NewMachinePoolService()
@@ -293,6 +294,6 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
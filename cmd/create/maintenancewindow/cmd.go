@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "maintenance-window"
+	short = "Create the maintenance window of a cluster"
+	long  = "Create the maintenance window of a cluster, the days and hours when it can be upgraded or " +
+		"have its nodes recreated, and the freeze periods when it can't. The window is saved locally and " +
+		"checked by 'rosa upgrade cluster', 'rosa upgrade machinepool' and 'rosa edit machinepool', that " +
+		"refuse changes outside of it unless '--override-window' is used. Recurring upgrades have to run in " +
+		"the window at all their occurrences within the next year. A cluster has at most one window, " +
+		"creating another one replaces it."
+	example = `  # Allow changes to cluster 'mycluster' on weekend nights only
+  rosa create maintenance-window -c mycluster --days sat,sun --start-time 22:00 --end-time 04:00 \
+    --timezone Europe/Berlin
+
+  # Allow changes at any time except at the end of the quarter
+  rosa create maintenance-window -c mycluster --freeze end-of-quarter=2024-03-25/2024-04-05`
+)
+
+var aliases = []string{"maintenancewindow"}
+
+type options struct {
+	days      []string
+	startTime string
+	endTime   string
+	timezone  string
+	freezes   []string
+}
+
+func NewCreateMaintenanceWindowCommand() *cobra.Command {
+	options := &options{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), CreateMaintenanceWindowRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	ocm.AddClusterFlag(cmd)
+	flags.StringSliceVar(
+		&options.days,
+		"days",
+		nil,
+		"Days of the week when changes are allowed, for example 'sat,sun'. Defaults to every day.",
+	)
+	flags.StringVar(
+		&options.startTime,
+		"start-time",
+		"",
+		"Time of the day when the window starts, in 'HH:MM' format. Defaults to all day.",
+	)
+	flags.StringVar(
+		&options.endTime,
+		"end-time",
+		"",
+		"Time of the day when the window ends, in 'HH:MM' format. When it isn't after the start time the "+
+			"window continues past midnight.",
+	)
+	flags.StringVar(
+		&options.timezone,
+		"timezone",
+		maintenance.DefaultTimezone,
+		"Time zone of the days, times and freeze periods of the window, for example 'Europe/Berlin'.",
+	)
+	flags.StringArrayVar(
+		&options.freezes,
+		"freeze",
+		nil,
+		"Period when no changes are allowed, in 'name=yyyy-mm-dd/yyyy-mm-dd' format, where the name and "+
+			"the end date are optional. The dates are inclusive. Can be used several times.",
+	)
+	confirm.AddFlag(flags)
+	return cmd
+}
+
+func CreateMaintenanceWindowRunner(options *options) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		cluster, err := r.OCMClient.GetCluster(r.GetClusterKey(), r.Creator)
+		if err != nil {
+			return err
+		}
+		days, err := maintenance.ParseDays(options.days)
+		if err != nil {
+			return err
+		}
+		window := &maintenance.Window{
+			ClusterID:   cluster.ID(),
+			ClusterName: cluster.Name(),
+			Days:        days,
+			StartTime:   options.startTime,
+			EndTime:     options.endTime,
+			Timezone:    options.timezone,
+			CreatedAt:   time.Now(),
+		}
+		for _, value := range options.freezes {
+			freeze, err := maintenance.ParseFreeze(value)
+			if err != nil {
+				return err
+			}
+			window.Freezes = append(window.Freezes, freeze)
+		}
+		err = window.Validate()
+		if err != nil {
+			return err
+		}
+
+		store, err := maintenance.Load()
+		if err != nil {
+			return err
+		}
+		if store.Get(cluster.ID()) != nil &&
+			!confirm.Prompt(true, "Replace the maintenance window of cluster '%s'?", cluster.Name()) {
+			return nil
+		}
+		store.Set(window)
+		err = store.Save()
+		if err != nil {
+			return fmt.Errorf("Failed to save the maintenance window: %v", err)
+		}
+
+		r.Reporter.Infof("Created maintenance window of cluster '%s': %s", cluster.Name(), window.Summary())
+		if len(window.Freezes) > 0 {
+			r.Reporter.Infof("Changes are frozen during %d periods", len(window.Freezes))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,352 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/assets"
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	defaultName = "rosa-network"
+	defaultCIDR = "10.0.0.0/16"
+
+	// subnetCount is the number of subnets that the CIDR block of the VPC is split into: a private
+	// and a public subnet in each of the three availability zones that the template supports.
+	subnetCount = 6
+
+	// templateFile is the file where the template is saved in manual mode.
+	templateFile = "rosa_network.json"
+)
+
+var args struct {
+	name      string
+	cidr      string
+	azCount   int
+	private   bool
+	endpoints bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Create a network for clusters",
+	Long: "Creates a VPC ready to install clusters, using a CloudFormation stack with a template shipped " +
+		"with rosa. The VPC has a private subnet in each availability zone, and unless '--private' is used " +
+		"also a public subnet with a NAT gateway in each availability zone, an internet gateway, and the " +
+		"route tables. The subnets have the 'kubernetes.io/role/elb' and 'kubernetes.io/role/internal-elb' " +
+		"tags that the load balancers need. Optionally the VPC has endpoints for the AWS services that the " +
+		"clusters use, so that private clusters can reach them through AWS PrivateLink.",
+	Example: `  # Create a network with subnets in three availability zones
+  rosa create network --name mynet --az-count 3
+
+  # Create a network without internet access for private clusters
+  rosa create network --name mynet --az-count 3 --private --cidr 10.0.0.0/16
+
+  # Print the commands to create the network with the AWS CLI
+  rosa create network --name mynet --mode manual`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		defaultName,
+		"Name of the CloudFormation stack, also used as prefix of the names of the resources.",
+	)
+	flags.StringVar(
+		&args.cidr,
+		"cidr",
+		defaultCIDR,
+		"CIDR block of the VPC. It is split in six subnets of the same size, so it has to be between /16 "+
+			"and /24. Use it as '--machine-cidr' when creating the clusters.",
+	)
+	flags.IntVar(
+		&args.azCount,
+		"az-count",
+		1,
+		"Number of availability zones with subnets, between 1 and 3.",
+	)
+	flags.BoolVar(
+		&args.private,
+		"private",
+		false,
+		"Create only private subnets, without internet gateway or NAT gateways. Enables the PrivateLink "+
+			"endpoints unless '--privatelink-endpoints=false' is used.",
+	)
+	flags.BoolVar(
+		&args.endpoints,
+		"privatelink-endpoints",
+		false,
+		"Create VPC endpoints for S3, STS, EC2, Elastic Load Balancing and ECR in the private subnets.",
+	)
+
+	interactive.AddModeFlag(Cmd)
+	interactive.AddFlag(flags)
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	mode, err := interactive.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		r.Reporter.Errorf("Error getting region: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	name := args.name
+	if interactive.Enabled() {
+		name, err = interactive.GetString(interactive.Input{
+			Question: "Network name",
+			Help:     cmd.Flags().Lookup("name").Usage,
+			Default:  name,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid network name: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+
+	cidr := args.cidr
+	if interactive.Enabled() {
+		cidr, err = interactive.GetString(interactive.Input{
+			Question: "VPC CIDR",
+			Help:     cmd.Flags().Lookup("cidr").Usage,
+			Default:  cidr,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid CIDR: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+	subnetBits, err := subnetCidrBits(cidr)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid value for --cidr: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	azCount := args.azCount
+	if interactive.Enabled() {
+		azCount, err = interactive.GetInt(interactive.Input{
+			Question: "Number of availability zones",
+			Help:     cmd.Flags().Lookup("az-count").Usage,
+			Default:  azCount,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid number of availability zones: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+	if azCount < 1 || azCount > 3 {
+		r.Reporter.Errorf("Expected a number of availability zones between 1 and 3, got %d", azCount)
+		os.Exit(1)
+	}
+
+	private := args.private
+	if interactive.Enabled() {
+		private, err = interactive.GetBool(interactive.Input{
+			Question: "Private network",
+			Help:     cmd.Flags().Lookup("private").Usage,
+			Default:  private,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid private value: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+
+	endpoints := args.endpoints
+	if private && !cmd.Flags().Changed("privatelink-endpoints") {
+		endpoints = true
+	}
+	if interactive.Enabled() {
+		endpoints, err = interactive.GetBool(interactive.Input{
+			Question: "Create PrivateLink endpoints",
+			Help:     cmd.Flags().Lookup("privatelink-endpoints").Usage,
+			Default:  endpoints,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid privatelink-endpoints value: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+	if private && !endpoints {
+		r.Reporter.Warnf("The private subnets won't have access to the internet nor to the AWS services, " +
+			"make sure that the clusters can reach them through a proxy or a transit gateway")
+	}
+
+	parameters := map[string]string{
+		"Name":                  name,
+		"VpcCidr":               cidr,
+		"SubnetCidrBits":        strconv.Itoa(subnetBits),
+		"AvailabilityZoneCount": strconv.Itoa(azCount),
+		"Private":               strconv.FormatBool(private),
+		"PrivateLinkEndpoints":  strconv.FormatBool(endpoints),
+	}
+	stackTags := map[string]string{
+		tags.RedHatManaged: tags.True,
+	}
+
+	if interactive.Enabled() {
+		mode, err = interactive.GetOptionMode(cmd, mode, "Network creation mode")
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid network creation mode: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+
+	switch mode {
+	case interactive.ModeManual:
+		template, err := assets.Asset(aws.NetworkTemplatePath)
+		if err != nil {
+			r.Reporter.Errorf("Failed to read the network template: %v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		err = helper.SaveDocument(string(template), templateFile)
+		if err != nil {
+			r.Reporter.Errorf("Failed to save the network template: %v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("The template has been saved to '%s'. Run the following command to create "+
+				"the network:\n", templateFile)
+		}
+		fmt.Println(buildCommand(name, region, parameters, stackTags))
+	case interactive.ModeAuto, "":
+		stack, err := r.AWSClient.GetNetworkStack(name)
+		if err != nil {
+			r.Reporter.Errorf("Failed to check if network '%s' exists: %v", name, err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		if stack != nil {
+			r.Reporter.Errorf("Network '%s' already exists with status '%s'", name, stack.Status)
+			os.Exit(1)
+		}
+		if !confirm.Prompt(true, "Create network '%s' in region '%s'?", name, region) {
+			os.Exit(0)
+		}
+		r.Reporter.Infof("Creating network '%s', this takes a few minutes", name)
+		stack, err = r.AWSClient.CreateNetworkStack(name, parameters, stackTags)
+		if err != nil {
+			r.Reporter.Errorf("Failed to create network '%s': %v", name, err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		r.Reporter.Infof("Created network '%s' with VPC '%s'", name, stack.VpcID)
+		r.Reporter.Infof("Private subnets: %s", strings.Join(stack.PrivateSubnetIDs, ","))
+		if len(stack.PublicSubnetIDs) > 0 {
+			r.Reporter.Infof("Public subnets: %s", strings.Join(stack.PublicSubnetIDs, ","))
+		}
+		r.Reporter.Infof("To create a cluster in the network, run:\n\n\t%s\n",
+			buildClusterCommand(stack, cidr, private))
+		r.Reporter.Infof("To delete the network once it isn't used, run 'rosa delete network --name %s'", name)
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+		os.Exit(1)
+	}
+}
+
+// subnetCidrBits returns the number of host bits of the subnets, so that the CIDR block of the VPC
+// can be split in all the subnets that the template may create.
+func subnetCidrBits(cidr string) (int, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0, err
+	}
+	if ip.To4() == nil {
+		return 0, fmt.Errorf("'%s' isn't an IPv4 CIDR block", cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return 0, fmt.Errorf("'%s' isn't the first address of the CIDR block, use '%s'", cidr, network)
+	}
+	ones, bits := network.Mask.Size()
+	if ones < 16 || ones > 24 {
+		return 0, fmt.Errorf("the prefix length of '%s' has to be between 16 and 24", cidr)
+	}
+	// Number of bits needed to number the subnets:
+	subnetIndexBits := 0
+	for 1<<subnetIndexBits < subnetCount {
+		subnetIndexBits++
+	}
+	return bits - ones - subnetIndexBits, nil
+}
+
+func buildCommand(name string, region string, parameters map[string]string,
+	stackTags map[string]string) string {
+	keys := helper.MapKeys(parameters)
+	sort.Strings(keys)
+	values := []string{}
+	for _, key := range keys {
+		values = append(values, fmt.Sprintf("ParameterKey=%s,ParameterValue=%s", key, parameters[key]))
+	}
+	allTags := map[string]string{tags.NetworkStack: tags.True}
+	for key, value := range stackTags {
+		allTags[key] = value
+	}
+	return awscb.NewCloudFormationCommandBuilder().
+		SetCommand(awscb.CreateStack).
+		AddParam(awscb.StackName, name).
+		AddParam(awscb.TemplateBody, fmt.Sprintf("file://%s", templateFile)).
+		AddParam(awscb.Parameters, strings.Join(values, " ")).
+		AddParam(awscb.Region, region).
+		AddTags(allTags).
+		Build()
+}
+
+func buildClusterCommand(stack *aws.NetworkStack, cidr string, private bool) string {
+	subnets := append(append([]string{}, stack.PrivateSubnetIDs...), stack.PublicSubnetIDs...)
+	command := fmt.Sprintf("rosa create cluster --cluster-name <name> --sts --subnet-ids %s --machine-cidr %s",
+		strings.Join(subnets, ","), cidr)
+	if len(stack.PrivateSubnetIDs) > 1 {
+		command += " --multi-az"
+	}
+	if private {
+		command += " --private-link"
+	}
+	return command
+}
@@ -0,0 +1,86 @@
+package network
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/assets"
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Create network", func() {
+	Context("subnetCidrBits", func() {
+		DescribeTable("Splits the CIDR block of the VPC in eight subnets",
+			func(cidr string, expected int) {
+				bits, err := subnetCidrBits(cidr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bits).To(Equal(expected))
+			},
+			Entry("/16", "10.0.0.0/16", 13),
+			Entry("/20", "172.16.16.0/20", 9),
+			Entry("/24", "192.168.1.0/24", 5),
+		)
+
+		DescribeTable("Fails with invalid CIDR blocks",
+			func(cidr string) {
+				_, err := subnetCidrBits(cidr)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("Not a CIDR", "10.0.0.0"),
+			Entry("Too large", "10.0.0.0/8"),
+			Entry("Too small", "10.0.0.0/26"),
+			Entry("Not the network address", "10.0.1.0/16"),
+			Entry("IPv6", "fd00::/56"),
+		)
+	})
+
+	It("Builds the command to create the stack", func() {
+		command := buildCommand("mynet", "us-east-1", map[string]string{
+			"Name":    "mynet",
+			"VpcCidr": "10.0.0.0/16",
+		}, map[string]string{"red-hat-managed": "true"})
+		Expect(command).To(Equal("aws cloudformation create-stack \\\n" +
+			"\t--parameters ParameterKey=Name,ParameterValue=mynet " +
+			"ParameterKey=VpcCidr,ParameterValue=10.0.0.0/16 \\\n" +
+			"\t--region us-east-1 \\\n" +
+			"\t--stack-name mynet \\\n" +
+			"\t--tags Key=rosa_network,Value=true Key=red-hat-managed,Value=true \\\n" +
+			"\t--template-body file://rosa_network.json"))
+	})
+
+	It("Builds the command to create a cluster in the network", func() {
+		stack := &aws.NetworkStack{
+			PrivateSubnetIDs: []string{"subnet-1", "subnet-2", "subnet-3"},
+			PublicSubnetIDs:  []string{"subnet-4", "subnet-5", "subnet-6"},
+		}
+		Expect(buildClusterCommand(stack, "10.0.0.0/16", false)).To(Equal(
+			"rosa create cluster --cluster-name <name> --sts " +
+				"--subnet-ids subnet-1,subnet-2,subnet-3,subnet-4,subnet-5,subnet-6 " +
+				"--machine-cidr 10.0.0.0/16 --multi-az"))
+		stack.PrivateSubnetIDs = stack.PrivateSubnetIDs[:1]
+		stack.PublicSubnetIDs = nil
+		Expect(buildClusterCommand(stack, "10.0.0.0/16", true)).To(Equal(
+			"rosa create cluster --cluster-name <name> --sts --subnet-ids subnet-1 " +
+				"--machine-cidr 10.0.0.0/16 --private-link"))
+	})
+
+	It("Ships a template with the parameters that the command passes", func() {
+		data, err := assets.Asset(aws.NetworkTemplatePath)
+		Expect(err).NotTo(HaveOccurred())
+		var template struct {
+			Parameters map[string]interface{} `json:"Parameters"`
+			Outputs    map[string]interface{} `json:"Outputs"`
+		}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		Expect(template.Parameters).To(HaveLen(6))
+		for _, parameter := range []string{"Name", "VpcCidr", "SubnetCidrBits", "AvailabilityZoneCount",
+			"Private", "PrivateLinkEndpoints"} {
+			Expect(template.Parameters).To(HaveKey(parameter))
+		}
+		Expect(template.Outputs).To(HaveKey("VpcId"))
+		Expect(template.Outputs).To(HaveKey("PrivateSubnetIds"))
+		Expect(template.Outputs).To(HaveKey("PublicSubnetIds"))
+	})
+})
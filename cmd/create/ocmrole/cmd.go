@@ -29,10 +29,12 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/drift"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -116,13 +118,13 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Determine if Classic ROSA managed policies are enabled
@@ -156,7 +158,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
@@ -179,7 +181,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --admin value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -195,7 +197,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -203,7 +205,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -219,7 +221,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -233,7 +235,7 @@ func run(cmd *cobra.Command, _ []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -241,7 +243,7 @@ func run(cmd *cobra.Command, _ []string) {
 	orgID, externalID, err := r.OCMClient.GetCurrentOrganization()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get organization account: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	roleNameRequested := aws.GetOCMRoleName(prefix, aws.OCMRole, externalID)
@@ -250,7 +252,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if err != nil {
 		r.Reporter.Errorf("Error checking existing ocm-role: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if existsOnOCM {
 		r.Reporter.Errorf("Only one ocm-role can be created per AWS account '%s' per organization '%s'.\n"+
@@ -262,8 +264,19 @@ func run(cmd *cobra.Command, _ []string) {
 	policies, err := r.OCMClient.GetPolicies("OCMRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	policyKeys := []string{fmt.Sprintf("sts_%s_permission_policy", aws.OCMRolePolicyFile)}
+	if isAdmin {
+		policyKeys = append(policyKeys, fmt.Sprintf("sts_%s_permission_policy", aws.OCMAdminRolePolicyFile))
+	}
+	preflight := &drift.Preflight{
+		AWSClient: r.AWSClient,
+		Partition: r.Creator.Partition,
+		Policies:  policies,
 	}
+	preflight.Warn(r.Reporter, permissionsBoundary, policyKeys)
 
 	switch mode {
 	case interactive.ModeAuto:
@@ -275,7 +288,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSACreateOCMRoleModeAuto", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateOCMRoleModeAuto", map[string]string{
 			ocm.Response: ocm.Success,
@@ -295,7 +308,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSACreateOCMRoleModeManual", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
@@ -316,7 +329,7 @@ func run(cmd *cobra.Command, _ []string) {
 		)
 		if err != nil {
 			r.Reporter.Errorf("Failed to generate commands for manual mode: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		fmt.Println(commands)
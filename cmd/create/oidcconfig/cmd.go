@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path"
 	"strings"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/iac"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	. "github.com/openshift/rosa/pkg/constants"
 	"github.com/openshift/rosa/pkg/helper"
@@ -40,6 +42,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveRoles "github.com/openshift/rosa/pkg/interactive/roles"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -59,7 +62,10 @@ var Cmd = &cobra.Command{
 		"client AWS account and populates it to be compliant with OIDC protocol. " +
 		"It also creates a Secret in Secrets Manager containing the private key.",
 	Example: `  # Create OIDC config
-	rosa create oidc-config`,
+	rosa create oidc-config
+
+  # Output a Terraform module that creates the OIDC config and provider, instead of 'aws' commands
+  rosa create oidc-config --managed=false --mode manual --format terraform > oidc-config.tf`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -107,6 +113,7 @@ func init() {
 	)
 
 	interactive.AddModeFlag(Cmd)
+	iac.AddFormatFlag(Cmd)
 
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
@@ -141,14 +148,19 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	format, mode, err := iac.GetFormat(cmd, mode)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	args.region = region
 
@@ -183,7 +195,7 @@ func run(cmd *cobra.Command, _ []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, question)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid %s: %s", question, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -227,7 +239,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid prefix for the configuration: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				args.userPrefix = prefix
 			}
@@ -239,7 +251,7 @@ func run(cmd *cobra.Command, _ []string) {
 				err := aws.ARNValidator(args.installerRoleArn)
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid ARN: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				roleExists, _, err := r.AWSClient.CheckRoleExists(roleName)
 				if err != nil {
@@ -248,7 +260,7 @@ func run(cmd *cobra.Command, _ []string) {
 						args.installerRoleArn,
 						err,
 					)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				if !roleExists {
 					r.Reporter.Errorf("Role '%s' does not exist", args.installerRoleArn)
@@ -258,7 +270,7 @@ func run(cmd *cobra.Command, _ []string) {
 					roleName, aws.InstallerAccountRole, MinorVersionForGetSecret)
 				if err != nil {
 					r.Reporter.Errorf("There was a problem listing role tags: %v", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				if !isValid {
 					r.Reporter.Errorf(
@@ -285,17 +297,18 @@ func run(cmd *cobra.Command, _ []string) {
 		oidcConfigInput, err = oidcconfigs.BuildOidcConfigInput(args.userPrefix, args.region)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
-	oidcConfigStrategy, err := getOidcConfigStrategy(mode, &oidcConfigInput)
+	oidcConfigStrategy, err := getOidcConfigStrategy(mode, format, &oidcConfigInput)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	oidcConfigStrategy.execute(r)
-	if !args.rawFiles {
+	// The template of the unmanaged configuration already contains the OIDC provider:
+	if !args.rawFiles && (format == "" || args.managed) {
 		arguments.DisableRegionDeprecationWarning = true // disable region deprecation warning
 		oidcprovider.Cmd.Run(oidcprovider.Cmd, []string{"", mode, oidcConfigInput.IssuerUrl})
 		arguments.DisableRegionDeprecationWarning = false // enable region deprecation again
@@ -319,19 +332,19 @@ func (s *CreateUnmanagedOidcConfigRawStrategy) execute(r *rosa.Runtime) {
 	err := helper.SaveDocument(string(privateKey), privateKeyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	discoveryDocumentFilename := fmt.Sprintf("discovery-document-%s.json", bucketName)
 	err = helper.SaveDocument(discoveryDocument, discoveryDocumentFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving discovery document to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	jwksFilename := fmt.Sprintf("jwks-%s.json", bucketName)
 	err = helper.SaveDocument(string(jwks[:]), jwksFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving JSON Web Key Set to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if !output.HasFlag() && r.Reporter.IsTerminal() {
 		r.Reporter.Infof(
@@ -368,14 +381,14 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	err := r.AWSClient.CreateS3Bucket(bucketName, args.region)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem creating S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	err = r.AWSClient.PutPublicReadObjectInS3Bucket(
 		bucketName, strings.NewReader(discoveryDocument), discoveryDocumentKey)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem populating discovery "+
 			"document to S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	err = r.AWSClient.PutPublicReadObjectInS3Bucket(bucketName, bytes.NewReader(jwks), jwksKey)
 	if err != nil {
@@ -384,12 +397,12 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 		}
 		r.Reporter.Errorf("There was a problem populating JWKS "+
 			"to S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	secretARN, err := r.AWSClient.CreateSecretInSecretsManager(privateKeySecretName, string(privateKey[:]))
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to secrets manager: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	oidcConfig, err := v1.NewOidcConfig().
 		Managed(false).
@@ -408,13 +421,13 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 			"Please refer to documentation and try again through:\n"+
 			"\trosa register oidc-config --issuer-url %s --secret-arn %s --role-arn %s",
 			err, bucketUrl, secretARN, installerRoleArn)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if output.HasFlag() {
 		err = output.Print(oidcConfig)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -429,9 +442,14 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 
 type CreateUnmanagedOidcConfigManualStrategy struct {
 	oidcConfig *oidcconfigs.OidcConfigInput
+	format     string
 }
 
 func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
+	if s.format != "" {
+		s.writeTemplate(r)
+		return
+	}
 	commands := []string{}
 	bucketName := s.oidcConfig.BucketName
 	discoveryDocument := s.oidcConfig.DiscoveryDocument
@@ -442,7 +460,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	err := helper.SaveDocument(string(privateKey), privateKeyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	createBucketConfig := ""
 	if args.region != aws.DefaultRegion {
@@ -475,7 +493,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	err = helper.SaveDocument(fmt.Sprintf(aws.ReadOnlyAnonUserPolicyTemplate, bucketName), readOnlyPolicyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving bucket policy document to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	putBucketBucketPolicyCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutBucketPolicy).
@@ -489,7 +507,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	err = helper.SaveDocument(discoveryDocument, discoveryDocumentFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving discovery document to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	putDiscoveryDocumentCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutObject).
@@ -504,7 +522,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	err = helper.SaveDocument(string(jwks[:]), jwksFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving JSON Web Key Set to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	putJwksCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutObject).
@@ -536,6 +554,92 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	}
 }
 
+// writeTemplate writes the resources of the OIDC configuration, including the OIDC provider, in the
+// selected format instead of 'aws' commands. Only the private key is saved to a file, so that it
+// isn't part of the output.
+func (s *CreateUnmanagedOidcConfigManualStrategy) writeTemplate(r *rosa.Runtime) {
+	bucketName := s.oidcConfig.BucketName
+	err := helper.SaveDocument(string(s.oidcConfig.PrivateKey), s.oidcConfig.PrivateKeyFilename)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	objectTags := map[string]string{
+		tags.RedHatManaged: tags.True,
+	}
+	template := iac.NewTemplate(fmt.Sprintf("ROSA OIDC configuration '%s'", bucketName))
+	template.Region = args.region
+	template.AddBucket(&iac.Bucket{
+		Name: bucketName,
+		Tags: map[string]string{
+			tags.RedHatManaged: tags.True,
+		},
+		PublicAccessBlock: &iac.PublicAccessBlock{
+			BlockPublicAcls:  true,
+			IgnorePublicAcls: true,
+		},
+		Policy: iac.Document(fmt.Sprintf(aws.ReadOnlyAnonUserPolicyTemplate, bucketName)),
+		Objects: []*iac.Object{
+			{
+				Key:     discoveryDocumentKey,
+				Content: s.oidcConfig.DiscoveryDocument,
+				Tags:    objectTags,
+			},
+			{
+				Key:     jwksKey,
+				Content: string(s.oidcConfig.Jwks),
+				Tags:    objectTags,
+			},
+		},
+	})
+	template.AddSecret(&iac.Secret{
+		Name:        s.oidcConfig.PrivateKeySecretName,
+		Description: fmt.Sprintf("Secret for %s", bucketName),
+		ValueFile:   s.oidcConfig.PrivateKeyFilename,
+		Tags: map[string]string{
+			tags.RedHatManaged: tags.True,
+		},
+	})
+	err = oidcprovider.AddToTemplate(r, template, s.oidcConfig.IssuerUrl, "")
+	if err != nil {
+		r.Reporter.Errorf("There was a problem building the OIDC provider: %s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	err = template.Write(os.Stdout, s.format)
+	if err != nil {
+		r.Reporter.Errorf("There was an error generating the %s output: %s", s.format, err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	if s.format == iac.FormatCloudFormation {
+		// CloudFormation can't create S3 objects, so they are uploaded with commands:
+		commands := []string{}
+		for _, object := range template.Buckets[0].Objects {
+			filename := fmt.Sprintf("%s-%s", bucketName, path.Base(object.Key))
+			err = helper.SaveDocument(object.Content, filename)
+			if err != nil {
+				r.Reporter.Errorf("There was a problem saving '%s' to a file: %s", object.Key, err)
+				os.Exit(reporter.Code(err).ExitCode())
+			}
+			commands = append(commands, awscb.NewS3ApiCommandBuilder().
+				SetCommand(awscb.PutObject).
+				AddParam(awscb.Body, fmt.Sprintf("./%s", filename)).
+				AddParam(awscb.Bucket, bucketName).
+				AddParam(awscb.Key, object.Key).
+				AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
+				Build())
+		}
+		r.Reporter.Warnf("CloudFormation doesn't support S3 objects. After the stack is created, "+
+			"run the following commands to upload the OIDC documents:\n%s", awscb.JoinCommands(commands))
+	}
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Please apply the resources above to generate OIDC compliant configuration in your " +
+			"AWS account. To register this OIDC Configuration, please run the following command:\n" +
+			"rosa register oidc-config\n" +
+			"For more information please refer to the documentation")
+	}
+}
+
 type CreateManagedOidcConfigAutoStrategy struct {
 	oidcConfigInput *oidcconfigs.OidcConfigInput
 }
@@ -552,7 +656,7 @@ func (s *CreateManagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	oidcConfig, err := v1.NewOidcConfig().Managed(true).Build()
 	if err != nil {
 		r.Reporter.Errorf("There was a problem building the managed OIDC Configuration: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	oidcConfig, err = r.OCMClient.CreateOidcConfig(oidcConfig)
 	if err != nil {
@@ -560,14 +664,14 @@ func (s *CreateManagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 			spin.Stop()
 		}
 		r.Reporter.Errorf("There was a problem registering your managed OIDC Configuration: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	s.oidcConfigInput.IssuerUrl = oidcConfig.IssuerUrl()
 	if output.HasFlag() {
 		err = output.Print(oidcConfig)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -580,7 +684,8 @@ func (s *CreateManagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	}
 }
 
-func getOidcConfigStrategy(mode string, input *oidcconfigs.OidcConfigInput) (CreateOidcConfigStrategy, error) {
+func getOidcConfigStrategy(mode string, format string,
+	input *oidcconfigs.OidcConfigInput) (CreateOidcConfigStrategy, error) {
 	if args.rawFiles {
 		return &CreateUnmanagedOidcConfigRawStrategy{oidcConfig: input}, nil
 	}
@@ -591,7 +696,7 @@ func getOidcConfigStrategy(mode string, input *oidcconfigs.OidcConfigInput) (Cre
 	case interactive.ModeAuto:
 		return &CreateUnmanagedOidcConfigAutoStrategy{oidcConfig: input}, nil
 	case interactive.ModeManual:
-		return &CreateUnmanagedOidcConfigManualStrategy{oidcConfig: input}, nil
+		return &CreateUnmanagedOidcConfigManualStrategy{oidcConfig: input, format: format}, nil
 	default:
 		return nil, weberr.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
 	}
@@ -27,12 +27,14 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/iac"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -69,6 +71,7 @@ func init() {
 
 	ocm.AddOptionalClusterFlag(Cmd)
 	interactive.AddModeFlag(Cmd)
+	iac.AddFormatFlag(Cmd)
 
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
@@ -104,7 +107,12 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	format, mode, err := iac.GetFormat(cmd, mode)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -128,7 +136,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "OIDC provider creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OIDC provider creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -145,7 +153,7 @@ func run(cmd *cobra.Command, argv []string) {
 			oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
 			if err != nil {
 				r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", args.oidcConfigId, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			oidcEndpointURL = oidcConfig.IssuerUrl()
 		}
@@ -163,7 +171,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Reporter.Debugf("Failed to verify if OIDC provider exists: %s", err)
 		} else {
 			r.Reporter.Errorf("Failed to verify if OIDC provider exists: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if oidcProviderExists {
@@ -197,17 +205,36 @@ func run(cmd *cobra.Command, argv []string) {
 				ocm.ClusterID: clusterKey,
 				ocm.Response:  ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateOIDCProviderModeAuto", map[string]string{
 			ocm.ClusterID: clusterKey,
 			ocm.Response:  ocm.Success,
 		})
 	case interactive.ModeManual:
+		if format != "" {
+			template := iac.NewTemplate("ROSA OIDC provider")
+			err = AddToTemplate(r, template, oidcEndpointURL, clusterId)
+			if err == nil {
+				err = template.Write(os.Stdout, format)
+			}
+			if err != nil {
+				r.Reporter.Errorf("There was an error generating the %s output: %s", format, err)
+				r.OCMClient.LogEvent("ROSACreateOIDCProviderModeManual", map[string]string{
+					ocm.ClusterID: clusterKey,
+					ocm.Response:  ocm.Failure,
+				})
+				os.Exit(reporter.Code(err).ExitCode())
+			}
+			r.OCMClient.LogEvent("ROSACreateOIDCProviderModeManual", map[string]string{
+				ocm.ClusterID: clusterKey,
+			})
+			return
+		}
 		commands, err := buildCommands(r, oidcEndpointURL, clusterId)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 			r.OCMClient.LogEvent("ROSACreateOIDCProviderModeManual", map[string]string{
 				ocm.ClusterID: clusterKey,
 				ocm.Response:  ocm.Failure,
@@ -244,6 +271,34 @@ func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) e
 	return nil
 }
 
+func getProviderTags(clusterId string) map[string]string {
+	iamTags := map[string]string{
+		tags.RedHatManaged: tags.True,
+	}
+	if clusterId != "" {
+		iamTags[tags.ClusterID] = clusterId
+	}
+	return iamTags
+}
+
+// AddToTemplate adds the OIDC provider of the given endpoint to the template of the resources
+// output by the manual mode.
+func AddToTemplate(r *rosa.Runtime, template *iac.Template, oidcEndpointUrl string, clusterId string) error {
+	thumbprint, err := oidcconfigs.FetchThumbprint(oidcEndpointUrl)
+	if err != nil {
+		return err
+	}
+	r.Reporter.Debugf("Using thumbprint '%s'", thumbprint)
+
+	template.AddOIDCProvider(&iac.OIDCProvider{
+		URL:         oidcEndpointUrl,
+		ClientIDs:   []string{aws.OIDCClientIDOpenShift, aws.OIDCClientIDSTSAWS},
+		Thumbprints: []string{thumbprint},
+		Tags:        getProviderTags(clusterId),
+	})
+	return nil
+}
+
 func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) (string, error) {
 	commands := []string{}
 
@@ -253,12 +308,7 @@ func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) (s
 	}
 	r.Reporter.Debugf("Using thumbprint '%s'", thumbprint)
 
-	iamTags := map[string]string{
-		tags.RedHatManaged: tags.True,
-	}
-	if clusterId != "" {
-		iamTags[tags.ClusterID] = clusterId
-	}
+	iamTags := getProviderTags(clusterId)
 
 	clientIdList := strings.Join([]string{aws.OIDCClientIDOpenShift, aws.OIDCClientIDSTSAWS}, " ")
 
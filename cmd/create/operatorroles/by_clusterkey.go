@@ -10,16 +10,18 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/iac"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
-	permissionsBoundary string, mode string,
+	permissionsBoundary string, mode string, format string,
 	policies map[string]*cmv1.AWSSTSPolicy,
 	defaultPolicyVersion string) error {
 	clusterKey := r.GetClusterKey()
@@ -36,7 +38,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 			r.Reporter.Debugf("Failed to verify if operator roles exist: '%v'", err)
 		} else {
 			r.Reporter.Errorf("Failed to verify if operator roles exist: '%v'", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -50,7 +52,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM '%v'", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	managedPolicies := cluster.AWS().STS().ManagedPolicies()
@@ -59,6 +61,9 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		os.Exit(1)
 	}
 
+	warnDeniedActions(r, permissionsBoundary, policies, credRequests, hostedCPPolicies,
+		cluster.AWS().PrivateHostedZoneRoleARN() != "")
+
 	switch mode {
 	case interactive.ModeAuto:
 
@@ -78,13 +83,13 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		roleName, err := aws.GetInstallerAccountRoleName(cluster)
 		if err != nil {
 			r.Reporter.Errorf("Expected parsing role account role '%s': '%v'", cluster.AWS().STS().RoleARN(), err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		path, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path for '%s': '%v'", cluster.AWS().STS().RoleARN(), err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if path != "" && !output.HasFlag() && r.Reporter.IsTerminal() {
 			r.Reporter.Infof("ARN path '%s' detected in installer role '%s'. "+
@@ -99,7 +104,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		accountRoleVersion, err = r.AWSClient.GetAccountRoleVersion(roleName)
 		if err != nil {
 			r.Reporter.Errorf("Error getting account role version '%v'", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		err = createRoles(r, operatorRolePolicyPrefix, permissionsBoundary, cluster,
 			accountRoleVersion, policies, defaultPolicyVersion, credRequests, managedPolicies, hostedCPPolicies)
@@ -121,23 +126,30 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 			ocm.Response:  ocm.Success,
 		})
 	case interactive.ModeManual:
+		var template *iac.Template
+		if format != "" {
+			template = iac.NewTemplate(fmt.Sprintf("ROSA operator roles of cluster '%s'", clusterKey))
+		}
 		commands, err := buildCommands(r, env, operatorRolePolicyPrefix, permissionsBoundary, defaultPolicyVersion,
-			cluster, policies, credRequests, managedPolicies, hostedCPPolicies)
+			cluster, policies, credRequests, managedPolicies, hostedCPPolicies, template)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: '%v'", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 			r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
 				ocm.ClusterID: clusterKey,
 				ocm.Response:  ocm.Failure,
 			})
 		}
+		r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
+			ocm.ClusterID: clusterKey,
+		})
+		if template != nil {
+			return template.Write(os.Stdout, format)
+		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
 			r.Reporter.Infof("Run the following commands to create the operator roles:\n")
 		}
-		r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
-			ocm.ClusterID: clusterKey,
-		})
 		fmt.Println(commands)
 
 	default:
@@ -168,7 +180,7 @@ func createRoles(
 			isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(ver.ID()), operator.MinVersion())
 			if err != nil {
 				r.Reporter.Errorf("Error validating operator role '%s' version %s", operator.Name(), err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			if !isSupported {
 				continue
@@ -275,15 +287,15 @@ func createRoles(
 func buildCommands(r *rosa.Runtime, env string,
 	prefix string, permissionsBoundary string, defaultPolicyVersion string, cluster *cmv1.Cluster,
 	policies map[string]*cmv1.AWSSTSPolicy, credRequests map[string]*cmv1.STSOperator,
-	managedPolicies bool, hostedCPPolicies bool) (string, error) {
+	managedPolicies bool, hostedCPPolicies bool, template *iac.Template) (string, error) {
 	sharedVpcRoleArn := cluster.AWS().PrivateHostedZoneRoleARN()
 	isSharedVpc := sharedVpcRoleArn != ""
 
-	if !managedPolicies {
+	if !managedPolicies && template == nil {
 		err := aws.GenerateOperatorRolePolicyFiles(r.Reporter, policies, credRequests, sharedVpcRoleArn, r.Creator.Partition)
 		if err != nil {
 			r.Reporter.Errorf("There was an error generating the policy files: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -295,7 +307,7 @@ func buildCommands(r *rosa.Runtime, env string,
 			isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(ver.ID()), operator.MinVersion())
 			if err != nil {
 				r.Reporter.Errorf("Error validating operator role '%s' version %s", operator.Name(), err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			if !isSupported {
 				continue
@@ -308,6 +320,7 @@ func buildCommands(r *rosa.Runtime, env string,
 		}
 
 		var policyARN string
+		var templatePolicy string
 		if managedPolicies {
 			policyARN, err = aws.GetManagedPolicyARN(policies, aws.GetOperatorPolicyKey(
 				credrequest, hostedCPPolicies, isSharedVpc))
@@ -327,7 +340,16 @@ func buildCommands(r *rosa.Runtime, env string,
 			operatorPolicyKey := aws.GetOperatorPolicyKey(credrequest, hostedCPPolicies, isSharedVpc)
 			fileName := fmt.Sprintf("file://%s.json", operatorPolicyKey)
 			_, err = r.AWSClient.IsPolicyExists(policyARN)
-			if err != nil {
+			if err != nil && template != nil {
+				template.AddPolicy(&iac.Policy{
+					Name: name,
+					Path: path,
+					Document: iac.Document(getOperatorPolicyDocument(policies, operatorPolicyKey,
+						sharedVpcRoleArn, r.Creator.Partition)),
+					Tags: iamTags,
+				})
+				templatePolicy = name
+			} else if err != nil {
 				createPolicy := awscb.NewIAMCommandBuilder().
 					SetCommand(awscb.CreatePolicy).
 					AddParam(awscb.PolicyName, name).
@@ -348,6 +370,11 @@ func buildCommands(r *rosa.Runtime, env string,
 					AddParam(awscb.PolicyDocument, fileName).
 					AddParamNoValue(awscb.SetAsDefault).
 					Build()
+				if template != nil {
+					// Existing policies can't be part of the template, so they are updated with a command:
+					r.Reporter.Warnf("Policy '%s' already exists, run the following command to update it:\n%s",
+						policyARN, createPolicyVersion)
+				}
 				commands = append(commands, createPolicyVersion)
 			}
 		}
@@ -361,10 +388,12 @@ func buildCommands(r *rosa.Runtime, env string,
 
 		filename := fmt.Sprintf("operator_%s_policy", credrequest)
 		filename = aws.GetFormattedFileName(filename)
-		r.Reporter.Debugf("Saving '%s' to the current directory", filename)
-		err = helper.SaveDocument(policy, filename)
-		if err != nil {
-			return "", err
+		if template == nil {
+			r.Reporter.Debugf("Saving '%s' to the current directory", filename)
+			err = helper.SaveDocument(policy, filename)
+			if err != nil {
+				return "", err
+			}
 		}
 		iamTags := map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
@@ -380,6 +409,22 @@ func buildCommands(r *rosa.Runtime, env string,
 		if hostedCPPolicies {
 			iamTags[tags.HypershiftPolicies] = helper.True
 		}
+		if template != nil {
+			role := &iac.Role{
+				Name:                roleName,
+				Path:                path,
+				PermissionsBoundary: permissionsBoundary,
+				AssumeRolePolicy:    iac.Document(policy),
+				Tags:                iamTags,
+			}
+			if templatePolicy != "" {
+				role.Policies = []string{templatePolicy}
+			} else {
+				role.PolicyARNs = []string{policyARN}
+			}
+			template.AddRole(role)
+			continue
+		}
 		createRole := awscb.NewIAMCommandBuilder().
 			SetCommand(awscb.CreateRole).
 			AddParam(awscb.RoleName, roleName).
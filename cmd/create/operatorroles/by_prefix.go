@@ -13,6 +13,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/iac"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
@@ -20,6 +21,7 @@ import (
 	interactiveRoles "github.com/openshift/rosa/pkg/interactive/roles"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -37,7 +39,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 	})
 	if err != nil {
 		r.Reporter.Errorf("Expected a prefix for the operator IAM roles: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	args.prefix = operatorRolesPrefix
 
@@ -62,7 +64,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --hosted-cp value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	args.hostedCp = isHostedCP
@@ -76,13 +78,13 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 }
 
 func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
-	permissionsBoundary string, mode string,
+	permissionsBoundary string, mode string, format string,
 	policies map[string]*cmv1.AWSSTSPolicy,
 	defaultPolicyVersion string) error {
 	oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", args.oidcConfigId, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	includeHostedCpSet := args.hostedCp
 	operatorRolesPrefix := args.prefix
@@ -95,12 +97,12 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	installerRoleName, err := aws.GetResourceIdFromARN(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	path, err := aws.GetPathFromARN(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid path for '%s': %v", installerRoleArn, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if path != "" && !output.HasFlag() && r.Reporter.IsTerminal() {
 		r.Reporter.Infof("ARN path '%s' detected in installer role '%s'. "+
@@ -118,12 +120,12 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	credRequests, err := r.OCMClient.GetCredRequests(includeHostedCpSet)
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	managedPolicies, err := r.AWSClient.HasManagedPolicies(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if managedPolicies && sharedVpcRoleArn != "" {
 		r.Reporter.Errorf("Installer role '%s' has managed policies, the 'shared-vpc-role-arn' flag is not "+
@@ -133,14 +135,14 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	awsCreator, err := r.AWSClient.GetCreator()
 	if err != nil {
 		r.Reporter.Errorf("Unable to get IAM credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	operatorIAMRoleList, err := convertCredRequestsOperatorRolesIntoV1OperatorIAMRole(credRequests,
 		args.prefix, awsCreator, path)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	var hostedCPPolicies bool
@@ -148,7 +150,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 		hostedCPPolicies, err = r.AWSClient.HasHostedCPPolicies(args.installerRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Failed to determine if the Installer role ARN has hosted CP policies: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if !hostedCPPolicies {
@@ -162,15 +164,17 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	operatorRolesList, err := convertV1OperatorIAMRoleIntoOcmOperatorIamRole(operatorIAMRoleList)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	err = ocm.ValidateOperatorRolesMatchOidcProvider(r.Reporter, r.AWSClient,
 		operatorRolesList, oidcConfig.IssuerUrl(), "4.0", path, managedPolicies, true)
 	if err != nil && !awserr.IsNoSuchEntityException(err) {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
+	warnDeniedActions(r, permissionsBoundary, policies, credRequests, hostedCPPolicies, sharedVpcRoleArn != "")
+
 	switch mode {
 	case interactive.ModeAuto:
 		if !output.HasFlag() || r.Reporter.IsTerminal() {
@@ -212,27 +216,34 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 			ocm.Response:            ocm.Success,
 		})
 	case interactive.ModeManual:
+		var template *iac.Template
+		if format != "" {
+			template = iac.NewTemplate(fmt.Sprintf("ROSA operator roles with prefix '%s'", operatorRolesPrefix))
+		}
 		commands, err := buildCommandsFromPrefix(r, env,
 			operatorRolePolicyPrefix, permissionsBoundary,
 			defaultPolicyVersion, policies,
 			credRequests, managedPolicies,
 			path, operatorIAMRoleList,
-			oidcEndpointUrl, hostedCPPolicies, sharedVpcRoleArn)
+			oidcEndpointUrl, hostedCPPolicies, sharedVpcRoleArn, template)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 			r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
 				ocm.OperatorRolesPrefix: operatorRolesPrefix,
 				ocm.Response:            ocm.Failure,
 			})
 		}
+		r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
+			ocm.OperatorRolesPrefix: operatorRolesPrefix,
+		})
+		if template != nil {
+			return template.Write(os.Stdout, format)
+		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
 			r.Reporter.Infof("Run the following commands to create the operator roles:\n")
 		}
-		r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
-			ocm.OperatorRolesPrefix: operatorRolesPrefix,
-		})
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
@@ -276,7 +287,7 @@ func validateArgumentsOperatorRolesCreationByPrefix(r *rosa.Runtime, operatorRol
 	parsedURI, err := url.ParseRequestURI(oidcEndpointUrl)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if parsedURI.Scheme != helper.ProtocolHttps {
 		r.Reporter.Errorf("Expected OIDC endpoint URL '%s' to use an https:// scheme", oidcEndpointUrl)
@@ -285,7 +296,7 @@ func validateArgumentsOperatorRolesCreationByPrefix(r *rosa.Runtime, operatorRol
 	err = aws.ARNValidator(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
@@ -396,12 +407,12 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 	policies map[string]*cmv1.AWSSTSPolicy, credRequests map[string]*cmv1.STSOperator,
 	managedPolicies bool, path string,
 	operatorIAMRoleList []*cmv1.OperatorIAMRole,
-	oidcEndpointUrl string, hostedCPPolicies bool, sharedVpcRoleArn string) (string, error) {
-	if !managedPolicies {
+	oidcEndpointUrl string, hostedCPPolicies bool, sharedVpcRoleArn string, template *iac.Template) (string, error) {
+	if !managedPolicies && template == nil {
 		err := aws.GenerateOperatorRolePolicyFiles(r.Reporter, policies, credRequests, sharedVpcRoleArn, r.Creator.Partition)
 		if err != nil {
 			r.Reporter.Errorf("There was an error generating the policy files: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -416,6 +427,7 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 		}
 
 		var policyARN string
+		var templatePolicy string
 		if managedPolicies {
 			policyARN, err = aws.GetManagedPolicyARN(policies, aws.GetOperatorPolicyKey(
 				credrequest, hostedCPPolicies, false))
@@ -435,7 +447,16 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 			operatorPolicyKey := aws.GetOperatorPolicyKey(credrequest, hostedCPPolicies, isSharedVpc)
 			fileName := fmt.Sprintf("file://%s.json", operatorPolicyKey)
 			_, err = r.AWSClient.IsPolicyExists(policyARN)
-			if err != nil {
+			if err != nil && template != nil {
+				template.AddPolicy(&iac.Policy{
+					Name: name,
+					Path: path,
+					Document: iac.Document(getOperatorPolicyDocument(policies, operatorPolicyKey,
+						sharedVpcRoleArn, r.Creator.Partition)),
+					Tags: iamTags,
+				})
+				templatePolicy = name
+			} else if err != nil {
 				createPolicy := awscb.NewIAMCommandBuilder().
 					SetCommand(awscb.CreatePolicy).
 					AddParam(awscb.PolicyName, name).
@@ -456,6 +477,11 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 					AddParam(awscb.PolicyDocument, fileName).
 					AddParamNoValue(awscb.SetAsDefault).
 					Build()
+				if template != nil {
+					// Existing policies can't be part of the template, so they are updated with a command:
+					r.Reporter.Warnf("Policy '%s' already exists, run the following command to update it:\n%s",
+						policyARN, createPolicyVersion)
+				}
 				commands = append(commands, createPolicyVersion)
 			}
 		}
@@ -469,10 +495,12 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 
 		filename := fmt.Sprintf("operator_%s_policy", credrequest)
 		filename = aws.GetFormattedFileName(filename)
-		r.Reporter.Debugf("Saving '%s' to the current directory", filename)
-		err = helper.SaveDocument(policy, filename)
-		if err != nil {
-			return "", err
+		if template == nil {
+			r.Reporter.Debugf("Saving '%s' to the current directory", filename)
+			err = helper.SaveDocument(policy, filename)
+			if err != nil {
+				return "", err
+			}
 		}
 		iamTags := map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
@@ -485,6 +513,22 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 		if hostedCPPolicies {
 			iamTags[tags.HypershiftPolicies] = helper.True
 		}
+		if template != nil {
+			role := &iac.Role{
+				Name:                roleName,
+				Path:                path,
+				PermissionsBoundary: permissionsBoundary,
+				AssumeRolePolicy:    iac.Document(policy),
+				Tags:                iamTags,
+			}
+			if templatePolicy != "" {
+				role.Policies = []string{templatePolicy}
+			} else {
+				role.PolicyARNs = []string{policyARN}
+			}
+			template.AddRole(role)
+			continue
+		}
 		createRole := awscb.NewIAMCommandBuilder().
 			SetCommand(awscb.CreateRole).
 			AddParam(awscb.RoleName, roleName).
@@ -24,9 +24,11 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/iac"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -57,7 +59,10 @@ var Cmd = &cobra.Command{
   rosa create operator-roles --cluster=mycluster
 
   # Create operator roles with a specific permissions boundary
-  rosa create operator-roles -c mycluster --permissions-boundary arn:aws:iam::123456789012:policy/perm-boundary`,
+  rosa create operator-roles -c mycluster --permissions-boundary arn:aws:iam::123456789012:policy/perm-boundary
+
+  # Output a CloudFormation template that creates the operator roles, instead of 'aws' commands
+  rosa create operator-roles -c mycluster --mode manual --format cloudformation > operator-roles.json`,
 	Run:  run,
 	Args: cobra.MaximumNArgs(3),
 }
@@ -130,6 +135,7 @@ func init() {
 	flags.MarkHidden("channel-group")
 
 	interactive.AddModeFlag(Cmd)
+	iac.AddFormatFlag(Cmd)
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
 }
@@ -154,13 +160,18 @@ func run(cmd *cobra.Command, argv []string) {
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	format, mode, err := iac.GetFormat(cmd, mode)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -199,7 +210,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -219,7 +230,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -227,14 +238,14 @@ func run(cmd *cobra.Command, argv []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
 	policies, err := r.OCMClient.GetPolicies("OperatorRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if args.prefix != "" {
@@ -251,26 +262,26 @@ func run(cmd *cobra.Command, argv []string) {
 		latestPolicyVersion, err := r.OCMClient.GetLatestVersion(channelGroup)
 		if err != nil {
 			r.Reporter.Errorf("Error getting latest version: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		err = handleOperatorRoleCreationByPrefix(r, env, permissionsBoundary,
-			mode, policies, latestPolicyVersion)
+			mode, format, policies, latestPolicyVersion)
 		if err != nil {
 			r.Reporter.Errorf("Error creating operator roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		return
 	}
 	latestPolicyVersion, err := r.OCMClient.GetLatestVersion(cluster.Version().ChannelGroup())
 	if err != nil {
 		r.Reporter.Errorf("Error getting latest version: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	err = handleOperatorRoleCreationByClusterKey(r, env, permissionsBoundary,
-		mode, policies, latestPolicyVersion)
+		mode, format, policies, latestPolicyVersion)
 	if err != nil {
 		r.Reporter.Errorf("Error creating operator roles: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
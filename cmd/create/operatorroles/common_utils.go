@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	errors "github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/drift"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -25,6 +27,35 @@ func computePolicyARN(creator aws.Creator,
 	return fmt.Sprintf("arn:%s:iam::%s:policy/%s", creator.Partition, creator.AccountID, policy)
 }
 
+// getOperatorPolicyDocument returns the permission policy document of an operator, with the same
+// content as the files saved by aws.GenerateOperatorRolePolicyFiles.
+func getOperatorPolicyDocument(policies map[string]*cmv1.AWSSTSPolicy, operatorPolicyKey string,
+	sharedVpcRoleArn string, partition string) string {
+	policyDetail := aws.GetPolicyDetails(policies, operatorPolicyKey)
+	if sharedVpcRoleArn != "" {
+		policyDetail = aws.InterpolatePolicyDocument(partition, policyDetail, map[string]string{
+			"shared_vpc_role_arn": sharedVpcRoleArn,
+		})
+	}
+	return policyDetail
+}
+
+// warnDeniedActions warns about the actions of the operator policies that the permissions boundary,
+// or the service control policies of the organization, deny.
+func warnDeniedActions(r *rosa.Runtime, permissionsBoundary string, policies map[string]*cmv1.AWSSTSPolicy,
+	credRequests map[string]*cmv1.STSOperator, hostedCPPolicies bool, isSharedVpc bool) {
+	keys := []string{}
+	for credrequest := range credRequests {
+		keys = append(keys, aws.GetOperatorPolicyKey(credrequest, hostedCPPolicies, isSharedVpc))
+	}
+	preflight := &drift.Preflight{
+		AWSClient: r.AWSClient,
+		Partition: r.Creator.Partition,
+		Policies:  policies,
+	}
+	preflight.Warn(r.Reporter, permissionsBoundary, keys)
+}
+
 func validateIngressOperatorPolicyOverride(r *rosa.Runtime, policyArn string, sharedVpcRoleArn string,
 	installerRolePrefix string) error {
 	_, err := r.AWSClient.IsPolicyExists(policyArn)
@@ -36,6 +36,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/properties"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -165,7 +166,7 @@ func run(cmd *cobra.Command, argv []string) {
 	args.AwsRegion, err = aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Debugf("Using AWS region: %q", args.AwsRegion)
 
@@ -183,7 +184,7 @@ func run(cmd *cobra.Command, argv []string) {
 	version, err := r.OCMClient.ManagedServiceVersionInquiry(args.ServiceType)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	versionMajorMinor := ocm.GetVersionMinor(version)
 
@@ -191,7 +192,7 @@ func run(cmd *cobra.Command, argv []string) {
 	addOn, err := r.OCMClient.GetAddOn(args.ServiceType)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-on %q: %s", args.ServiceType, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	parameters := addOn.Parameters()
 
@@ -263,7 +264,7 @@ func run(cmd *cobra.Command, argv []string) {
 		subnets, err := r.AWSClient.ListSubnets()
 		if err != nil {
 			r.Reporter.Errorf("Failed to get the list of subnets: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		mapSubnetToAZ := make(map[string]string)
@@ -318,7 +319,7 @@ func run(cmd *cobra.Command, argv []string) {
 	roleARNs, err := r.AWSClient.FindRoleARNs(aws.InstallerAccountRole, versionMajorMinor)
 	if err != nil {
 		r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if len(roleARNs) > 1 {
@@ -359,7 +360,7 @@ func run(cmd *cobra.Command, argv []string) {
 			roleARNs, err := r.AWSClient.FindRoleARNs(roleType, versionMajorMinor)
 			if err != nil {
 				r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			selectedARN := ""
 			for _, rARN := range roleARNs {
@@ -397,7 +398,7 @@ func run(cmd *cobra.Command, argv []string) {
 	path, err := aws.GetPathFromARN(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid path for  '%s': %v", roleARN, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// operator role logic.
@@ -408,7 +409,7 @@ func run(cmd *cobra.Command, argv []string) {
 	credRequests, err := r.OCMClient.GetCredRequests(false)
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	for _, operator := range credRequests {
@@ -417,7 +418,7 @@ func run(cmd *cobra.Command, argv []string) {
 			isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(version), operator.MinVersion())
 			if err != nil {
 				r.Reporter.Errorf("Error validating operator role %q version %s", operator.Name(), err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			if !isSupported {
 				continue
@@ -436,12 +437,12 @@ func run(cmd *cobra.Command, argv []string) {
 		name, err := aws.GetResourceIdFromARN(role.RoleARN)
 		if err != nil {
 			r.Reporter.Errorf("Error validating role: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		err = r.AWSClient.ValidateRoleNameAvailable(name)
 		if err != nil {
 			r.Reporter.Errorf("Error validating role: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -452,7 +453,7 @@ func run(cmd *cobra.Command, argv []string) {
 	service, err := r.OCMClient.CreateManagedService(args)
 	if err != nil {
 		r.Reporter.Errorf("Failed to create managed service: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	r.Reporter.Infof("Service created!\n\n\tService ID: %s\n", service.ID())
@@ -91,13 +91,13 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -123,7 +123,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
@@ -146,7 +146,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -154,7 +154,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -170,7 +170,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -184,7 +184,7 @@ func run(cmd *cobra.Command, _ []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -192,13 +192,13 @@ func run(cmd *cobra.Command, _ []string) {
 	currentAccount, err := r.OCMClient.GetCurrentAccount()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get current account: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	policies, err := r.OCMClient.GetPolicies("")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	switch mode {
@@ -211,7 +211,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSACreateUserRoleModeAuto", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateUserRoleModeAuto", map[string]string{
 			ocm.Response: ocm.Success,
@@ -224,7 +224,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = generateUserRolePolicyFiles(r.Reporter, env, r.Creator.Partition, currentAccount.ID(), policies)
 		if err != nil {
 			r.Reporter.Errorf("There was an error generating the policy files: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
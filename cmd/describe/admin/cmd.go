@@ -24,6 +24,7 @@ import (
 
 	cadmin "github.com/openshift/rosa/cmd/create/admin"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -65,7 +66,7 @@ func run(_ *cobra.Command, _ []string) {
 	existingClusterAdminIdp, _, err := cadmin.FindIDPWithAdmin(cluster, r)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if existingClusterAdminIdp != nil {
 		r.Reporter.Infof("There is '%s' user on cluster '%s'. To login, run the following command:\n"+
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -29,10 +30,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/clusterspec"
 	"github.com/openshift/rosa/pkg/helper/rolepolicybindings"
+	"github.com/openshift/rosa/pkg/helper/versions"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/watch"
 )
 
 const (
@@ -50,7 +55,13 @@ var Cmd = &cobra.Command{
 	Short: "Show details of a cluster",
 	Long:  "Show details of a cluster",
 	Example: `  # Describe a cluster named "mycluster"
-  rosa describe cluster --cluster=mycluster`,
+  rosa describe cluster --cluster=mycluster
+
+  # Print the spec file that creates a cluster with the same options as "mycluster"
+  rosa describe cluster --cluster=mycluster -o spec
+
+  # Keep printing the details of "mycluster" that change until it is ready
+  rosa describe cluster --cluster=mycluster --watch --until state=ready`,
 	Run:  run,
 	Args: cobra.MaximumNArgs(1),
 }
@@ -69,6 +80,7 @@ func init() {
 		false,
 		"List the attached policies for the sts roles",
 	)
+	watch.AddFlag(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -83,7 +95,47 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	clusterKey := r.GetClusterKey()
 
+	err = watch.Validate()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
 	cluster := r.FetchCluster()
+
+	if output.Output() == clusterspec.OutputFormat {
+		spec, err := clusterspec.FromCluster(cluster).Marshal()
+		if err != nil {
+			r.Reporter.Errorf("Failed to generate spec for cluster '%s': %v", clusterKey, err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		fmt.Print(string(spec))
+		return
+	}
+
+	if watch.Enabled() {
+		err = watch.Run(func(writer io.Writer) (interface{}, error) {
+			cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprint(writer, describeCluster(r, clusterKey, cluster))
+			return cluster, nil
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		return
+	}
+
+	// Print short cluster description:
+	fmt.Print(describeCluster(r, clusterKey, cluster))
+}
+
+// describeCluster returns the description of the cluster, or prints it and returns an empty string
+// if an output format was requested.
+func describeCluster(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) string {
 	isHypershift := cluster.Hypershift().Enabled()
 
 	displayName := ""
@@ -103,41 +155,41 @@ func run(cmd *cobra.Command, argv []string) {
 		scheduledUpgrade, upgradeState, err = r.OCMClient.GetScheduledUpgrade(cluster.ID())
 		if err != nil {
 			r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if output.HasFlag() {
 			f, err := formatCluster(cluster, scheduledUpgrade, upgradeState, displayName)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
-			return
+			return ""
 		}
 	} else {
 		controlPlaneScheduledUpgrade, err = r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
 		if err != nil {
 			r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if output.HasFlag() {
 			f, err := formatClusterHypershift(cluster, controlPlaneScheduledUpgrade, displayName)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
-			return
+			return ""
 		}
 	}
 
@@ -210,7 +262,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	if err != nil {
 		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Print short cluster description:
@@ -304,7 +356,7 @@ func run(cmd *cobra.Command, argv []string) {
 			rolePolicyBindings, err := r.OCMClient.ListRolePolicyBindings(cluster.ID(), true)
 			if err != nil {
 				r.Reporter.Errorf("Failed to get rolePolicyBinding: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			rolePolicyDetails = rolepolicybindings.TransformToRolePolicyDetails(rolePolicyBindings)
 		}
@@ -316,7 +368,7 @@ func run(cmd *cobra.Command, argv []string) {
 				"                            -")
 			if err != nil {
 				r.Reporter.Errorf(err.Error())
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			str = str + policyStr
 		}
@@ -334,7 +386,7 @@ func run(cmd *cobra.Command, argv []string) {
 					"                            -")
 				if err != nil {
 					r.Reporter.Errorf(err.Error())
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				str = str + policyStr
 			}
@@ -352,7 +404,7 @@ func run(cmd *cobra.Command, argv []string) {
 						"                            -")
 					if err != nil {
 						r.Reporter.Errorf(err.Error())
-						os.Exit(1)
+						os.Exit(reporter.Code(err).ExitCode())
 					}
 					str = str + policyStr
 				}
@@ -367,7 +419,7 @@ func run(cmd *cobra.Command, argv []string) {
 						"                            -")
 					if err != nil {
 						r.Reporter.Errorf(err.Error())
-						os.Exit(1)
+						os.Exit(reporter.Code(err).ExitCode())
 					}
 					str = str + policyStr
 				}
@@ -385,7 +437,7 @@ func run(cmd *cobra.Command, argv []string) {
 						"   -")
 					if err != nil {
 						r.Reporter.Errorf(err.Error())
-						os.Exit(1)
+						os.Exit(reporter.Code(err).ExitCode())
 					}
 					str = str + policyStr
 				}
@@ -504,7 +556,7 @@ func run(cmd *cobra.Command, argv []string) {
 	limitedSupportReasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if len(limitedSupportReasons) > 0 {
 		str = fmt.Sprintf("%s"+"Limited Support:\n", str)
@@ -516,10 +568,14 @@ func run(cmd *cobra.Command, argv []string) {
 			str, reason.Summary(), reason.Details())
 	}
 
+	if isHypershift {
+		str += machinePoolVersionSkew(cluster, nodePools)
+	}
+
 	inflightChecks, err := r.OCMClient.GetInflightChecks(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get inflight checks for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if len(inflightChecks) > 0 {
 		summaries := []string{}
@@ -549,10 +605,32 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
-	str = fmt.Sprintf("%s\n", str)
+	return fmt.Sprintf("%s\n", str)
+}
 
-	// Print short cluster description:
-	fmt.Print(str)
+// machinePoolVersionSkew returns the section that lists the machine pools of a Hosted Control Plane
+// cluster whose versions are outside the skew supported by the version of the control plane.
+func machinePoolVersionSkew(cluster *cmv1.Cluster, nodePools []*cmv1.NodePool) string {
+	controlPlaneVersion := cluster.Version().RawID()
+	str := ""
+	for _, nodePool := range nodePools {
+		nodePoolVersion := ocm.GetRawVersionId(nodePool.Version().ID())
+		supported, err := versions.IsHostedMachinePoolVersionSkewSupported(controlPlaneVersion, nodePoolVersion)
+		if err != nil || supported {
+			continue
+		}
+		reason := fmt.Sprintf("more than %d minor versions older than the control plane",
+			versions.MinorVersionsSupported)
+		if newer, err := versions.IsGreaterThanOrEqual(nodePoolVersion, controlPlaneVersion); err == nil && newer {
+			reason = "newer than the control plane"
+		}
+		str = fmt.Sprintf("%s"+
+			" - %-24s %s, %s\n", str, nodePool.ID()+":", nodePoolVersion, reason)
+	}
+	if str == "" {
+		return ""
+	}
+	return fmt.Sprintf("Unsupported Version Skew:\n%s", str)
 }
 
 var mapInflightErrorTypeToTitle = map[string]string{
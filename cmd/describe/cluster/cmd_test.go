@@ -122,6 +122,33 @@ var _ = Describe("Cluster description", Ordered, func() {
 	})
 })
 
+var _ = Describe("Machine pool version skew", func() {
+	buildNodePool := func(id string, version string) *cmv1.NodePool {
+		nodePool, err := cmv1.NewNodePool().ID(id).Version(cmv1.NewVersion().ID("openshift-v" + version)).Build()
+		Expect(err).NotTo(HaveOccurred())
+		return nodePool
+	}
+	cluster, err := cmv1.NewCluster().Version(cmv1.NewVersion().RawID("4.15.3")).Build()
+	Expect(err).NotTo(HaveOccurred())
+
+	It("Is empty when the machine pools are within the supported skew", func() {
+		Expect(machinePoolVersionSkew(cluster, []*cmv1.NodePool{
+			buildNodePool("workers", "4.15.3"),
+			buildNodePool("old", "4.13.10"),
+		})).To(BeEmpty())
+	})
+
+	It("Lists the machine pools outside the supported skew", func() {
+		Expect(machinePoolVersionSkew(cluster, []*cmv1.NodePool{
+			buildNodePool("workers", "4.15.3"),
+			buildNodePool("older", "4.12.30"),
+			buildNodePool("newer", "4.15.4"),
+		})).To(Equal("Unsupported Version Skew:\n" +
+			" - older:                   4.12.30, more than 2 minor versions older than the control plane\n" +
+			" - newer:                   4.15.4, newer than the control plane\n"))
+	})
+})
+
 func printJson(cluster func() *cmv1.Cluster,
 	upgrade func() *cmv1.UpgradePolicy,
 	state func() *cmv1.UpgradePolicyState,
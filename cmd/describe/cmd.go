@@ -25,10 +25,12 @@ import (
 	"github.com/openshift/rosa/cmd/describe/breakglasscredential"
 	"github.com/openshift/rosa/cmd/describe/cluster"
 	"github.com/openshift/rosa/cmd/describe/externalauthprovider"
+	"github.com/openshift/rosa/cmd/describe/hibernationschedule"
 	"github.com/openshift/rosa/cmd/describe/ingress"
 	"github.com/openshift/rosa/cmd/describe/installation"
 	"github.com/openshift/rosa/cmd/describe/kubeletconfig"
 	"github.com/openshift/rosa/cmd/describe/machinepool"
+	"github.com/openshift/rosa/cmd/describe/maintenancewindow"
 	"github.com/openshift/rosa/cmd/describe/service"
 	"github.com/openshift/rosa/cmd/describe/tuningconfigs"
 	"github.com/openshift/rosa/cmd/describe/upgrade"
@@ -52,6 +54,8 @@ func init() {
 		machinePoolCommand, kubeletconfig,
 		autoscaler.NewDescribeAutoscalerCommand(), ingressCommand,
 		externalauthprovider.Cmd, breakglasscredential.Cmd,
+		hibernationschedule.NewDescribeHibernationScheduleCommand(),
+		maintenancewindow.NewDescribeMaintenanceWindowCommand(),
 	}
 	for _, cmd := range cmds {
 		Cmd.AddCommand(cmd)
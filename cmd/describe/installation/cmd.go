@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -81,7 +82,7 @@ func run(_ *cobra.Command, _ []string) {
 
 	if err := describeAddonInstallation(r, args.installationKey); err != nil {
 		r.Reporter.Errorf("Failed to describe add-on installation: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
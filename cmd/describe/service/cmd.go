@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -66,7 +67,7 @@ func run(cmd *cobra.Command, _ []string) {
 	service, err := r.OCMClient.GetManagedService(args)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get service with id %q: %v", args.ID, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	fmt.Printf(`%-28s%s
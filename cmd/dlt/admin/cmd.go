@@ -27,6 +27,7 @@ import (
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -41,7 +42,7 @@ func (d *DeleteAdminIDP) deleteAdmin(r *rosa.Runtime, identityProvider *cmv1.Ide
 	if err != nil {
 		r.Reporter.Errorf("Failed to delete htpasswd idp '%s' of cluster '%s': %s",
 			identityProvider.ID(), r.ClusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
@@ -56,21 +57,21 @@ func (d *DeleteUserAdminFromIDP) deleteAdmin(r *rosa.Runtime, identityProvider *
 	if err != nil {
 		r.Reporter.Errorf("Failed to delete '%s' user from htpasswd idp users list of cluster '%s': %s",
 			cadmin.ClusterAdminUsername, r.ClusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	users, err := r.OCMClient.GetHTPasswdUserList(clusterID, identityProvider.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to list htpasswd idp users of cluster '%s': %s",
 			r.ClusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	htpasswdIdentityProvider, ok := identityProvider.GetHtpasswd()
 	if !ok {
 		r.Reporter.Errorf("Failed to get htpasswd idp of cluster '%s': %s",
 			r.ClusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if users.Len() == 0 && htpasswdIdentityProvider.Username() == "" {
@@ -79,7 +80,7 @@ func (d *DeleteUserAdminFromIDP) deleteAdmin(r *rosa.Runtime, identityProvider *
 		if err != nil {
 			r.Reporter.Errorf("Failed to delete htpasswd idp '%s' of cluster '%s': %s",
 				identityProvider.ID(), r.ClusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 }
@@ -119,7 +120,7 @@ func run(_ *cobra.Command, _ []string) {
 	clusterAdminIDP, _, err := cadmin.FindIDPWithAdmin(cluster, r)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if clusterAdminIDP == nil {
@@ -134,7 +135,7 @@ func run(_ *cobra.Command, _ []string) {
 		err := r.OCMClient.DeleteUser(clusterID, admin.ClusterAdminGroupname, cadmin.ClusterAdminUsername)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		deletionStrategy := getAdminUserDeletionStrategy(r, clusterAdminIDP)
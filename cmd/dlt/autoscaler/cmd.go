@@ -23,6 +23,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -63,7 +64,7 @@ func run(_ *cobra.Command, _ []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed to delete autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Successfully deleted autoscaler configuration for cluster '%s'", cluster.ID())
 }
@@ -25,14 +25,18 @@ import (
 	"github.com/openshift/rosa/cmd/dlt/cluster"
 	"github.com/openshift/rosa/cmd/dlt/dnsdomains"
 	"github.com/openshift/rosa/cmd/dlt/externalauthprovider"
+	"github.com/openshift/rosa/cmd/dlt/hibernationschedule"
 	"github.com/openshift/rosa/cmd/dlt/idp"
 	"github.com/openshift/rosa/cmd/dlt/ingress"
 	"github.com/openshift/rosa/cmd/dlt/kubeletconfig"
 	"github.com/openshift/rosa/cmd/dlt/machinepool"
+	"github.com/openshift/rosa/cmd/dlt/maintenancewindow"
+	"github.com/openshift/rosa/cmd/dlt/network"
 	"github.com/openshift/rosa/cmd/dlt/ocmrole"
 	"github.com/openshift/rosa/cmd/dlt/oidcconfig"
 	"github.com/openshift/rosa/cmd/dlt/oidcprovider"
 	"github.com/openshift/rosa/cmd/dlt/operatorrole"
+	"github.com/openshift/rosa/cmd/dlt/orphans"
 	"github.com/openshift/rosa/cmd/dlt/service"
 	"github.com/openshift/rosa/cmd/dlt/tuningconfigs"
 	"github.com/openshift/rosa/cmd/dlt/upgrade"
@@ -60,6 +64,7 @@ func init() {
 	Cmd.AddCommand(oidcconfig.Cmd)
 	Cmd.AddCommand(oidcprovider.Cmd)
 	Cmd.AddCommand(operatorrole.Cmd)
+	Cmd.AddCommand(orphans.Cmd)
 	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(ocmrole.Cmd)
 	Cmd.AddCommand(userrole.Cmd)
@@ -70,6 +75,9 @@ func init() {
 	kubeletconfig := kubeletconfig.NewDeleteKubeletConfigCommand()
 	Cmd.AddCommand(kubeletconfig)
 	Cmd.AddCommand(externalauthprovider.Cmd)
+	Cmd.AddCommand(network.Cmd)
+	Cmd.AddCommand(hibernationschedule.NewDeleteHibernationScheduleCommand())
+	Cmd.AddCommand(maintenancewindow.NewDeleteMaintenanceWindowCommand())
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "hibernation-schedule"
+	short = "Delete the hibernation schedule of a cluster"
+	long  = "Delete the hibernation schedule of a cluster. The cluster stays in its current state, use " +
+		"'rosa resume cluster' to resume it if it is hibernating."
+	example = `  # Delete the hibernation schedule of cluster 'mycluster'
+  rosa delete hibernation-schedule -c mycluster`
+)
+
+var aliases = []string{"hibernationschedule"}
+
+func NewDeleteHibernationScheduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), DeleteHibernationScheduleRunner()),
+	}
+	ocm.AddClusterFlag(cmd)
+	confirm.AddFlag(cmd.Flags())
+	return cmd
+}
+
+func DeleteHibernationScheduleRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		clusterKey := r.GetClusterKey()
+		store, schedule, err := hibernation.Find(clusterKey)
+		if err != nil {
+			return err
+		}
+		if !confirm.Confirm("delete the hibernation schedule of cluster '%s'", schedule.ClusterName) {
+			return nil
+		}
+		err = store.Update(func(current *hibernation.Store) error {
+			current.Delete(schedule.ClusterID)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to delete the hibernation schedule: %v", err)
+		}
+		r.Reporter.Infof("Deleted hibernation schedule of cluster '%s'", schedule.ClusterName)
+		return nil
+	}
+}
@@ -26,6 +26,7 @@ import (
 	cadmin "github.com/openshift/rosa/cmd/create/admin"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -71,7 +72,7 @@ func run(_ *cobra.Command, argv []string) {
 	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	var idp *cmv1.IdentityProvider
@@ -89,7 +90,7 @@ func run(_ *cobra.Command, argv []string) {
 		clusterAdminIDP, _, err := cadmin.FindIDPWithAdmin(cluster, r)
 		if err != nil {
 			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if clusterAdminIDP != nil && clusterAdminIDP.Name() == idp.Name() {
 			r.Reporter.Warnf("The cluster-admin user is contained in the HTPasswd IDP. Deleting the IDP will " +
@@ -102,7 +103,7 @@ func run(_ *cobra.Command, argv []string) {
 		if err != nil {
 			r.Reporter.Errorf("Failed to delete identity provider '%s' on cluster '%s': %s",
 				idpName, clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Successfully deleted identity provider '%s' from cluster '%s'", idpName, clusterKey)
 	}
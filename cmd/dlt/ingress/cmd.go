@@ -26,6 +26,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -79,7 +80,7 @@ func run(_ *cobra.Command, argv []string) {
 	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	var ingress *cmv1.Ingress
@@ -105,7 +106,7 @@ func run(_ *cobra.Command, argv []string) {
 		if err != nil {
 			r.Reporter.Errorf("Failed to delete ingress '%s' on cluster '%s': %s",
 				ingress.ID(), clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Successfully deleted ingress '%s' from cluster '%s'", ingressID, clusterKey)
 	}
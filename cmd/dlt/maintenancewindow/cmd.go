@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "maintenance-window"
+	short = "Delete the maintenance window of a cluster"
+	long  = "Delete the maintenance window of a cluster. Upgrades and changes that recreate its nodes are " +
+		"allowed at any time afterwards."
+	example = `  # Delete the maintenance window of cluster 'mycluster'
+  rosa delete maintenance-window -c mycluster`
+)
+
+var aliases = []string{"maintenancewindow"}
+
+func NewDeleteMaintenanceWindowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), DeleteMaintenanceWindowRunner()),
+	}
+	ocm.AddClusterFlag(cmd)
+	confirm.AddFlag(cmd.Flags())
+	return cmd
+}
+
+func DeleteMaintenanceWindowRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		clusterKey := r.GetClusterKey()
+		store, window, err := maintenance.Find(clusterKey)
+		if err != nil {
+			return err
+		}
+		if !confirm.Confirm("delete the maintenance window of cluster '%s'", window.ClusterName) {
+			return nil
+		}
+		err = store.Update(func(current *maintenance.Store) error {
+			current.Delete(window.ClusterID)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to delete the maintenance window: %v", err)
+		}
+		r.Reporter.Infof("Deleted maintenance window of cluster '%s'", window.ClusterName)
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	name string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Delete a network created with 'rosa create network'",
+	Long: "Deletes the CloudFormation stack of a network created with 'rosa create network', and all its " +
+		"resources. The clusters that use the network have to be deleted first.",
+	Example: `  # Delete the network named "mynet"
+  rosa delete network --name mynet`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the network to delete.",
+	)
+	Cmd.MarkFlagRequired("name")
+
+	interactive.AddModeFlag(Cmd)
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	mode, err := interactive.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	stack, err := r.AWSClient.GetNetworkStack(args.name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get network '%s': %v", args.name, err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	if stack == nil {
+		r.Reporter.Errorf("Network '%s' doesn't exist", args.name)
+		os.Exit(1)
+	}
+
+	switch mode {
+	case interactive.ModeManual:
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Run the following command to delete the network:\n")
+		}
+		fmt.Println(awscb.NewCloudFormationCommandBuilder().
+			SetCommand(awscb.DeleteStack).
+			AddParam(awscb.StackName, stack.Name).
+			Build())
+	case interactive.ModeAuto, "":
+		if !confirm.Prompt(false, "Delete network '%s' with VPC '%s'?", stack.Name, stack.VpcID) {
+			os.Exit(0)
+		}
+		r.Reporter.Infof("Deleting network '%s', this takes a few minutes", stack.Name)
+		err = r.AWSClient.DeleteNetworkStack(stack.Name)
+		if err != nil {
+			r.Reporter.Errorf("Failed to delete network '%s': %v", stack.Name, err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		r.Reporter.Infof("Deleted network '%s'", stack.Name)
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+		os.Exit(1)
+	}
+}
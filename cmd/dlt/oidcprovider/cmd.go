@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -89,7 +90,7 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -102,7 +103,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "OIDC provider deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OIDC provider deletion mode: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -119,7 +120,7 @@ func run(cmd *cobra.Command, argv []string) {
 				os.Exit(1)
 			}
 			r.Reporter.Errorf("Error validating cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if sub != nil {
@@ -129,10 +130,10 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			if errors.GetType(err) != errors.NotFound {
 				r.Reporter.Errorf("Error validating cluster '%s': %v", clusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			} else if sub == nil {
 				r.Reporter.Errorf("Failed to get cluster '%s': %v", r.ClusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 
 		}
@@ -164,7 +165,7 @@ func run(cmd *cobra.Command, argv []string) {
 			oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
 			if err != nil {
 				r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", args.oidcConfigId, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			oidcEndpointUrl = oidcConfig.IssuerUrl()
 		}
@@ -176,7 +177,7 @@ func run(cmd *cobra.Command, argv []string) {
 		providerArn, err = r.AWSClient.GetOpenIDConnectProviderByOidcEndpointUrl(oidcEndpointUrl)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get the OIDC provider for endpoint URL '%s': %v", oidcEndpointUrl, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if providerArn == "" {
 			r.Reporter.Infof("Provider '%s' not found.", oidcEndpointUrl)
@@ -188,7 +189,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			r.Reporter.Errorf("There was a problem checking if any clusters are using OIDC provider '%s' : %v",
 				oidcEndpointUrl, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if hasClusterUsingOidcProvider {
 			r.Reporter.Errorf("There are clusters using OIDC config '%s', can't delete the provider", oidcEndpointUrl)
@@ -204,7 +205,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err := r.AWSClient.DeleteOpenIDConnectProvider(providerArn)
 		if err != nil {
 			r.Reporter.Errorf("There was an error deleting the OIDC provider: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Successfully deleted the OIDC provider %s", providerArn)
 	case interactive.ModeManual:
@@ -0,0 +1,254 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/orphans"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "orphans",
+	Aliases: []string{"orphan"},
+	Short:   "Delete the AWS resources of deleted clusters",
+	Long: "Finds the operator roles, operator policies, OIDC providers, and the S3 buckets and secrets of " +
+		"OIDC configs, that ROSA created in the AWS account and that no cluster or OIDC config in OCM uses " +
+		"anymore, for example because the cluster was deleted without '--mode auto'. Lists them with their " +
+		"age and the reason they are orphaned, and deletes them, or prints the commands to delete them. " +
+		"Buckets and secrets are only searched in the selected region. Resources tagged with a cluster " +
+		"are only orphaned when OCM reports that the cluster doesn't exist, when it doesn't tell, for " +
+		"example because the cluster belongs to a different OCM organization, they are listed with an " +
+		"unknown owner and never deleted. Resources without a cluster tag are compared with the clusters " +
+		"and OIDC configs of the current organization only, so review the list before deleting.",
+	Example: `  # List the orphaned resources and print the commands to delete them
+  rosa delete orphans --mode manual
+
+  # Delete the orphaned resources
+  rosa delete orphans --mode auto`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	region string
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	interactive.AddModeFlag(Cmd)
+	interactive.AddFlag(flags)
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	mode, err := interactive.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		r.Reporter.Errorf("Error getting region: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	args.region = region
+
+	// Determine if interactive mode is needed
+	if !interactive.Enabled() && !cmd.Flags().Changed("mode") {
+		interactive.Enable()
+	}
+
+	var spin *spinner.Spinner
+	if r.Reporter.IsTerminal() {
+		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		r.Reporter.Infof("Looking for orphaned resources in AWS account '%s'", r.Creator.AccountID)
+		spin.Start()
+	}
+	found := findOrphans(r)
+	if spin != nil {
+		spin.Stop()
+	}
+
+	if len(found) == 0 {
+		r.Reporter.Infof("There are no orphaned resources in AWS account '%s'", r.Creator.AccountID)
+		return
+	}
+	printOrphans(found)
+
+	// Resources with an unknown owner may still be in use, so they are only listed:
+	found = deletable(found)
+	if len(found) == 0 {
+		r.Reporter.Infof("There are no orphaned resources to delete in AWS account '%s'", r.Creator.AccountID)
+		return
+	}
+
+	if interactive.Enabled() {
+		mode, err = interactive.GetOptionMode(cmd, mode, "Orphaned resources deletion mode")
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid deletion mode: %s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+
+	switch mode {
+	case interactive.ModeAuto:
+		r.OCMClient.LogEvent("ROSADeleteOrphansModeAuto", nil)
+		if !confirm.Prompt(true, "Delete the %d orphaned resources?", len(found)) {
+			os.Exit(0)
+		}
+		errOccurred := false
+		for _, orphan := range found {
+			r.Reporter.Infof("Deleting %s '%s'", orphan.Type, orphan.Name)
+			err = r.AWSClient.DeleteManagedResource(orphan.Resource)
+			if err != nil {
+				r.Reporter.Warnf("There was an error deleting %s '%s': %v", orphan.Type, orphan.Name, err)
+				errOccurred = true
+			}
+		}
+		if errOccurred {
+			r.Reporter.Errorf("Failed to delete some of the orphaned resources")
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Successfully deleted the %d orphaned resources", len(found))
+	case interactive.ModeManual:
+		r.OCMClient.LogEvent("ROSADeleteOrphansModeManual", nil)
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Run the following commands to delete the orphaned resources:\n")
+		}
+		fmt.Println(buildCommands(found))
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+		os.Exit(1)
+	}
+}
+
+func findOrphans(r *rosa.Runtime) []*orphans.Orphan {
+	clusters, err := r.OCMClient.GetClusters(r.Creator, 100)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get clusters: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	oidcConfigs, err := r.OCMClient.ListOidcConfigs(r.Creator.AccountID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get OIDC configs: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	resources, err := r.AWSClient.ListManagedResources()
+	if err != nil {
+		r.Reporter.Errorf("Failed to list the resources of AWS account '%s': %v", r.Creator.AccountID, err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	return orphans.Find(resources, clusters, oidcConfigs, func(clusterID string) orphans.ClusterState {
+		_, err := r.OCMClient.FetchClusterByID(clusterID)
+		switch {
+		case err == nil:
+			return orphans.ClusterExists
+		case errors.GetType(err) == errors.NotFound:
+			return orphans.ClusterDeleted
+		default:
+			r.Reporter.Debugf("Failed to get cluster '%s': %v", clusterID, err)
+			return orphans.ClusterUnknown
+		}
+	})
+}
+
+func deletable(found []*orphans.Orphan) []*orphans.Orphan {
+	result := []*orphans.Orphan{}
+	for _, orphan := range found {
+		if !orphan.UnknownOwner {
+			result = append(result, orphan)
+		}
+	}
+	return result
+}
+
+func printOrphans(found []*orphans.Orphan) {
+	now := time.Now()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TYPE\tNAME\tAGE\tREASON\n")
+	for _, orphan := range found {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", orphan.Type, orphan.Name, orphan.Age(now), orphan.Reason)
+	}
+	writer.Flush()
+}
+
+func buildCommands(found []*orphans.Orphan) string {
+	commands := []string{}
+	for _, orphan := range found {
+		switch orphan.Type {
+		case aws.OperatorRoleResource:
+			for _, policy := range orphan.Resource.AttachedPolicies {
+				commands = append(commands, awscb.NewIAMCommandBuilder().
+					SetCommand(awscb.DetachRolePolicy).
+					AddParam(awscb.RoleName, orphan.Name).
+					AddParam(awscb.PolicyArn, policy).
+					Build())
+			}
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DeleteRole).
+				AddParam(awscb.RoleName, orphan.Name).
+				Build())
+		case aws.OperatorPolicyResource:
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DeletePolicy).
+				AddParam(awscb.PolicyArn, orphan.ARN).
+				Build())
+		case aws.OIDCProviderResource:
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DeleteOpenIdConnectProvider).
+				AddParam(awscb.OpenIdConnectProviderArn, orphan.ARN).
+				Build())
+		case aws.OIDCSecretResource:
+			commands = append(commands, awscb.NewSecretsManagerCommandBuilder().
+				SetCommand(awscb.DeleteSecret).
+				AddParam(awscb.SecretID, orphan.ARN).
+				AddParam(awscb.Region, args.region).
+				Build())
+		case aws.OIDCBucketResource:
+			commands = append(commands, awscb.NewS3CommandBuilder().
+				SetCommand(awscb.Remove).
+				AddValueNoParam(fmt.Sprintf("s3://%s", orphan.Name)).
+				AddParamNoValue(awscb.Recursive).
+				Build())
+			commands = append(commands, awscb.NewS3CommandBuilder().
+				SetCommand(awscb.RemoveBucket).
+				AddValueNoParam(fmt.Sprintf("s3://%s", orphan.Name)).
+				Build())
+		}
+	}
+	return awscb.JoinCommands(commands)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -69,7 +70,7 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if len(argv) > 0 {
@@ -103,20 +104,20 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid user role ARN to delete from the current AWS account: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
 	err = aws.ARNValidator(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid user role ARN to delete from the current AWS account: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	err = r.AWSClient.ValidateRoleARNAccountIDMatchCallerAccountID(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if !confirm.Prompt(true, "Delete the '%s' role from the AWS account?", roleARN) {
@@ -126,7 +127,7 @@ func run(cmd *cobra.Command, argv []string) {
 	currentAccount, err := r.OCMClient.GetCurrentAccount()
 	if err != nil {
 		r.Reporter.Errorf("Error getting current account: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	linkedRoles, err := r.OCMClient.GetAccountLinkedUserRoles(currentAccount.ID())
@@ -140,14 +141,14 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "User role deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role deletion mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
 	roleName, err := aws.GetResourceIdFromARN(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	roleExistOnAWS, existingRoleARN, err := r.AWSClient.CheckRoleExists(roleName)
@@ -164,7 +165,7 @@ func run(cmd *cobra.Command, argv []string) {
 	isUserRole, err := r.AWSClient.IsUserRole(&roleName)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if !isUserRole {
 		r.Reporter.Errorf("Role '%s' is not a user role", roleName)
@@ -184,7 +185,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err := r.AWSClient.DeleteUserRole(roleName)
 		if err != nil {
 			r.Reporter.Errorf("There was an error deleting the user role: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Successfully deleted the user role")
 	case interactive.ModeManual:
@@ -192,7 +193,7 @@ func run(cmd *cobra.Command, argv []string) {
 		commands, err := buildCommands(roleName, roleARN, isLinked, r.AWSClient)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("Run the following commands to delete the user role:\n")
@@ -56,7 +56,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err := helper.Download(downloadURL, filename)
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	reporter.Infof("Successfully downloaded %s", filename)
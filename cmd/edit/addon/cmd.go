@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -82,13 +83,13 @@ func run(cmd *cobra.Command, argv []string) {
 	addonParameters, err := r.OCMClient.GetAddOnParameters(cluster.ID(), addOnID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	addOnInstallation, err := r.OCMClient.GetAddOnInstallation(cluster.ID(), addOnID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-on '%s' installation: %v", addOnID, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if addonParameters.Len() == 0 {
@@ -157,7 +158,7 @@ func run(cmd *cobra.Command, argv []string) {
 			val, err = interactive.GetAddonArgument(*param, dflt)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		val = strings.Trim(val, " ")
@@ -182,7 +183,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = r.OCMClient.UpdateAddOnInstallation(cluster.ID(), addOnID, addonArguments)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Add-on '%s' is now updating. To check the status run 'rosa list addons -c %s'", addOnID, clusterKey)
 }
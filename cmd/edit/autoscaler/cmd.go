@@ -27,6 +27,7 @@ import (
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -86,7 +87,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if autoscaler == nil {
@@ -135,7 +136,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			r.Reporter.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
 				cluster.ID(), err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		autoscalerArgs.ScaleDown.UtilizationThreshold = utilizationThreshold
 	}
@@ -144,21 +145,21 @@ func run(cmd *cobra.Command, _ []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	autoscalerConfig, err := clusterautoscaler.CreateAutoscalerConfig(autoscalerArgs)
 	if err != nil {
 		r.Reporter.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	_, err = r.OCMClient.UpdateClusterAutoscaler(cluster.ID(), autoscalerConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
 			cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	r.Reporter.Infof("Successfully updated autoscaler configuration for cluster '%s'", cluster.ID())
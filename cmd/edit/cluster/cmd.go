@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -189,7 +190,7 @@ func run(cmd *cobra.Command, _ []string) {
 	expiration, err := validateExpiration()
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if interactive.Enabled() {
@@ -263,7 +264,7 @@ func run(cmd *cobra.Command, _ []string) {
 	privateWarning, err = warnUserForOAuthHCPVisibility(r, clusterKey, cluster, privateWarning)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if interactive.Enabled() {
 		privateValue, err = interactive.GetBool(interactive.Input{
@@ -273,7 +274,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		private = &privateValue
 	} else if privateValue {
@@ -301,7 +302,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid disable-workload-monitoring value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		disableWorkloadMonitoring = &disableWorkloadMonitoringValue
 	} else if disableWorkloadMonitoringValue {
@@ -322,7 +323,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid proxy-enabled value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		enableProxy = enableProxyValue
 	}
@@ -356,7 +357,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid http proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if len(httpProxyValue) == 0 {
@@ -374,7 +375,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = ocm.ValidateHTTPProxy(*httpProxy)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -398,7 +399,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid https proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		if len(httpsProxyValue) == 0 {
 			//user skipped the prompt by pressing 'enter'
@@ -415,7 +416,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = interactive.IsURL(*httpsProxy)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -432,7 +433,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of no proxy domains/CIDR's: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		noProxySlice = helper.HandleEmptyStringOnSlice(strings.Split(noProxyInput, ","))
 	}
@@ -455,7 +456,7 @@ func run(cmd *cobra.Command, _ []string) {
 			err := aws.UserNoProxyValidator(domain)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -473,7 +474,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid -update-additional-trust-bundle value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		updateAdditionalTrustBundle = updateAdditionalTrustBundleValue
 	}
@@ -496,7 +497,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid additional trust bundle file name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if len(additionalTrustBundleFileValue) == 0 {
@@ -514,7 +515,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = ocm.ValidateAdditionalTrustBundle(*additionalTrustBundleFile)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -531,7 +532,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid update-additional-allowed-principals value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		updateAdditionalAllowedPrincipals = updateAdditionalAllowedPrincipalsValue
 	}
@@ -543,7 +544,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for Additional Allowed Principal ARNs: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		additionalAllowedPrincipals = helper.HandleEmptyStringOnSlice(strings.Split(aapInputs, ","))
 	}
@@ -554,7 +555,7 @@ func run(cmd *cobra.Command, _ []string) {
 		} else {
 			if err := roles.ValidateAdditionalAllowedPrincipals(additionalAllowedPrincipals); err != nil {
 				r.Reporter.Errorf(err.Error())
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -563,13 +564,13 @@ func run(cmd *cobra.Command, _ []string) {
 	auditLogRole, err := setAuditLogForwarding(r, cmd, cluster, args.auditLogRoleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if interactive.Enabled() && aws.IsHostedCP(cluster) {
 		auditLogRole, err = auditLogInteractivePrompt(r, cmd, cluster)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -601,7 +602,7 @@ func run(cmd *cobra.Command, _ []string) {
 				cert, err := os.ReadFile(*additionalTrustBundleFile)
 				if err != nil {
 					r.Reporter.Errorf("Failed to read additional trust bundle file: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 				*clusterConfig.AdditionalTrustBundle = string(cert)
 			}
@@ -633,7 +634,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -642,12 +643,12 @@ func run(cmd *cobra.Command, _ []string) {
 		newDeleteProtection, err := cmv1.NewDeleteProtection().Enabled(deleteProtection).Build()
 		if err != nil {
 			r.Reporter.Errorf("Failed to build delete protection: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if err := r.OCMClient.UpdateClusterDeletionProtection(cluster.ID(), newDeleteProtection); err != nil {
 			r.Reporter.Errorf("Failed to update cluster delete protection: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -655,7 +656,7 @@ func run(cmd *cobra.Command, _ []string) {
 	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Updated cluster '%s'", clusterKey)
 }
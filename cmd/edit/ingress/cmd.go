@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/interactive/consts"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -181,7 +182,7 @@ func run(cmd *cobra.Command, argv []string) {
 		hasLegacyIngressSupport, err = r.OCMClient.HasLegacyIngressSupport(cluster)
 		if err != nil {
 			r.Reporter.Errorf("There was a problem checking version compatibility: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -218,7 +219,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		private = &privArg
 	}
@@ -231,7 +232,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err := r.OCMClient.UpdateCluster(clusterKey, r.Creator, clusterConfig)
 		if err != nil {
 			r.Reporter.Errorf("Failed to update cluster API on cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Updated ingress '%s' on cluster '%s'", ingressKey, clusterKey)
 		os.Exit(0)
@@ -240,7 +241,7 @@ func run(cmd *cobra.Command, argv []string) {
 	ingress, err := r.OCMClient.GetIngress(cluster.ID(), ingressKey)
 	if err != nil {
 		r.Reporter.Errorf("Failed to fetch ingress: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	var routeSelector *string
@@ -269,7 +270,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		routeSelector = &routeSelectorArg
 	}
@@ -299,7 +300,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Load Balancer type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		lbType = &lbTypeArg
 	}
@@ -324,7 +325,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			excludedNamespaces = &excludedNamespacesArg
 		}
@@ -343,7 +344,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid Wildcard Policy: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			wildcardPolicy = &wildcardPolicyArg
 		}
@@ -364,7 +365,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid Namespace Ownership Policy: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 			namespaceOwnershipPolicy = &namespaceOwnershipPolicyArg
 		}
@@ -379,7 +380,7 @@ func run(cmd *cobra.Command, argv []string) {
 			componentRoutes, err = parseComponentRoutes(args.componentRoutes)
 			if err != nil {
 				r.Reporter.Errorf("An error occurred whilst parsing the supplied component routes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		} else if isInteractiveEnabledAndNotHcp {
 			componentRoutes = map[string]*cmv1.ComponentRouteBuilder{}
@@ -400,7 +401,7 @@ func run(cmd *cobra.Command, argv []string) {
 						})
 						if err != nil {
 							r.Reporter.Errorf("Expected a valid component route '%s': %s", parameterName, err)
-							os.Exit(1)
+							os.Exit(reporter.Code(err).ExitCode())
 						}
 						// TODO: use reflection, couldn't get it to work
 						if parameterName == hostnameParameter {
@@ -439,7 +440,7 @@ func run(cmd *cobra.Command, argv []string) {
 			routeSelectors, err = helper.GetRouteSelector(*routeSelector)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		ingressBuilder = ingressBuilder.RouteSelectors(routeSelectors)
@@ -472,7 +473,7 @@ func run(cmd *cobra.Command, argv []string) {
 	ingress, err = ingressBuilder.Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create ingress for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	sameRouteSelectors := routeSelector == nil || reflect.DeepEqual(curRouteSelectors, ingress.RouteSelectors())
@@ -504,7 +505,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed to update ingress '%s' on cluster '%s': %s",
 			ingress.ID(), clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Updated ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/machinepool"
+	"github.com/openshift/rosa/pkg/maintenance"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -161,6 +162,7 @@ func NewEditMachinePoolCommand() *cobra.Command {
 			"absolute number i.e. 1, or a percentage i.e. '20%'.",
 	)
 
+	maintenance.AddOverrideFlag(flags)
 	output.AddFlag(cmd)
 	ocm.AddClusterFlag(cmd)
 	return cmd
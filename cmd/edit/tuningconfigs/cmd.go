@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/rosa/pkg/input"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -81,7 +82,7 @@ func run(cmd *cobra.Command, argv []string) {
 	tuningConfig, err := r.OCMClient.FindTuningConfigByName(cluster.ID(), tuningConfigName)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	specPath := args.specPath
@@ -98,21 +99,21 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid spec path: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
 	tuningConfigPatch, err := buildPatchFromInputFile(specPath, tuningConfig, clusterKey)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	r.Reporter.Debugf("Updating tuning config '%s' on cluster '%s'", tuningConfig.Name(), clusterKey)
 	_, err = r.OCMClient.UpdateTuningConfig(cluster.ID(), tuningConfigPatch)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update tuning config for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Updated tuning config '%s' for cluster '%s'", tuningConfig.Name(), clusterKey)
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -129,7 +130,7 @@ func run(_ *cobra.Command, argv []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed to grant '%s' to user '%s' to cluster '%s': %s",
 			role, username, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	r.Reporter.Infof("Granted role '%s' to user '%s' on cluster '%s'", role, username, clusterKey)
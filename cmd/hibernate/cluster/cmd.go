@@ -24,6 +24,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -67,7 +68,7 @@ func run(_ *cobra.Command, _ []string) {
 	err := r.OCMClient.HibernateCluster(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof(hibernationPeriodWarning)
 	r.Reporter.Infof("Cluster '%s' is hibernating.", clusterKey)
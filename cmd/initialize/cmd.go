@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -93,11 +94,9 @@ func init() {
 	)
 	flags.MarkHidden("use-local-credentials")
 
-	// Force-load all flags from `login` into `init`
+	// Force-load all flags from `login` into `init`, including '--profile'
 	flags.AddFlagSet(login.Cmd.Flags())
 
-	arguments.AddProfileFlag(flags)
-
 	confirm.AddFlag(flags)
 }
 
@@ -111,14 +110,14 @@ func run(cmd *cobra.Command, argv []string) {
 	err := login.Call(cmd, argv, r.Reporter)
 	if err != nil {
 		r.Reporter.Errorf("Failed to login to OCM: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Get AWS region
 	awsRegion, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	supportedRegions, err := r.OCMClient.GetDatabaseRegionList()
 	if err != nil {
@@ -142,7 +141,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.OCMClient.LogEvent("ROSAInitCredentialsSTS", nil)
 		}
 		r.Reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Validate AWS credentials for current user
@@ -151,7 +150,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		r.OCMClient.LogEvent("ROSAInitCredentialsFailed", nil)
 		r.Reporter.Errorf("Error validating AWS credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if !ok {
 		r.OCMClient.LogEvent("ROSAInitCredentialsInvalid", nil)
@@ -171,7 +170,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err = deleteStack(cfClient, r.OCMClient)
 		if err != nil {
 			r.Reporter.Errorf("%v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		r.Reporter.Infof("Admin user '%s' deleted successfully!", aws.AdminUserName)
@@ -200,7 +199,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		r.OCMClient.LogEvent("ROSAInitCreateStackFailed", nil)
 		r.Reporter.Errorf("Failed to create user '%s': %v", aws.AdminUserName, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if created {
 		r.Reporter.Infof("Admin user '%s' created successfully!", aws.AdminUserName)
@@ -217,13 +216,13 @@ func run(cmd *cobra.Command, argv []string) {
 		policies, err := r.OCMClient.GetPolicies("OSDSCPPolicy")
 		if err != nil {
 			r.Reporter.Errorf("Failed to get 'osdscppolicy' for '%s': %v", aws.AdminUserName, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		isValid, err := client.ValidateSCP(&target, policies)
 		if !isValid {
 			r.OCMClient.LogEvent("ROSAInitSCPPoliciesFailed", nil)
 			r.Reporter.Errorf("Failed to verify permissions for user '%s': %v", target, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("AWS SCP policies ok")
 	} else {
@@ -36,6 +36,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -139,11 +140,11 @@ func run(cmd *cobra.Command, argv []string) {
 					err = createAddonRole(r, roleName, cr, cmd, cluster)
 					if err != nil {
 						r.Reporter.Errorf("%s", err)
-						os.Exit(1)
+						os.Exit(reporter.Code(err).ExitCode())
 					}
 				} else {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 			}
 			// TODO : verify the role has the right permissions
@@ -156,13 +157,13 @@ func run(cmd *cobra.Command, argv []string) {
 				Build()
 			if err != nil {
 				r.Reporter.Errorf("Failed to build operator role '%s': %s", roleName, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 
 			err = r.OCMClient.AddClusterOperatorRole(cluster, operatorRole)
 			if err != nil {
 				r.Reporter.Errorf("Failed to add operator role to cluster '%s': %s", clusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -170,7 +171,7 @@ func run(cmd *cobra.Command, argv []string) {
 	addonParameters, err := r.OCMClient.GetAddOnParameters(cluster.ID(), addOnID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	var addonArguments []ocm.AddOnParam
@@ -212,7 +213,7 @@ func run(cmd *cobra.Command, argv []string) {
 				val, err = interactive.GetAddonArgument(*param, param.DefaultValue())
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.Code(err).ExitCode())
 				}
 			}
 
@@ -249,7 +250,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid billing model: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	}
@@ -262,7 +263,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid account id: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -275,7 +276,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = r.OCMClient.InstallAddOn(cluster.ID(), addOnID, addonArguments, billing)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Add-on '%s' is now installing. To check the status run 'rosa list addons -c %s'",
 		addOnID, clusterKey)
@@ -289,7 +290,7 @@ func ensureAddonNotInstalled(r *rosa.Runtime, clusterID, addOnID string) {
 	installation, err := r.OCMClient.GetAddOnInstallation(clusterID, addOnID)
 	if err != nil && errors.GetType(err) != errors.NotFound {
 		r.Reporter.Errorf("An error occurred while trying to get addon installation : %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if installation != nil {
 		r.Reporter.Warnf("Addon '%s' is already installed on cluster '%s'", addOnID, clusterID)
@@ -19,19 +19,25 @@ package accountroles
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/briandowns/spinner"
+	ver "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var args struct {
-	version string
+	version       string
+	compatibility bool
+	channelGroup  string
 }
 
 var Cmd = &cobra.Command{
@@ -40,7 +46,10 @@ var Cmd = &cobra.Command{
 	Short:   "List account roles and policies",
 	Long:    "List account roles and policies for the current AWS account.",
 	Example: `  # List all account roles
-  rosa list account-roles`,
+  rosa list account-roles
+
+  # Show which OpenShift versions can be installed or upgraded to with each account role prefix
+  rosa list account-roles --compatibility`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -54,17 +63,30 @@ func init() {
 		"",
 		"List only account-roles that are associated with the given version.",
 	)
+	flags.BoolVar(
+		&args.compatibility,
+		"compatibility",
+		false,
+		"Show, for each account role prefix, the OpenShift versions that clusters can be installed with or "+
+			"upgraded to, and the clusters that use the roles.",
+	)
+	flags.StringVar(
+		&args.channelGroup,
+		"channel-group",
+		ocm.DefaultChannelGroup,
+		"Channel group of the OpenShift versions to show with '--compatibility'.",
+	)
 	output.AddFlag(Cmd)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
 	versionList, err := ocm.GetVersionMinorList(r.OCMClient)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	_, err = r.OCMClient.ValidateVersion(args.version, versionList,
@@ -91,6 +113,15 @@ func run(_ *cobra.Command, _ []string) {
 
 	if err != nil {
 		r.Reporter.Errorf("Failed to get account roles: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	if args.compatibility {
+		printCompatibility(r, accountRoles)
+		os.Exit(0)
+	}
+	if cmd.Flags().Changed("channel-group") {
+		r.Reporter.Errorf("Option '--channel-group' can only be used with '--compatibility'")
 		os.Exit(1)
 	}
 
@@ -98,7 +129,7 @@ func run(_ *cobra.Command, _ []string) {
 		err = output.Print(accountRoles)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -128,3 +159,106 @@ func run(_ *cobra.Command, _ []string) {
 	}
 	writer.Flush()
 }
+
+func printCompatibility(r *rosa.Runtime, accountRoles []aws.Role) {
+	classicMinors, hostedCPMinors, err := getMinors(r, args.channelGroup)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the versions of channel group '%s': %v", args.channelGroup, err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	sets := aws.GroupAccountRoles(accountRoles)
+	for _, set := range sets {
+		if set.HostedCP {
+			set.SetCompatibleVersions(hostedCPMinors)
+		} else {
+			set.SetCompatibleVersions(classicMinors)
+		}
+		set.Clusters = []string{}
+		installerRole, ok := set.InstallerRole()
+		if !ok {
+			continue
+		}
+		clusters, err := r.OCMClient.GetClustersUsingAccountRole(r.Creator, installerRole, 100)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the clusters using role '%s': %v", installerRole.RoleName, err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		for _, cluster := range clusters {
+			set.Clusters = append(set.Clusters, cluster.Name())
+		}
+	}
+
+	if output.HasFlag() {
+		err = output.Print(sets)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "PREFIX\tTOPOLOGY\tAWS MANAGED\tOPENSHIFT VERSION\tCOMPATIBLE VERSIONS\tCLUSTERS\n")
+	for _, set := range sets {
+		topology := "Classic"
+		if set.HostedCP {
+			topology = "Hosted CP"
+		}
+		awsManaged := "No"
+		if set.ManagedPolicies {
+			awsManaged = "Yes"
+		}
+		compatible := strings.Join(set.CompatibleVersions, ", ")
+		if len(set.MissingRoles) > 0 {
+			compatible = fmt.Sprintf("None, missing %s role", strings.Join(set.MissingRoles, ", "))
+		} else if compatible == "" {
+			compatible = "None"
+		}
+		clusters := "-"
+		if len(set.Clusters) > 0 {
+			clusters = strings.Join(set.Clusters, ", ")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			set.Prefix,
+			topology,
+			awsManaged,
+			set.Version,
+			compatible,
+			clusters,
+		)
+	}
+	writer.Flush()
+}
+
+// getMinors returns the minor versions of the channel group that support STS for classic clusters
+// and that support hosted control planes.
+func getMinors(r *rosa.Runtime, channelGroup string) (classic []string, hostedCP []string, err error) {
+	versions, err := r.OCMClient.GetVersions(channelGroup, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	classicSet := map[string]bool{}
+	hostedCPSet := map[string]bool{}
+	for _, version := range versions {
+		parsed, err := ver.NewVersion(version.RawID())
+		if err != nil {
+			return nil, nil, err
+		}
+		segments := parsed.Segments64()
+		minor := fmt.Sprintf("%d.%d", segments[0], segments[1])
+		if ocm.HasSTSSupport(version.RawID(), version.ChannelGroup()) && !classicSet[minor] {
+			classicSet[minor] = true
+			classic = append(classic, minor)
+		}
+		isHostedCP, err := ocm.HasHostedCPSupport(version)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isHostedCP && !hostedCPSet[minor] {
+			hostedCPSet[minor] = true
+			hostedCP = append(hostedCP, minor)
+		}
+	}
+	return classic, hostedCP, nil
+}
@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
@@ -26,7 +27,9 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/watch"
 )
 
 var Cmd = &cobra.Command{
@@ -35,7 +38,10 @@ var Cmd = &cobra.Command{
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # Keep listing the clusters that change until all of them are ready
+  rosa list clusters --watch --interval 1m --until state=ready`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -56,6 +62,7 @@ func init() {
 		"accounts under the same Red Hat organization")
 	flags.StringVar(&args.accountRoleArn, "account-role-arn", "", "List all clusters "+
 		"using the account role identified by the ARN")
+	watch.AddFlag(Cmd)
 }
 
 func listClustersUsingAccountRole(creator *aws.Creator, runtime *rosa.Runtime) ([]*v1.Cluster, error) {
@@ -71,33 +78,39 @@ func run(_ *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
-	// Retrieve the list of clusters:
-	var creator *aws.Creator
-	if args.listAll {
-		creator = nil
-	} else {
-		creator = r.Creator
+	err := watch.Validate()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
-	var clusters []*v1.Cluster
-	var err error
-
-	if args.accountRoleArn != "" {
-		clusters, err = listClustersUsingAccountRole(creator, r)
-	} else {
-		clusters, err = r.OCMClient.GetClusters(creator, clusterCount)
+	if watch.Enabled() {
+		err = watch.Run(func(writer io.Writer) (interface{}, error) {
+			clusters, err := getClusters(r)
+			if err != nil {
+				return nil, err
+			}
+			printClusters(writer, clusters)
+			return clusters, nil
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		return
 	}
 
+	clusters, err := getClusters(r)
 	if err != nil {
-		r.Reporter.Errorf("Failed to get clusters: %v", err)
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(clusters)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -107,8 +120,36 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
+	printClusters(os.Stdout, clusters)
+}
+
+func getClusters(r *rosa.Runtime) ([]*v1.Cluster, error) {
+	// Retrieve the list of clusters:
+	var creator *aws.Creator
+	if args.listAll {
+		creator = nil
+	} else {
+		creator = r.Creator
+	}
+
+	var clusters []*v1.Cluster
+	var err error
+
+	if args.accountRoleArn != "" {
+		clusters, err = listClustersUsingAccountRole(creator, r)
+	} else {
+		clusters, err = r.OCMClient.GetClusters(creator, clusterCount)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get clusters: %v", err)
+	}
+	return clusters, nil
+}
+
+func printClusters(out io.Writer, clusters []*v1.Cluster) {
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\n")
 	for _, cluster := range clusters {
 		typeOutput := "Classic"
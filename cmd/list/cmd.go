@@ -26,11 +26,13 @@ import (
 	"github.com/openshift/rosa/cmd/list/dnsdomains"
 	"github.com/openshift/rosa/cmd/list/externalauthprovider"
 	"github.com/openshift/rosa/cmd/list/gates"
+	"github.com/openshift/rosa/cmd/list/hibernationschedule"
 	"github.com/openshift/rosa/cmd/list/idp"
 	"github.com/openshift/rosa/cmd/list/ingress"
 	"github.com/openshift/rosa/cmd/list/instancetypes"
 	"github.com/openshift/rosa/cmd/list/kubeletconfig"
 	"github.com/openshift/rosa/cmd/list/machinepool"
+	"github.com/openshift/rosa/cmd/list/maintenancewindow"
 	"github.com/openshift/rosa/cmd/list/ocmroles"
 	"github.com/openshift/rosa/cmd/list/oidcconfig"
 	"github.com/openshift/rosa/cmd/list/oidcprovider"
@@ -80,6 +82,8 @@ func init() {
 	Cmd.AddCommand(breakglasscredential.Cmd)
 	kubeletconfig := kubeletconfig.NewListKubeletConfigsCommand()
 	Cmd.AddCommand(kubeletconfig)
+	Cmd.AddCommand(hibernationschedule.NewListHibernationSchedulesCommand())
+	Cmd.AddCommand(maintenancewindow.NewListMaintenanceWindowsCommand())
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -69,14 +70,14 @@ func run(_ *cobra.Command, _ []string) {
 	dnsDomains, err := r.OCMClient.ListDNSDomains(search)
 	if err != nil {
 		r.Reporter.Errorf("Failed to list DNS Domains: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(dnsDomains)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
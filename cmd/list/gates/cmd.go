@@ -29,6 +29,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -126,7 +127,7 @@ func run(_ *cobra.Command, _ []string) {
 		upgradePolicy, err := upgradePolicyBuilder.Build()
 		if err != nil {
 			r.Reporter.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		// check if the cluster upgrade requires gate agreements
@@ -134,7 +135,7 @@ func run(_ *cobra.Command, _ []string) {
 		if err != nil {
 			r.Reporter.Errorf("Failed to check for missing gate agreements upgrade for "+
 				"cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	} else {
 		// Query OCM for available OCP gates
@@ -144,19 +145,19 @@ func run(_ *cobra.Command, _ []string) {
 			versionGates, err = r.OCMClient.ListStsGates(version)
 			if err != nil {
 				r.Reporter.Errorf("Failed to fetch available %s gates for OCP version %s: %v", args.gate, args.version, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		case GateOCP:
 			versionGates, err = r.OCMClient.ListOcpGates(version)
 			if err != nil {
 				r.Reporter.Errorf("Failed to fetch available %s gates for OCP version %s: %v", args.gate, args.version, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		case "":
 			versionGates, err = r.OCMClient.ListAllOcpGates(version)
 			if err != nil {
 				r.Reporter.Errorf("Failed to fetch available %s gates for OCP version %s: %v", args.gate, args.version, err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		default:
 			r.Reporter.Errorf("Invalid gate. Allowed values are %s and \"\" for all", strings.Join(Gates, ","))
@@ -165,7 +166,7 @@ func run(_ *cobra.Command, _ []string) {
 
 		if err != nil {
 			r.Reporter.Errorf("Failed to fetch available OCP gates for OCP version %s: %v", err, args.version)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		if len(versionGates) == 0 {
@@ -178,7 +179,7 @@ func run(_ *cobra.Command, _ []string) {
 		err = output.Print(versionGates)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
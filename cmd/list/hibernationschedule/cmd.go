@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernationschedule
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use     = "hibernation-schedules"
+	short   = "List hibernation schedules"
+	long    = "List the schedules that hibernate and resume clusters, with the next time that they do it."
+	example = `  # List the hibernation schedules
+  rosa list hibernation-schedules`
+)
+
+var aliases = []string{"hibernationschedules", "hibernation-schedule", "hibernationschedule"}
+
+var Writer io.Writer = os.Stdout
+
+func NewListHibernationSchedulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), ListHibernationSchedulesRunner()),
+	}
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func ListHibernationSchedulesRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		store, err := hibernation.Load()
+		if err != nil {
+			return err
+		}
+		if output.HasFlag() {
+			return output.Print(store.Items)
+		}
+		if len(store.Items) == 0 {
+			r.Reporter.Infof("There are no hibernation schedules")
+			return nil
+		}
+		_, err = fmt.Fprint(Writer, hibernation.PrintSchedulesForTabularOutput(store.Items, time.Now()))
+		return err
+	}
+}
@@ -19,6 +19,7 @@ package machinepool
 import (
 	"context"
 	"fmt"
+	"io"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
@@ -27,6 +28,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/watch"
 )
 
 const (
@@ -34,7 +36,10 @@ const (
 	short   = "List cluster machine pools"
 	long    = "List machine pools configured on a cluster."
 	example = `  # List all machine pools on a cluster named "mycluster"
-  rosa list machinepools --cluster=mycluster`
+  rosa list machinepools --cluster=mycluster
+
+  # Keep listing the machine pools that change every 10 seconds
+  rosa list machinepools --cluster=mycluster --watch --interval 10s`
 )
 
 var (
@@ -54,12 +59,17 @@ func NewListMachinePoolCommand() *cobra.Command {
 
 	output.AddFlag(cmd)
 	ocm.AddClusterFlag(cmd)
+	watch.AddFlag(cmd)
 	return cmd
 }
 
 func ListMachinePoolRunner() rosa.CommandRunner {
 	return func(_ context.Context, runtime *rosa.Runtime, cmd *cobra.Command, _ []string) error {
 		clusterKey := runtime.GetClusterKey()
+		err := watch.Validate()
+		if err != nil {
+			return err
+		}
 
 		cluster := runtime.FetchCluster()
 		if cluster.State() != cmv1.ClusterStateReady &&
@@ -67,8 +77,18 @@ func ListMachinePoolRunner() rosa.CommandRunner {
 			return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 		}
 
+		if watch.Enabled() {
+			return watch.Run(func(writer io.Writer) (interface{}, error) {
+				pools, err := machinepool.WriteMachinePools(writer, runtime, clusterKey, cluster)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to list machinepools: %s", err)
+				}
+				return pools, nil
+			})
+		}
+
 		service := machinepool.NewMachinePoolService()
-		err := service.ListMachinePools(runtime, clusterKey, cluster)
+		err = service.ListMachinePools(runtime, clusterKey, cluster)
 		if err != nil {
 			return fmt.Errorf("Failed to list machinepools: %s", err)
 		}
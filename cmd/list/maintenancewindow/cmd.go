@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenancewindow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use     = "maintenance-windows"
+	short   = "List maintenance windows"
+	long    = "List the maintenance windows of clusters, with their days, hours and freeze periods."
+	example = `  # List the maintenance windows
+  rosa list maintenance-windows`
+)
+
+var aliases = []string{"maintenancewindows", "maintenance-window", "maintenancewindow"}
+
+var Writer io.Writer = os.Stdout
+
+func NewListMaintenanceWindowsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), ListMaintenanceWindowsRunner()),
+	}
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func ListMaintenanceWindowsRunner() rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		store, err := maintenance.Load()
+		if err != nil {
+			return err
+		}
+		if output.HasFlag() {
+			return output.Print(store.Items)
+		}
+		if len(store.Items) == 0 {
+			r.Reporter.Infof("There are no maintenance windows")
+			return nil
+		}
+		_, err = fmt.Fprint(Writer, maintenance.PrintWindowsForTabularOutput(store.Items))
+		return err
+	}
+}
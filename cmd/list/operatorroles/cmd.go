@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -83,7 +84,7 @@ func run(cmd *cobra.Command, _ []string) {
 	versionList, err := ocm.GetVersionMinorList(r.OCMClient)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	_, err = r.OCMClient.ValidateVersion(args.version, versionList,
@@ -109,7 +110,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		clusterId = cluster.ID()
 		args.prefix = cluster.AWS().STS().OperatorRolePrefix()
@@ -125,7 +126,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if err != nil {
 		r.Reporter.Errorf("Failed to get operator roles: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if len(operatorsMap) == 0 {
@@ -150,7 +151,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = output.Print(resource)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -190,7 +191,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OIDC Config ID: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if args.prefix != "" {
@@ -207,7 +208,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			r.Reporter.Errorf("There was a problem checking if any clusters"+
 				" are using Operator Roles Prefix '%s' : %v", args.prefix, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		fmt.Fprintf(writer, "OPERATOR NAME\tOPERATOR NAMESPACE\tROLE NAME\t"+
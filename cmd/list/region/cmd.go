@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -85,7 +86,7 @@ func run(cmd *cobra.Command, _ []string) {
 	regions, err := r.OCMClient.GetRegions(args.roleARN, args.externalID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to fetch regions: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Filter out unwanted regions
@@ -111,7 +112,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = output.Print(availableRegions)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -53,7 +54,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err := ListRhRegions(args.discoveryURL, r)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine gateway URL: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -60,7 +61,7 @@ func run(cmd *cobra.Command, argv []string) {
 	servicesList, err := r.OCMClient.ListManagedServices(1000)
 	if err != nil {
 		r.Reporter.Errorf("Failed to retrieve list of managed services: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if output.HasFlag() {
@@ -72,7 +73,7 @@ func run(cmd *cobra.Command, argv []string) {
 		output.Print(outList)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -18,6 +18,7 @@ package upgrade
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -29,7 +30,9 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/watch"
 )
 
 var args struct {
@@ -41,8 +44,13 @@ var Cmd = &cobra.Command{
 	Aliases: []string{"upgrade"},
 	Short:   "List available cluster upgrades",
 	Long:    "List available and scheduled cluster version upgrades",
-	Run:     run,
-	Args:    cobra.NoArgs,
+	Example: `  # List the available upgrades of a cluster named "mycluster"
+  rosa list upgrades --cluster=mycluster
+
+  # Keep listing the upgrades of a machine pool until its upgrade completes
+  rosa list upgrades --cluster=mycluster --machinepool=workers --watch --until version.raw_id=4.15.3`,
+	Run:  run,
+	Args: cobra.NoArgs,
 }
 
 func init() {
@@ -60,6 +68,7 @@ func init() {
 
 	confirm.AddFlag(flags)
 	output.AddFlag(Cmd)
+	watch.AddFlag(Cmd)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -68,23 +77,45 @@ func run(cmd *cobra.Command, _ []string) {
 	err := runWithRuntime(r, cmd)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
 func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 	clusterKey := r.GetClusterKey()
+	err := watch.Validate()
+	if err != nil {
+		return err
+	}
 	cluster := r.FetchCluster()
+
+	if watch.Enabled() {
+		return watch.Run(func(writer io.Writer) (interface{}, error) {
+			cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+			if err != nil {
+				return nil, err
+			}
+			return listUpgrades(r, writer, clusterKey, cluster)
+		})
+	}
+
+	_, err = listUpgrades(r, os.Stdout, clusterKey, cluster)
+	return err
+}
+
+// listUpgrades writes the table of the available upgrades of the cluster or the node pool, and
+// returns the cluster or the node pool.
+func listUpgrades(r *rosa.Runtime, out io.Writer, clusterKey string, cluster *cmv1.Cluster) (interface{}, error) {
 	isNodePool := args.nodePool != ""
 	isHypershift := ocm.IsHyperShiftCluster(cluster)
 
 	if cluster.State() != cmv1.ClusterStateReady &&
 		cluster.State() != cmv1.ClusterStateHibernating {
-		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		return nil, fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
 	}
 
 	if isNodePool && !ocm.IsHyperShiftCluster(cluster) {
-		return fmt.Errorf("The '--machinepool' option is only supported for Hosted Control Planes")
+		return nil, fmt.Errorf("The '--machinepool' option is only supported for Hosted Control Planes")
 	}
 
 	var scheduledUpgrade *cmv1.UpgradePolicy
@@ -94,20 +125,22 @@ func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 	var controlPlaneScheduledUpgrade *cmv1.ControlPlaneUpgradePolicy
 	var availableUpgrades []string
 	var err error
+	var resource interface{} = cluster
 
 	if isNodePool {
 		r.Reporter.Debugf("Loading available upgrades for node pool '%s' cluster '%s'", args.nodePool, clusterKey)
 		nodePool, nodePoolScheduledUpgrade, err = r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), clusterKey,
 			args.nodePool)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		resource = nodePool
 
 		// Get available node pool upgrades
 		availableUpgrades = ocm.GetNodePoolAvailableUpgrades(nodePool)
 		if len(availableUpgrades) == 0 {
 			r.Reporter.Infof("There are no available upgrades for machine pool '%s'", args.nodePool)
-			return nil
+			return resource, nil
 		}
 	} else {
 		// Control plane or cluster updates
@@ -117,13 +150,13 @@ func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 		} else {
 			availableUpgrades, err = r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
 			if err != nil {
-				return fmt.Errorf("Failed to get available upgrades for cluster '%s': %v", clusterKey, err)
+				return nil, fmt.Errorf("Failed to get available upgrades for cluster '%s': %v", clusterKey, err)
 			}
 		}
 
 		if len(availableUpgrades) == 0 {
 			r.Reporter.Infof("There are no available upgrades for cluster '%s'", clusterKey)
-			return nil
+			return resource, nil
 		}
 	}
 
@@ -131,7 +164,7 @@ func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 		err := output.Print(availableUpgrades)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 
 		os.Exit(0)
@@ -143,19 +176,19 @@ func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 	if !isHypershift {
 		scheduledUpgrade, upgradeState, err = r.OCMClient.GetScheduledUpgrade(cluster.ID())
 		if err != nil {
-			return fmt.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
+			return nil, fmt.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
 		}
 	} else {
 		if !isNodePool {
 			controlPlaneScheduledUpgrade, err = r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
 			if err != nil {
-				return fmt.Errorf("Failed to get scheduled control plane upgrades for cluster '%s': %v", clusterKey, err)
+				return nil, fmt.Errorf("Failed to get scheduled control plane upgrades for cluster '%s': %v", clusterKey, err)
 			}
 		}
 	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 	fmt.Fprintf(writer, "VERSION\tNOTES\n")
 	for i, availableUpgrade := range availableUpgrades {
 		notes := make([]string, 0)
@@ -183,7 +216,7 @@ func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 		fmt.Fprintf(writer, "%s\t%s\n", availableUpgrade, strings.Join(notes, " - "))
 	}
 	writer.Flush()
-	return nil
+	return resource, nil
 }
 
 func formatScheduledUpgrade(availableUpgrade string,
@@ -28,6 +28,7 @@ import (
 	"github.com/openshift/rosa/cmd/list/upgrade"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -77,7 +78,7 @@ func run(_ *cobra.Command, _ []string) {
 	versions, err := r.OCMClient.GetVersionsWithProduct(product, args.channelGroup, false)
 	if err != nil {
 		r.Reporter.Errorf("Failed to fetch versions: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	var availableVersions []*cmv1.Version
@@ -94,7 +95,7 @@ func run(_ *cobra.Command, _ []string) {
 		err = output.Print(availableVersions)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		os.Exit(0)
 	}
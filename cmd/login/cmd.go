@@ -17,6 +17,7 @@ limitations under the License.
 package login
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws/sso"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/interactive"
@@ -63,6 +65,7 @@ var args struct {
 	useAuthCode   bool
 	useDeviceCode bool
 	rhRegion      string
+	awsSSO        bool
 }
 
 var Cmd = &cobra.Command{
@@ -163,6 +166,16 @@ func init() {
 			"to see available regions.",
 	)
 	flags.MarkHidden("rh-region")
+	flags.BoolVar(
+		&args.awsSSO,
+		"aws-sso",
+		false,
+		"Also log in to AWS IAM Identity Center (SSO) with the device code flow, like 'aws sso login', "+
+			"for the AWS profile selected with '--profile' or the AWS_PROFILE environment variable. "+
+			"The temporary AWS credentials are only cached between commands when the configuration is "+
+			"kept in an OS keyring, selected with the "+properties.KeyringEnvKey+" environment variable.",
+	)
+	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 	fedramp.AddFlag(flags)
 }
@@ -173,7 +186,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 }
 
@@ -207,6 +220,13 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		os.Exit(1)
 	}
 
+	if args.awsSSO {
+		err := loginToAWSSSO(ctx, r)
+		if err != nil {
+			return err
+		}
+	}
+
 	if args.useAuthCode {
 		r.Reporter.Infof("You will now be redirected to Red Hat SSO login")
 
@@ -261,7 +281,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	err = CheckAndLogIntoFedramp(fedramp.HasFlag(cmd), fedramp.HasAdminFlag(cmd), cfg, token, r)
 	if err != nil {
 		r.Reporter.Errorf("%s", err.Error())
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	haveReqs := token != "" || (args.clientID != "" && args.clientSecret != "")
@@ -310,7 +330,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	gatewayURL, err := ocm.ResolveGatewayUrl(env, cfg)
 	if err != nil {
 		r.Reporter.Errorf("Failed to resolve gateway URL: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	var ok bool
@@ -368,7 +388,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		regValue, err := sdk.GetRhRegion(gatewayURL, args.rhRegion)
 		if err != nil {
 			r.Reporter.Errorf("Can't find region: %v", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		gatewayURL = fmt.Sprintf("https://%s", regValue.URL)
 	}
@@ -469,6 +489,23 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// loginToAWSSSO logs in to the AWS SSO of the selected profile, so that the commands that use the
+// profile don't need the AWS CLI to refresh the SSO token.
+func loginToAWSSSO(ctx context.Context, r *rosa.Runtime) error {
+	settings, err := sso.ProfileSettings(ctx, arguments.GetProfile())
+	if err != nil {
+		return fmt.Errorf("Failed to get the AWS SSO settings: %v", err)
+	}
+	err = sso.Login(ctx, settings, func(url string, code string) {
+		r.Reporter.Infof("To log in to AWS SSO, navigate to %s and check that the code is %s", url, code)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to log in to AWS SSO: %v", err)
+	}
+	r.Reporter.Infof("Logged in to AWS SSO '%s'", settings.StartURL)
+	return nil
+}
+
 func reattemptLogin(cmd *cobra.Command, argv []string) {
 	logout.Cmd.Run(cmd, argv)
 	reAttempt = true
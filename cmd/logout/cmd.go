@@ -39,6 +39,6 @@ func run(_ *cobra.Command, _ []string) {
 	err := config.Remove()
 	if err != nil {
 		reporter.Errorf("Failed to remove config file: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 }
@@ -28,6 +28,7 @@ import (
 	errors "github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -123,7 +124,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Reporter.Infof(pendingMessage)
 		} else {
 			r.Reporter.Errorf("Failed to get logs for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	printLog(logs, nil)
@@ -159,7 +160,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			if errors.GetType(err) != errors.NotFound {
 				r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 		printLog(response, spin)
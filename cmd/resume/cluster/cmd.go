@@ -24,6 +24,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -61,7 +62,7 @@ func run(_ *cobra.Command, _ []string) {
 	err := r.OCMClient.ResumeCluster(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Cluster '%s' is resuming.", clusterKey)
 }
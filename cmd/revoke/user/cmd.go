@@ -25,6 +25,7 @@ import (
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -118,7 +119,7 @@ func run(_ *cobra.Command, argv []string) {
 	user, err := r.OCMClient.GetUser(cluster.ID(), role, username)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if user == nil {
@@ -135,7 +136,7 @@ func run(_ *cobra.Command, argv []string) {
 	if err != nil {
 		r.Reporter.Errorf("Failed to revoke '%s' from user '%s' in cluster '%s': %s",
 			role, username, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Revoked role '%s' from user '%s' on cluster '%s'", role, username, clusterKey)
 }
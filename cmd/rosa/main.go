@@ -23,7 +23,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/apply"
 	"github.com/openshift/rosa/cmd/attach"
+	"github.com/openshift/rosa/cmd/cache"
 	"github.com/openshift/rosa/cmd/completion"
 	"github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
@@ -45,15 +47,23 @@ import (
 	"github.com/openshift/rosa/cmd/register"
 	"github.com/openshift/rosa/cmd/resume"
 	"github.com/openshift/rosa/cmd/revoke"
+	"github.com/openshift/rosa/cmd/schedule"
 	"github.com/openshift/rosa/cmd/token"
 	"github.com/openshift/rosa/cmd/uninstall"
 	"github.com/openshift/rosa/cmd/unlink"
 	"github.com/openshift/rosa/cmd/upgrade"
 	"github.com/openshift/rosa/cmd/verify"
 	"github.com/openshift/rosa/cmd/version"
+	"github.com/openshift/rosa/cmd/wait"
 	"github.com/openshift/rosa/cmd/whoami"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws/assumerole"
+	"github.com/openshift/rosa/pkg/aws/profile"
+	"github.com/openshift/rosa/pkg/aws/region"
+	rosacache "github.com/openshift/rosa/pkg/cache"
 	"github.com/openshift/rosa/pkg/color"
+	rosaconfig "github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/dryrun"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/reporter"
 	versionUtils "github.com/openshift/rosa/pkg/version"
@@ -65,7 +75,7 @@ var root = &cobra.Command{
 	Long: "Command line tool for Red Hat OpenShift Service on AWS.\n" +
 		"For further documentation visit " +
 		"https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws\n",
-	PersistentPreRun: versionCheck,
+	PersistentPreRun: preRun,
 	Args:             cobra.NoArgs,
 }
 
@@ -73,7 +83,12 @@ func init() {
 	// Add the command line flags:
 	fs := root.PersistentFlags()
 	color.AddFlag(root)
+	reporter.AddFlag(root)
 	arguments.AddDebugFlag(fs)
+	dryrun.AddFlag(fs)
+	assumerole.AddFlags(fs)
+	rosaconfig.AddContextFlag(fs)
+	rosacache.AddNoCacheFlag(fs)
 
 	// Register the subcommands:
 	root.AddCommand(completion.Cmd)
@@ -105,6 +120,10 @@ func init() {
 	root.AddCommand(config.Cmd)
 	root.AddCommand(attach.NewRosaAttachCommand())
 	root.AddCommand(detach.NewRosaDetachCommand())
+	root.AddCommand(apply.NewApplyCommand())
+	root.AddCommand(wait.NewWaitCommand())
+	root.AddCommand(cache.NewCacheCommand())
+	root.AddCommand(schedule.NewScheduleCommand())
 }
 
 func main() {
@@ -113,12 +132,45 @@ func main() {
 	err := root.Execute()
 	if err != nil {
 		if !strings.Contains(err.Error(), "Did you mean this?") {
-			fmt.Fprintf(os.Stderr, "Failed to execute root command: %s\n", err)
+			if reporter.LogFormat() == reporter.JSONFormat {
+				reporter.CreateReporter().Errorf("Failed to execute root command: %s", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Failed to execute root command: %s\n", err)
+			}
 		}
-		os.Exit(1)
+		// Errors returned by the root command are failures to parse the command line:
+		os.Exit(reporter.ErrorCodeValidation.ExitCode())
 	}
 }
 
+func preRun(cmd *cobra.Command, args []string) {
+	reporter.SetCommand(cmd.CommandPath())
+	err := reporter.ValidateLogFormat()
+	if err != nil {
+		reporter.CreateReporter().Errorf("%v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	applyContextDefaults()
+	versionCheck(cmd, args)
+}
+
+// applyContextDefaults makes the AWS profile and region of the selected configuration context the
+// defaults of the '--profile' and '--region' options.
+func applyContextDefaults() {
+	name, context, err := rosaconfig.CurrentContext()
+	if err != nil {
+		// Commands that load the configuration will report the error
+		reporter.CreateReporter().Debugf("Failed to load the configuration context: %v", err)
+		return
+	}
+	if context == nil {
+		return
+	}
+	reporter.CreateReporter().Debugf("Using configuration context '%s'", name)
+	profile.SetDefault(context.AWSProfile)
+	region.SetDefault(context.AWSRegion)
+}
+
 func versionCheck(cmd *cobra.Command, _ []string) {
 	if !versionUtils.ShouldRunCheck(cmd) {
 		return
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	. "github.com/openshift/rosa/pkg/test"
 )
@@ -44,6 +45,14 @@ var _ = Describe("ROSA Commands", func() {
 		assertCommandArgs(root)
 	})
 
+	It("Don't shadow the global flags", func() {
+		/*
+			Cobra silently uses the flag of the command when it has the same name than a
+			persistent flag of the root command, so the global flag can't be used in that command.
+		*/
+		assertNoShadowedFlags(root)
+	})
+
 	XIt("Re-generates the command_arg directory structure and files", func() {
 		/*
 			This test can be used to regenerate the structure_test/command_args directory and files.
@@ -64,6 +73,28 @@ func assertCommandArgs(command *cobra.Command) {
 	}
 }
 
+// Flags that have the same meaning than the global flag that they shadow:
+var shadowingFlags = map[string]bool{
+	// Simulates the creation of the cluster in OCM:
+	"rosa create cluster --dry-run": true,
+}
+
+func assertNoShadowedFlags(command *cobra.Command) {
+	for _, flags := range []*pflag.FlagSet{command.Flags(), command.PersistentFlags()} {
+		flags.VisitAll(func(flag *pflag.Flag) {
+			global := root.PersistentFlags().Lookup(flag.Name)
+			if command == root || global == nil || global == flag {
+				return
+			}
+			Expect(shadowingFlags[command.CommandPath()+" --"+flag.Name]).To(BeTrue(),
+				"Flag '--%s' of command '%s' shadows the global flag", flag.Name, command.CommandPath())
+		})
+	}
+	for _, c := range command.Commands() {
+		assertNoShadowedFlags(c)
+	}
+}
+
 func generateCommandArgsFiles(command *cobra.Command) {
 	cmdPath := filepath.Join(strings.Split(command.CommandPath(), " ")...)
 	dirPath := filepath.Join(structureTestDirectory, CommandArgDirectoryName, cmdPath)
@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "run"
+	short = "Run the hibernation schedules of clusters"
+	long  = "Hibernate and resume the clusters following their hibernation schedules. By default it keeps " +
+		"running and checks the schedules every minute. With '--once' it performs the actions that are " +
+		"due since the last run and exits, so that it can be run periodically by cron. With '--export' " +
+		"it prints a cron entry or a systemd user unit that runs it."
+	example = `  # Run the schedules till interrupted
+  rosa schedule run
+
+  # Perform the actions that are due and exit
+  rosa schedule run --once
+
+  # Install a systemd user unit that keeps running the schedules
+  rosa schedule run --export systemd > ~/.config/systemd/user/rosa-schedules.service
+  systemctl --user enable --now rosa-schedules.service`
+)
+
+var Writer io.Writer = os.Stdout
+
+type options struct {
+	once     bool
+	interval time.Duration
+	export   string
+}
+
+func NewRunCommand() *cobra.Command {
+	options := &options{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.DefaultRuntime(), RunRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(
+		&options.once,
+		"once",
+		false,
+		"Perform the actions that are due and exit.",
+	)
+	flags.DurationVar(
+		&options.interval,
+		"interval",
+		time.Minute,
+		"How often to check the schedules when running continuously.",
+	)
+	flags.StringVar(
+		&options.export,
+		"export",
+		"",
+		fmt.Sprintf("Print the configuration to run the schedules periodically instead of running them. "+
+			"Allowed values are %s.", strings.Join(hibernation.ExportFormats, ", ")),
+	)
+	return cmd
+}
+
+func RunRunner(options *options) rosa.CommandRunner {
+	return func(ctx context.Context, r *rosa.Runtime, _ *cobra.Command, _ []string) error {
+		if options.export != "" {
+			executable, err := os.Executable()
+			if err != nil {
+				return err
+			}
+			config, err := hibernation.Export(options.export, executable)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprint(Writer, config)
+			return err
+		}
+		if options.interval < time.Minute {
+			return fmt.Errorf("Expected an interval of at least one minute, got '%s'", options.interval)
+		}
+
+		store, err := hibernation.Load()
+		if err != nil {
+			return err
+		}
+		r.WithOCM()
+
+		if options.once {
+			failed, err := RunSchedules(r.Reporter, r.OCMClient, store, time.Now())
+			if err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("Failed to perform %d scheduled actions", failed)
+			}
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		r.Reporter.Infof("Running the hibernation schedules of '%s' every %s", store.Path(), options.interval)
+		ticker := time.NewTicker(options.interval)
+		defer ticker.Stop()
+		for {
+			// Failures are reported and retried in the next iteration, a long running process
+			// shouldn't stop because OCM isn't reachable for a while:
+			_, err = RunSchedules(r.Reporter, r.OCMClient, store, time.Now())
+			if err != nil {
+				r.Reporter.Errorf("Failed to run the hibernation schedules: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// RunSchedules performs the actions of the schedules that are due at the given time, saves when
+// the schedules ran, and returns the number of actions that failed.
+func RunSchedules(r *reporter.Object, client hibernation.ClusterClient, store *hibernation.Store,
+	now time.Time) (int, error) {
+	failed := 0
+	err := store.Update(func(current *hibernation.Store) error {
+		failed = 0
+		for _, schedule := range current.Items {
+			result := hibernation.Run(client, schedule, now)
+			switch {
+			case result.Action == hibernation.ActionNone:
+				r.Debugf("Nothing to do for cluster '%s'", schedule.ClusterName)
+			case result.Err != nil:
+				failed++
+				r.Errorf("Failed to %s cluster '%s' as scheduled at %s: %v", result.Action,
+					schedule.ClusterName, result.Due.Format(time.RFC3339), result.Err)
+			case result.Skipped:
+				r.Warnf("Skipped to %s cluster '%s' as scheduled at %s: %s", result.Action,
+					schedule.ClusterName, result.Due.Format(time.RFC3339), result.Message)
+			default:
+				r.Infof("%s cluster '%s' as scheduled at %s: %s", actionVerb(result.Action),
+					schedule.ClusterName, result.Due.Format(time.RFC3339), result.Message)
+			}
+		}
+		return nil
+	})
+	return failed, err
+}
+
+func actionVerb(action hibernation.Action) string {
+	if action == hibernation.ActionResume {
+		return "Resumed"
+	}
+	return "Hibernated"
+}
@@ -0,0 +1,64 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/hibernation"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var _ = Describe("Schedule run", func() {
+	Context("Create Command", func() {
+		It("Creates the command correctly", func() {
+			cmd := NewRunCommand()
+			Expect(cmd.Use).To(Equal(use))
+			Expect(cmd.Short).To(Equal(short))
+			Expect(cmd.Run).NotTo(BeNil())
+
+			flags := cmd.Flags()
+			Expect(flags.Lookup("once")).NotTo(BeNil())
+			Expect(flags.Lookup("interval").DefValue).To(Equal("1m0s"))
+			Expect(flags.Lookup("export")).NotTo(BeNil())
+		})
+	})
+
+	Context("Command Runner", func() {
+		var buffer *bytes.Buffer
+
+		BeforeEach(func() {
+			buffer = &bytes.Buffer{}
+			Writer = buffer
+			GinkgoT().Setenv(hibernation.PathEnv, filepath.Join(GinkgoT().TempDir(), "schedules.json"))
+		})
+
+		AfterEach(func() {
+			Writer = os.Stdout
+		})
+
+		It("Exports a systemd unit", func() {
+			runner := RunRunner(&options{export: hibernation.ExportSystemd})
+			Expect(runner(context.Background(), rosa.NewRuntime(), nil, nil)).To(Succeed())
+			Expect(buffer.String()).To(ContainSubstring("schedule run\n"))
+			Expect(buffer.String()).To(ContainSubstring("Environment=" + hibernation.PathEnv))
+		})
+
+		It("Fails with an unknown export format", func() {
+			runner := RunRunner(&options{export: "launchd"})
+			err := runner(context.Background(), rosa.NewRuntime(), nil, nil)
+			Expect(err).To(MatchError(ContainSubstring("Unknown export format 'launchd'")))
+		})
+
+		It("Fails with an interval shorter than a minute", func() {
+			runner := RunRunner(&options{interval: 10 * time.Second})
+			err := runner(context.Background(), rosa.NewRuntime(), nil, nil)
+			Expect(err).To(MatchError(ContainSubstring("at least one minute")))
+		})
+	})
+})
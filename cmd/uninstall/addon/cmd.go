@@ -25,6 +25,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -78,7 +79,7 @@ func run(_ *cobra.Command, argv []string) {
 	err := r.OCMClient.UninstallAddOn(cluster.ID(), addOnID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to remove add-on installation '%s' from cluster '%s': %s", addOnID, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Add-on '%s' is now uninstalling. To check the status run 'rosa list addons -c %s'",
 		addOnID, clusterKey)
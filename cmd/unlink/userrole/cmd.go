@@ -23,6 +23,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -76,7 +77,7 @@ func run(cmd *cobra.Command, argv []string) {
 		currentAccount, err := r.OCMClient.GetCurrentAccount()
 		if err != nil {
 			r.Reporter.Errorf("Error getting current account: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		accountID = currentAccount.ID()
 	}
@@ -104,14 +105,14 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid user role ARN to unlink from the current account: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if roleArn != "" {
 		err = aws.ARNValidator(roleArn)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid user role ARN to unlink from the current account: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	if !confirm.Prompt(true, "Unlink the '%s' role from the current account '%s'?", roleArn, accountID) {
@@ -128,7 +129,7 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 		r.Reporter.Errorf("Unable to unlink role ARN '%s' from the account id : '%s' : %v",
 			roleArn, accountID, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Reporter.Infof("Successfully unlinked role ARN '%s' from account '%s'", roleArn, accountID)
 }
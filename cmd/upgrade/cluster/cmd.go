@@ -18,9 +18,11 @@ package cluster
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	commonUtils "github.com/openshift-online/ocm-common/pkg/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -28,10 +30,15 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/roles"
 	"github.com/openshift/rosa/pkg/arguments"
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/maintenance"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/upgradecheck"
 )
 
 var args struct {
@@ -42,8 +49,26 @@ var args struct {
 	controlPlane             bool
 	schedule                 string
 	allowMinorVersionUpdates bool
+	check                    bool
+	includeMachinePools      bool
+	machinePoolParallelism   int
+	maxSurge                 string
+	maxUnavailable           string
 }
 
+// checkExclusiveFlags are the flags that schedule the upgrade, they can't be used with '--check'.
+var checkExclusiveFlags = []string{
+	"schedule-date", "schedule-time", "schedule", "allow-minor-version-updates", "node-drain-grace-period",
+	"control-plane", "include-machinepools", "machinepool-parallelism", "max-surge", "max-unavailable",
+	maintenance.OverrideFlag,
+}
+
+// machinePoolFlags are the flags that configure the upgrade of the machine pools, they can only be
+// used with '--include-machinepools'.
+var machinePoolFlags = []string{"machinepool-parallelism", "max-surge", "max-unavailable"}
+
+var Writer io.Writer = os.Stdout
+
 var nodeDrainOptions = []string{
 	"15 minutes",
 	"30 minutes",
@@ -62,7 +87,13 @@ var Cmd = &cobra.Command{
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgrade cluster -c mycluster --version 4.12.20`,
+  rosa upgrade cluster -c mycluster --version 4.12.20
+
+  # Upgrade the control plane of the hosted cluster "mycluster" and then its machine pools, two at a time
+  rosa upgrade cluster -c mycluster --version 4.15.3 --include-machinepools --machinepool-parallelism 2
+
+  # Check if the cluster is ready to be upgraded to 4.12.20, without scheduling the upgrade
+  rosa upgrade cluster -c mycluster --version 4.12.20 --check`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -133,6 +164,50 @@ func init() {
 		"For Hosted Control Plane, whether the upgrade should cover only the control plane",
 	)
 
+	flags.BoolVar(
+		&args.includeMachinePools,
+		"include-machinepools",
+		false,
+		"For Hosted Control Plane, upgrade the control plane and then all the machine pools to the same version. "+
+			"The command waits for the control plane upgrade to finish before upgrading the machine pools, "+
+			"in order of their identifiers.",
+	)
+
+	flags.IntVar(
+		&args.machinePoolParallelism,
+		"machinepool-parallelism",
+		1,
+		"Maximum number of machine pools upgraded at the same time with '--include-machinepools'.",
+	)
+
+	flags.StringVar(
+		&args.maxSurge,
+		"max-surge",
+		"",
+		"Overrides the max surge of the machine pools upgraded with '--include-machinepools'. "+
+			"The value is saved in the machine pools and used by their later upgrades too.",
+	)
+
+	flags.StringVar(
+		&args.maxUnavailable,
+		"max-unavailable",
+		"",
+		"Overrides the max unavailable of the machine pools upgraded with '--include-machinepools'. "+
+			"The value is saved in the machine pools and used by their later upgrades too.",
+	)
+
+	flags.BoolVar(
+		&args.check,
+		"check",
+		false,
+		"Check if the cluster is ready to be upgraded to the version, without scheduling the upgrade. "+
+			"Checks the version gates, the compatibility of the account and operator roles, the version "+
+			"skew of the machine pools, the end of life of the version, the limited support reasons and "+
+			"the add-ons. Defaults to the latest available upgrade when '--version' isn't used.",
+	)
+
+	maintenance.AddOverrideFlag(flags)
+	output.AddFlag(Cmd)
 	confirm.AddFlag(flags)
 }
 
@@ -142,7 +217,7 @@ func run(cmd *cobra.Command, _ []string) {
 	err := runWithRuntime(r, cmd)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
@@ -158,13 +233,25 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 	}
 	isHypershift := cluster.Hypershift().Enabled()
 
+	if args.check {
+		return runCheck(r, cmd, cluster, clusterKey)
+	}
+	if output.HasFlag() {
+		return fmt.Errorf("The '--output' option is only supported with '--check'")
+	}
+
 	// Check parameters preconditions
 	if args.controlPlane && !isHypershift {
 		return fmt.Errorf("The '--control-plane' option is only supported for Hosted Control Planes")
 	}
 
+	err := validateMachinePoolFlags(cmd, isHypershift, currentUpgradeScheduling.Schedule)
+	if err != nil {
+		return err
+	}
+
 	if !interactive.Enabled() {
-		if !args.controlPlane && isHypershift {
+		if !args.controlPlane && !args.includeMachinePools && isHypershift {
 			return fmt.Errorf("The '--control-plane' option is currently mandatory for Hosted Control Planes")
 		}
 	}
@@ -245,7 +332,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		mode, err = interactive.GetOptionMode(cmd, mode, "IAM Roles/Policies upgrade mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role upgrade mode: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 
@@ -293,6 +380,17 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			}
 			currentUpgradeScheduling.Schedule = schedule
 		}
+	} else {
+		nextRun, err := interactive.BuildManualUpgradeSchedule(cmd, currentUpgradeScheduling.ScheduleDate,
+			currentUpgradeScheduling.ScheduleTime)
+		if err != nil {
+			return err
+		}
+		currentUpgradeScheduling.NextRun = nextRun
+	}
+
+	if currentUpgradeScheduling.AutomaticUpgrades && args.includeMachinePools {
+		return fmt.Errorf("The '--include-machinepools' option isn't supported with automatic upgrades")
 	}
 
 	// Version
@@ -329,6 +427,11 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		clusterSpec = buildNodeDrainGracePeriod(r, cmd, cluster)
 	}
 
+	err = checkMaintenanceWindow(r, cluster, currentUpgradeScheduling)
+	if err != nil {
+		return err
+	}
+
 	// Validate version
 	if !currentUpgradeScheduling.AutomaticUpgrades {
 		version, err = ocm.CheckAndParseVersion(availableUpgrades, version, cluster)
@@ -336,7 +439,12 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			return fmt.Errorf("Error parsing version to upgrade to")
 		}
 
-		if r.Reporter.IsTerminal() && !confirm.Confirm("upgrade cluster to version '%s'", version) {
+		if args.includeMachinePools {
+			if r.Reporter.IsTerminal() && !confirm.Confirm("upgrade cluster and its machine pools to version '%s'",
+				version) {
+				os.Exit(0)
+			}
+		} else if r.Reporter.IsTerminal() && !confirm.Confirm("upgrade cluster to version '%s'", version) {
 			os.Exit(0)
 		}
 	} else {
@@ -350,8 +458,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 	if isHypershift {
 		err = createUpgradePolicyHypershift(r, clusterKey, cluster, version, currentUpgradeScheduling)
 	} else {
-		err = createUpgradePolicyClassic(r, cmd, clusterKey, cluster, version, currentUpgradeScheduling.ScheduleDate,
-			currentUpgradeScheduling.ScheduleTime)
+		err = createUpgradePolicyClassic(r, clusterKey, cluster, version, currentUpgradeScheduling.NextRun)
 	}
 	if err != nil {
 		return fmt.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
@@ -364,6 +471,93 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 	}
 
 	r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+	if args.includeMachinePools {
+		return upgradeMachinePools(r, cluster, clusterKey, version)
+	}
+	return nil
+}
+
+// validateMachinePoolFlags checks the flags that configure the upgrade of the machine pools with the
+// control plane.
+func validateMachinePoolFlags(cmd *cobra.Command, isHypershift bool, schedule string) error {
+	if !args.includeMachinePools {
+		for _, flag := range machinePoolFlags {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("The '--%s' option can only be used with '--include-machinepools'", flag)
+			}
+		}
+		return nil
+	}
+	if !isHypershift {
+		return fmt.Errorf("The '--include-machinepools' option is only supported for Hosted Control Planes")
+	}
+	if schedule != "" {
+		return fmt.Errorf("The '--include-machinepools' option is mutually exclusive with '--schedule'")
+	}
+	if args.machinePoolParallelism < 1 {
+		return fmt.Errorf("The '--machinepool-parallelism' option must be at least 1")
+	}
+	err := mpHelpers.ValidateUpgradeMaxSurgeUnavailable(args.maxSurge)
+	if err != nil {
+		return fmt.Errorf("Expected a valid value for max surge: %v", err)
+	}
+	err = mpHelpers.ValidateUpgradeMaxSurgeUnavailable(args.maxUnavailable)
+	if err != nil {
+		return fmt.Errorf("Expected a valid value for max unavailable: %v", err)
+	}
+	return nil
+}
+
+// checkMaintenanceWindow checks that the upgrade, or all the occurrences of the automatic upgrades,
+// happen in the maintenance window of the cluster.
+func checkMaintenanceWindow(r *rosa.Runtime, cluster *cmv1.Cluster, scheduling ocm.UpgradeScheduling) error {
+	if scheduling.AutomaticUpgrades {
+		return maintenance.CheckCron(r.Reporter, cluster, "Automatic upgrade", scheduling.Schedule)
+	}
+	return maintenance.CheckTime(r.Reporter, cluster, "Upgrade", scheduling.NextRun)
+}
+
+// runCheck prints the pre-flight checklist of the upgrade of the cluster, and fails if any of the
+// checks fails.
+func runCheck(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster, clusterKey string) error {
+	for _, flag := range checkExclusiveFlags {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("The '--%s' option can't be used with '--check'", flag)
+		}
+	}
+	version := args.version
+	if version == "" {
+		var availableUpgrades []string
+		var err error
+		if ocm.IsHyperShiftCluster(cluster) {
+			availableUpgrades = ocm.GetAvailableUpgradesByCluster(cluster)
+		} else {
+			availableUpgrades, err = r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+			if err != nil {
+				return fmt.Errorf("Failed to find available upgrades: %v", err)
+			}
+		}
+		if len(availableUpgrades) == 0 {
+			return fmt.Errorf("There are no available upgrades for cluster '%s', "+
+				"use '--version' to check a version", clusterKey)
+		}
+		version = availableUpgrades[0]
+	}
+
+	checklist := upgradecheck.Run(r.OCMClient, r.AWSClient, cluster, version)
+	if output.HasFlag() {
+		err := output.Print(checklist)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(Writer, "%s", upgradecheck.PrintChecklist(checklist))
+	}
+	failed := checklist.Count(upgradecheck.StatusFail)
+	if failed > 0 {
+		return fmt.Errorf("Cluster '%s' isn't ready to be upgraded to version '%s', %d checks failed",
+			clusterKey, version, failed)
+	}
 	return nil
 }
 
@@ -394,8 +588,8 @@ func createUpgradePolicyHypershift(r *rosa.Runtime, clusterKey string,
 	return nil
 }
 
-func createUpgradePolicyClassic(r *rosa.Runtime, cmd *cobra.Command, clusterKey string,
-	cluster *cmv1.Cluster, version string, scheduleDate string, scheduleTime string) error {
+func createUpgradePolicyClassic(r *rosa.Runtime, clusterKey string,
+	cluster *cmv1.Cluster, version string, nextRun time.Time) error {
 	upgradePolicyBuilder := cmv1.NewUpgradePolicy().
 		ScheduleType(cmv1.ScheduleTypeManual).
 		Version(version)
@@ -408,10 +602,6 @@ func createUpgradePolicyClassic(r *rosa.Runtime, cmd *cobra.Command, clusterKey
 		return err
 	}
 
-	nextRun, err := interactive.BuildManualUpgradeSchedule(cmd, scheduleDate, scheduleTime)
-	if err != nil {
-		return err
-	}
 	upgradePolicyBuilder = upgradePolicyBuilder.NextRun(nextRun)
 	upgradePolicy, err = upgradePolicyBuilder.Build()
 	if err != nil {
@@ -521,7 +711,7 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	isValidNodeDrainGracePeriod := false
@@ -540,7 +730,7 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 	nodeDrainValue, err := strconv.ParseFloat(nodeDrainParsed[0], commonUtils.MaxByteSize)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if nodeDrainParsed[1] == "hours" || nodeDrainParsed[1] == "hour" {
 		nodeDrainValue = nodeDrainValue * 60
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
@@ -12,6 +13,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
 
+	"github.com/openshift/rosa/pkg/maintenance"
 	"github.com/openshift/rosa/pkg/test"
 )
 
@@ -293,6 +295,72 @@ var _ = Describe("Upgrade", Ordered, func() {
 		Expect(err.Error()).To(
 			ContainSubstring("node-drain-grace-period flag is not supported to hosted clusters"))
 	})
+	It("Fails if the check is mixed with scheduling flags", func() {
+		args.check = true
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReadyWithUpdates))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(
+			ContainSubstring("The '--node-drain-grace-period' option can't be used with '--check'"))
+	})
+	It("Fails to check the upgrade if there are no available upgrades", func() {
+		args.check = true
+		args.version = ""
+		Cmd.Flags().Lookup("node-drain-grace-period").Changed = false
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+		args.check = false
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("There are no available upgrades for cluster 'cluster1', " +
+			"use '--version' to check a version"))
+	})
+	Context("Maintenance window", func() {
+		BeforeEach(func() {
+			path := filepath.Join(GinkgoT().TempDir(), "maintenance-windows.json")
+			GinkgoT().Setenv(maintenance.PathEnv, path)
+			store, err := maintenance.LoadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			store.Set(&maintenance.Window{
+				ClusterID:   test.MockClusterID,
+				ClusterName: test.MockClusterName,
+				Days:        []string{"sat", "sun"},
+				Timezone:    "UTC",
+			})
+			Expect(store.Save()).To(Succeed())
+
+			args.controlPlane = true
+			args.schedule = "20 5 * * 1"
+			args.scheduleDate = ""
+			args.scheduleTime = ""
+			args.version = ""
+			maintenance.SetOverridden(false)
+			DeferCleanup(maintenance.SetOverridden, false)
+		})
+		It("Fails if the automatic upgrades run outside the maintenance window", func() {
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				formatControlPlaneUpgradePolicyList([]*cmv1.ControlPlaneUpgradePolicy{})))
+			err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("Automatic upgrade isn't allowed by the maintenance window " +
+				"of cluster 'cluster': the occurrence of schedule '20 5 * * 1' at"))
+			Expect(err.Error()).To(ContainSubstring("Use '--override-window' to proceed anyway"))
+		})
+		It("Schedules the automatic upgrades outside the maintenance window when overridden", func() {
+			maintenance.SetOverridden(true)
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				formatControlPlaneUpgradePolicyList([]*cmv1.ControlPlaneUpgradePolicy{})))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusNoContent, ""))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusCreated, ""))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+			stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(BeNil())
+			Expect(stdout).To(ContainSubstring("INFO: Upgrade successfully scheduled for cluster 'cluster1'"))
+			Expect(stderr).To(ContainSubstring("Proceeding because of '--override-window'"))
+		})
+	})
 })
 
 func formatControlPlaneUpgradePolicyList(upgradePolicies []*cmv1.ControlPlaneUpgradePolicy) string {
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+const (
+	// machinePoolUpgradeDelay is how long after being scheduled the upgrades of the machine pools
+	// start, the same default as the upgrades scheduled with 'rosa upgrade machinepool'.
+	machinePoolUpgradeDelay = 10 * time.Minute
+
+	upgradePollInterval = time.Minute
+	upgradeTimeout      = 4 * time.Hour
+)
+
+// machinePoolsToUpgrade returns the machine pools that aren't in the version, sorted by identifier.
+func machinePoolsToUpgrade(nodePools []*cmv1.NodePool, version string) []*cmv1.NodePool {
+	result := []*cmv1.NodePool{}
+	for _, nodePool := range nodePools {
+		if ocm.GetRawVersionId(nodePool.Version().ID()) != version {
+			result = append(result, nodePool)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID() < result[j].ID()
+	})
+	return result
+}
+
+// buildManagementUpgradeUpdate returns the update of the machine pool that overrides its max surge
+// and max unavailable, or nil if none of them is overridden.
+func buildManagementUpgradeUpdate(id string, maxSurge string, maxUnavailable string) (*cmv1.NodePool, error) {
+	if maxSurge == "" && maxUnavailable == "" {
+		return nil, nil
+	}
+	mgmtUpgradeBuilder := cmv1.NewNodePoolManagementUpgrade()
+	if maxSurge != "" {
+		mgmtUpgradeBuilder.MaxSurge(maxSurge)
+	}
+	if maxUnavailable != "" {
+		mgmtUpgradeBuilder.MaxUnavailable(maxUnavailable)
+	}
+	return cmv1.NewNodePool().ID(id).ManagementUpgrade(mgmtUpgradeBuilder).Build()
+}
+
+// upgradeMachinePools waits for the upgrade of the control plane of the cluster to the version,
+// then upgrades the machine pools that aren't in the version, with at most the given number of
+// machine pools upgrading at the same time.
+func upgradeMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, version string) error {
+	r.Reporter.Infof("Waiting for the control plane of cluster '%s' to be upgraded to version '%s'",
+		clusterKey, version)
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeTimeout)
+	defer cancel()
+	err := wait.For(ctx, wait.NewUpgradeResource(r, cluster), wait.CompletedCondition, upgradePollInterval)
+	if err != nil {
+		return fmt.Errorf("Failed to upgrade the control plane of cluster '%s': %v", clusterKey, err)
+	}
+	cluster, err = r.OCMClient.GetClusterByID(cluster.ID(), r.Creator)
+	if err != nil {
+		return fmt.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+	}
+	if cluster.Version().RawID() != version {
+		return fmt.Errorf("The upgrade of the control plane of cluster '%s' finished but it is in version '%s'",
+			clusterKey, cluster.Version().RawID())
+	}
+	r.Reporter.Infof("Upgraded the control plane of cluster '%s' to version '%s'", clusterKey, version)
+
+	nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+	}
+	pending := machinePoolsToUpgrade(nodePools, version)
+	if len(pending) == 0 {
+		r.Reporter.Infof("All the machine pools of cluster '%s' are in version '%s'", clusterKey, version)
+		return nil
+	}
+	r.Reporter.Infof("Upgrading %d machine pools of cluster '%s' to version '%s', %d at a time",
+		len(pending), clusterKey, version, args.machinePoolParallelism)
+
+	jobs := make(chan *cmv1.NodePool)
+	failed := []string{}
+	var mutex sync.Mutex
+	var group sync.WaitGroup
+	for i := 0; i < args.machinePoolParallelism; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for nodePool := range jobs {
+				err := upgradeMachinePool(r, cluster, nodePool.ID(), version)
+				if err != nil {
+					r.Reporter.Errorf("Failed to upgrade machine pool '%s': %v", nodePool.ID(), err)
+					mutex.Lock()
+					failed = append(failed, nodePool.ID())
+					mutex.Unlock()
+					continue
+				}
+				r.Reporter.Infof("Upgraded machine pool '%s' to version '%s'", nodePool.ID(), version)
+			}
+		}()
+	}
+	for _, nodePool := range pending {
+		jobs <- nodePool
+	}
+	close(jobs)
+	group.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("Failed to upgrade machine pools '%s' of cluster '%s'",
+			strings.Join(failed, "', '"), clusterKey)
+	}
+	r.Reporter.Infof("Upgraded cluster '%s' and its machine pools to version '%s'", clusterKey, version)
+	return nil
+}
+
+// upgradeMachinePool schedules the upgrade of the machine pool to the version, unless it was
+// already scheduled, and waits for it to finish.
+func upgradeMachinePool(r *rosa.Runtime, cluster *cmv1.Cluster, id string, version string) error {
+	_, scheduledUpgrade, err := r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), cluster.Name(), id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case scheduledUpgrade == nil:
+		nextRun := time.Now().UTC().Add(machinePoolUpgradeDelay)
+		err = maintenance.CheckTime(r.Reporter, cluster, fmt.Sprintf("Upgrade of machine pool '%s'", id), nextRun)
+		if err != nil {
+			return err
+		}
+		update, err := buildManagementUpgradeUpdate(id, args.maxSurge, args.maxUnavailable)
+		if err != nil {
+			return err
+		}
+		if update != nil {
+			_, err = r.OCMClient.UpdateNodePool(cluster.ID(), update)
+			if err != nil {
+				return fmt.Errorf("Failed to update the max surge and max unavailable: %v", err)
+			}
+		}
+		upgradePolicy, err := r.OCMClient.BuildNodeUpgradePolicy(version, id, ocm.UpgradeScheduling{
+			NextRun: nextRun,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = r.OCMClient.ScheduleNodePoolUpgrade(cluster.ID(), id, upgradePolicy)
+		if err != nil {
+			return fmt.Errorf("Failed to schedule the upgrade: %v", err)
+		}
+		r.Reporter.Infof("Scheduled upgrade of machine pool '%s' to version '%s'", id, version)
+	case scheduledUpgrade.Version() != version:
+		return fmt.Errorf("There is already an upgrade to version '%s' scheduled", scheduledUpgrade.Version())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeTimeout)
+	defer cancel()
+	err = wait.For(ctx, wait.NewMachinePoolUpgradeResource(r, cluster, id), wait.CompletedCondition,
+		upgradePollInterval)
+	if err != nil {
+		return err
+	}
+	nodePool, exists, err := r.OCMClient.GetNodePool(cluster.ID(), id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Machine pool doesn't exist anymore")
+	}
+	current := ocm.GetRawVersionId(nodePool.Version().ID())
+	if current != version {
+		return fmt.Errorf("The upgrade finished but the machine pool is in version '%s'", current)
+	}
+	return nil
+}
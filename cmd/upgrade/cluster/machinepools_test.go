@@ -0,0 +1,151 @@
+package cluster
+
+import (
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Machine pools upgrade", func() {
+	buildNodePool := func(id string, version string) *cmv1.NodePool {
+		nodePool, err := cmv1.NewNodePool().ID(id).Version(cmv1.NewVersion().ID("openshift-v" + version)).Build()
+		Expect(err).NotTo(HaveOccurred())
+		return nodePool
+	}
+
+	It("Selects the machine pools that aren't in the version, in order", func() {
+		nodePools := machinePoolsToUpgrade([]*cmv1.NodePool{
+			buildNodePool("workers-b", "4.14.12"),
+			buildNodePool("workers-c", "4.15.3"),
+			buildNodePool("workers-a", "4.13.20"),
+		}, "4.15.3")
+		ids := []string{}
+		for _, nodePool := range nodePools {
+			ids = append(ids, nodePool.ID())
+		}
+		Expect(ids).To(Equal([]string{"workers-a", "workers-b"}))
+	})
+
+	It("Overrides the max surge and max unavailable", func() {
+		update, err := buildManagementUpgradeUpdate("workers", "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(update).To(BeNil())
+
+		update, err = buildManagementUpgradeUpdate("workers", "2", "10%")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(update.ID()).To(Equal("workers"))
+		Expect(update.ManagementUpgrade().MaxSurge()).To(Equal("2"))
+		Expect(update.ManagementUpgrade().MaxUnavailable()).To(Equal("10%"))
+
+		update, err = buildManagementUpgradeUpdate("workers", "", "1")
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := update.ManagementUpgrade().GetMaxSurge()
+		Expect(ok).To(BeFalse())
+	})
+
+	Context("Validates the flags", func() {
+		var testRuntime test.TestingRuntime
+		hypershiftCluster := test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+			c.Hypershift(cmv1.NewHypershift().Enabled(true))
+		})})
+		classicCluster := test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+			c.Hypershift(cmv1.NewHypershift().Enabled(false))
+		})})
+
+		// The other tests of the package change the arguments, so they are restored after each test:
+		saved := args
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			saved = args
+			args.schedule = ""
+			args.scheduleDate = ""
+			args.scheduleTime = ""
+			args.allowMinorVersionUpdates = false
+			args.check = false
+			args.controlPlane = false
+		})
+		AfterEach(func() {
+			args = saved
+			Cmd.Flags().Lookup("max-surge").Changed = false
+		})
+
+		It("Fails if the machine pool options are used without '--include-machinepools'", func() {
+			Expect(Cmd.Flags().Set("max-surge", "1")).To(Succeed())
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftCluster))
+			err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError(
+				"The '--max-surge' option can only be used with '--include-machinepools'"))
+		})
+
+		It("Fails if the cluster isn't a Hosted Control Plane", func() {
+			args.includeMachinePools = true
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, classicCluster))
+			err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError(
+				"The '--include-machinepools' option is only supported for Hosted Control Planes"))
+		})
+
+		It("Fails if the parallelism isn't valid", func() {
+			args.includeMachinePools = true
+			args.machinePoolParallelism = 0
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftCluster))
+			err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError("The '--machinepool-parallelism' option must be at least 1"))
+		})
+
+		It("Fails if the max surge isn't valid", func() {
+			args.includeMachinePools = true
+			args.maxSurge = "120%"
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftCluster))
+			err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError(ContainSubstring("Expected a valid value for max surge")))
+		})
+	})
+
+	Context("Maintenance window", func() {
+		var testRuntime test.TestingRuntime
+		var cluster *cmv1.Cluster
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			path := filepath.Join(GinkgoT().TempDir(), "maintenance-windows.json")
+			GinkgoT().Setenv(maintenance.PathEnv, path)
+			store, err := maintenance.LoadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			store.Set(&maintenance.Window{
+				ClusterID:   test.MockClusterID,
+				ClusterName: test.MockClusterName,
+				Timezone:    "UTC",
+				Freezes:     []maintenance.Freeze{{Name: "forever", Start: "2000-01-01", End: "2999-12-31"}},
+			})
+			Expect(store.Save()).To(Succeed())
+			maintenance.SetOverridden(false)
+			DeferCleanup(maintenance.SetOverridden, false)
+			cluster = test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			})
+		})
+
+		It("Doesn't schedule the upgrade of a machine pool outside the window", func() {
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, test.FormatResource(buildNodePool("workers", "4.14.12"))),
+				RespondWithJSON(http.StatusOK, test.FormatNodePoolUpgradePolicyList(nil)),
+			)
+			err := upgradeMachinePool(testRuntime.RosaRuntime, cluster, "workers", "4.15.3")
+			Expect(err).To(MatchError(ContainSubstring("Upgrade of machine pool 'workers' isn't allowed by " +
+				"the maintenance window of cluster '" + test.MockClusterName + "'")))
+			Expect(err).To(MatchError(ContainSubstring("in freeze period 'forever'")))
+			Expect(testRuntime.ApiServer.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/fleet"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	use   = "clusters"
+	short = "Upgrade many clusters in waves"
+	long  = "Upgrade the clusters that match a selection to a version, in waves: first the canary clusters, " +
+		"then the rest in batches. A wave starts when all the clusters of the previous one are upgraded. " +
+		"The rollout halts when an upgrade can't be scheduled, fails, takes longer than '--timeout' or " +
+		"puts the cluster in limited support. Upgrades can't be scheduled outside the maintenance window " +
+		"of the cluster, unless '--override-window' is used.\n\n" +
+		"The command first saves the plan of the rollout to the state file and asks to approve it. " +
+		"Approving the plan acknowledges the version gates that it lists. The state file records the " +
+		"progress of each cluster, run the command again with the same state file to resume an " +
+		"interrupted or halted rollout.\n\n" +
+		"The account and operator roles of STS clusters have to be compatible with the version, use " +
+		"'rosa upgrade roles' to upgrade them first."
+	example = `  # Plan the upgrade of the clusters tagged 'env=dev' to 4.15.3, and review it before approving
+  rosa upgrade clusters --selector env=dev --version 4.15.3 --plan-only
+
+  # Approve and run the plan saved by the previous command
+  rosa upgrade clusters
+
+  # Upgrade the clusters named 'test-*' in version 4.14, one canary and then batches of 20
+  # clusters with at most 5 upgrades at the same time
+  rosa upgrade clusters --name "test-*" --current-version 4.14 --version 4.15.3 --canary 1 \
+    --batch-size 20 --max-parallel 5
+
+  # Resume a halted rollout, upgrading again the clusters that failed
+  rosa upgrade clusters --state-file upgrade.json --retry-failed`
+)
+
+// DefaultStateFile is the file where the plan is saved when '--state-file' isn't used.
+const DefaultStateFile = "rosa-upgrade-clusters.json"
+
+// planFlags are the flags that define a new plan, they can't be used to resume a saved one.
+var planFlags = []string{
+	"selector", "name", "current-version", "channel-group", "version",
+	"canary", "batch-size", "max-parallel",
+}
+
+var Writer io.Writer = os.Stdout
+
+type options struct {
+	spec         fleet.Spec
+	stateFile    string
+	planOnly     bool
+	retryFailed  bool
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+func NewUpgradeClustersCommand() *cobra.Command {
+	options := &options{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), UpgradeClustersRunner(options)),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(
+		&options.spec.Selector,
+		"selector",
+		"",
+		"Upgrade the clusters whose tags match the selector, for example 'env=dev,team!=payments'.",
+	)
+	flags.StringVar(
+		&options.spec.NamePattern,
+		"name",
+		"",
+		"Upgrade the clusters whose names match the pattern, for example 'dev-*'.",
+	)
+	flags.StringVar(
+		&options.spec.CurrentVersion,
+		"current-version",
+		"",
+		"Upgrade the clusters in the version, or in the versions that start with it, for example '4.14'.",
+	)
+	flags.StringVar(
+		&options.spec.ChannelGroup,
+		"channel-group",
+		"",
+		"Upgrade the clusters of the channel group.",
+	)
+	flags.StringVar(
+		&options.spec.Version,
+		"version",
+		"",
+		"Version of OpenShift to upgrade the clusters to.",
+	)
+	flags.IntVar(
+		&options.spec.Canary,
+		"canary",
+		1,
+		"Number of clusters of the first wave.",
+	)
+	flags.IntVar(
+		&options.spec.BatchSize,
+		"batch-size",
+		10,
+		"Number of clusters of each wave after the canary.",
+	)
+	flags.IntVar(
+		&options.spec.MaxParallel,
+		"max-parallel",
+		0,
+		"Maximum number of clusters of a wave that are upgraded at the same time. Defaults to the batch size.",
+	)
+	flags.StringVar(
+		&options.stateFile,
+		"state-file",
+		DefaultStateFile,
+		"File where the plan and the progress of the rollout are saved.",
+	)
+	flags.BoolVar(
+		&options.planOnly,
+		"plan-only",
+		false,
+		"Save the plan to the state file without approving or running it.",
+	)
+	flags.BoolVar(
+		&options.retryFailed,
+		"retry-failed",
+		false,
+		"When resuming a halted rollout, upgrade again the clusters that failed instead of skipping them.",
+	)
+	flags.DurationVar(
+		&options.timeout,
+		"timeout",
+		fleet.DefaultTimeout,
+		"Maximum time that the upgrade of a cluster can take since it is scheduled.",
+	)
+	flags.DurationVar(
+		&options.pollInterval,
+		"poll-interval",
+		fleet.DefaultPollInterval,
+		"How often to check the status of the upgrades.",
+	)
+	flags.MarkHidden("poll-interval")
+	maintenance.AddOverrideFlag(flags)
+	confirm.AddFlag(flags)
+	return cmd
+}
+
+func UpgradeClustersRunner(options *options) rosa.CommandRunner {
+	return func(_ context.Context, r *rosa.Runtime, cmd *cobra.Command, _ []string) error {
+		plan, err := fleet.LoadPlan(options.stateFile)
+		if err != nil {
+			return err
+		}
+		save := func(plan *fleet.Plan) error {
+			return plan.Save(options.stateFile)
+		}
+
+		if plan != nil {
+			for _, flag := range planFlags {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("There is already a plan in '%s', remove it or use another '--state-file' "+
+						"to plan a new rollout", options.stateFile)
+				}
+			}
+			r.Reporter.Infof("Loaded the upgrade plan from '%s'", options.stateFile)
+		} else {
+			if options.spec.Version == "" {
+				return fmt.Errorf("Expected a version to upgrade the clusters to with '--version'")
+			}
+			r.Reporter.Infof("Planning the upgrade of the clusters to version '%s'", options.spec.Version)
+			clusters, err := r.OCMClient.GetClusters(nil, 100)
+			if err != nil {
+				return fmt.Errorf("Failed to get clusters: %v", err)
+			}
+			plan, err = fleet.NewPlan(r.OCMClient, clusters, options.spec, time.Now())
+			if err != nil {
+				return err
+			}
+			err = save(plan)
+			if err != nil {
+				return fmt.Errorf("Failed to save the upgrade plan: %v", err)
+			}
+			r.Reporter.Infof("Saved the upgrade plan to '%s'", options.stateFile)
+		}
+		fmt.Fprintf(Writer, "\n%s\n", fleet.PrintPlan(plan))
+
+		if len(plan.Clusters()) == 0 {
+			r.Reporter.Warnf("There are no clusters to upgrade")
+			return nil
+		}
+		retry := options.retryFailed && plan.Count(fleet.StatusFailed) > 0
+		if plan.Done() && !retry {
+			r.Reporter.Infof("All the clusters of the plan are upgraded or failed")
+			return nil
+		}
+		if options.planOnly {
+			r.Reporter.Infof("Review the plan and run 'rosa upgrade clusters --state-file %s' to approve it",
+				options.stateFile)
+			return nil
+		}
+
+		if !plan.Approved {
+			if !confirm.Prompt(false, "Approve the plan to upgrade %d clusters to version '%s'?",
+				len(plan.Clusters()), plan.Version) {
+				r.Reporter.Infof("The plan isn't approved, it stays in '%s'", options.stateFile)
+				return nil
+			}
+			plan.Approve(time.Now())
+		}
+		if plan.Halted || retry {
+			action := "skipping the clusters that failed"
+			if retry {
+				action = "upgrading again the clusters that failed"
+			}
+			if !confirm.Prompt(false, "Resume the rollout, %s?", action) {
+				return nil
+			}
+			plan.Resume(options.retryFailed)
+		}
+		err = save(plan)
+		if err != nil {
+			return fmt.Errorf("Failed to save the upgrade plan: %v", err)
+		}
+
+		executor := fleet.NewExecutor(r.OCMClient, r.Reporter, save)
+		executor.Timeout = options.timeout
+		executor.PollInterval = options.pollInterval
+		err = executor.Run(plan)
+		if err != nil {
+			r.Reporter.Infof("Fix the problem and run 'rosa upgrade clusters --state-file %s' to resume the "+
+				"rollout", options.stateFile)
+			return err
+		}
+		r.Reporter.Infof("Upgraded %d clusters to version '%s', %d failed",
+			plan.Count(fleet.StatusCompleted), plan.Version, plan.Count(fleet.StatusFailed))
+		return nil
+	}
+}
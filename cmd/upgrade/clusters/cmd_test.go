@@ -0,0 +1,88 @@
+package clusters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/fleet"
+	. "github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("rosa upgrade clusters", func() {
+	It("Correctly builds the command", func() {
+		cmd := NewUpgradeClustersCommand()
+		Expect(cmd).NotTo(BeNil())
+
+		Expect(cmd.Use).To(Equal(use))
+		Expect(cmd.Short).To(Equal(short))
+		Expect(cmd.Long).To(Equal(long))
+		Expect(cmd.Example).To(Equal(example))
+		Expect(cmd.Run).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("state-file").DefValue).To(Equal(DefaultStateFile))
+	})
+
+	Context("Upgrade clusters runner", func() {
+		var t *TestingRuntime
+
+		BeforeEach(func() {
+			t = NewTestRuntime()
+			writer := Writer
+			Writer = &bytes.Buffer{}
+			DeferCleanup(func() {
+				Writer = writer
+			})
+		})
+
+		// clusterPage returns a page of the list of clusters with the given number of installing
+		// clusters, that the plan skips without further requests.
+		clusterPage := func(page int, size int) http.HandlerFunc {
+			clusters := make([]*cmv1.Cluster, size)
+			for i := range clusters {
+				clusters[i] = MockCluster(func(c *cmv1.ClusterBuilder) {
+					c.ID(fmt.Sprintf("%d-%d", page, i))
+					c.Name(fmt.Sprintf("cluster-%d-%d", page, i))
+					c.State(cmv1.ClusterStateInstalling)
+				})
+			}
+			var items bytes.Buffer
+			Expect(cmv1.MarshalClusterList(clusters, &items)).To(Succeed())
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				ghttp.VerifyFormKV("page", fmt.Sprint(page)),
+				ghttp.VerifyFormKV("size", "100"),
+				testing.RespondWithJSON(http.StatusOK, fmt.Sprintf(
+					`{"kind": "ClusterList", "page": %d, "size": %d, "total": 101, "items": %s}`,
+					page, size, items.String())),
+			)
+		}
+
+		It("Plans the upgrade of the clusters of all the pages", func() {
+			t.ApiServer.AppendHandlers(clusterPage(1, 100), clusterPage(2, 1))
+			stateFile := filepath.Join(GinkgoT().TempDir(), "plan.json")
+			options := &options{
+				spec:      fleet.Spec{Version: "4.15.3", Canary: 1, BatchSize: 10},
+				stateFile: stateFile,
+				planOnly:  true,
+			}
+
+			err := UpgradeClustersRunner(options)(context.Background(), t.RosaRuntime,
+				NewUpgradeClustersCommand(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.ApiServer.ReceivedRequests()).To(HaveLen(2))
+
+			plan, err := fleet.LoadPlan(stateFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Skipped).To(HaveLen(101))
+			Expect(plan.Skipped[100].Name).To(Equal("cluster-2-0"))
+		})
+	})
+})
@@ -21,6 +21,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/accountroles"
 	"github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/cmd/upgrade/clusters"
 	"github.com/openshift/rosa/cmd/upgrade/machinepool"
 	"github.com/openshift/rosa/cmd/upgrade/operatorroles"
 	"github.com/openshift/rosa/cmd/upgrade/roles"
@@ -37,6 +38,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(clusters.NewUpgradeClustersCommand())
 	Cmd.AddCommand(machinepool.Cmd)
 	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(operatorroles.Cmd)
@@ -27,7 +27,9 @@ import (
 	"github.com/openshift/rosa/pkg/input"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/maintenance"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -106,6 +108,7 @@ func init() {
 	// Hidden for now as not supported yet
 	flags.MarkHidden("allow-minor-version-updates")
 
+	maintenance.AddOverrideFlag(flags)
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
 }
@@ -116,7 +119,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
 		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 }
 
@@ -208,7 +211,8 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	// Build the upgrade policy if it is a manual or automatic upgrade
 	var upgradePolicy *cmv1.NodePoolUpgradePolicy
 	if currentUpgradeScheduling.AutomaticUpgrades {
-		upgradePolicy, err = buildAutomaticUpgradePolicy(r, cmd, currentUpgradeScheduling, clusterKey, nodePool)
+		upgradePolicy, err = buildAutomaticUpgradePolicy(r, cmd, currentUpgradeScheduling, clusterKey, cluster,
+			nodePool)
 	} else {
 		upgradePolicy, err = buildManualUpgradePolicy(r, cmd, currentUpgradeScheduling, clusterKey,
 			cluster, nodePool, isVersionSet, args.version)
@@ -271,6 +275,12 @@ func buildManualUpgradePolicy(r *rosa.Runtime, cmd *cobra.Command, currentUpgrad
 			nodePool.ID(), clusterKey)
 	}
 
+	err = maintenance.CheckTime(r.Reporter, cluster, fmt.Sprintf("Upgrade of machine pool '%s'", nodePool.ID()),
+		currentUpgradeScheduling.NextRun)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ask for confirmation
 	if r.Reporter.IsTerminal() && !confirm.Confirm("upgrade machine pool '%s' to version '%s'", nodePool.ID(),
 		version) {
@@ -281,7 +291,7 @@ func buildManualUpgradePolicy(r *rosa.Runtime, cmd *cobra.Command, currentUpgrad
 }
 
 func buildAutomaticUpgradePolicy(r *rosa.Runtime, cmd *cobra.Command, currentUpgradeScheduling ocm.UpgradeScheduling,
-	clusterKey string, nodePool *cmv1.NodePool) (*cmv1.NodePoolUpgradePolicy, error) {
+	clusterKey string, cluster *cmv1.Cluster, nodePool *cmv1.NodePool) (*cmv1.NodePoolUpgradePolicy, error) {
 	var err error
 	// Build schedule
 	schedule, err := interactive.BuildAutomaticUpgradeSchedule(cmd, currentUpgradeScheduling.Schedule)
@@ -300,6 +310,12 @@ func buildAutomaticUpgradePolicy(r *rosa.Runtime, cmd *cobra.Command, currentUpg
 			nodePool.ID(), clusterKey)
 	}
 
+	err = maintenance.CheckCron(r.Reporter, cluster,
+		fmt.Sprintf("Automatic upgrade of machine pool '%s'", nodePool.ID()), currentUpgradeScheduling.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
 	// Ask for confirmation
 	if r.Reporter.IsTerminal() && !confirm.Confirm("schedule automatic upgrades for machine pool '%s' at '%s'",
 		nodePool.ID(), currentUpgradeScheduling.Schedule) {
@@ -131,7 +131,7 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	clusterUpgradeVersion := args.clusterUpgradeVersion
@@ -139,7 +139,7 @@ func run(cmd *cobra.Command, argv []string) {
 	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
 	if err != nil {
 		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 	if len(availableUpgrades) == 0 {
 		r.Reporter.Warnf("There are no available upgrades")
@@ -148,13 +148,13 @@ func run(cmd *cobra.Command, argv []string) {
 	err = ocmClient.CheckUpgradeClusterVersion(availableUpgrades, clusterUpgradeVersion, cluster)
 	if err != nil {
 		reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	managedPolicies := cluster.AWS().STS().ManagedPolicies()
@@ -162,13 +162,13 @@ func run(cmd *cobra.Command, argv []string) {
 	unifiedPath, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid path for '%s': %v", cluster.AWS().STS().RoleARN(), err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	credRequests, err := ocmClient.GetCredRequests(cluster.Hypershift().Enabled())
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -181,7 +181,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Roles upgrade mode")
 		if err != nil {
 			r.Reporter.Errorf("expected a valid Account role upgrade mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -190,7 +190,7 @@ func run(cmd *cobra.Command, argv []string) {
 		accountRolePrefix, err = aws.GetPrefixFromAccountRole(cluster, "Installer")
 		if err != nil {
 			r.Reporter.Errorf("Failed while trying to get account role prefix: '%v'", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 
 		hostedCPPolicies := aws.IsHostedCPManagedPolicies(cluster)
@@ -198,20 +198,20 @@ func run(cmd *cobra.Command, argv []string) {
 		err = roles.ValidateAccountRolesManagedPolicies(r, accountRolePrefix, hostedCPPolicies)
 		if err != nil {
 			r.Reporter.Errorf("Failed while validating managed policies: %v", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Account roles with the prefix '%s' have attached managed policies.", accountRolePrefix)
 
 		policies, err := r.OCMClient.GetPolicies("OperatorRole")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		err = roles.ValidateOperatorRolesManagedPolicies(r, cluster, credRequests, policies, mode,
 			accountRolePrefix, unifiedPath, clusterUpgradeVersion, hostedCPPolicies)
 		if err != nil {
 			r.Reporter.Errorf("Failed while validating managed policies: %v", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		r.Reporter.Infof("Cluster '%s' operator roles have attached managed policies. "+
 			"An upgrade isn't needed", cluster.Name())
@@ -224,19 +224,19 @@ func run(cmd *cobra.Command, argv []string) {
 	policyVersion, err = ocmClient.GetPolicyVersion(policyVersion, channelGroup)
 	if err != nil {
 		reporter.Errorf("Error getting version: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	err = checkPolicyAndClusterVersionCompatibility(policyVersion, clusterUpgradeVersion)
 	if err != nil {
 		reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	creator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get IAM credentials: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	var spin *spinner.Spinner
@@ -260,7 +260,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		reporter.Errorf("%s", err)
 		LogError(roles.RosaUpgradeAccRolesModeAuto, ocmClient, policyVersion, err, reporter)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	if spin != nil {
@@ -273,13 +273,13 @@ func run(cmd *cobra.Command, argv []string) {
 			reporter.Debugf(err.Error())
 		} else {
 			reporter.Errorf("Failed to get rolePolicyBinding: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	} else {
 		err = rolepolicybindings.CheckRolePolicyBindingStatus(rolePolicyBindings)
 		if err != nil {
 			reporter.Errorf("Error in rolePolicyBinding: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -294,7 +294,7 @@ func run(cmd *cobra.Command, argv []string) {
 		accountRolePolicies, err := ocmClient.GetPolicies("")
 		if err != nil {
 			reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 
 		switch mode {
@@ -320,10 +320,10 @@ func run(cmd *cobra.Command, argv []string) {
 						if reporter.IsTerminal() {
 							reporter.Infof(generateClusterUpgradeInfo(clusterKey, args.clusterUpgradeVersion, mode))
 						}
-						os.Exit(1)
+						os.Exit(rprtr.Code(err).ExitCode())
 					}
 					reporter.Errorf("Error upgrading the account role policies: %s", err)
-					os.Exit(1)
+					os.Exit(rprtr.Code(err).ExitCode())
 				}
 			}
 		case interactive.ModeManual:
@@ -332,7 +332,7 @@ func run(cmd *cobra.Command, argv []string) {
 					aws.AccountRoles, creator.Partition)
 				if err != nil {
 					reporter.Errorf("There was an error generating the policy files: %s", err)
-					os.Exit(1)
+					os.Exit(rprtr.Code(err).ExitCode())
 				}
 			}
 			if reporter.IsTerminal() {
@@ -352,7 +352,7 @@ func run(cmd *cobra.Command, argv []string) {
 			)
 			if err != nil {
 				reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(rprtr.Code(err).ExitCode())
 			}
 
 			fmt.Println(commands)
@@ -381,7 +381,7 @@ func run(cmd *cobra.Command, argv []string) {
 	operatorRolePolicyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator role policy prefix: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	isOperatorPolicyUpgradeNeeded := false
@@ -395,13 +395,13 @@ func run(cmd *cobra.Command, argv []string) {
 	)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	missingRolesInCS, err := ocmClient.FindMissingOperatorRolesForUpgrade(cluster, clusterUpgradeVersion)
 	if err != nil {
 		r.Reporter.Errorf("Error finding operator roles for upgrade '%s'", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	if spin != nil {
@@ -422,7 +422,7 @@ func run(cmd *cobra.Command, argv []string) {
 	operatorRolePolicies, err := ocmClient.GetPolicies("OperatorRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 
 	if isOperatorPolicyUpgradeNeeded {
@@ -443,7 +443,7 @@ func run(cmd *cobra.Command, argv []string) {
 			if args.isInvokedFromClusterUpgrade && reporter.IsTerminal() {
 				reporter.Infof(generateClusterUpgradeInfo(clusterKey, args.clusterUpgradeVersion, mode))
 			}
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 	}
 
@@ -454,7 +454,7 @@ func run(cmd *cobra.Command, argv []string) {
 			exists, _, err := r.AWSClient.CheckRoleExists(roleName)
 			if err != nil {
 				r.Reporter.Errorf("Error when detecting checking missing operator IAM roles %s", err)
-				os.Exit(1)
+				os.Exit(rprtr.Code(err).ExitCode())
 			}
 			if !exists {
 				err = createOperatorRole(
@@ -472,7 +472,7 @@ func run(cmd *cobra.Command, argv []string) {
 					if args.isInvokedFromClusterUpgrade && reporter.IsTerminal() {
 						reporter.Infof(generateClusterUpgradeInfo(clusterKey, args.clusterUpgradeVersion, mode))
 					}
-					os.Exit(1)
+					os.Exit(rprtr.Code(err).ExitCode())
 				}
 				createdMissingRoles++
 			}
@@ -19,25 +19,42 @@ package permissions
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
+	awsutil "github.com/aws/aws-sdk-go-v2/aws"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	roleARN  string
+	hostedCP bool
+}
+
 var Cmd = &cobra.Command{
 	Use:     "permissions",
 	Aliases: []string{"scp"},
-	Short:   "Verify AWS permissions are ok for non-STS cluster install",
-	Long:    "Verify AWS permissions needed to create a non-STS cluster are configured as expected",
+	Short:   "Verify AWS permissions are ok for cluster install",
+	Long: "Verify AWS permissions needed to create a non-STS cluster are configured as expected. " +
+		"With '--role-arn', simulate the actions needed to create an STS or hosted CP cluster with the " +
+		"given installer role, using the IAM policy simulator, and report the actions that are denied.",
 	Example: `  # Verify AWS permissions are configured correctly
   rosa verify permissions
 
   # Verify AWS permissions in a different region
-  rosa verify permissions --region=us-west-2`,
+  rosa verify permissions --region=us-west-2
+
+  # Verify the installer role allows the actions needed to create an STS cluster
+  rosa verify permissions --role-arn arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -45,6 +62,21 @@ var Cmd = &cobra.Command{
 func init() {
 	flags := Cmd.Flags()
 
+	flags.StringVar(
+		&args.roleARN,
+		"role-arn",
+		"",
+		"ARN of the installer role to verify. The actions needed to create an STS cluster are simulated "+
+			"with the policies of the role, its permissions boundary and the service control policies.",
+	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Simulate the actions needed to create a hosted CP cluster. By default this is detected from "+
+			"the tags of the installer role.",
+	)
+
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 }
@@ -57,7 +89,7 @@ func run(_ *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Create the AWS client:
@@ -71,7 +103,12 @@ func run(_ *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSAInitCredentialsSTS", nil)
 		}
 		r.Reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	if args.roleARN != "" {
+		verifyRolePermissions(r, args.roleARN, region)
+		return
 	}
 
 	r.Reporter.Infof("Verifying permissions for non-STS clusters")
@@ -79,7 +116,7 @@ func run(_ *cobra.Command, _ []string) {
 	policies, err := r.OCMClient.GetPolicies("OSDSCPPolicy")
 	if err != nil {
 		r.Reporter.Errorf("Failed to get 'osdscppolicy' for '%s': %v", aws.AdminUserName, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	ok, err := r.AWSClient.ValidateSCP(nil, policies)
 	if err != nil {
@@ -91,7 +128,7 @@ func run(_ *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if !ok {
 		r.OCMClient.LogEvent("ROSAVerifyPermissionsSCPInvalid", nil)
@@ -99,3 +136,110 @@ func run(_ *cobra.Command, _ []string) {
 	}
 	r.Reporter.Infof("AWS SCP policies ok")
 }
+
+func verifyRolePermissions(r *rosa.Runtime, roleARN string, region string) {
+	if !aws.RoleArnRE.MatchString(roleARN) {
+		r.Reporter.Errorf("Expected a valid role ARN matching %s", aws.RoleArnRE.String())
+		os.Exit(1)
+	}
+	role, err := r.AWSClient.GetRoleByARN(roleARN)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get role '%s': %v", roleARN, err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	roleTags := map[string]string{}
+	for _, tag := range role.Tags {
+		roleTags[awsutil.ToString(tag.Key)] = awsutil.ToString(tag.Value)
+	}
+	hostedCP := args.hostedCP || roleTags[tags.HypershiftPolicies] == tags.True
+	managedPolicies := hostedCP || roleTags[common.ManagedPolicies] == tags.True
+
+	policies, err := r.OCMClient.GetPolicies("AccountRole")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get account role policies: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	actions, err := getInstallerActions(r, policies, hostedCP, managedPolicies)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the actions of the installer policies: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	topology := "classic"
+	if hostedCP {
+		topology = "hosted CP"
+	}
+	r.Reporter.Infof("Simulating the %d actions needed to create %s clusters with role '%s'",
+		len(actions), topology, roleARN)
+	denied, err := r.AWSClient.SimulatePrincipalPermissions(roleARN, actions, &aws.SimulateParams{
+		Region: region,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Unable to simulate the permissions of role '%s'. Make sure that the current "+
+			"credentials are allowed to perform 'iam:SimulatePrincipalPolicy': %v", roleARN, err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+	if len(denied) == 0 {
+		r.Reporter.Infof("Role '%s' is allowed to perform all the actions", roleARN)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ACTION\tDECISION\tDENIED BY\n")
+	for _, action := range denied {
+		deniedBy := "no policy allows it"
+		if len(action.DeniedBy) > 0 {
+			deniedBy = strings.Join(action.DeniedBy, ", ")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", action.Action, action.Decision, deniedBy)
+	}
+	writer.Flush()
+	r.Reporter.Errorf("Role '%s' is not allowed to perform %d of the %d actions needed to create %s clusters",
+		roleARN, len(denied), len(actions), topology)
+	os.Exit(1)
+}
+
+// getInstallerActions returns the actions allowed by the installer policies. When OCM doesn't return
+// the document of a managed policy, it is read from AWS. The simulator doesn't support wildcards, so
+// the actions that contain them are left out.
+func getInstallerActions(r *rosa.Runtime, policies map[string]*cmv1.AWSSTSPolicy, hostedCP bool,
+	managedPolicies bool) ([]string, error) {
+	keys := []string{fmt.Sprintf("sts_%s_permission_policy", aws.InstallerAccountRole)}
+	if hostedCP {
+		keys = []string{fmt.Sprintf("sts_hcp_%s_permission_policy", aws.InstallerAccountRole)}
+	} else if managedPolicies {
+		keys = aws.GetAccountRolePolicyKeys(aws.InstallerAccountRole)
+	}
+
+	seen := map[string]bool{}
+	actions := []string{}
+	for _, key := range keys {
+		details := aws.GetPolicyDetails(policies, key)
+		if details == "" {
+			arn, err := aws.GetManagedPolicyARN(policies, key)
+			if err != nil {
+				return nil, err
+			}
+			details, err = r.AWSClient.GetDefaultPolicyDocument(arn)
+			if err != nil {
+				return nil, err
+			}
+		}
+		document, err := aws.ParsePolicyDocument(details)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range document.GetAllowedActions() {
+			if strings.ContainsAny(action, "*?") {
+				r.Reporter.Debugf("Skipping action '%s', as the simulator doesn't support wildcards", action)
+				continue
+			}
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, action)
+			}
+		}
+	}
+	sort.Strings(actions)
+	return actions, nil
+}
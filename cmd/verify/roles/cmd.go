@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roles
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/drift"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	prefix string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "roles",
+	Aliases: []string{"role", "drift"},
+	Short:   "Verify account and operator roles match the ROSA policies",
+	Long: "Compares the account roles, operator roles, trust policies, attached policies and tags with the " +
+		"policies expected by ROSA, and reports the differences, for example permissions that were removed " +
+		"by hand or a trust policy with a wrong OIDC issuer. Exits with a non-zero code when any role drifted.",
+	Example: `  # Verify the account roles with prefix 'ManagedOpenShift'
+  rosa verify roles --prefix ManagedOpenShift
+
+  # Verify the account roles and operator roles of cluster 'mycluster'
+  rosa verify roles --cluster mycluster
+
+  # Get the drift report in JSON format
+  rosa verify roles --cluster mycluster -o json`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.prefix,
+		"prefix",
+		"p",
+		"",
+		"Prefix of the account roles to verify.",
+	)
+
+	ocm.AddOptionalClusterFlag(Cmd)
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	output.AddFlag(Cmd)
+	Cmd.MarkFlagsMutuallyExclusive("prefix", "cluster")
+	Cmd.MarkFlagsOneRequired("prefix", "cluster")
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	env, err := ocm.GetEnv()
+	if err != nil {
+		r.Reporter.Errorf("Error getting environment %s", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	accountRolePolicies, err := r.OCMClient.GetPolicies("AccountRole")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get account role policies: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
+	}
+
+	verifier := &drift.Verifier{
+		AWSClient:           r.AWSClient,
+		Partition:           r.Creator.Partition,
+		AccountID:           r.Creator.AccountID,
+		JumpAccount:         aws.GetJumpAccount(env),
+		AccountRolePolicies: accountRolePolicies,
+	}
+
+	var result *drift.Result
+	if cmd.Flags().Changed("cluster") {
+		cluster := r.FetchCluster()
+		if !cluster.AWS().STS().Enabled() {
+			r.Reporter.Errorf("Cluster '%s' doesn't use STS, so it has no account or operator roles",
+				r.GetClusterKey())
+			os.Exit(1)
+		}
+		verifier.Version, err = r.OCMClient.GetPolicyVersion("", cluster.Version().ChannelGroup())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the version of the policies: %v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		verifier.OperatorRolePolicies, err = r.OCMClient.GetPolicies("OperatorRole")
+		if err != nil {
+			r.Reporter.Errorf("Failed to get operator role policies: %v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get operator credential requests: %v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		result, err = verifier.VerifyCluster(cluster, credRequests)
+		if err != nil {
+			r.Reporter.Errorf("Failed to verify the roles of cluster '%s': %v", r.GetClusterKey(), err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	} else {
+		if !aws.RoleNameRE.MatchString(args.prefix) {
+			r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
+			os.Exit(1)
+		}
+		verifier.Version, err = r.OCMClient.GetPolicyVersion("", ocm.DefaultChannelGroup)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the version of the policies: %v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+		result, err = verifier.VerifyAccountRoles(args.prefix)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	}
+
+	if output.HasFlag() {
+		err = output.Print(result)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(reporter.Code(err).ExitCode())
+		}
+	} else {
+		printResult(r, result)
+	}
+
+	if result.HasDrift() {
+		os.Exit(1)
+	}
+}
+
+func printResult(r *rosa.Runtime, result *drift.Result) {
+	if !result.HasDrift() {
+		r.Reporter.Infof("The %d verified roles match the ROSA policies", len(result.Roles))
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ROLE NAME\tROLE TYPE\tFINDING\tPOLICY\tEXPECTED\tACTUAL\n")
+	for _, role := range result.Roles {
+		for _, finding := range role.Findings {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				role.Name,
+				role.Type,
+				finding.Kind,
+				finding.Policy,
+				finding.Expected,
+				finding.Actual,
+			)
+		}
+	}
+	writer.Flush()
+	r.Reporter.Warnf("Found %d differences with the ROSA policies in %d roles", result.Count(), driftedRoles(result))
+}
+
+func driftedRoles(result *drift.Result) int {
+	count := 0
+	for _, role := range result.Roles {
+		if len(role.Findings) > 0 {
+			count++
+		}
+	}
+	return count
+}
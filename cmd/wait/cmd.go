@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+const (
+	use   = "wait KIND[/NAME] [NAME]"
+	short = "Wait for a resource to meet a condition"
+	long  = "Wait until a cluster, machine pool, add-on or upgrade meets a condition. The command " +
+		"fails if the timeout expires first, or if the resource reaches a state that it can't leave " +
+		"without user intervention, like the 'error' state of clusters or the 'failed' state of " +
+		"add-ons. The condition is either 'delete', a field of the resource and its value, like " +
+		"'state=ready', or one of 'replicas=current' for machine pools and 'completed' for upgrades. " +
+		"Fields use the JSON names of the '--output json' format of the describe commands, and nested " +
+		"fields are separated with dots."
+	example = `  # Wait for the cluster named "mycluster" to be ready
+  rosa wait cluster/mycluster --for=state=ready --timeout=60m
+
+  # Wait for the cluster named "mycluster" to be hibernating
+  rosa wait cluster/mycluster --for=state=hibernating
+
+  # Wait for the nodes of a machine pool of a Hosted Control Plane cluster to be ready
+  rosa wait machinepool/workers -c mycluster --for=replicas=current
+
+  # Wait for the scheduled upgrade of the cluster named "mycluster" to complete
+  rosa wait upgrade -c mycluster --for=completed --timeout=3h
+
+  # Wait for an add-on to be installed
+  rosa wait addon/cluster-logging-operator -c mycluster --for=state=ready
+
+  # Wait for the cluster named "mycluster" to be deleted
+  rosa wait cluster/mycluster --for=delete`
+
+	forFlag      = "for"
+	timeoutFlag  = "timeout"
+	intervalFlag = "interval"
+
+	defaultTimeout  = 30 * time.Minute
+	defaultInterval = 30 * time.Second
+)
+
+type WaitOptions struct {
+	For      string
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+func NewWaitCommand() *cobra.Command {
+	options := &WaitOptions{}
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		Run:     rosa.DefaultRunner(rosa.RuntimeWithOCM(), WaitRunner(options)),
+		Args:    cobra.RangeArgs(1, 2),
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVar(
+		&options.For,
+		forFlag,
+		"",
+		"Condition to wait for: 'delete', 'field=value', 'replicas=current' or 'completed'.",
+	)
+	cmd.MarkFlagRequired(forFlag)
+	flags.DurationVar(
+		&options.Timeout,
+		timeoutFlag,
+		defaultTimeout,
+		"Time to wait before giving up.",
+	)
+	flags.DurationVar(
+		&options.Interval,
+		intervalFlag,
+		defaultInterval,
+		"Time between checks of the condition.",
+	)
+	ocm.AddOptionalClusterFlag(cmd)
+	return cmd
+}
+
+func WaitRunner(options *WaitOptions) rosa.CommandRunner {
+	return func(ctx context.Context, r *rosa.Runtime, command *cobra.Command, argv []string) error {
+		kind, name, err := parseResource(argv)
+		if err != nil {
+			return err
+		}
+		if options.Timeout <= 0 || options.Interval <= 0 {
+			return fmt.Errorf("The '--%s' and '--%s' options must be positive durations", timeoutFlag, intervalFlag)
+		}
+
+		var resource *wait.Resource
+		switch kind {
+		case wait.ClusterKind:
+			// Allow the name of the cluster instead of the '--cluster' option
+			if name != "" && !command.Flags().Changed("cluster") {
+				ocm.SetClusterKey(name)
+			}
+			clusterKey := r.GetClusterKey()
+			if r.Creator == nil {
+				r.WithAWS()
+			}
+			resource = wait.NewClusterResource(r, clusterKey)
+		case wait.MachinePoolKind, wait.AddOnKind:
+			if name == "" {
+				return fmt.Errorf("The name of the %s is required", kind)
+			}
+			cluster := r.FetchCluster()
+			if kind == wait.MachinePoolKind {
+				resource = wait.NewMachinePoolResource(r, cluster, name)
+			} else {
+				resource = wait.NewAddOnResource(r, cluster, name)
+			}
+		case wait.UpgradeKind:
+			resource = wait.NewUpgradeResource(r, r.FetchCluster())
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+		r.Reporter.Debugf("Waiting up to %s for %s '%s' to meet condition '%s'",
+			options.Timeout, resource.Kind, resource.Name, options.For)
+		err = wait.For(ctx, resource, options.For, options.Interval)
+		if err != nil {
+			return err
+		}
+		r.Reporter.Infof("The %s '%s' meets condition '%s'", resource.Kind, resource.Name, options.For)
+		return nil
+	}
+}
+
+// parseResource returns the kind and name of the resource given as 'kind/name' or 'kind name'.
+// Plural kinds and dashes, like in 'machine-pools', are accepted.
+func parseResource(argv []string) (string, string, error) {
+	kind, name, _ := strings.Cut(argv[0], "/")
+	if len(argv) == 2 {
+		if name != "" {
+			return "", "", fmt.Errorf("Expected either 'KIND/NAME' or 'KIND NAME', got '%s'", strings.Join(argv, " "))
+		}
+		name = argv[1]
+	}
+	kind = strings.TrimSuffix(strings.ReplaceAll(strings.ToLower(kind), "-", ""), "s")
+	for _, known := range wait.Kinds {
+		if kind == known {
+			return kind, name, nil
+		}
+	}
+	return "", "", fmt.Errorf("Unknown kind of resource '%s', expected one of '%s'",
+		argv[0], strings.Join(wait.Kinds, "', '"))
+}
@@ -0,0 +1,145 @@
+package wait
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/reporter"
+	. "github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("rosa wait", func() {
+
+	It("Correctly builds the command", func() {
+		cmd := NewWaitCommand()
+		Expect(cmd).NotTo(BeNil())
+
+		Expect(cmd.Use).To(Equal(use))
+		Expect(cmd.Short).To(Equal(short))
+		Expect(cmd.Long).To(Equal(long))
+		Expect(cmd.Example).To(Equal(example))
+		Expect(cmd.Args).NotTo(BeNil())
+		Expect(cmd.Run).NotTo(BeNil())
+
+		Expect(cmd.Flags().Lookup(forFlag)).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup(timeoutFlag).DefValue).To(Equal("30m0s"))
+		Expect(cmd.Flags().Lookup(intervalFlag).DefValue).To(Equal("30s"))
+		Expect(cmd.Flags().Lookup("cluster")).NotTo(BeNil())
+	})
+
+	DescribeTable("Parses the resource",
+		func(argv []string, kind string, name string, expectedErr string) {
+			actualKind, actualName, err := parseResource(argv)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualKind).To(Equal(kind))
+			Expect(actualName).To(Equal(name))
+		},
+		Entry("Kind and name", []string{"cluster/mycluster"}, "cluster", "mycluster", ""),
+		Entry("Separate name", []string{"machine-pools", "workers"}, "machinepool", "workers", ""),
+		Entry("Kind only", []string{"upgrade"}, "upgrade", "", ""),
+		Entry("Two names", []string{"addon/a", "b"}, "", "", "Expected either"),
+		Entry("Unknown kind", []string{"node/a"}, "", "", "Unknown kind of resource 'node/a'"),
+	)
+
+	Context("Wait Runner", func() {
+		var t *TestingRuntime
+		var cmd *cobra.Command
+		var options *WaitOptions
+
+		clusterIn := func(state cmv1.ClusterState) string {
+			return FormatClusterList([]*cmv1.Cluster{MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.State(state)
+			})})
+		}
+
+		BeforeEach(func() {
+			// The command resets the cluster key that the runtime sets
+			cmd = NewWaitCommand()
+			t = NewTestRuntime()
+			options = &WaitOptions{For: "state=ready", Timeout: time.Minute, Interval: time.Millisecond}
+		})
+
+		It("Waits until the cluster meets the condition", func() {
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, clusterIn(cmv1.ClusterStateInstalling)),
+				testing.RespondWithJSON(http.StatusOK, clusterIn(cmv1.ClusterStateInstalling)),
+				testing.RespondWithJSON(http.StatusOK, clusterIn(cmv1.ClusterStateReady)),
+			)
+			err := WaitRunner(options)(context.Background(), t.RosaRuntime, cmd,
+				[]string{"cluster/cluster1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.ApiServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("Fails when the cluster is in an error state", func() {
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, clusterIn(cmv1.ClusterStateError)),
+			)
+			err := WaitRunner(options)(context.Background(), t.RosaRuntime, cmd,
+				[]string{"cluster", "cluster1"})
+			Expect(err).To(MatchError(ContainSubstring(
+				"The cluster 'cluster1' can't meet condition 'state=ready': its state is 'error'")))
+		})
+
+		It("Waits until the cluster is deleted", func() {
+			options.For = "delete"
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, clusterIn(cmv1.ClusterStateUninstalling)),
+				testing.RespondWithJSON(http.StatusOK, FormatClusterList([]*cmv1.Cluster{})),
+			)
+			err := WaitRunner(options)(context.Background(), t.RosaRuntime, cmd,
+				[]string{"cluster/cluster1"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Times out", func() {
+			options.Timeout = 50 * time.Millisecond
+			options.Interval = 10 * time.Millisecond
+			t.ApiServer.RouteToHandler(http.MethodGet, "/api/clusters_mgmt/v1/clusters",
+				testing.RespondWithJSON(http.StatusOK, clusterIn(cmv1.ClusterStateInstalling)))
+			err := WaitRunner(options)(context.Background(), t.RosaRuntime, cmd,
+				[]string{"cluster/cluster1"})
+			Expect(err).To(MatchError("Timed out waiting for cluster 'cluster1' to meet condition 'state=ready'"))
+			Expect(reporter.Code(err)).To(Equal(reporter.ErrorCodeTimeout))
+		})
+
+		It("Waits until the node pool has the requested replicas", func() {
+			options.For = "replicas=current"
+			cluster := MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.State(cmv1.ClusterStateReady)
+				c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			})
+			scaling := MockNodePool(func(n *cmv1.NodePoolBuilder) {
+				n.ID("workers").Replicas(3).Status(cmv1.NewNodePoolStatus().CurrentReplicas(2))
+			})
+			scaled := MockNodePool(func(n *cmv1.NodePoolBuilder) {
+				n.ID("workers").Replicas(3).Status(cmv1.NewNodePoolStatus().CurrentReplicas(3))
+			})
+			t.ApiServer.AppendHandlers(
+				testing.RespondWithJSON(http.StatusOK, FormatClusterList([]*cmv1.Cluster{cluster})),
+				testing.RespondWithJSON(http.StatusOK, FormatResource(scaling)),
+				testing.RespondWithJSON(http.StatusOK, FormatResource(scaled)),
+			)
+			err := WaitRunner(options)(context.Background(), t.RosaRuntime, cmd,
+				[]string{"machinepool/workers"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Requires the name of machine pools", func() {
+			err := WaitRunner(options)(context.Background(), t.RosaRuntime, cmd,
+				[]string{"machinepool"})
+			Expect(err).To(MatchError("The name of the machinepool is required"))
+		})
+	})
+})
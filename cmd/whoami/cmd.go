@@ -20,16 +20,19 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/assumerole"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/object"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -57,14 +60,14 @@ func run(_ *cobra.Command, _ []string) {
 	awsRegion, err := aws.GetRegion("")
 	if err != nil {
 		r.Reporter.Errorf("Error getting AWS region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	// Load the configuration file:
 	cfg, err := config.Load()
 	if err != nil {
 		r.Reporter.Errorf("Failed to load config file: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if cfg == nil {
 		r.Reporter.Errorf("User is not logged in to OCM")
@@ -75,7 +78,7 @@ func run(_ *cobra.Command, _ []string) {
 	loggedIn, err := cfg.Armed()
 	if err != nil {
 		r.Reporter.Errorf("Failed to verify configuration: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if !loggedIn {
 		r.Reporter.Errorf("User is not logged in to OCM")
@@ -89,7 +92,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	defer r.Cleanup()
 
@@ -97,14 +100,14 @@ func run(_ *cobra.Command, _ []string) {
 	account, err := r.OCMClient.GetCurrentAccount()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get current account: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	if account == nil {
 		account, err = getAccountDataFromToken(cfg)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get account data from token: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	outputObject := object.Object{
@@ -119,6 +122,10 @@ func run(_ *cobra.Command, _ []string) {
 		"OCM Organization ID":   account.Organization().ID(),
 		"OCM Organization Name": account.Organization().Name(),
 	}
+	// The ARN is the one of the last role assumed, so show how it was reached:
+	if roleARNs := assumerole.RoleARNs(); len(roleARNs) > 0 {
+		outputObject["AWS Assumed Roles"] = strings.Join(roleARNs, " -> ")
+	}
 	if account.Organization().ExternalID() != "" {
 		outputObject["OCM Organization External ID"] = account.Organization().ExternalID()
 	}
@@ -127,7 +134,7 @@ func run(_ *cobra.Command, _ []string) {
 		err = output.Print(outputObject)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		return
 	}
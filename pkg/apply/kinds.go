@@ -0,0 +1,465 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	MachinePoolKind      = "machinepool"
+	IdentityProviderKind = "idp"
+	IngressKind          = "ingress"
+	KubeletConfigKind    = "kubeletconfig"
+
+	// Key of the default ingress, which is the same one accepted by 'rosa edit ingress'
+	defaultIngressKey = "apps"
+	// Key of the default machine pool of a classic cluster
+	defaultMachinePoolKey = "worker"
+	// Key of the single kubelet config of a classic cluster
+	classicKubeletConfigKey = "cluster"
+)
+
+// ResourceKind knows how to read and modify one kind of sub-resource of a cluster. Resources are
+// handled in the JSON representation used by the OCM API.
+type ResourceKind interface {
+	// Name returns the name of the kind, as used in the plan
+	Name() string
+	// Key returns the identifier of a resource, used to match desired and current resources
+	Key(item map[string]interface{}) string
+	// IgnoredFields returns the paths of the fields that can't be read back from the service
+	IgnoredFields() []string
+	// Validate checks that a desired resource can be created or updated
+	Validate(desired map[string]interface{}, exists bool) error
+	// Deletable returns false for resources that must never be pruned. It receives the number of
+	// resources of the kind that would remain after deleting this one.
+	Deletable(current map[string]interface{}, remaining int) bool
+	List(ctx context.Context) ([]map[string]interface{}, error)
+	Create(ctx context.Context, desired map[string]interface{}) error
+	// Update receives the paths of the changed fields, as returned by ChangedFields
+	Update(ctx context.Context, current, desired map[string]interface{}, fields []string) error
+	Delete(ctx context.Context, current map[string]interface{}) error
+}
+
+// NewResourceKinds returns the kinds of resources that are reconciled for the given cluster, in
+// the order in which they are created.
+func NewResourceKinds(client *ocm.Client, cluster *cmv1.Cluster) []ResourceKind {
+	return []ResourceKind{
+		&kubeletConfigKind{client: client, cluster: cluster},
+		&machinePoolKind{client: client, cluster: cluster},
+		&identityProviderKind{client: client, cluster: cluster},
+		&ingressKind{client: client, cluster: cluster},
+	}
+}
+
+type machinePoolKind struct {
+	client  *ocm.Client
+	cluster *cmv1.Cluster
+}
+
+func (k *machinePoolKind) Name() string {
+	return MachinePoolKind
+}
+
+func (k *machinePoolKind) Key(item map[string]interface{}) string {
+	return stringField(item, "id")
+}
+
+func (k *machinePoolKind) IgnoredFields() []string {
+	return []string{"status", "version"}
+}
+
+func (k *machinePoolKind) Validate(_ map[string]interface{}, _ bool) error {
+	return nil
+}
+
+func (k *machinePoolKind) Deletable(current map[string]interface{}, remaining int) bool {
+	// Hosted clusters need at least one node pool, classic clusters keep their default machine pool
+	if k.cluster.Hypershift().Enabled() {
+		return remaining > 0
+	}
+	return k.Key(current) != defaultMachinePoolKey
+}
+
+func (k *machinePoolKind) List(_ context.Context) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	if k.cluster.Hypershift().Enabled() {
+		nodePools, err := k.client.GetNodePools(k.cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, nodePool := range nodePools {
+			item, err := toMap(func(w io.Writer) error { return cmv1.MarshalNodePool(nodePool, w) })
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, item)
+		}
+		return result, nil
+	}
+	machinePools, err := k.client.GetMachinePools(k.cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	for _, machinePool := range machinePools {
+		item, err := toMap(func(w io.Writer) error { return cmv1.MarshalMachinePool(machinePool, w) })
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (k *machinePoolKind) Create(_ context.Context, desired map[string]interface{}) error {
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	if k.cluster.Hypershift().Enabled() {
+		nodePool, err := cmv1.UnmarshalNodePool(data)
+		if err != nil {
+			return err
+		}
+		_, err = k.client.CreateNodePool(k.cluster.ID(), nodePool)
+		return err
+	}
+	machinePool, err := cmv1.UnmarshalMachinePool(data)
+	if err != nil {
+		return err
+	}
+	_, err = k.client.CreateMachinePool(k.cluster.ID(), machinePool)
+	return err
+}
+
+func (k *machinePoolKind) Update(_ context.Context, current, desired map[string]interface{},
+	fields []string) error {
+	// Only the changed fields are sent, as some of the fields of a machine pool can't be modified
+	data, err := json.Marshal(patchFor(current, desired, fields))
+	if err != nil {
+		return err
+	}
+	if k.cluster.Hypershift().Enabled() {
+		nodePool, err := cmv1.UnmarshalNodePool(data)
+		if err != nil {
+			return err
+		}
+		_, err = k.client.UpdateNodePool(k.cluster.ID(), nodePool)
+		return err
+	}
+	machinePool, err := cmv1.UnmarshalMachinePool(data)
+	if err != nil {
+		return err
+	}
+	_, err = k.client.UpdateMachinePool(k.cluster.ID(), machinePool)
+	return err
+}
+
+func (k *machinePoolKind) Delete(_ context.Context, current map[string]interface{}) error {
+	if k.cluster.Hypershift().Enabled() {
+		return k.client.DeleteNodePool(k.cluster.ID(), k.Key(current))
+	}
+	return k.client.DeleteMachinePool(k.cluster.ID(), k.Key(current))
+}
+
+type identityProviderKind struct {
+	client  *ocm.Client
+	cluster *cmv1.Cluster
+}
+
+func (k *identityProviderKind) Name() string {
+	return IdentityProviderKind
+}
+
+func (k *identityProviderKind) Key(item map[string]interface{}) string {
+	return stringField(item, "name")
+}
+
+func (k *identityProviderKind) IgnoredFields() []string {
+	// Secrets are never returned by the service, and htpasswd users are managed with
+	// 'rosa create/delete user'
+	return []string{
+		"id",
+		"github.client_secret",
+		"gitlab.client_secret",
+		"google.client_secret",
+		"open_id.client_secret",
+		"ldap.bind_password",
+		"htpasswd",
+	}
+}
+
+func (k *identityProviderKind) Validate(desired map[string]interface{}, exists bool) error {
+	if !exists && stringField(desired, "type") == "" {
+		return fmt.Errorf("the 'type' of the identity provider is required")
+	}
+	return nil
+}
+
+func (k *identityProviderKind) Deletable(_ map[string]interface{}, _ int) bool {
+	return true
+}
+
+func (k *identityProviderKind) List(_ context.Context) ([]map[string]interface{}, error) {
+	idps, err := k.client.GetIdentityProviders(k.cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]interface{}
+	for _, idp := range idps {
+		item, err := toMap(func(w io.Writer) error { return cmv1.MarshalIdentityProvider(idp, w) })
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (k *identityProviderKind) unmarshal(desired map[string]interface{}) (*cmv1.IdentityProvider, error) {
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalIdentityProvider(data)
+}
+
+func (k *identityProviderKind) Create(_ context.Context, desired map[string]interface{}) error {
+	idp, err := k.unmarshal(desired)
+	if err != nil {
+		return err
+	}
+	_, err = k.client.CreateIdentityProvider(k.cluster.ID(), idp)
+	return err
+}
+
+func (k *identityProviderKind) Update(_ context.Context, current, desired map[string]interface{},
+	_ []string) error {
+	idp, err := k.unmarshal(desired)
+	if err != nil {
+		return err
+	}
+	_, err = k.client.UpdateIdentityProvider(k.cluster.ID(), stringField(current, "id"), idp)
+	return err
+}
+
+func (k *identityProviderKind) Delete(_ context.Context, current map[string]interface{}) error {
+	return k.client.DeleteIdentityProvider(k.cluster.ID(), stringField(current, "id"))
+}
+
+type ingressKind struct {
+	client  *ocm.Client
+	cluster *cmv1.Cluster
+}
+
+func (k *ingressKind) Name() string {
+	return IngressKind
+}
+
+func (k *ingressKind) Key(item map[string]interface{}) string {
+	if isDefault, ok := item["default"].(bool); ok && isDefault {
+		return defaultIngressKey
+	}
+	return stringField(item, "id")
+}
+
+func (k *ingressKind) IgnoredFields() []string {
+	return []string{"dns_name"}
+}
+
+func (k *ingressKind) Validate(_ map[string]interface{}, exists bool) error {
+	if !exists {
+		return fmt.Errorf("ingresses can't be created, only existing ingresses can be updated")
+	}
+	return nil
+}
+
+func (k *ingressKind) Deletable(current map[string]interface{}, _ int) bool {
+	return k.Key(current) != defaultIngressKey
+}
+
+func (k *ingressKind) List(_ context.Context) ([]map[string]interface{}, error) {
+	ingresses, err := k.client.GetIngresses(k.cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]interface{}
+	for _, ingress := range ingresses {
+		item, err := toMap(func(w io.Writer) error { return cmv1.MarshalIngress(ingress, w) })
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (k *ingressKind) Create(_ context.Context, _ map[string]interface{}) error {
+	return fmt.Errorf("ingresses can't be created")
+}
+
+func (k *ingressKind) Update(_ context.Context, current, desired map[string]interface{}, fields []string) error {
+	// The identifier of the default ingress is usually not in the file, so patchFor always takes it
+	// from the current ingress
+	data, err := json.Marshal(patchFor(current, desired, fields))
+	if err != nil {
+		return err
+	}
+	ingress, err := cmv1.UnmarshalIngress(data)
+	if err != nil {
+		return err
+	}
+	_, err = k.client.UpdateIngress(k.cluster.ID(), ingress)
+	return err
+}
+
+func (k *ingressKind) Delete(_ context.Context, current map[string]interface{}) error {
+	return k.client.DeleteIngress(k.cluster.ID(), stringField(current, "id"))
+}
+
+type kubeletConfigKind struct {
+	client  *ocm.Client
+	cluster *cmv1.Cluster
+}
+
+func (k *kubeletConfigKind) Name() string {
+	return KubeletConfigKind
+}
+
+func (k *kubeletConfigKind) Key(item map[string]interface{}) string {
+	// Classic clusters have a single kubelet config, so it doesn't need to be named in the file
+	if !k.cluster.Hypershift().Enabled() {
+		return classicKubeletConfigKey
+	}
+	return stringField(item, "name")
+}
+
+func (k *kubeletConfigKind) IgnoredFields() []string {
+	if !k.cluster.Hypershift().Enabled() {
+		return []string{"id", "name"}
+	}
+	return []string{"id"}
+}
+
+func (k *kubeletConfigKind) Validate(desired map[string]interface{}, _ bool) error {
+	if _, ok := desired["pod_pids_limit"].(float64); !ok {
+		return fmt.Errorf("the 'pod_pids_limit' of the kubelet config is required")
+	}
+	return nil
+}
+
+func (k *kubeletConfigKind) Deletable(_ map[string]interface{}, _ int) bool {
+	return true
+}
+
+func (k *kubeletConfigKind) List(ctx context.Context) ([]map[string]interface{}, error) {
+	var kubeletConfigs []*cmv1.KubeletConfig
+	if k.cluster.Hypershift().Enabled() {
+		var err error
+		kubeletConfigs, err = k.client.ListKubeletConfigs(ctx, k.cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		kubeletConfig, exists, err := k.client.GetClusterKubeletConfig(k.cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			kubeletConfigs = append(kubeletConfigs, kubeletConfig)
+		}
+	}
+	var result []map[string]interface{}
+	for _, kubeletConfig := range kubeletConfigs {
+		item, err := toMap(func(w io.Writer) error { return cmv1.MarshalKubeletConfig(kubeletConfig, w) })
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (k *kubeletConfigKind) args(desired map[string]interface{}) ocm.KubeletConfigArgs {
+	args := ocm.KubeletConfigArgs{
+		PodPidsLimit: int(desired["pod_pids_limit"].(float64)),
+	}
+	if k.cluster.Hypershift().Enabled() {
+		args.Name = stringField(desired, "name")
+	}
+	return args
+}
+
+func (k *kubeletConfigKind) Create(_ context.Context, desired map[string]interface{}) error {
+	_, err := k.client.CreateKubeletConfig(k.cluster.ID(), k.args(desired))
+	return err
+}
+
+func (k *kubeletConfigKind) Update(ctx context.Context, current, desired map[string]interface{},
+	_ []string) error {
+	_, err := k.client.UpdateKubeletConfig(ctx, k.cluster.ID(), stringField(current, "id"), k.args(desired))
+	return err
+}
+
+func (k *kubeletConfigKind) Delete(ctx context.Context, current map[string]interface{}) error {
+	if k.cluster.Hypershift().Enabled() {
+		return k.client.DeleteKubeletConfigByName(ctx, k.cluster.ID(), stringField(current, "name"))
+	}
+	return k.client.DeleteKubeletConfig(ctx, k.cluster.ID())
+}
+
+// toMap converts a resource marshalled by the OCM SDK into its generic JSON representation
+func toMap(marshal func(w io.Writer) error) (map[string]interface{}, error) {
+	var b bytes.Buffer
+	err := marshal(&b)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(b.Bytes(), &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// patchFor returns the top level fields of the desired resource that contain changes, together with
+// the identifier of the current resource
+func patchFor(current, desired map[string]interface{}, fields []string) map[string]interface{} {
+	patch := map[string]interface{}{
+		"id": current["id"],
+	}
+	for _, field := range fields {
+		name := strings.SplitN(field, ".", 2)[0]
+		patch[name] = desired[name]
+	}
+	return patch
+}
+
+func stringField(item map[string]interface{}, name string) string {
+	value, _ := item[name].(string)
+	return value
+}
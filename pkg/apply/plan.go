@@ -0,0 +1,261 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+type ActionType string
+
+const (
+	ActionCreate ActionType = "create"
+	ActionUpdate ActionType = "update"
+	ActionDelete ActionType = "delete"
+)
+
+var actionSymbols = map[ActionType]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+// Fields that are set by the service and never compared with the desired state
+var metadataFields = map[string]bool{
+	"kind": true,
+	"href": true,
+}
+
+// Action is a single change needed to converge a resource to its desired state.
+type Action struct {
+	Type    ActionType
+	Kind    ResourceKind
+	Key     string
+	Fields  []string
+	current map[string]interface{}
+	desired map[string]interface{}
+}
+
+func (a *Action) String() string {
+	result := fmt.Sprintf("%s %s/%s", actionSymbols[a.Type], a.Kind.Name(), a.Key)
+	if len(a.Fields) > 0 {
+		result += fmt.Sprintf(" (%s)", strings.Join(a.Fields, ", "))
+	}
+	return result
+}
+
+// Plan is the ordered list of actions that converge the cluster to the desired state.
+type Plan struct {
+	Actions []*Action
+}
+
+// BuildPlan compares the desired resources with the current ones of each kind and returns the
+// actions needed to converge them. Resources that only exist in the cluster are deleted only when
+// prune is set.
+func BuildPlan(ctx context.Context, kinds []ResourceKind, resources *ClusterResources, prune bool) (*Plan, error) {
+	plan := &Plan{}
+	var deletions []*Action
+	for _, kind := range kinds {
+		desiredItems, managed := resources.items(kind.Name())
+		if !managed {
+			continue
+		}
+		currentItems, err := kind.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get %s resources: %v", kind.Name(), err)
+		}
+		current := map[string]map[string]interface{}{}
+		for _, item := range currentItems {
+			current[kind.Key(item)] = item
+		}
+
+		seen := map[string]bool{}
+		for _, desired := range desiredItems {
+			key := kind.Key(desired)
+			if key == "" {
+				return nil, fmt.Errorf("Found %s without an identifier in the cluster resources", kind.Name())
+			}
+			if seen[key] {
+				return nil, fmt.Errorf("Found duplicated %s '%s' in the cluster resources", kind.Name(), key)
+			}
+			seen[key] = true
+
+			existing, exists := current[key]
+			err = kind.Validate(desired, exists)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s '%s': %v", kind.Name(), key, err)
+			}
+			if !exists {
+				plan.Actions = append(plan.Actions, &Action{
+					Type:    ActionCreate,
+					Kind:    kind,
+					Key:     key,
+					desired: desired,
+				})
+				continue
+			}
+			fields := ChangedFields(desired, existing, kind.IgnoredFields())
+			if len(fields) > 0 {
+				plan.Actions = append(plan.Actions, &Action{
+					Type:    ActionUpdate,
+					Kind:    kind,
+					Key:     key,
+					Fields:  fields,
+					current: existing,
+					desired: desired,
+				})
+			}
+		}
+
+		if !prune {
+			continue
+		}
+		var candidates []string
+		for key := range current {
+			if !seen[key] {
+				candidates = append(candidates, key)
+			}
+		}
+		sort.Strings(candidates)
+		// The desired resources are created before any deletion, so they count as remaining
+		remaining := len(seen) + len(candidates)
+		var keys []string
+		for _, key := range candidates {
+			if kind.Deletable(current[key], remaining-1) {
+				keys = append(keys, key)
+				remaining--
+			}
+		}
+		var kindDeletions []*Action
+		for _, key := range keys {
+			kindDeletions = append(kindDeletions, &Action{
+				Type:    ActionDelete,
+				Kind:    kind,
+				Key:     key,
+				current: current[key],
+			})
+		}
+		// Resources are deleted in the reverse order of the kinds, so that for example machine
+		// pools are deleted before the kubelet configs they reference
+		deletions = append(kindDeletions, deletions...)
+	}
+	plan.Actions = append(plan.Actions, deletions...)
+	return plan, nil
+}
+
+// Empty returns true if the cluster already matches the desired state.
+func (p *Plan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// Count returns the number of actions of the given type.
+func (p *Plan) Count(actionType ActionType) int {
+	count := 0
+	for _, action := range p.Actions {
+		if action.Type == actionType {
+			count++
+		}
+	}
+	return count
+}
+
+// HasKind returns true if the plan changes resources of the given kind.
+func (p *Plan) HasKind(kind string) bool {
+	for _, action := range p.Actions {
+		if action.Kind.Name() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Plan) String() string {
+	var result strings.Builder
+	for _, action := range p.Actions {
+		fmt.Fprintf(&result, "  %s\n", action)
+	}
+	fmt.Fprintf(&result, "Plan: %d to create, %d to update, %d to delete.\n",
+		p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete))
+	return result.String()
+}
+
+// Execute applies the actions of the plan in order, stopping at the first failure.
+func (p *Plan) Execute(ctx context.Context, r *reporter.Object) error {
+	for _, action := range p.Actions {
+		var err error
+		r.Debugf("Applying '%s'", action)
+		switch action.Type {
+		case ActionCreate:
+			err = action.Kind.Create(ctx, action.desired)
+		case ActionUpdate:
+			err = action.Kind.Update(ctx, action.current, action.desired, action.Fields)
+		case ActionDelete:
+			err = action.Kind.Delete(ctx, action.current)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to %s %s '%s': %v", action.Type, action.Kind.Name(), action.Key, err)
+		}
+		r.Infof("%s %s '%s'", actionPastTense[action.Type], action.Kind.Name(), action.Key)
+	}
+	return nil
+}
+
+var actionPastTense = map[ActionType]string{
+	ActionCreate: "Created",
+	ActionUpdate: "Updated",
+	ActionDelete: "Deleted",
+}
+
+// ChangedFields returns the paths of the fields of the desired resource that have a different
+// value in the current one. Fields that aren't in the desired resource are left as they are, so
+// they are not reported.
+func ChangedFields(desired, current map[string]interface{}, ignored []string) []string {
+	ignoredPaths := map[string]bool{}
+	for _, path := range ignored {
+		ignoredPaths[path] = true
+	}
+	var result []string
+	changedFields("", desired, current, ignoredPaths, &result)
+	sort.Strings(result)
+	return result
+}
+
+func changedFields(prefix string, desired, current map[string]interface{}, ignored map[string]bool,
+	result *[]string) {
+	for name, desiredValue := range desired {
+		path := prefix + name
+		if ignored[path] || (prefix == "" && metadataFields[name]) {
+			continue
+		}
+		currentValue := current[name]
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		currentMap, currentIsMap := currentValue.(map[string]interface{})
+		if desiredIsMap && (currentIsMap || currentValue == nil) {
+			changedFields(path+".", desiredMap, currentMap, ignored, result)
+			continue
+		}
+		if !reflect.DeepEqual(desiredValue, currentValue) {
+			*result = append(*result, path)
+		}
+	}
+}
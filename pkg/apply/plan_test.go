@@ -0,0 +1,225 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+type fakeKind struct {
+	name    string
+	current []map[string]interface{}
+	calls   []string
+}
+
+func (k *fakeKind) Name() string                           { return k.name }
+func (k *fakeKind) Key(item map[string]interface{}) string { return stringField(item, "id") }
+func (k *fakeKind) IgnoredFields() []string                { return []string{"status"} }
+func (k *fakeKind) Deletable(current map[string]interface{}, _ int) bool {
+	return current["id"] != "protected"
+}
+func (k *fakeKind) List(_ context.Context) ([]map[string]interface{}, error) { return k.current, nil }
+
+func (k *fakeKind) Validate(desired map[string]interface{}, _ bool) error {
+	if desired["invalid"] != nil {
+		return fmt.Errorf("it is invalid")
+	}
+	return nil
+}
+
+func (k *fakeKind) Create(_ context.Context, desired map[string]interface{}) error {
+	k.calls = append(k.calls, "create "+k.Key(desired))
+	return nil
+}
+
+func (k *fakeKind) Update(_ context.Context, current, _ map[string]interface{}, fields []string) error {
+	k.calls = append(k.calls, fmt.Sprintf("update %s %v", k.Key(current), fields))
+	return nil
+}
+
+func (k *fakeKind) Delete(_ context.Context, current map[string]interface{}) error {
+	k.calls = append(k.calls, "delete "+k.Key(current))
+	return nil
+}
+
+// listedKind replaces the resources listed by a kind, to test the kinds without a server.
+type listedKind struct {
+	ResourceKind
+	items []map[string]interface{}
+}
+
+func (k *listedKind) List(_ context.Context) ([]map[string]interface{}, error) { return k.items, nil }
+
+var _ = Describe("Plan", func() {
+	Context("ChangedFields", func() {
+		It("Only compares the fields of the desired resource", func() {
+			desired := map[string]interface{}{
+				"kind":     "MachinePool",
+				"replicas": float64(3),
+				"labels":   map[string]interface{}{"a": "b"},
+				"status":   map[string]interface{}{"current_replicas": float64(1)},
+			}
+			current := map[string]interface{}{
+				"kind":          "MachinePool",
+				"href":          "/api/clusters_mgmt/v1/clusters/123/machine_pools/a",
+				"replicas":      float64(2),
+				"labels":        map[string]interface{}{"a": "c", "d": "e"},
+				"instance_type": "m5.xlarge",
+				"status":        map[string]interface{}{"current_replicas": float64(2)},
+			}
+			Expect(ChangedFields(desired, current, []string{"status"})).To(Equal([]string{"labels.a", "replicas"}))
+		})
+
+		It("Reports nested fields that are missing in the current resource", func() {
+			desired := map[string]interface{}{
+				"autoscaling": map[string]interface{}{"min_replicas": float64(1)},
+			}
+			Expect(ChangedFields(desired, map[string]interface{}{}, nil)).To(Equal([]string{"autoscaling.min_replicas"}))
+		})
+	})
+
+	Context("BuildPlan", func() {
+		var pools, idps *fakeKind
+
+		BeforeEach(func() {
+			pools = &fakeKind{
+				name: MachinePoolKind,
+				current: []map[string]interface{}{
+					{"id": "a", "replicas": float64(2)},
+					{"id": "b", "replicas": float64(2)},
+					{"id": "protected"},
+				},
+			}
+			idps = &fakeKind{
+				name:    IdentityProviderKind,
+				current: []map[string]interface{}{{"id": "github"}},
+			}
+		})
+
+		It("Creates, updates and prunes the resources of the kinds in the file", func() {
+			resources, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterResources
+machinePools:
+- id: a
+  replicas: 3
+- id: c
+  replicas: 1
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			plan, err := BuildPlan(context.Background(), []ResourceKind{pools, idps}, resources, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.String()).To(Equal("" +
+				"  ~ machinepool/a (replicas)\n" +
+				"  + machinepool/c\n" +
+				"  - machinepool/b\n" +
+				"Plan: 1 to create, 1 to update, 1 to delete.\n"))
+			Expect(plan.HasKind(IdentityProviderKind)).To(BeFalse())
+
+			Expect(plan.Execute(context.Background(), reporter.CreateReporter())).To(Succeed())
+			Expect(pools.calls).To(Equal([]string{"update a [replicas]", "create c", "delete b"}))
+			Expect(idps.calls).To(BeEmpty())
+		})
+
+		It("Doesn't delete resources without prune", func() {
+			resources, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterResources
+machinePools:
+- id: a
+  replicas: 2
+identityProviders: []
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			plan, err := BuildPlan(context.Background(), []ResourceKind{pools, idps}, resources, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Empty()).To(BeTrue())
+		})
+
+		It("Deletes resources in the reverse order of the kinds", func() {
+			resources, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterResources
+machinePools: []
+identityProviders: []
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			plan, err := BuildPlan(context.Background(), []ResourceKind{pools, idps}, resources, true)
+			Expect(err).NotTo(HaveOccurred())
+			var actions []string
+			for _, action := range plan.Actions {
+				actions = append(actions, action.String())
+			}
+			Expect(actions).To(Equal([]string{"- idp/github", "- machinepool/a", "- machinepool/b"}))
+		})
+
+		It("Keeps the last node pool of a hosted cluster", func() {
+			cluster, err := cmv1.NewCluster().ID("123").Hypershift(cmv1.NewHypershift().Enabled(true)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			nodePools := &machinePoolKind{cluster: cluster}
+			current := []map[string]interface{}{{"id": "a"}, {"id": "b"}}
+
+			resources := &ClusterResources{MachinePools: []map[string]interface{}{}}
+			kind := &listedKind{ResourceKind: nodePools, items: current}
+			plan, err := BuildPlan(context.Background(), []ResourceKind{kind}, resources, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.String()).To(Equal("  - machinepool/a\nPlan: 0 to create, 0 to update, 1 to delete.\n"))
+
+			// The new node pool is created before the others are deleted
+			resources.MachinePools = []map[string]interface{}{{"id": "c"}}
+			plan, err = BuildPlan(context.Background(), []ResourceKind{kind}, resources, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Count(ActionDelete)).To(Equal(2))
+		})
+
+		It("Keeps the default machine pool of a classic cluster", func() {
+			cluster, err := cmv1.NewCluster().ID("123").Build()
+			Expect(err).NotTo(HaveOccurred())
+			machinePools := &machinePoolKind{cluster: cluster}
+			Expect(machinePools.Deletable(map[string]interface{}{"id": "worker"}, 1)).To(BeFalse())
+			Expect(machinePools.Deletable(map[string]interface{}{"id": "infra"}, 0)).To(BeTrue())
+		})
+
+		It("Fails with invalid or duplicated resources", func() {
+			resources, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterResources
+machinePools:
+- id: a
+- id: a
+`))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = BuildPlan(context.Background(), []ResourceKind{pools}, resources, false)
+			Expect(err).To(MatchError("Found duplicated machinepool 'a' in the cluster resources"))
+
+			resources.MachinePools = []map[string]interface{}{{"id": "d", "invalid": true}}
+			_, err = BuildPlan(context.Background(), []ResourceKind{pools}, resources, false)
+			Expect(err).To(MatchError("Invalid machinepool 'd': it is invalid"))
+		})
+	})
+
+	Context("Parse", func() {
+		It("Fails with unknown fields", func() {
+			_, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterResources
+nodePools: []
+`))
+			Expect(err).To(MatchError(`Failed to parse cluster resources: json: unknown field "nodePools"`))
+		})
+
+		It("Fails with an unsupported kind", func() {
+			_, err := Parse([]byte("apiVersion: rosa.openshift.io/v1alpha1\nkind: Cluster\n"))
+			Expect(err).To(MatchError("Unsupported cluster resources kind 'Cluster'. Expected 'ClusterResources'"))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	APIVersion = "rosa.openshift.io/v1alpha1"
+	Kind       = "ClusterResources"
+)
+
+// ClusterResources is the desired state of the sub-resources of a cluster. Every resource uses the
+// same representation as the '-o yaml' output of the corresponding 'rosa list' command, so the
+// output of those commands can be used as a starting point.
+//
+// A list that is not present in the file is not reconciled at all, while an empty list means that
+// there should be no resources of that kind.
+type ClusterResources struct {
+	APIVersion        string                   `json:"apiVersion"`
+	Kind              string                   `json:"kind"`
+	Cluster           string                   `json:"cluster,omitempty"`
+	MachinePools      []map[string]interface{} `json:"machinePools,omitempty"`
+	IdentityProviders []map[string]interface{} `json:"identityProviders,omitempty"`
+	Ingresses         []map[string]interface{} `json:"ingresses,omitempty"`
+	KubeletConfigs    []map[string]interface{} `json:"kubeletConfigs,omitempty"`
+}
+
+// Load reads the cluster resources from a YAML or JSON file.
+func Load(path string) (*ClusterResources, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse reads the cluster resources from YAML or JSON contents.
+func Parse(data []byte) (*ClusterResources, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	resources := &ClusterResources{}
+	err = decoder.Decode(resources)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse cluster resources: %v", err)
+	}
+	if resources.APIVersion != APIVersion {
+		return nil, fmt.Errorf("Unsupported cluster resources apiVersion '%s'. Supported version is '%s'",
+			resources.APIVersion, APIVersion)
+	}
+	if resources.Kind != Kind {
+		return nil, fmt.Errorf("Unsupported cluster resources kind '%s'. Expected '%s'", resources.Kind, Kind)
+	}
+	return resources, nil
+}
+
+// items returns the desired resources of the given kind, and whether the kind is managed by the file.
+func (c *ClusterResources) items(kind string) ([]map[string]interface{}, bool) {
+	var items []map[string]interface{}
+	switch kind {
+	case MachinePoolKind:
+		items = c.MachinePools
+	case IdentityProviderKind:
+		items = c.IdentityProviders
+	case IngressKind:
+		items = c.Ingresses
+	case KubeletConfigKind:
+		items = c.KubeletConfigs
+	}
+	return items, items != nil
+}
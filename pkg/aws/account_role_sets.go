@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	semver "github.com/hashicorp/go-version"
+)
+
+// AccountRoleSet is the set of account roles created together with the same prefix, either for
+// classic clusters or for hosted control planes.
+type AccountRoleSet struct {
+	Prefix          string `json:"prefix"`
+	HostedCP        bool   `json:"hosted_cp"`
+	ManagedPolicies bool   `json:"managed_policies"`
+	// Version is the lowest OpenShift version tag of the roles of the set
+	Version            string   `json:"version,omitempty"`
+	Roles              []Role   `json:"roles"`
+	MissingRoles       []string `json:"missing_roles,omitempty"`
+	CompatibleVersions []string `json:"compatible_versions"`
+	Clusters           []string `json:"clusters"`
+}
+
+// GroupAccountRoles groups the account roles returned by ListAccountRoles by prefix and by
+// classic or hosted control plane policies, sorted by prefix.
+func GroupAccountRoles(roles []Role) []*AccountRoleSet {
+	sets := []*AccountRoleSet{}
+	index := map[string]*AccountRoleSet{}
+	for _, role := range roles {
+		prefix := accountRolePrefix(role)
+		key := fmt.Sprintf("%s/%t", prefix, role.HostedCPPolicies)
+		set, ok := index[key]
+		if !ok {
+			set = &AccountRoleSet{
+				Prefix:          prefix,
+				HostedCP:        role.HostedCPPolicies,
+				ManagedPolicies: true,
+			}
+			index[key] = set
+			sets = append(sets, set)
+		}
+		set.Roles = append(set.Roles, role)
+		set.ManagedPolicies = set.ManagedPolicies && role.ManagedPolicy
+		if len(set.Roles) == 1 || versionLess(role.Version, set.Version) {
+			set.Version = role.Version
+		}
+	}
+
+	for _, set := range sets {
+		expected := AccountRoles
+		if set.HostedCP {
+			expected = HCPAccountRoles
+		}
+		for file := range expected {
+			found := false
+			for _, role := range set.Roles {
+				if role.RoleType == roleTypeMap[file] {
+					found = true
+				}
+			}
+			if !found {
+				set.MissingRoles = append(set.MissingRoles, roleTypeMap[file])
+			}
+		}
+		sort.Strings(set.MissingRoles)
+	}
+
+	sort.SliceStable(sets, func(i, j int) bool {
+		if sets[i].Prefix != sets[j].Prefix {
+			return sets[i].Prefix < sets[j].Prefix
+		}
+		return !sets[i].HostedCP && sets[j].HostedCP
+	})
+	return sets
+}
+
+// SetCompatibleVersions sets the OpenShift minor versions, out of the given ones, that clusters
+// can be installed with or upgraded to using the roles of the set. Roles with managed policies are
+// always up to date, while roles with customer managed policies need a version tag at least as
+// recent as the minor version.
+func (s *AccountRoleSet) SetCompatibleVersions(minors []string) {
+	s.CompatibleVersions = []string{}
+	if len(s.MissingRoles) > 0 {
+		return
+	}
+	for _, minor := range minors {
+		if s.ManagedPolicies || !versionLess(s.Version, minor) {
+			s.CompatibleVersions = append(s.CompatibleVersions, minor)
+		}
+	}
+	sort.SliceStable(s.CompatibleVersions, func(i, j int) bool {
+		return versionLess(s.CompatibleVersions[i], s.CompatibleVersions[j])
+	})
+}
+
+// InstallerRole returns the installer role of the set, if it exists.
+func (s *AccountRoleSet) InstallerRole() (Role, bool) {
+	for _, role := range s.Roles {
+		if role.RoleType == InstallerAccountRoleType {
+			return role, true
+		}
+	}
+	return Role{}, false
+}
+
+func accountRolePrefix(role Role) string {
+	if role.RolePrefix != "" {
+		return role.RolePrefix
+	}
+	for file, roleType := range roleTypeMap {
+		if roleType != role.RoleType {
+			continue
+		}
+		name := AccountRoles[file].Name
+		if role.HostedCPPolicies {
+			name = HCPAccountRoles[file].Name
+		}
+		return TrimRoleSuffix(role.RoleName, fmt.Sprintf("-%s-Role", name))
+	}
+	return role.RoleName
+}
+
+// versionLess returns true if version a is older than version b. Versions that can't be parsed,
+// like missing version tags, are older than any other version.
+func versionLess(a string, b string) bool {
+	va, erra := semver.NewVersion(a)
+	vb, errb := semver.NewVersion(b)
+	if erra != nil {
+		return errb == nil
+	}
+	if errb != nil {
+		return false
+	}
+	return va.LessThan(vb)
+}
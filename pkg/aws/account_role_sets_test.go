@@ -0,0 +1,67 @@
+package aws
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GroupAccountRoles", func() {
+	classicRoles := func(prefix string, version string, managed bool) []Role {
+		return []Role{
+			{RoleName: prefix + "-Installer-Role", RoleType: InstallerAccountRoleType, Version: version,
+				ManagedPolicy: managed},
+			{RoleName: prefix + "-Support-Role", RoleType: SupportAccountRoleType, Version: version,
+				ManagedPolicy: managed},
+			{RoleName: prefix + "-Worker-Role", RoleType: WorkerAccountRoleType, Version: version,
+				ManagedPolicy: managed},
+			{RoleName: prefix + "-ControlPlane-Role", RoleType: ControlPlaneAccountRoleType, Version: version,
+				ManagedPolicy: managed},
+		}
+	}
+
+	It("Groups the roles by prefix and type of cluster", func() {
+		roles := classicRoles("team-b", "4.14", false)
+		roles = append(roles, classicRoles("team-a", "4.16", false)...)
+		roles[1].Version = "4.13"
+		roles = append(roles,
+			Role{RoleName: "team-a-HCP-ROSA-Installer-Role", RoleType: InstallerAccountRoleType, Version: "4.16",
+				ManagedPolicy: true, HostedCPPolicies: true},
+			Role{RoleName: "team-a-HCP-ROSA-Worker-Role", RoleType: WorkerAccountRoleType, Version: "4.16",
+				ManagedPolicy: true, HostedCPPolicies: true, RolePrefix: "team-a"},
+		)
+
+		sets := GroupAccountRoles(roles)
+		Expect(sets).To(HaveLen(3))
+
+		Expect(sets[0].Prefix).To(Equal("team-a"))
+		Expect(sets[0].HostedCP).To(BeFalse())
+		Expect(sets[0].Roles).To(HaveLen(4))
+		Expect(sets[0].MissingRoles).To(BeEmpty())
+
+		Expect(sets[1].Prefix).To(Equal("team-a"))
+		Expect(sets[1].HostedCP).To(BeTrue())
+		Expect(sets[1].ManagedPolicies).To(BeTrue())
+		Expect(sets[1].MissingRoles).To(Equal([]string{SupportAccountRoleType}))
+
+		Expect(sets[2].Prefix).To(Equal("team-b"))
+		Expect(sets[2].Version).To(Equal("4.13"))
+		Expect(sets[2].ManagedPolicies).To(BeFalse())
+	})
+
+	It("Computes the compatible versions", func() {
+		minors := []string{"4.16", "4.13", "4.15", "4.14"}
+
+		sets := GroupAccountRoles(classicRoles("unmanaged", "4.14", false))
+		sets[0].SetCompatibleVersions(minors)
+		Expect(sets[0].CompatibleVersions).To(Equal([]string{"4.13", "4.14"}))
+
+		sets = GroupAccountRoles(classicRoles("managed", "4.12", true))
+		sets[0].SetCompatibleVersions(minors)
+		Expect(sets[0].CompatibleVersions).To(Equal([]string{"4.13", "4.14", "4.15", "4.16"}))
+
+		sets = GroupAccountRoles(classicRoles("incomplete", "4.16", false)[:3])
+		sets[0].SetCompatibleVersions(minors)
+		Expect(sets[0].MissingRoles).To(Equal([]string{ControlPlaneAccountRoleType}))
+		Expect(sets[0].CompatibleVersions).To(BeEmpty())
+	})
+})
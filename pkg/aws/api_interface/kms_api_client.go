@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// KmsApiClient is an interface that defines the methods that we want to use
+// from the Client type in the AWS SDK ("github.com/aws/aws-sdk-go-v2/service/kms")
+// The aim is to only contain methods that are defined in the AWS SDK's KMS
+// Client.
+// For the cases where logic is desired to be implemened combining KMS calls and
+// other logic use the pkg/aws.Client type.
+// If you need to use a method provided by the AWS SDK's KMS Client but it
+// is not defined in this interface then it has to be added and all
+// the types implementing this interface have to implement the new method.
+// The reason this interface has been defined is so we can perform unit testing
+// on methods that make use of the AWS KMS service.
+//
+
+type KmsApiClient interface {
+	DescribeKey(ctx context.Context,
+		params *kms.DescribeKeyInput, optFns ...func(*kms.Options),
+	) (*kms.DescribeKeyOutput, error)
+
+	GetKeyPolicy(ctx context.Context,
+		params *kms.GetKeyPolicyInput, optFns ...func(*kms.Options),
+	) (*kms.GetKeyPolicyOutput, error)
+
+	PutKeyPolicy(ctx context.Context,
+		params *kms.PutKeyPolicyInput, optFns ...func(*kms.Options),
+	) (*kms.PutKeyPolicyOutput, error)
+}
+
+// interface guard to ensure that all methods defined in the KmsApiClient
+// interface are implemented by the real AWS KMS client. This interface
+// guard should always compile
+var _ KmsApiClient = (*kms.Client)(nil)
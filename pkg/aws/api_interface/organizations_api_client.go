@@ -40,10 +40,22 @@ type OrganizationsApiClient interface {
 		params *organizations.DeleteResourcePolicyInput, optFns ...func(*organizations.Options),
 	) (*organizations.DeleteResourcePolicyOutput, error)
 
+	DescribePolicy(ctx context.Context,
+		params *organizations.DescribePolicyInput, optFns ...func(*organizations.Options),
+	) (*organizations.DescribePolicyOutput, error)
+
+	ListParents(ctx context.Context,
+		params *organizations.ListParentsInput, optFns ...func(*organizations.Options),
+	) (*organizations.ListParentsOutput, error)
+
 	ListPolicies(ctx context.Context,
 		params *organizations.ListPoliciesInput, optFns ...func(*organizations.Options),
 	) (*organizations.ListPoliciesOutput, error)
 
+	ListPoliciesForTarget(ctx context.Context,
+		params *organizations.ListPoliciesForTargetInput, optFns ...func(*organizations.Options),
+	) (*organizations.ListPoliciesForTargetOutput, error)
+
 	ListTagsForResource(ctx context.Context,
 		params *organizations.ListTagsForResourceInput, optFns ...func(*organizations.Options),
 	) (*organizations.ListTagsForResourceOutput, error)
@@ -31,10 +31,18 @@ type S3ApiClient interface {
 		params *s3.DeleteObjectInput, optFns ...func(*s3.Options),
 	) (*s3.DeleteObjectOutput, error)
 
+	GetBucketTagging(ctx context.Context,
+		params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options),
+	) (*s3.GetBucketTaggingOutput, error)
+
 	HeadBucket(context.Context,
 		*s3.HeadBucketInput, ...func(*s3.Options),
 	) (*s3.HeadBucketOutput, error)
 
+	ListBuckets(ctx context.Context,
+		params *s3.ListBucketsInput, optFns ...func(*s3.Options),
+	) (*s3.ListBucketsOutput, error)
+
 	ListObjects(ctx context.Context,
 		params *s3.ListObjectsInput, optFns ...func(*s3.Options),
 	) (*s3.ListObjectsOutput, error)
@@ -35,6 +35,10 @@ type SecretsManagerApiClient interface {
 	CreateSecret(ctx context.Context,
 		params *secretsmanager.CreateSecretInput, optFns ...func(*secretsmanager.Options),
 	) (*secretsmanager.CreateSecretOutput, error)
+
+	ListSecrets(ctx context.Context,
+		params *secretsmanager.ListSecretsInput, optFns ...func(*secretsmanager.Options),
+	) (*secretsmanager.ListSecretsOutput, error)
 }
 
 // interface guard to ensure that all methods defined in the SecretsManagerApiClient
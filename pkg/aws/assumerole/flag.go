@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--assume-role-arn',
+// '--assume-role-external-id' and '--role-session-name' command line options.
+
+package assumerole
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	roleARNEnv     = "ROSA_ASSUME_ROLE_ARN"
+	externalIDEnv  = "ROSA_EXTERNAL_ID"
+	sessionNameEnv = "ROSA_ROLE_SESSION_NAME"
+
+	defaultSessionName = "rosa-cli"
+)
+
+// AddFlags adds the flags that select the role to assume to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&roleARNs,
+		"assume-role-arn",
+		nil,
+		"ARN of a role to assume with the AWS credentials before running the command, for example a "+
+			"role in another AWS account. Repeat the option, or separate the ARNs with commas, to assume "+
+			"a chain of roles in order. The first role is assumed with the credentials of the AWS profile, "+
+			"or with a web identity token when AWS_ROLE_ARN and AWS_WEB_IDENTITY_TOKEN_FILE are set, for "+
+			"example in CI. Can also be set with the "+roleARNEnv+" environment variable.",
+	)
+	flags.StringVar(
+		&externalID,
+		"assume-role-external-id",
+		"",
+		"External ID to pass when assuming the last role of '--assume-role-arn'. Can also be set with "+
+			"the "+externalIDEnv+" environment variable.",
+	)
+	flags.StringVar(
+		&sessionName,
+		"role-session-name",
+		"",
+		"Session name to use when assuming the roles of '--assume-role-arn'. Defaults to '"+
+			defaultSessionName+"'. Can also be set with the "+sessionNameEnv+" environment variable.",
+	)
+}
+
+// RoleARNs returns the ARNs of the roles to assume, in the order in which they have to be assumed.
+func RoleARNs() []string {
+	if len(roleARNs) > 0 {
+		return roleARNs
+	}
+	result := []string{}
+	for _, value := range strings.Split(os.Getenv(roleARNEnv), ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// ExternalID returns the external ID to use when assuming the last role.
+func ExternalID() string {
+	if externalID != "" {
+		return externalID
+	}
+	return os.Getenv(externalIDEnv)
+}
+
+// SessionName returns the session name to use when assuming the roles.
+func SessionName() string {
+	if sessionName != "" {
+		return sessionName
+	}
+	if value := os.Getenv(sessionNameEnv); value != "" {
+		return value
+	}
+	return defaultSessionName
+}
+
+// roleARNs is a list of flags that indicates which roles are assumed.
+var roleARNs []string
+
+// externalID is a string flag with the external ID required by the trust policy of the last role.
+var externalID string
+
+// sessionName is a string flag with the name of the role sessions.
+var sessionName string
@@ -32,11 +32,14 @@ import (
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
@@ -52,13 +55,19 @@ import (
 	"github.com/zgalor/weberr"
 
 	client "github.com/openshift/rosa/pkg/aws/api_interface"
+	"github.com/openshift/rosa/pkg/aws/assumerole"
+	"github.com/openshift/rosa/pkg/aws/credcache"
+	"github.com/openshift/rosa/pkg/aws/mfa"
 	"github.com/openshift/rosa/pkg/aws/profile"
 	regionflag "github.com/openshift/rosa/pkg/aws/region"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	rosacache "github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/dryrun"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/properties"
 	"github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -107,9 +116,15 @@ type Client interface {
 	ValidateCredentials() (isValid bool, err error)
 	EnsureOsdCcsAdminUser(stackName string, adminUserName string, awsRegion string) (bool, error)
 	DeleteOsdCcsAdminUser(stackName string) error
+	CreateNetworkStack(stackName string, parameters map[string]string, stackTags map[string]string) (*NetworkStack,
+		error)
+	GetNetworkStack(stackName string) (*NetworkStack, error)
+	DeleteNetworkStack(stackName string) error
 	AccessKeyGetter
 	GetCreator() (*Creator, error)
 	ValidateSCP(*string, map[string]*cmv1.AWSSTSPolicy) (bool, error)
+	SimulatePrincipalPermissions(principalARN string, actions []string, params *SimulateParams) ([]DeniedAction, error)
+	ListServiceControlPolicies() ([]ServiceControlPolicy, error)
 	ListSubnets(subnetIds ...string) ([]ec2types.Subnet, error)
 	GetSubnetAvailabilityZone(subnetID string) (string, error)
 	GetAvailabilityZoneType(availabilityZoneName string) (string, error)
@@ -144,6 +159,8 @@ type Client interface {
 	GetRoleByARN(roleARN string) (iamtypes.Role, error)
 	GetRoleByName(roleName string) (iamtypes.Role, error)
 	DeleteOperatorRole(roles string, managedPolicies bool) error
+	ListManagedResources() ([]ManagedResource, error)
+	DeleteManagedResource(resource ManagedResource) error
 	GetOperatorRolesFromAccountByClusterID(
 		clusterID string,
 		credRequests map[string]*cmv1.STSOperator,
@@ -207,6 +224,9 @@ type Client interface {
 	PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string) error
 	CreateSecretInSecretsManager(name string, secret string) (string, error)
 	DeleteSecretInSecretsManager(secretArn string) error
+	DescribeKMSKey(keyARN string) (*kmstypes.KeyMetadata, error)
+	GetKMSKeyPolicy(keyARN string) (string, error)
+	PutKMSKeyPolicy(keyARN string, policy string) error
 	ValidateAccountRoleVersionCompatibility(roleName string, roleType string, minVersion string) (bool, error)
 	GetDefaultPolicyDocument(policyArn string) (string, error)
 	GetAccountRoleByArn(roleArn string) (Role, error)
@@ -238,6 +258,7 @@ type awsClient struct {
 	orgClient           client.OrganizationsApiClient
 	s3Client            client.S3ApiClient
 	smClient            client.SecretsManagerApiClient
+	kmsClient           client.KmsApiClient
 	stsClient           client.StsApiClient
 	cfClient            client.CloudFormationApiClient
 	serviceQuotasClient client.ServiceQuotasApiClient
@@ -246,13 +267,13 @@ type awsClient struct {
 	useLocalCredentials bool
 }
 
-func CreateNewClientOrExit(logger *logrus.Logger, reporter *reporter.Object) Client {
+func CreateNewClientOrExit(logger *logrus.Logger, r *reporter.Object) Client {
 	awsClient, err := NewClient().
 		Logger(logger).
 		Build()
 	if err != nil {
-		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		r.Errorf("Failed to create AWS client: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	return awsClient
@@ -271,6 +292,7 @@ func New(
 	orgClient client.OrganizationsApiClient,
 	s3Client client.S3ApiClient,
 	smClient client.SecretsManagerApiClient,
+	kmsClient client.KmsApiClient,
 	stsClient client.StsApiClient,
 	cfClient client.CloudFormationApiClient,
 	serviceQuotasClient client.ServiceQuotasApiClient,
@@ -287,6 +309,7 @@ func New(
 		orgClient,
 		s3Client,
 		smClient,
+		kmsClient,
 		stsClient,
 		cfClient,
 		serviceQuotasClient,
@@ -324,13 +347,14 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(value.AccessKeyID,
 			value.SecretAccessKey, "")),
 		config.WithRegion(*b.region),
-		config.WithHTTPClient(&http.Client{
+		config.WithHTTPClient(logging.CassetteHTTPClient(logging.AWSCassette, &http.Client{
 			Transport: http.DefaultTransport,
-		}),
+		})),
 		config.WithClientLogMode(logLevel),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
 			smithyhttp.AddHeaderValue("User-Agent",
 				strings.Join([]string{"ROSACLI", info.Version}, ";")),
+			dryrun.AddMiddleware,
 		}),
 		config.WithRetryer(func() aws.Retryer {
 			retryer := retry.AddWithMaxAttempts(retry.NewStandard(), numMaxRetries)
@@ -351,14 +375,26 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 }
 
 func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws.Config, error) {
+	if logging.ReplayEnabled() {
+		// Requests aren't sent when replaying cassettes, so the credentials don't need to be valid:
+		return b.BuildSessionWithOptionsCredentials(&AccessKey{
+			AccessKeyID:     "REPLAY",
+			SecretAccessKey: "REPLAY",
+		}, logLevel)
+	}
 	cfg, err := config.LoadDefaultConfig(context.TODO(),
 		config.WithSharedConfigProfile(profile.Profile()),
 		config.WithRegion(*b.region),
-		config.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions()),
+		config.WithAssumeRoleCredentialOptions(func(options *stscreds.AssumeRoleOptions) {
+			options.TokenProvider = mfa.TokenProvider
+		}),
+		config.WithHTTPClient(logging.CassetteHTTPClient(logging.AWSCassette,
+			awshttp.NewBuildableClient().WithTransportOptions())),
 		config.WithClientLogMode(logLevel),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
 			smithyhttp.AddHeaderValue("User-Agent",
 				strings.Join([]string{"ROSACLI", info.Version}, ";")),
+			dryrun.AddMiddleware,
 		}),
 		config.WithRetryer(func() aws.Retryer {
 			retryer := retry.AddWithMaxAttempts(retry.NewStandard(), numMaxRetries)
@@ -375,9 +411,83 @@ func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws
 		return aws.Config{}, err
 	}
 
+	cfg = assumeRoleChain(cfg)
+	if !rosacache.Disabled() {
+		if !credcache.Enabled() {
+			b.logger.Debugf("Temporary AWS credentials aren't cached because the configuration isn't "+
+				"kept in an OS keyring, select one with the %s environment variable to cache them",
+				properties.KeyringEnvKey)
+		}
+		cfg.Credentials = aws.NewCredentialsCache(credcache.NewProvider(credentialsCacheKey(cfg), cfg.Credentials))
+	}
 	return cfg, nil
 }
 
+// credentialsCacheKey returns the key of the cached credentials, which depends on everything that
+// selects the credentials, so that changing the profile or the roles doesn't return stale ones.
+func credentialsCacheKey(cfg aws.Config) string {
+	values := []string{
+		profile.Profile(),
+		os.Getenv("AWS_PROFILE"),
+		os.Getenv("AWS_ACCESS_KEY_ID"),
+		os.Getenv("AWS_ROLE_ARN"),
+		strings.Join(assumerole.RoleARNs(), ","),
+		assumerole.ExternalID(),
+		assumerole.SessionName(),
+	}
+	return credcache.Key(append(values, profileSettings(cfg)...)...)
+}
+
+// profileSettings returns the settings of the AWS profile, and of its source profiles, that select
+// the credentials, as read from the shared configuration files when the configuration was loaded.
+func profileSettings(cfg aws.Config) []string {
+	var result []string
+	for _, source := range cfg.ConfigSources {
+		shared, ok := source.(config.SharedConfig)
+		if !ok {
+			continue
+		}
+		for current := &shared; current != nil; current = current.Source {
+			result = append(result,
+				current.Profile,
+				current.RoleARN,
+				current.SourceProfileName,
+				current.MFASerial,
+				current.ExternalID,
+				current.RoleSessionName,
+				current.CredentialSource,
+				current.CredentialProcess,
+				current.WebIdentityTokenFile,
+				current.SSOSessionName,
+				current.SSOStartURL,
+				current.SSOAccountID,
+				current.SSORoleName,
+				current.Credentials.AccessKeyID,
+			)
+		}
+	}
+	return result
+}
+
+// assumeRoleChain replaces the credentials of the configuration with the ones of the roles selected
+// with '--assume-role-arn', assuming each role with the credentials of the previous one. The external
+// ID is only passed to the last role, which is usually the one in the target account.
+func assumeRoleChain(cfg aws.Config) aws.Config {
+	roleARNs := assumerole.RoleARNs()
+	for i, roleARN := range roleARNs {
+		last := i == len(roleARNs)-1
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN,
+			func(options *stscreds.AssumeRoleOptions) {
+				options.RoleSessionName = assumerole.SessionName()
+				if last && assumerole.ExternalID() != "" {
+					options.ExternalID = aws.String(assumerole.ExternalID())
+				}
+			})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+	return cfg
+}
+
 func (b *ClientBuilder) BuildSession() (aws.Config, error) {
 	var logLevel aws.ClientLogMode
 	logLevel = 0
@@ -432,11 +542,9 @@ func (b *ClientBuilder) Build() (Client, error) {
 		b.logger.Debugf("Using AWS profile: %s", profile.Profile())
 	}
 
-	// IAM Service is only available in "us-east-1", need to create specific config for it
-	iamCfg, err := b.BuildSession()
-	if err != nil {
-		return nil, err
-	}
+	// IAM Service is only available in "us-east-1", need to create specific config for it. The
+	// credentials are shared, so that the roles of '--assume-role-arn' are only assumed once:
+	iamCfg := cfg.Copy()
 	iamCfg.Region = IAMServiceRegion
 
 	// Create and populate the object:
@@ -448,6 +556,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 		orgClient:           organizations.NewFromConfig(cfg),
 		s3Client:            s3.NewFromConfig(cfg),
 		smClient:            secretsmanager.NewFromConfig(cfg),
+		kmsClient:           kms.NewFromConfig(cfg),
 		stsClient:           sts.NewFromConfig(cfg),
 		cfClient:            cloudformation.NewFromConfig(cfg),
 		serviceQuotasClient: servicequotas.NewFromConfig(cfg),
@@ -827,6 +936,12 @@ type AccessKey struct {
 	SecretAccessKey string
 }
 
+// dryRunAccessKey is returned instead of the access keys that aren't created in dry-run mode.
+var dryRunAccessKey = AccessKey{
+	AccessKeyID:     "DRYRUN",
+	SecretAccessKey: "DRYRUN",
+}
+
 // GetAWSAccessKeys uses UpsertAccessKey to delete and create new access keys
 // for `osdCcsAdmin` each time we use the client to create a cluster.
 // There is no need to permanently store these credentials since they are only used
@@ -845,6 +960,9 @@ func (c *awsClient) GetAWSAccessKeys() (*AccessKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	if *accessKey == dryRunAccessKey {
+		return accessKey, nil
+	}
 
 	err = c.ValidateAccessKeys(accessKey)
 	if err != nil {
@@ -928,6 +1046,10 @@ func (c *awsClient) UpsertAccessKey(username string) (*AccessKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	if createAccessKeyOutput.AccessKey == nil {
+		// The request isn't sent in dry-run mode, so there is no key to return
+		return &dryRunAccessKey, nil
+	}
 
 	return &AccessKey{
 		AccessKeyID:     *createAccessKeyOutput.AccessKey.AccessKeyId,
@@ -1267,6 +1389,16 @@ func (c *awsClient) CreateSecretInSecretsManager(name string, secret string) (st
 	if err != nil {
 		return "", err
 	}
+	if createSecretResponse.ARN == nil {
+		// The request isn't sent in dry-run mode, return the ARN that the secret would have, without
+		// the random suffix that AWS adds to it
+		creator, err := c.GetCreator()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("arn:%s:secretsmanager:%s:%s:secret:%s", creator.Partition, c.GetRegion(),
+			creator.AccountID, name), nil
+	}
 	return *createSecretResponse.ARN, nil
 }
 
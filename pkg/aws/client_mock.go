@@ -16,6 +16,7 @@ import (
 	types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	iam "github.com/aws/aws-sdk-go-v2/service/iam"
 	types0 "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	types1 "github.com/aws/aws-sdk-go-v2/service/kms/types"
 	servicequotas "github.com/aws/aws-sdk-go-v2/service/servicequotas"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	gomock "go.uber.org/mock/gomock"
@@ -132,6 +133,21 @@ func (mr *MockClientMockRecorder) CheckStackReadyOrNotExisting(stackName any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStackReadyOrNotExisting", reflect.TypeOf((*MockClient)(nil).CheckStackReadyOrNotExisting), stackName)
 }
 
+// CreateNetworkStack mocks base method.
+func (m *MockClient) CreateNetworkStack(stackName string, parameters, stackTags map[string]string) (*NetworkStack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNetworkStack", stackName, parameters, stackTags)
+	ret0, _ := ret[0].(*NetworkStack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNetworkStack indicates an expected call of CreateNetworkStack.
+func (mr *MockClientMockRecorder) CreateNetworkStack(stackName, parameters, stackTags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkStack", reflect.TypeOf((*MockClient)(nil).CreateNetworkStack), stackName, parameters, stackTags)
+}
+
 // CreateOpenIDConnectProvider mocks base method.
 func (m *MockClient) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -204,6 +220,34 @@ func (mr *MockClientMockRecorder) DeleteInlineRolePolicies(roleName any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInlineRolePolicies", reflect.TypeOf((*MockClient)(nil).DeleteInlineRolePolicies), roleName)
 }
 
+// DeleteManagedResource mocks base method.
+func (m *MockClient) DeleteManagedResource(resource ManagedResource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteManagedResource", resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteManagedResource indicates an expected call of DeleteManagedResource.
+func (mr *MockClientMockRecorder) DeleteManagedResource(resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManagedResource", reflect.TypeOf((*MockClient)(nil).DeleteManagedResource), resource)
+}
+
+// DeleteNetworkStack mocks base method.
+func (m *MockClient) DeleteNetworkStack(stackName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkStack", stackName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkStack indicates an expected call of DeleteNetworkStack.
+func (mr *MockClientMockRecorder) DeleteNetworkStack(stackName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkStack", reflect.TypeOf((*MockClient)(nil).DeleteNetworkStack), stackName)
+}
+
 // DeleteOCMRole mocks base method.
 func (m *MockClient) DeleteOCMRole(roleARN string, managedPolicies bool) error {
 	m.ctrl.T.Helper()
@@ -317,6 +361,21 @@ func (mr *MockClientMockRecorder) DescribeAvailabilityZones() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeAvailabilityZones", reflect.TypeOf((*MockClient)(nil).DescribeAvailabilityZones))
 }
 
+// DescribeKMSKey mocks base method.
+func (m *MockClient) DescribeKMSKey(keyARN string) (*types1.KeyMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeKMSKey", keyARN)
+	ret0, _ := ret[0].(*types1.KeyMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeKMSKey indicates an expected call of DescribeKMSKey.
+func (mr *MockClientMockRecorder) DescribeKMSKey(keyARN any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeKMSKey", reflect.TypeOf((*MockClient)(nil).DescribeKMSKey), keyARN)
+}
+
 // DetachRolePolicies mocks base method.
 func (m *MockClient) DetachRolePolicies(roleName string) error {
 	m.ctrl.T.Helper()
@@ -751,6 +810,21 @@ func (mr *MockClientMockRecorder) GetInstanceProfilesForRole(role any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceProfilesForRole", reflect.TypeOf((*MockClient)(nil).GetInstanceProfilesForRole), role)
 }
 
+// GetKMSKeyPolicy mocks base method.
+func (m *MockClient) GetKMSKeyPolicy(keyARN string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKMSKeyPolicy", keyARN)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKMSKeyPolicy indicates an expected call of GetKMSKeyPolicy.
+func (mr *MockClientMockRecorder) GetKMSKeyPolicy(keyARN any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKMSKeyPolicy", reflect.TypeOf((*MockClient)(nil).GetKMSKeyPolicy), keyARN)
+}
+
 // GetLocalAWSAccessKeys mocks base method.
 func (m *MockClient) GetLocalAWSAccessKeys() (*AccessKey, error) {
 	m.ctrl.T.Helper()
@@ -766,6 +840,21 @@ func (mr *MockClientMockRecorder) GetLocalAWSAccessKeys() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalAWSAccessKeys", reflect.TypeOf((*MockClient)(nil).GetLocalAWSAccessKeys))
 }
 
+// GetNetworkStack mocks base method.
+func (m *MockClient) GetNetworkStack(stackName string) (*NetworkStack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkStack", stackName)
+	ret0, _ := ret[0].(*NetworkStack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkStack indicates an expected call of GetNetworkStack.
+func (mr *MockClientMockRecorder) GetNetworkStack(stackName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkStack", reflect.TypeOf((*MockClient)(nil).GetNetworkStack), stackName)
+}
+
 // GetOpenIDConnectProviderByClusterIdTag mocks base method.
 func (m *MockClient) GetOpenIDConnectProviderByClusterIdTag(clusterID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -1216,6 +1305,21 @@ func (mr *MockClientMockRecorder) ListAttachedRolePolicies(roleName any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachedRolePolicies", reflect.TypeOf((*MockClient)(nil).ListAttachedRolePolicies), roleName)
 }
 
+// ListManagedResources mocks base method.
+func (m *MockClient) ListManagedResources() ([]ManagedResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListManagedResources")
+	ret0, _ := ret[0].([]ManagedResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListManagedResources indicates an expected call of ListManagedResources.
+func (mr *MockClientMockRecorder) ListManagedResources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListManagedResources", reflect.TypeOf((*MockClient)(nil).ListManagedResources))
+}
+
 // ListOCMRoles mocks base method.
 func (m *MockClient) ListOCMRoles() ([]Role, error) {
 	m.ctrl.T.Helper()
@@ -1261,6 +1365,21 @@ func (mr *MockClientMockRecorder) ListOperatorRoles(version, clusterID, prefix a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperatorRoles", reflect.TypeOf((*MockClient)(nil).ListOperatorRoles), version, clusterID, prefix)
 }
 
+// ListServiceControlPolicies mocks base method.
+func (m *MockClient) ListServiceControlPolicies() ([]ServiceControlPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceControlPolicies")
+	ret0, _ := ret[0].([]ServiceControlPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceControlPolicies indicates an expected call of ListServiceControlPolicies.
+func (mr *MockClientMockRecorder) ListServiceControlPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceControlPolicies", reflect.TypeOf((*MockClient)(nil).ListServiceControlPolicies))
+}
+
 // ListSubnets mocks base method.
 func (m *MockClient) ListSubnets(subnetIds ...string) ([]types.Subnet, error) {
 	m.ctrl.T.Helper()
@@ -1295,6 +1414,20 @@ func (mr *MockClientMockRecorder) ListUserRoles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockClient)(nil).ListUserRoles))
 }
 
+// PutKMSKeyPolicy mocks base method.
+func (m *MockClient) PutKMSKeyPolicy(keyARN, policy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutKMSKeyPolicy", keyARN, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutKMSKeyPolicy indicates an expected call of PutKMSKeyPolicy.
+func (mr *MockClientMockRecorder) PutKMSKeyPolicy(keyARN, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutKMSKeyPolicy", reflect.TypeOf((*MockClient)(nil).PutKMSKeyPolicy), keyARN, policy)
+}
+
 // PutPublicReadObjectInS3Bucket mocks base method.
 func (m *MockClient) PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string) error {
 	m.ctrl.T.Helper()
@@ -1323,6 +1456,21 @@ func (mr *MockClientMockRecorder) PutRolePolicy(roleName, policyName, policy any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRolePolicy", reflect.TypeOf((*MockClient)(nil).PutRolePolicy), roleName, policyName, policy)
 }
 
+// SimulatePrincipalPermissions mocks base method.
+func (m *MockClient) SimulatePrincipalPermissions(principalARN string, actions []string, params *SimulateParams) ([]DeniedAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulatePrincipalPermissions", principalARN, actions, params)
+	ret0, _ := ret[0].([]DeniedAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulatePrincipalPermissions indicates an expected call of SimulatePrincipalPermissions.
+func (mr *MockClientMockRecorder) SimulatePrincipalPermissions(principalARN, actions, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulatePrincipalPermissions", reflect.TypeOf((*MockClient)(nil).SimulatePrincipalPermissions), principalARN, actions, params)
+}
+
 // TagUserRegion mocks base method.
 func (m *MockClient) TagUserRegion(username, region string) error {
 	m.ctrl.T.Helper()
@@ -3,10 +3,18 @@ package aws
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 
 	gomock "go.uber.org/mock/gomock"
 
 	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -60,6 +68,7 @@ var _ = Describe("Client", func() {
 			mocks.NewMockOrganizationsApiClient(mockCtrl),
 			mockS3API,
 			mockSecretsManagerAPI,
+			mocks.NewMockKmsApiClient(mockCtrl),
 			mockSTSApi,
 			mockCfAPI,
 			mocks.NewMockServiceQuotasApiClient(mockCtrl),
@@ -752,3 +761,161 @@ var _ = Describe("Client", func() {
 		)
 	})
 })
+
+var _ = Describe("assumeRoleChain", func() {
+	var requests []url.Values
+
+	buildConfig := func() awsSdk.Config {
+		requests = nil
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			requests = append(requests, r.PostForm)
+			fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>key-%d</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>2100-01-01T00:00:00Z</Expiration>
+    </Credentials>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`, len(requests))
+		}))
+		DeferCleanup(server.Close)
+		return awsSdk.Config{
+			Region:       "us-east-1",
+			BaseEndpoint: awsSdk.String(server.URL),
+			Credentials:  credentials.NewStaticCredentialsProvider("base", "secret", ""),
+		}
+	}
+
+	It("Keeps the credentials when no role is given", func() {
+		GinkgoT().Setenv("ROSA_ASSUME_ROLE_ARN", "")
+		cfg := assumeRoleChain(buildConfig())
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("base"))
+		Expect(requests).To(BeEmpty())
+	})
+
+	It("Assumes the roles in order and passes the external ID to the last one", func() {
+		GinkgoT().Setenv("ROSA_ASSUME_ROLE_ARN",
+			"arn:aws:iam::111111111111:role/hub, arn:aws:iam::222222222222:role/target")
+		GinkgoT().Setenv("ROSA_EXTERNAL_ID", "my-id")
+		cfg := assumeRoleChain(buildConfig())
+		creds, err := cfg.Credentials.Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("key-2"))
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[0].Get("RoleArn")).To(Equal("arn:aws:iam::111111111111:role/hub"))
+		Expect(requests[0].Get("ExternalId")).To(BeEmpty())
+		Expect(requests[0].Get("RoleSessionName")).To(Equal("rosa-cli"))
+		Expect(requests[1].Get("RoleArn")).To(Equal("arn:aws:iam::222222222222:role/target"))
+		Expect(requests[1].Get("ExternalId")).To(Equal("my-id"))
+	})
+})
+
+var _ = Describe("credentialsCacheKey", func() {
+	var path string
+
+	loadConfig := func(content string) awsSdk.Config {
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		cfg, err := config.LoadDefaultConfig(context.Background(),
+			config.WithSharedConfigFiles([]string{path}),
+			config.WithSharedCredentialsFiles([]string{}),
+			config.WithSharedConfigProfile("test"),
+			config.WithAssumeRoleCredentialOptions(func(options *stscreds.AssumeRoleOptions) {
+				options.TokenProvider = func() (string, error) {
+					return "123456", nil
+				}
+			}),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		return cfg
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config")
+	})
+
+	It("Changes when the role of the profile changes", func() {
+		first := credentialsCacheKey(loadConfig(`
+[profile base]
+aws_access_key_id = key
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/first
+source_profile = base
+`))
+		second := credentialsCacheKey(loadConfig(`
+[profile base]
+aws_access_key_id = key
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/second
+source_profile = base
+`))
+		Expect(second).NotTo(Equal(first))
+	})
+
+	It("Changes when the MFA device of the profile changes", func() {
+		first := credentialsCacheKey(loadConfig(`
+[profile base]
+aws_access_key_id = key
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/test
+source_profile = base
+mfa_serial = arn:aws:iam::111111111111:mfa/first
+`))
+		second := credentialsCacheKey(loadConfig(`
+[profile base]
+aws_access_key_id = key
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/test
+source_profile = base
+mfa_serial = arn:aws:iam::111111111111:mfa/second
+`))
+		Expect(second).NotTo(Equal(first))
+	})
+
+	It("Changes when the credentials of the source profile change", func() {
+		first := credentialsCacheKey(loadConfig(`
+[profile base]
+aws_access_key_id = first
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/test
+source_profile = base
+`))
+		second := credentialsCacheKey(loadConfig(`
+[profile base]
+aws_access_key_id = second
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/test
+source_profile = base
+`))
+		Expect(second).NotTo(Equal(first))
+	})
+
+	It("Doesn't change when the profile doesn't change", func() {
+		content := `
+[profile base]
+aws_access_key_id = key
+aws_secret_access_key = secret
+
+[profile test]
+role_arn = arn:aws:iam::111111111111:role/test
+source_profile = base
+`
+		Expect(credentialsCacheKey(loadConfig(content))).To(Equal(credentialsCacheKey(loadConfig(content))))
+	})
+})
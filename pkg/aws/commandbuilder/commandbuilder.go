@@ -15,6 +15,7 @@ const (
 	S3Api Service = "s3api"
 	S3    Service = "s3"
 	SM    Service = "secretsmanager"
+	CF    Service = "cloudformation"
 )
 
 type Command string
@@ -46,6 +47,9 @@ const (
 	//SecretsManager
 	CreateSecret Command = "create-secret"
 	DeleteSecret Command = "delete-secret"
+	//CloudFormation
+	CreateStack Command = "create-stack"
+	DeleteStack Command = "delete-stack"
 )
 
 type Param string
@@ -83,6 +87,11 @@ const (
 	Description  Param = "description"
 	SecretID     Param = "secret-id"
 	Recursive    Param = "recursive"
+
+	//CloudFormation
+	StackName    Param = "stack-name"
+	TemplateBody Param = "template-body"
+	Parameters   Param = "parameters"
 )
 
 type Redirect string
@@ -191,6 +200,10 @@ func NewSecretsManagerCommandBuilder() *CommandBuilder {
 	return &CommandBuilder{service: SM}
 }
 
+func NewCloudFormationCommandBuilder() *CommandBuilder {
+	return &CommandBuilder{service: CF}
+}
+
 func createParamString(awsParam Param, value string) string {
 	return fmt.Sprintf("\t--%s %s", awsParam, value)
 }
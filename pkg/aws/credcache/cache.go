@@ -0,0 +1,253 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credcache caches on disk the temporary AWS credentials obtained assuming roles or with
+// SSO, so that consecutive rosa commands don't assume the roles again, or ask again for the MFA
+// token code, until the credentials expire.
+//
+// The credentials are encrypted with AES-GCM using a random key kept in the OS keyring, so that
+// the cache files are useless without it. When the configuration isn't managed by a keyring the
+// credentials aren't cached.
+package credcache
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+const (
+	dirEnv       = "ROSA_AWS_CREDENTIALS_CACHE_DIR"
+	fileSuffix   = ".cred"
+	keySizeBytes = 32
+
+	// expiryWindow is how long before their expiration the cached credentials are renewed, so that
+	// they don't expire in the middle of a command.
+	expiryWindow = 5 * time.Minute
+)
+
+// The key that encrypts the credentials is kept in the OS keyring, replaced in tests:
+var (
+	getKey  = config.GetCredentialsCacheKey
+	saveKey = config.SaveCredentialsCacheKey
+)
+
+// Key returns the cache key for the credentials obtained from the given values, like the name of
+// the profile and the ARNs of the assumed roles.
+func Key(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Provider is a credentials provider that returns the credentials of the cache while they are
+// valid, and otherwise retrieves them from the wrapped provider and saves them to the cache.
+type Provider struct {
+	key      string
+	provider aws.CredentialsProvider
+	dir      string
+	now      func() time.Time
+}
+
+var _ aws.CredentialsProvider = &Provider{}
+
+// Enabled returns true if the credentials are cached, which requires the configuration to be
+// managed by an OS keyring, as otherwise there is nowhere to keep the encryption key.
+func Enabled() bool {
+	_, ok := config.IsKeyringManaged()
+	return ok
+}
+
+// NewProvider creates a provider that caches the credentials of the given one with the given key.
+// Nothing is cached when caching isn't enabled.
+func NewProvider(key string, provider aws.CredentialsProvider) *Provider {
+	dir := ""
+	if Enabled() {
+		dir = Dir()
+	}
+	return &Provider{
+		key:      key,
+		provider: provider,
+		dir:      dir,
+		now:      time.Now,
+	}
+}
+
+// Retrieve returns the cached credentials, or retrieves new ones if they are missing or about to
+// expire. Errors reading or writing the cache aren't fatal, the credentials just aren't cached.
+func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	if p.dir != "" {
+		credentials, err := p.load()
+		if err == nil && credentials.Expires.After(p.now().Add(expiryWindow)) {
+			return credentials, nil
+		}
+	}
+	credentials, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	// Only temporary credentials are cached, long term ones are already stored by the user:
+	if p.dir != "" && credentials.CanExpire {
+		_ = p.save(credentials)
+	}
+	return credentials, nil
+}
+
+// entry is the content of a cache file, once decrypted.
+type entry struct {
+	AccessKeyID     string    `json:"access_key_id"`
+	SecretAccessKey string    `json:"secret_access_key"`
+	SessionToken    string    `json:"session_token"`
+	Source          string    `json:"source,omitempty"`
+	AccountID       string    `json:"account_id,omitempty"`
+	Expires         time.Time `json:"expires"`
+}
+
+func (p *Provider) load() (aws.Credentials, error) {
+	data, err := os.ReadFile(p.file())
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	gcm, err := p.cipher(false)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return aws.Credentials{}, errors.New("cache file is too short")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], []byte(p.key))
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	var cached entry
+	err = json.Unmarshal(plain, &cached)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	return aws.Credentials{
+		AccessKeyID:     cached.AccessKeyID,
+		SecretAccessKey: cached.SecretAccessKey,
+		SessionToken:    cached.SessionToken,
+		Source:          cached.Source,
+		AccountID:       cached.AccountID,
+		CanExpire:       true,
+		Expires:         cached.Expires,
+	}, nil
+}
+
+func (p *Provider) save(credentials aws.Credentials) error {
+	plain, err := json.Marshal(entry{
+		AccessKeyID:     credentials.AccessKeyID,
+		SecretAccessKey: credentials.SecretAccessKey,
+		SessionToken:    credentials.SessionToken,
+		Source:          credentials.Source,
+		AccountID:       credentials.AccountID,
+		Expires:         credentials.Expires,
+	})
+	if err != nil {
+		return err
+	}
+	gcm, err := p.cipher(true)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.file(), gcm.Seal(nonce, nonce, plain, []byte(p.key)), 0600)
+}
+
+func (p *Provider) file() string {
+	return filepath.Join(p.dir, p.key+fileSuffix)
+}
+
+// cipher returns the cipher built with the key kept in the OS keyring, creating the key if it
+// doesn't exist and create is true. The cache directory is also created then.
+func (p *Provider) cipher(create bool) (cipher.AEAD, error) {
+	key, err := getKey()
+	if err != nil {
+		return nil, err
+	}
+	if key == nil && !create {
+		return nil, errors.New("there is no credentials cache key in the OS keyring")
+	}
+	if key == nil {
+		key = make([]byte, keySizeBytes)
+		_, err = io.ReadFull(rand.Reader, key)
+		if err != nil {
+			return nil, err
+		}
+		err = saveKey(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if create {
+		err = os.MkdirAll(p.dir, 0700)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(key) != keySizeBytes {
+		return nil, errors.New("invalid credentials cache key in the OS keyring")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Dir returns the directory where the credentials are cached, or an empty string if the cache
+// directory of the user can't be determined.
+func Dir() string {
+	if dir := os.Getenv(dirEnv); dir != "" {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "rosa", "aws-credentials")
+}
+
+// Clear removes all the cached credentials, and the key used to encrypt them.
+func Clear() error {
+	err := saveKey(nil)
+	if err != nil {
+		return err
+	}
+	dir := Dir()
+	if dir == "" {
+		return nil
+	}
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,150 @@
+package credcache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+var _ = Describe("Provider", func() {
+	var (
+		dir   string
+		now   time.Time
+		calls int
+		next  aws.Credentials
+		key   []byte
+	)
+
+	inner := aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		calls++
+		return next, nil
+	})
+
+	newProvider := func(key string) *Provider {
+		return &Provider{
+			key:      key,
+			provider: inner,
+			dir:      dir,
+			now:      func() time.Time { return now },
+		}
+	}
+
+	BeforeEach(func() {
+		// The key is kept in memory instead of the OS keyring:
+		key = nil
+		getKey = func() ([]byte, error) { return key, nil }
+		saveKey = func(value []byte) error {
+			key = value
+			return nil
+		}
+		DeferCleanup(func() {
+			getKey = config.GetCredentialsCacheKey
+			saveKey = config.SaveCredentialsCacheKey
+		})
+
+		dir = filepath.Join(GinkgoT().TempDir(), "cache")
+		now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		calls = 0
+		next = aws.Credentials{
+			AccessKeyID:     "ASIA1",
+			SecretAccessKey: "secret",
+			SessionToken:    "token",
+			CanExpire:       true,
+			Expires:         now.Add(time.Hour),
+		}
+	})
+
+	It("Returns the cached credentials until they are about to expire", func() {
+		credentials, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials.AccessKeyID).To(Equal("ASIA1"))
+		Expect(calls).To(Equal(1))
+
+		next.AccessKeyID = "ASIA2"
+		now = now.Add(50 * time.Minute)
+		credentials, err = newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials.AccessKeyID).To(Equal("ASIA1"))
+		Expect(credentials.SessionToken).To(Equal("token"))
+		Expect(credentials.CanExpire).To(BeTrue())
+		Expect(calls).To(Equal(1))
+
+		now = now.Add(6 * time.Minute)
+		credentials, err = newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials.AccessKeyID).To(Equal("ASIA2"))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("Keeps the credentials of different keys apart", func() {
+		_, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = newProvider("b").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+	})
+
+	It("Doesn't cache long term credentials", func() {
+		next.CanExpire = false
+		_, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+		Expect(filepath.Join(dir, "a"+fileSuffix)).NotTo(BeAnExistingFile())
+	})
+
+	It("Encrypts the cache file and only lets the user read it", func() {
+		_, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(HaveLen(keySizeBytes))
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		file := filepath.Join(dir, "a"+fileSuffix)
+		data, err := os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("ASIA1"))
+		Expect(string(data)).NotTo(ContainSubstring("secret"))
+		info, err := os.Stat(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("Ignores cache files that can't be decrypted", func() {
+		_, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		key = make([]byte, keySizeBytes)
+		credentials, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials.AccessKeyID).To(Equal("ASIA1"))
+		Expect(calls).To(Equal(2))
+	})
+
+	It("Doesn't cache the credentials without a keyring", func() {
+		getKey = func() ([]byte, error) { return nil, config.ErrNoKeyring }
+		saveKey = func([]byte) error { return config.ErrNoKeyring }
+		_, err := newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = newProvider("a").Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(2))
+		Expect(filepath.Join(dir, "a"+fileSuffix)).NotTo(BeAnExistingFile())
+	})
+
+	It("Is disabled when the configuration isn't managed by a keyring", func() {
+		GinkgoT().Setenv(properties.KeyringEnvKey, "")
+		Expect(NewProvider("a", inner).dir).To(BeEmpty())
+		GinkgoT().Setenv(properties.KeyringEnvKey, "secret-service")
+		GinkgoT().Setenv(dirEnv, dir)
+		Expect(NewProvider("a", inner).dir).To(Equal(dir))
+	})
+})
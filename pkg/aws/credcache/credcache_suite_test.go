@@ -0,0 +1,13 @@
+package credcache
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCredCache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AWS credentials cache suite")
+}
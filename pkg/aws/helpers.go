@@ -184,7 +184,7 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 	awsRegionInUserConfig, err := GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 	if awsRegionInUserConfig == "" {
 		reporter.Errorf("AWS Region not set")
@@ -204,7 +204,7 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 		Build()
 	if err != nil {
 		reporter.Errorf("Error creating aws client for stack validation: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.Code(err).ExitCode())
 	}
 	regionUsedForInit, err := client.GetClusterRegionTagForUser(AdminUserName)
 	if err != nil || regionUsedForInit == "" {
@@ -226,7 +226,7 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 			Build()
 		if err != nil {
 			reporter.Errorf("Error creating aws client for stack validation: %v", err)
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		return awsClient
 	}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that write a template as a CloudFormation template.
+
+package iac
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type cfnTemplate struct {
+	AWSTemplateFormatVersion string                  `json:"AWSTemplateFormatVersion"`
+	Description              string                  `json:"Description,omitempty"`
+	Parameters               map[string]cfnParameter `json:"Parameters,omitempty"`
+	Resources                map[string]cfnResource  `json:"Resources"`
+}
+
+type cfnParameter struct {
+	Type        string `json:"Type"`
+	Description string `json:"Description,omitempty"`
+	NoEcho      bool   `json:"NoEcho,omitempty"`
+}
+
+type cfnResource struct {
+	Type       string                 `json:"Type"`
+	DependsOn  []string               `json:"DependsOn,omitempty"`
+	Properties map[string]interface{} `json:"Properties"`
+}
+
+type cfnTag struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+func cfnRef(name string) map[string]string {
+	return map[string]string{"Ref": name}
+}
+
+func cfnTags(tags map[string]string) []cfnTag {
+	result := []cfnTag{}
+	for _, key := range sortedKeys(tags) {
+		result = append(result, cfnTag{Key: key, Value: tags[key]})
+	}
+	return result
+}
+
+// setIfNotEmpty sets the property unless the value is empty, as CloudFormation rejects some empty
+// values, like paths.
+func setIfNotEmpty(properties map[string]interface{}, name string, value string) {
+	if value != "" {
+		properties[name] = value
+	}
+}
+
+// The CloudFormation templates don't support S3 objects, so the objects of the buckets are left
+// out and need to be uploaded after the stack is created.
+func (t *Template) writeCloudFormation(writer io.Writer) error {
+	template := cfnTemplate{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Description:              t.Description,
+		Parameters:               map[string]cfnParameter{},
+		Resources:                map[string]cfnResource{},
+	}
+
+	for _, policy := range t.Policies {
+		properties := map[string]interface{}{
+			"ManagedPolicyName": policy.Name,
+			"PolicyDocument":    policy.Document,
+		}
+		setIfNotEmpty(properties, "Path", policy.Path)
+		template.Resources[cfnName("Policy", policy.Name)] = cfnResource{
+			Type:       "AWS::IAM::ManagedPolicy",
+			Properties: properties,
+		}
+	}
+
+	for _, role := range t.Roles {
+		arns := []interface{}{}
+		for _, policyName := range role.Policies {
+			arns = append(arns, cfnRef(cfnName("Policy", policyName)))
+		}
+		for _, arn := range role.PolicyARNs {
+			arns = append(arns, arn)
+		}
+		properties := map[string]interface{}{
+			"RoleName":                 role.Name,
+			"AssumeRolePolicyDocument": role.AssumeRolePolicy,
+			"Tags":                     cfnTags(role.Tags),
+		}
+		if len(arns) > 0 {
+			properties["ManagedPolicyArns"] = arns
+		}
+		setIfNotEmpty(properties, "Path", role.Path)
+		setIfNotEmpty(properties, "PermissionsBoundary", role.PermissionsBoundary)
+		template.Resources[cfnName("Role", role.Name)] = cfnResource{
+			Type:       "AWS::IAM::Role",
+			Properties: properties,
+		}
+	}
+
+	for _, provider := range t.OIDCProviders {
+		template.Resources[cfnName("OIDCProvider", provider.URL)] = cfnResource{
+			Type: "AWS::IAM::OIDCProvider",
+			Properties: map[string]interface{}{
+				"Url":            provider.URL,
+				"ClientIdList":   provider.ClientIDs,
+				"ThumbprintList": provider.Thumbprints,
+				"Tags":           cfnTags(provider.Tags),
+			},
+		}
+	}
+
+	for _, bucket := range t.Buckets {
+		name := cfnName("Bucket", bucket.Name)
+		properties := map[string]interface{}{
+			"BucketName": bucket.Name,
+			"Tags":       cfnTags(bucket.Tags),
+		}
+		if bucket.PublicAccessBlock != nil {
+			properties["PublicAccessBlockConfiguration"] = map[string]bool{
+				"BlockPublicAcls":       bucket.PublicAccessBlock.BlockPublicAcls,
+				"IgnorePublicAcls":      bucket.PublicAccessBlock.IgnorePublicAcls,
+				"BlockPublicPolicy":     bucket.PublicAccessBlock.BlockPublicPolicy,
+				"RestrictPublicBuckets": bucket.PublicAccessBlock.RestrictPublicBuckets,
+			}
+		}
+		template.Resources[name] = cfnResource{
+			Type:       "AWS::S3::Bucket",
+			Properties: properties,
+		}
+		if bucket.Policy != "" {
+			template.Resources[cfnName("BucketPolicy", bucket.Name)] = cfnResource{
+				Type:      "AWS::S3::BucketPolicy",
+				DependsOn: []string{name},
+				Properties: map[string]interface{}{
+					"Bucket":         cfnRef(name),
+					"PolicyDocument": bucket.Policy,
+				},
+			}
+		}
+	}
+
+	for _, secret := range t.Secrets {
+		parameter := cfnName("SecretString", secret.Name)
+		template.Parameters[parameter] = cfnParameter{
+			Type:        "String",
+			Description: fmt.Sprintf("Content of the '%s' file", secret.ValueFile),
+			NoEcho:      true,
+		}
+		properties := map[string]interface{}{
+			"Name":         secret.Name,
+			"SecretString": cfnRef(parameter),
+			"Tags":         cfnTags(secret.Tags),
+		}
+		setIfNotEmpty(properties, "Description", secret.Description)
+		template.Resources[cfnName("Secret", secret.Name)] = cfnResource{
+			Type:       "AWS::SecretsManager::Secret",
+			Properties: properties,
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(template)
+}
+
+var cfnNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// cfnName returns the logical identifier of the CloudFormation resource that corresponds to the
+// given AWS name. Identifiers can only contain letters and digits, so the parts of the name are
+// joined in camel case, after the kind of resource that avoids conflicts between resources with
+// the same name, like a role and its policy.
+func cfnName(kind string, name string) string {
+	name = strings.TrimPrefix(name, "https://")
+	result := kind
+	for _, part := range cfnNameInvalidChars.Split(name, -1) {
+		if part != "" {
+			result += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return result
+}
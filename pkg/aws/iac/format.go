@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iac
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+const (
+	FormatFlagName = "format"
+
+	FormatTerraform      = "terraform"
+	FormatCloudFormation = "cloudformation"
+	FormatJSON           = "json"
+)
+
+var Formats = []string{FormatTerraform, FormatCloudFormation, FormatJSON}
+
+var format string
+
+// AddFormatFlag adds the flag that selects the format of the resources of the manual mode.
+func AddFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&format,
+		FormatFlagName,
+		"",
+		fmt.Sprintf("Format of the resources output by the manual mode, instead of 'aws' commands. "+
+			"Valid options are %s.", strings.Join(Formats, ", ")),
+	)
+	cmd.RegisterFlagCompletionFunc(FormatFlagName, formatCompletion)
+}
+
+func formatCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return Formats, cobra.ShellCompDirectiveDefault
+}
+
+// GetFormat returns the format selected with the format flag, or an empty string if the resources
+// should be output as 'aws' commands. As the format only applies to the manual mode, selecting it
+// without a mode selects the manual mode, as if it was selected with the mode flag.
+func GetFormat(cmd *cobra.Command, mode string) (string, string, error) {
+	if format == "" {
+		return "", mode, nil
+	}
+	valid := false
+	for _, f := range Formats {
+		if f == format {
+			valid = true
+		}
+	}
+	if !valid {
+		return "", mode, fmt.Errorf("Invalid format '%s'. Allowed values are %s", format, Formats)
+	}
+	switch mode {
+	case "":
+		err := cmd.Flags().Set(interactive.Mode, interactive.ModeManual)
+		if err != nil {
+			return "", mode, err
+		}
+		return format, interactive.ModeManual, nil
+	case interactive.ModeManual:
+		return format, mode, nil
+	default:
+		return "", mode, fmt.Errorf("The '--%s' option can only be used with '--%s %s'",
+			FormatFlagName, interactive.Mode, interactive.ModeManual)
+	}
+}
+
+// SetFormat sets the format, as if it was selected with the format flag.
+func SetFormat(value string) {
+	format = value
+}
+
+// Write writes the template in the given format.
+func (t *Template) Write(writer io.Writer, format string) error {
+	t.sort()
+	switch format {
+	case FormatTerraform:
+		return t.writeTerraform(writer)
+	case FormatCloudFormation:
+		return t.writeCloudFormation(writer)
+	case FormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(t)
+	default:
+		return fmt.Errorf("Invalid format '%s'. Allowed values are %s", format, Formats)
+	}
+}
@@ -0,0 +1,13 @@
+package iac
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIaC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "IaC Suite")
+}
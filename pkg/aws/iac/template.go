@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package iac contains the description of the AWS resources that the manual mode of the create
+// commands asks the user to create, and the functions that write it as infrastructure as code,
+// for example a Terraform module or a CloudFormation template, instead of 'aws' commands.
+package iac
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Template contains the AWS resources that the user should create.
+type Template struct {
+	Description   string          `json:"description,omitempty"`
+	Region        string          `json:"region,omitempty"`
+	Roles         []*Role         `json:"roles,omitempty"`
+	Policies      []*Policy       `json:"policies,omitempty"`
+	OIDCProviders []*OIDCProvider `json:"oidc_providers,omitempty"`
+	Buckets       []*Bucket       `json:"buckets,omitempty"`
+	Secrets       []*Secret       `json:"secrets,omitempty"`
+}
+
+// Role is an IAM role. The policies attached to it are either existing policies, like the AWS
+// managed policies, identified by their ARNs, or policies of the template, identified by their
+// names.
+type Role struct {
+	Name                string            `json:"name"`
+	Path                string            `json:"path,omitempty"`
+	PermissionsBoundary string            `json:"permissions_boundary,omitempty"`
+	AssumeRolePolicy    Document          `json:"assume_role_policy"`
+	Tags                map[string]string `json:"tags,omitempty"`
+	PolicyARNs          []string          `json:"policy_arns,omitempty"`
+	Policies            []string          `json:"policies,omitempty"`
+}
+
+// Policy is an IAM customer managed policy.
+type Policy struct {
+	Name     string            `json:"name"`
+	Path     string            `json:"path,omitempty"`
+	Document Document          `json:"document"`
+	Tags     map[string]string `json:"tags,omitempty"`
+}
+
+// OIDCProvider is an IAM OpenID Connect identity provider.
+type OIDCProvider struct {
+	URL         string            `json:"url"`
+	ClientIDs   []string          `json:"client_ids"`
+	Thumbprints []string          `json:"thumbprints"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// Bucket is an S3 bucket with its policy and objects.
+type Bucket struct {
+	Name              string             `json:"name"`
+	Tags              map[string]string  `json:"tags,omitempty"`
+	PublicAccessBlock *PublicAccessBlock `json:"public_access_block,omitempty"`
+	Policy            Document           `json:"policy,omitempty"`
+	Objects           []*Object          `json:"objects,omitempty"`
+}
+
+// PublicAccessBlock is the public access block configuration of a bucket.
+type PublicAccessBlock struct {
+	BlockPublicAcls       bool `json:"block_public_acls"`
+	IgnorePublicAcls      bool `json:"ignore_public_acls"`
+	BlockPublicPolicy     bool `json:"block_public_policy"`
+	RestrictPublicBuckets bool `json:"restrict_public_buckets"`
+}
+
+// Object is an object of an S3 bucket.
+type Object struct {
+	Key     string            `json:"key"`
+	Content string            `json:"content"`
+	Tags    map[string]string `json:"tags,omitempty"`
+}
+
+// Secret is a Secrets Manager secret. The value isn't part of the template, it is read from the
+// file that the create command saved.
+type Secret struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	ValueFile   string            `json:"value_file"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+// Document is a JSON policy document. It is written as a JSON object, instead of a string, when
+// the template is written as JSON.
+type Document string
+
+// MarshalJSON is the implementation of the json.Marshaler interface.
+func (d Document) MarshalJSON() ([]byte, error) {
+	if json.Valid([]byte(d)) {
+		return json.RawMessage(d), nil
+	}
+	return json.Marshal(string(d))
+}
+
+// NewTemplate creates an empty template with the given description.
+func NewTemplate(description string) *Template {
+	return &Template{
+		Description: description,
+	}
+}
+
+// AddRole adds a role to the template.
+func (t *Template) AddRole(role *Role) *Template {
+	t.Roles = append(t.Roles, role)
+	return t
+}
+
+// AddPolicy adds a policy to the template.
+func (t *Template) AddPolicy(policy *Policy) *Template {
+	t.Policies = append(t.Policies, policy)
+	return t
+}
+
+// AddOIDCProvider adds an OIDC provider to the template.
+func (t *Template) AddOIDCProvider(provider *OIDCProvider) *Template {
+	t.OIDCProviders = append(t.OIDCProviders, provider)
+	return t
+}
+
+// AddBucket adds a bucket to the template.
+func (t *Template) AddBucket(bucket *Bucket) *Template {
+	t.Buckets = append(t.Buckets, bucket)
+	return t
+}
+
+// AddSecret adds a secret to the template.
+func (t *Template) AddSecret(secret *Secret) *Template {
+	t.Secrets = append(t.Secrets, secret)
+	return t
+}
+
+// Policy returns the policy of the template with the given name, or nil if there is no such
+// policy.
+func (t *Template) Policy(name string) *Policy {
+	for _, policy := range t.Policies {
+		if policy.Name == name {
+			return policy
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true if the template doesn't contain any resource.
+func (t *Template) IsEmpty() bool {
+	return len(t.Roles) == 0 && len(t.Policies) == 0 && len(t.OIDCProviders) == 0 &&
+		len(t.Buckets) == 0 && len(t.Secrets) == 0
+}
+
+// sort sorts the resources by name, as they are usually collected iterating maps, so that the
+// output is the same for the same resources.
+func (t *Template) sort() {
+	sort.SliceStable(t.Roles, func(i, j int) bool {
+		return t.Roles[i].Name < t.Roles[j].Name
+	})
+	sort.SliceStable(t.Policies, func(i, j int) bool {
+		return t.Policies[i].Name < t.Policies[j].Name
+	})
+	sort.SliceStable(t.OIDCProviders, func(i, j int) bool {
+		return t.OIDCProviders[i].URL < t.OIDCProviders[j].URL
+	})
+	sort.SliceStable(t.Buckets, func(i, j int) bool {
+		return t.Buckets[i].Name < t.Buckets[j].Name
+	})
+	sort.SliceStable(t.Secrets, func(i, j int) bool {
+		return t.Secrets[i].Name < t.Secrets[j].Name
+	})
+}
+
+// sortedKeys returns the keys of the map in alphabetical order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,186 @@
+package iac
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+const trustPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [{"Effect": "Allow", "Action": "sts:AssumeRole"}]
+}`
+
+const permissionPolicy = `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "iam:GetUser",` +
+	` "Resource": "arn:aws:iam::*:user/${aws:username}"}]}`
+
+var _ = Describe("Template", func() {
+	var template *Template
+
+	write := func(format string) string {
+		var buffer bytes.Buffer
+		Expect(template.Write(&buffer, format)).To(Succeed())
+		return buffer.String()
+	}
+
+	BeforeEach(func() {
+		template = NewTemplate("Account roles").
+			AddRole(&Role{
+				Name:                "ManagedOpenShift-Worker-Role",
+				Path:                "/rosa/",
+				PermissionsBoundary: "arn:aws:iam::123456789012:policy/boundary",
+				AssumeRolePolicy:    trustPolicy,
+				Tags:                map[string]string{"red-hat-managed": "true"},
+				Policies:            []string{"ManagedOpenShift-Worker-Role-Policy"},
+			}).
+			AddRole(&Role{
+				Name:             "ManagedOpenShift-HCP-ROSA-Worker-Role",
+				AssumeRolePolicy: trustPolicy,
+				PolicyARNs:       []string{"arn:aws:iam::aws:policy/service-role/ROSAWorkerInstancePolicy"},
+			}).
+			AddPolicy(&Policy{
+				Name:     "ManagedOpenShift-Worker-Role-Policy",
+				Path:     "/rosa/",
+				Document: permissionPolicy,
+			}).
+			AddOIDCProvider(&OIDCProvider{
+				URL:         "https://oidc.example.com/1234",
+				ClientIDs:   []string{"openshift", "sts.amazonaws.com"},
+				Thumbprints: []string{"abcdef"},
+			}).
+			AddBucket(&Bucket{
+				Name: "oidc-1234",
+				PublicAccessBlock: &PublicAccessBlock{
+					BlockPublicAcls:  true,
+					IgnorePublicAcls: true,
+				},
+				Policy:  `{"Version": "2012-10-17"}`,
+				Objects: []*Object{{Key: "keys.json", Content: `{"keys": []}`}},
+			}).
+			AddSecret(&Secret{
+				Name:      "oidc-1234-private-key",
+				ValueFile: "oidc-1234.key",
+			})
+	})
+
+	It("writes a Terraform module", func() {
+		output := write(FormatTerraform)
+		Expect(output).To(HavePrefix("# Account roles\n"))
+		Expect(output).To(ContainSubstring(`resource "aws_iam_role" "ManagedOpenShift-Worker-Role" {
+  name = "ManagedOpenShift-Worker-Role"
+  path = "/rosa/"
+  permissions_boundary = "arn:aws:iam::123456789012:policy/boundary"
+  assume_role_policy = <<-EOT
+    {
+      "Version": "2012-10-17",
+      "Statement": [{"Effect": "Allow", "Action": "sts:AssumeRole"}]
+    }
+  EOT
+  tags = {
+    "red-hat-managed" = "true"
+  }
+}`))
+		Expect(output).To(ContainSubstring(`resource "aws_iam_role_policy_attachment" "ManagedOpenShift-Worker-Role_0" {
+  role = aws_iam_role.ManagedOpenShift-Worker-Role.name
+  policy_arn = aws_iam_policy.ManagedOpenShift-Worker-Role-Policy.arn
+}`))
+		Expect(output).To(ContainSubstring(
+			`policy_arn = "arn:aws:iam::aws:policy/service-role/ROSAWorkerInstancePolicy"`))
+		// Policy variables aren't evaluated by Terraform:
+		Expect(output).To(ContainSubstring(`user/$${aws:username}`))
+		Expect(output).To(ContainSubstring(`resource "aws_iam_openid_connect_provider" "oidc_example_com_1234" {
+  url = "https://oidc.example.com/1234"
+  client_id_list = ["openshift", "sts.amazonaws.com"]
+  thumbprint_list = ["abcdef"]
+}`))
+		Expect(output).To(ContainSubstring(`depends_on = [aws_s3_bucket_public_access_block.oidc-1234]`))
+		Expect(output).To(ContainSubstring(`resource "aws_s3_object" "oidc-1234_keys_json" {`))
+		Expect(output).To(ContainSubstring(`secret_string = file("${path.module}/oidc-1234.key")`))
+	})
+
+	It("writes a CloudFormation template", func() {
+		var result map[string]interface{}
+		Expect(json.Unmarshal([]byte(write(FormatCloudFormation)), &result)).To(Succeed())
+		Expect(result).To(HaveKeyWithValue("Description", "Account roles"))
+		resources := result["Resources"].(map[string]interface{})
+		Expect(resources).To(HaveLen(7))
+
+		role := resources["RoleManagedOpenShiftWorkerRole"].(map[string]interface{})
+		Expect(role["Type"]).To(Equal("AWS::IAM::Role"))
+		properties := role["Properties"].(map[string]interface{})
+		Expect(properties["Path"]).To(Equal("/rosa/"))
+		Expect(properties["ManagedPolicyArns"]).To(Equal([]interface{}{
+			map[string]interface{}{"Ref": "PolicyManagedOpenShiftWorkerRolePolicy"},
+		}))
+		Expect(properties["AssumeRolePolicyDocument"]).To(HaveKeyWithValue("Version", "2012-10-17"))
+		Expect(properties["Tags"]).To(Equal([]interface{}{
+			map[string]interface{}{"Key": "red-hat-managed", "Value": "true"},
+		}))
+		Expect(resources).To(HaveKey("PolicyManagedOpenShiftWorkerRolePolicy"))
+		Expect(resources).To(HaveKey("OIDCProviderOidcExampleCom1234"))
+		Expect(resources).To(HaveKey("BucketOidc1234"))
+		Expect(resources).To(HaveKey("BucketPolicyOidc1234"))
+		Expect(resources).To(HaveKey("SecretOidc1234PrivateKey"))
+		Expect(result["Parameters"]).To(HaveKeyWithValue("SecretStringOidc1234PrivateKey", map[string]interface{}{
+			"Type":        "String",
+			"Description": "Content of the 'oidc-1234.key' file",
+			"NoEcho":      true,
+		}))
+	})
+
+	It("writes the resources as JSON", func() {
+		var result map[string]interface{}
+		Expect(json.Unmarshal([]byte(write(FormatJSON)), &result)).To(Succeed())
+		roles := result["roles"].([]interface{})
+		Expect(roles).To(HaveLen(2))
+		// Resources are sorted by name and documents are written as objects:
+		Expect(roles[0]).To(HaveKeyWithValue("name", "ManagedOpenShift-HCP-ROSA-Worker-Role"))
+		Expect(roles[0]).To(HaveKeyWithValue("assume_role_policy", HaveKeyWithValue("Version", "2012-10-17")))
+	})
+
+	It("fails with an invalid format", func() {
+		Expect(template.Write(&bytes.Buffer{}, "yaml")).To(MatchError(ContainSubstring("Invalid format 'yaml'")))
+	})
+
+	Context("GetFormat", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = &cobra.Command{}
+			interactive.AddModeFlag(cmd)
+			AddFormatFlag(cmd)
+		})
+
+		AfterEach(func() {
+			SetFormat("")
+			interactive.SetModeKey("")
+		})
+
+		It("keeps the commands when no format is selected", func() {
+			format, mode, err := GetFormat(cmd, interactive.ModeAuto)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(BeEmpty())
+			Expect(mode).To(Equal(interactive.ModeAuto))
+		})
+
+		It("selects the manual mode", func() {
+			SetFormat(FormatTerraform)
+			format, mode, err := GetFormat(cmd, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(format).To(Equal(FormatTerraform))
+			Expect(mode).To(Equal(interactive.ModeManual))
+			Expect(cmd.Flags().Changed(interactive.Mode)).To(BeTrue())
+		})
+
+		It("fails with the auto mode", func() {
+			SetFormat(FormatCloudFormation)
+			_, _, err := GetFormat(cmd, interactive.ModeAuto)
+			Expect(err).To(MatchError("The '--format' option can only be used with '--mode manual'"))
+		})
+	})
+})
@@ -0,0 +1,264 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that write a template as a Terraform module.
+
+package iac
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hclWriter writes HCL blocks, remembering the first error so that it only needs to be checked
+// once at the end.
+type hclWriter struct {
+	writer *bufio.Writer
+	indent int
+	err    error
+}
+
+func (w *hclWriter) line(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.writer, "%s%s\n", strings.Repeat("  ", w.indent), fmt.Sprintf(format, args...))
+}
+
+func (w *hclWriter) open(format string, args ...interface{}) {
+	w.line(format+" {", args...)
+	w.indent++
+}
+
+func (w *hclWriter) close() {
+	w.indent--
+	w.line("}")
+}
+
+// attribute writes an attribute with an already formatted value.
+func (w *hclWriter) attribute(name string, value string) {
+	w.line("%s = %s", name, value)
+}
+
+// string writes a string attribute, unless the value is empty.
+func (w *hclWriter) string(name string, value string) {
+	if value != "" {
+		w.attribute(name, hclString(value))
+	}
+}
+
+func (w *hclWriter) bool(name string, value bool) {
+	w.attribute(name, strconv.FormatBool(value))
+}
+
+func (w *hclWriter) list(name string, values []string) {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = hclString(value)
+	}
+	w.attribute(name, "["+strings.Join(quoted, ", ")+"]")
+}
+
+// document writes a policy document as a heredoc, so that it can be reviewed as is.
+func (w *hclWriter) document(name string, document Document) {
+	if document == "" {
+		return
+	}
+	w.line("%s = <<-EOT", name)
+	w.indent++
+	for _, line := range strings.Split(strings.TrimRight(string(document), "\n"), "\n") {
+		w.line("%s", hclEscapeTemplate(line))
+	}
+	w.indent--
+	w.line("EOT")
+}
+
+func (w *hclWriter) tags(tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+	w.open("tags =")
+	for _, key := range sortedKeys(tags) {
+		w.attribute(hclString(key), hclString(tags[key]))
+	}
+	w.close()
+}
+
+func (t *Template) writeTerraform(writer io.Writer) error {
+	w := &hclWriter{
+		writer: bufio.NewWriter(writer),
+	}
+	if t.Description != "" {
+		w.line("# %s", t.Description)
+	}
+	if t.Region != "" {
+		w.line("# Apply it with an AWS provider configured for the '%s' region.", t.Region)
+	}
+	w.open("terraform")
+	w.open("required_providers")
+	w.open("aws =")
+	w.string("source", "hashicorp/aws")
+	w.close()
+	w.close()
+	w.close()
+
+	for _, policy := range t.Policies {
+		w.line("")
+		w.open("resource \"aws_iam_policy\" %s", hclString(hclName(policy.Name)))
+		w.string("name", policy.Name)
+		w.string("path", policy.Path)
+		w.document("policy", policy.Document)
+		w.tags(policy.Tags)
+		w.close()
+	}
+
+	for _, role := range t.Roles {
+		name := hclName(role.Name)
+		w.line("")
+		w.open("resource \"aws_iam_role\" %s", hclString(name))
+		w.string("name", role.Name)
+		w.string("path", role.Path)
+		w.string("permissions_boundary", role.PermissionsBoundary)
+		w.document("assume_role_policy", role.AssumeRolePolicy)
+		w.tags(role.Tags)
+		w.close()
+
+		arns := []string{}
+		for _, policyName := range role.Policies {
+			arns = append(arns, fmt.Sprintf("aws_iam_policy.%s.arn", hclName(policyName)))
+		}
+		for _, arn := range role.PolicyARNs {
+			arns = append(arns, hclString(arn))
+		}
+		for i, arn := range arns {
+			w.line("")
+			w.open("resource \"aws_iam_role_policy_attachment\" %s", hclString(fmt.Sprintf("%s_%d", name, i)))
+			w.attribute("role", fmt.Sprintf("aws_iam_role.%s.name", name))
+			w.attribute("policy_arn", arn)
+			w.close()
+		}
+	}
+
+	for _, provider := range t.OIDCProviders {
+		w.line("")
+		w.open("resource \"aws_iam_openid_connect_provider\" %s", hclString(hclName(provider.URL)))
+		w.string("url", provider.URL)
+		w.list("client_id_list", provider.ClientIDs)
+		w.list("thumbprint_list", provider.Thumbprints)
+		w.tags(provider.Tags)
+		w.close()
+	}
+
+	for _, bucket := range t.Buckets {
+		name := hclName(bucket.Name)
+		reference := fmt.Sprintf("aws_s3_bucket.%s.id", name)
+		w.line("")
+		w.open("resource \"aws_s3_bucket\" %s", hclString(name))
+		w.string("bucket", bucket.Name)
+		w.tags(bucket.Tags)
+		w.close()
+
+		if bucket.PublicAccessBlock != nil {
+			w.line("")
+			w.open("resource \"aws_s3_bucket_public_access_block\" %s", hclString(name))
+			w.attribute("bucket", reference)
+			w.bool("block_public_acls", bucket.PublicAccessBlock.BlockPublicAcls)
+			w.bool("ignore_public_acls", bucket.PublicAccessBlock.IgnorePublicAcls)
+			w.bool("block_public_policy", bucket.PublicAccessBlock.BlockPublicPolicy)
+			w.bool("restrict_public_buckets", bucket.PublicAccessBlock.RestrictPublicBuckets)
+			w.close()
+		}
+
+		if bucket.Policy != "" {
+			w.line("")
+			w.open("resource \"aws_s3_bucket_policy\" %s", hclString(name))
+			w.attribute("bucket", reference)
+			w.document("policy", bucket.Policy)
+			if bucket.PublicAccessBlock != nil {
+				// Public policies are rejected until the public access block allows them:
+				w.attribute("depends_on", fmt.Sprintf("[aws_s3_bucket_public_access_block.%s]", name))
+			}
+			w.close()
+		}
+
+		for _, object := range bucket.Objects {
+			w.line("")
+			w.open("resource \"aws_s3_object\" %s", hclString(hclName(bucket.Name+"_"+object.Key)))
+			w.attribute("bucket", reference)
+			w.string("key", object.Key)
+			w.document("content", Document(object.Content))
+			w.tags(object.Tags)
+			w.close()
+		}
+	}
+
+	for _, secret := range t.Secrets {
+		name := hclName(secret.Name)
+		w.line("")
+		w.open("resource \"aws_secretsmanager_secret\" %s", hclString(name))
+		w.string("name", secret.Name)
+		w.string("description", secret.Description)
+		w.tags(secret.Tags)
+		w.close()
+
+		w.line("")
+		w.open("resource \"aws_secretsmanager_secret_version\" %s", hclString(name))
+		w.attribute("secret_id", fmt.Sprintf("aws_secretsmanager_secret.%s.id", name))
+		w.attribute("secret_string", fmt.Sprintf("file(\"${path.module}/%s\")", hclEscapeString(secret.ValueFile)))
+		w.close()
+	}
+
+	if w.err != nil {
+		return w.err
+	}
+	return w.writer.Flush()
+}
+
+var hclNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// hclName returns the name of the Terraform resource that corresponds to the given AWS name.
+// Names can only contain letters, digits, underscores and dashes, and can't start with a digit.
+func hclName(name string) string {
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.Trim(hclNameInvalidChars.ReplaceAllString(name, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+// hclString returns the given text as a quoted HCL string.
+func hclString(text string) string {
+	return hclEscapeTemplate(strconv.Quote(text))
+}
+
+// hclEscapeString escapes the given text so that it can be used inside a quoted HCL string.
+func hclEscapeString(text string) string {
+	quoted := hclString(text)
+	return quoted[1 : len(quoted)-1]
+}
+
+// hclEscapeTemplate escapes the interpolation and directive sequences, as they are evaluated in
+// HCL strings and heredocs, and policy documents may contain IAM policy variables like
+// '${aws:username}'.
+func hclEscapeTemplate(text string) string {
+	text = strings.ReplaceAll(text, "${", "$${")
+	return strings.ReplaceAll(text, "%{", "%%{")
+}
@@ -60,6 +60,19 @@ func (c *awsClient) CreateOpenIDConnectProvider(providerURL string, thumbprint s
 	if err != nil {
 		return "", err
 	}
+	if output.OpenIDConnectProviderArn == nil {
+		// The request isn't sent in dry-run mode, return the ARN that the provider would have
+		creator, err := c.GetCreator()
+		if err != nil {
+			return "", err
+		}
+		parsedURL, err := url.ParseRequestURI(providerURL)
+		if err != nil {
+			return "", err
+		}
+		return GetOIDCProviderARN(creator.Partition, creator.AccountID,
+			fmt.Sprintf("%s%s", parsedURL.Host, parsedURL.Path)), nil
+	}
 
 	return aws.ToString(output.OpenIDConnectProviderArn), nil
 }
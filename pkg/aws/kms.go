@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// DefaultKMSKeyPolicyName is the name of the key policy of KMS keys, the only one that they can have.
+const DefaultKMSKeyPolicyName = "default"
+
+// DescribeKMSKey returns the metadata of the KMS key with the given ARN. The request is sent to the
+// region of the key, that may not be the region of the client.
+func (c *awsClient) DescribeKMSKey(keyARN string) (*kmstypes.KeyMetadata, error) {
+	region, err := kmsKeyRegion(keyARN)
+	if err != nil {
+		return nil, err
+	}
+	output, err := c.kmsClient.DescribeKey(context.Background(), &kms.DescribeKeyInput{
+		KeyId: aws.String(keyARN),
+	}, withKMSRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return output.KeyMetadata, nil
+}
+
+// GetKMSKeyPolicy returns the document of the key policy of the KMS key with the given ARN.
+func (c *awsClient) GetKMSKeyPolicy(keyARN string) (string, error) {
+	region, err := kmsKeyRegion(keyARN)
+	if err != nil {
+		return "", err
+	}
+	output, err := c.kmsClient.GetKeyPolicy(context.Background(), &kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(DefaultKMSKeyPolicyName),
+	}, withKMSRegion(region))
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.Policy), nil
+}
+
+// PutKMSKeyPolicy replaces the key policy of the KMS key with the given ARN.
+func (c *awsClient) PutKMSKeyPolicy(keyARN string, policy string) error {
+	region, err := kmsKeyRegion(keyARN)
+	if err != nil {
+		return err
+	}
+	_, err = c.kmsClient.PutKeyPolicy(context.Background(), &kms.PutKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(DefaultKMSKeyPolicyName),
+		Policy:     aws.String(policy),
+	}, withKMSRegion(region))
+	return err
+}
+
+func kmsKeyRegion(keyARN string) (string, error) {
+	parsed, err := arn.Parse(keyARN)
+	if err != nil {
+		return "", fmt.Errorf("Invalid KMS key ARN '%s': %v", keyARN, err)
+	}
+	return parsed.Region, nil
+}
+
+func withKMSRegion(region string) func(*kms.Options) {
+	return func(options *kms.Options) {
+		options.Region = region
+	}
+}
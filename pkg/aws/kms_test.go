@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("KMS keys", func() {
+	const keyARN = "arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	var (
+		client     Client
+		mockCtrl   *gomock.Controller
+		mockKmsAPI *mocks.MockKmsApiClient
+	)
+
+	// expectRegion checks that the request is sent to the region of the key:
+	expectRegion := func(optFns []func(*kms.Options)) {
+		options := kms.Options{Region: "us-east-1"}
+		for _, optFn := range optFns {
+			optFn(&options)
+		}
+		Expect(options.Region).To(Equal("us-west-2"))
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKmsAPI = mocks.NewMockKmsApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mockKmsAPI,
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Describes the key in its region", func() {
+		mockKmsAPI.EXPECT().DescribeKey(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *kms.DescribeKeyInput,
+				optFns ...func(*kms.Options)) (*kms.DescribeKeyOutput, error) {
+				Expect(awsSdk.ToString(input.KeyId)).To(Equal(keyARN))
+				expectRegion(optFns)
+				return &kms.DescribeKeyOutput{
+					KeyMetadata: &kmstypes.KeyMetadata{KeyState: kmstypes.KeyStateEnabled},
+				}, nil
+			})
+		metadata, err := client.DescribeKMSKey(keyARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metadata.KeyState).To(Equal(kmstypes.KeyStateEnabled))
+	})
+
+	It("Reads and writes the default key policy", func() {
+		mockKmsAPI.EXPECT().GetKeyPolicy(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *kms.GetKeyPolicyInput,
+				optFns ...func(*kms.Options)) (*kms.GetKeyPolicyOutput, error) {
+				Expect(awsSdk.ToString(input.PolicyName)).To(Equal(DefaultKMSKeyPolicyName))
+				expectRegion(optFns)
+				return &kms.GetKeyPolicyOutput{Policy: awsSdk.String(`{"Statement": []}`)}, nil
+			})
+		mockKmsAPI.EXPECT().PutKeyPolicy(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *kms.PutKeyPolicyInput,
+				optFns ...func(*kms.Options)) (*kms.PutKeyPolicyOutput, error) {
+				Expect(awsSdk.ToString(input.PolicyName)).To(Equal(DefaultKMSKeyPolicyName))
+				Expect(awsSdk.ToString(input.Policy)).To(Equal(`{"Statement": [{}]}`))
+				expectRegion(optFns)
+				return &kms.PutKeyPolicyOutput{}, nil
+			})
+		policy, err := client.GetKMSKeyPolicy(keyARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(`{"Statement": []}`))
+		Expect(client.PutKMSKeyPolicy(keyARN, `{"Statement": [{}]}`)).To(Succeed())
+	})
+
+	It("Returns the errors of the KMS API", func() {
+		mockKmsAPI.EXPECT().DescribeKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil,
+			fmt.Errorf("AccessDeniedException"))
+		_, err := client.DescribeKMSKey(keyARN)
+		Expect(err).To(MatchError("AccessDeniedException"))
+	})
+
+	It("Fails with invalid key ARNs", func() {
+		_, err := client.GetKMSKeyPolicy("my-key")
+		Expect(err).To(HaveOccurred())
+	})
+})
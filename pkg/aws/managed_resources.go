@@ -0,0 +1,331 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// ManagedResourceType is the type of an AWS resource created by ROSA.
+type ManagedResourceType string
+
+const (
+	OperatorRoleResource   ManagedResourceType = "OperatorRole"
+	OperatorPolicyResource ManagedResourceType = "OperatorPolicy"
+	OIDCProviderResource   ManagedResourceType = "OIDCProvider"
+	OIDCBucketResource     ManagedResourceType = "S3Bucket"
+	OIDCSecretResource     ManagedResourceType = "Secret"
+)
+
+// The private key secret of an OIDC config created by ROSA is named
+// rosa-private-key-<bucket name>-<random suffix added by AWS>
+const oidcPrivateKeySecretPrefix = "rosa-private-key-"
+
+var operatorResourceNameRE = regexp.MustCompile(`(?i)[\w+=,.@-]+-(openshift|kube-system)`)
+
+// ManagedResource is an operator role, operator policy, OIDC provider, OIDC bucket or OIDC private
+// key secret that ROSA created in the AWS account.
+type ManagedResource struct {
+	Type       ManagedResourceType
+	Name       string
+	ARN        string
+	CreateDate time.Time
+	Tags       map[string]string
+	// URL is the issuer URL of an OIDC provider, without the scheme
+	URL string
+	// OIDCProviders are the ARNs of the OIDC providers trusted by an operator role
+	OIDCProviders []string
+	// AttachedPolicies are the ARNs of the policies attached to an operator role
+	AttachedPolicies []string
+	// AttachmentCount is the number of entities that an operator policy is attached to
+	AttachmentCount int32
+}
+
+// IsRedHatManaged returns true if the resource has the tag that ROSA adds to the resources it creates.
+func (r ManagedResource) IsRedHatManaged() bool {
+	return r.Tags[tags.RedHatManaged] == tags.True
+}
+
+// ListManagedResources lists the operator roles, operator policies and OIDC providers of the account,
+// and the OIDC buckets and secrets of the region, that were created by ROSA. OIDC providers are
+// listed even if they weren't created by ROSA, so that the trust of the operator roles can be resolved.
+func (c *awsClient) ListManagedResources() ([]ManagedResource, error) {
+	resources := []ManagedResource{}
+	for _, list := range []func() ([]ManagedResource, error){
+		c.listManagedOperatorRoles,
+		c.listManagedOperatorPolicies,
+		c.listOIDCProviders,
+		c.listManagedOIDCBuckets,
+		c.listManagedOIDCSecrets,
+	} {
+		items, err := list()
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, items...)
+	}
+	return resources, nil
+}
+
+func (c *awsClient) listManagedOperatorRoles() ([]ManagedResource, error) {
+	roles, err := c.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	resources := []ManagedResource{}
+	for _, role := range roles {
+		if !operatorResourceNameRE.MatchString(aws.ToString(role.RoleName)) {
+			continue
+		}
+		output, err := c.iamClient.ListRoleTags(context.Background(), &iam.ListRoleTagsInput{
+			RoleName: role.RoleName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resource := ManagedResource{
+			Type:       OperatorRoleResource,
+			Name:       aws.ToString(role.RoleName),
+			ARN:        aws.ToString(role.Arn),
+			CreateDate: aws.ToTime(role.CreateDate),
+			Tags:       iamTagsMap(output.Tags),
+		}
+		if !resource.IsRedHatManaged() || resource.Tags[tags.OperatorNamespace] == "" {
+			continue
+		}
+		resource.OIDCProviders, err = trustedOIDCProviders(aws.ToString(role.AssumeRolePolicyDocument))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse the trust policy of role '%s': %v", resource.Name, err)
+		}
+		resource.AttachedPolicies, err = c.ListAttachedRolePolicies(resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+func (c *awsClient) listManagedOperatorPolicies() ([]ManagedResource, error) {
+	resources := []ManagedResource{}
+	paginator := iam.NewListPoliciesPaginator(c.iamClient, &iam.ListPoliciesInput{
+		Scope: iamtypes.PolicyScopeTypeLocal,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range output.Policies {
+			if !operatorResourceNameRE.MatchString(aws.ToString(policy.PolicyName)) {
+				continue
+			}
+			tagsOutput, err := c.iamClient.ListPolicyTags(context.Background(), &iam.ListPolicyTagsInput{
+				PolicyArn: policy.Arn,
+			})
+			if err != nil {
+				return nil, err
+			}
+			resource := ManagedResource{
+				Type:            OperatorPolicyResource,
+				Name:            aws.ToString(policy.PolicyName),
+				ARN:             aws.ToString(policy.Arn),
+				CreateDate:      aws.ToTime(policy.CreateDate),
+				Tags:            iamTagsMap(tagsOutput.Tags),
+				AttachmentCount: aws.ToInt32(policy.AttachmentCount),
+			}
+			if !resource.IsRedHatManaged() || resource.Tags[tags.OperatorNamespace] == "" {
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+func (c *awsClient) listOIDCProviders() ([]ManagedResource, error) {
+	output, err := c.iamClient.ListOpenIDConnectProviders(context.Background(),
+		&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, err
+	}
+	resources := []ManagedResource{}
+	for _, provider := range output.OpenIDConnectProviderList {
+		providerOutput, err := c.iamClient.GetOpenIDConnectProvider(context.Background(),
+			&iam.GetOpenIDConnectProviderInput{
+				OpenIDConnectProviderArn: provider.Arn,
+			})
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, ManagedResource{
+			Type:       OIDCProviderResource,
+			Name:       aws.ToString(providerOutput.Url),
+			ARN:        aws.ToString(provider.Arn),
+			CreateDate: aws.ToTime(providerOutput.CreateDate),
+			Tags:       iamTagsMap(providerOutput.Tags),
+			URL:        aws.ToString(providerOutput.Url),
+		})
+	}
+	return resources, nil
+}
+
+func (c *awsClient) listManagedOIDCBuckets() ([]ManagedResource, error) {
+	output, err := c.s3Client.ListBuckets(context.Background(), &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+	resources := []ManagedResource{}
+	for _, bucket := range output.Buckets {
+		name := aws.ToString(bucket.Name)
+		if !strings.Contains(name, "-oidc-") {
+			continue
+		}
+		// Buckets that have no tags, or that are in a different region, fail to return the tags and
+		// are skipped:
+		tagging, err := c.s3Client.GetBucketTagging(context.Background(), &s3.GetBucketTaggingInput{
+			Bucket: bucket.Name,
+		})
+		if err != nil {
+			c.logger.Debugf("Skipping bucket '%s': %v", name, err)
+			continue
+		}
+		bucketTags := map[string]string{}
+		for _, tag := range tagging.TagSet {
+			bucketTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		resource := ManagedResource{
+			Type:       OIDCBucketResource,
+			Name:       name,
+			CreateDate: aws.ToTime(bucket.CreationDate),
+			Tags:       bucketTags,
+		}
+		if !resource.IsRedHatManaged() {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+func (c *awsClient) listManagedOIDCSecrets() ([]ManagedResource, error) {
+	resources := []ManagedResource{}
+	paginator := secretsmanager.NewListSecretsPaginator(c.smClient, &secretsmanager.ListSecretsInput{
+		Filters: []secretsmanagertypes.Filter{
+			{
+				Key:    secretsmanagertypes.FilterNameStringTypeName,
+				Values: []string{oidcPrivateKeySecretPrefix},
+			},
+			{
+				Key:    secretsmanagertypes.FilterNameStringTypeTagKey,
+				Values: []string{tags.RedHatManaged},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range output.SecretList {
+			secretTags := map[string]string{}
+			for _, tag := range secret.Tags {
+				secretTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			resource := ManagedResource{
+				Type:       OIDCSecretResource,
+				Name:       aws.ToString(secret.Name),
+				ARN:        aws.ToString(secret.ARN),
+				CreateDate: aws.ToTime(secret.CreatedDate),
+				Tags:       secretTags,
+			}
+			if !resource.IsRedHatManaged() {
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// DeleteManagedResource deletes a resource returned by ListManagedResources. Operator roles are
+// deleted after detaching their policies, and operator policies are only deleted if they are no
+// longer attached to any role.
+func (c *awsClient) DeleteManagedResource(resource ManagedResource) error {
+	switch resource.Type {
+	case OperatorRoleResource:
+		err := c.detachOperatorRolePolicies(aws.String(resource.Name))
+		if err != nil {
+			return err
+		}
+		return c.DeleteRole(resource.Name)
+	case OperatorPolicyResource:
+		_, err := c.deletePolicies([]string{resource.ARN})
+		return err
+	case OIDCProviderResource:
+		return c.DeleteOpenIDConnectProvider(resource.ARN)
+	case OIDCBucketResource:
+		return c.DeleteS3Bucket(resource.Name)
+	case OIDCSecretResource:
+		return c.DeleteSecretInSecretsManager(resource.ARN)
+	default:
+		return fmt.Errorf("Unknown resource type '%s'", resource.Type)
+	}
+}
+
+// trustedOIDCProviders returns the ARNs of the OIDC providers that are federated principals of the
+// given URL encoded trust policy.
+func trustedOIDCProviders(trustPolicy string) ([]string, error) {
+	document, err := url.QueryUnescape(trustPolicy)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := ParsePolicyDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	providers := []string{}
+	for _, statement := range policy.Statement {
+		if statement.Principal == nil || !strings.Contains(statement.Principal.Federated, ":oidc-provider/") {
+			continue
+		}
+		providers = append(providers, statement.Principal.Federated)
+	}
+	return providers, nil
+}
+
+func iamTagsMap(iamTags []iamtypes.Tag) map[string]string {
+	result := map[string]string{}
+	for _, tag := range iamTags {
+		result[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"fmt"
+	"net/url"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+var _ = Describe("ListManagedResources", func() {
+	const (
+		providerARN = "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"
+		policyARN   = "arn:aws:iam::123456789012:policy/test-openshift-ingress-operator-cloud-credentials"
+	)
+
+	var (
+		client     Client
+		mockCtrl   *gomock.Controller
+		mockIamAPI *mocks.MockIamApiClient
+		mockS3API  *mocks.MockS3ApiClient
+		mockSmAPI  *mocks.MockSecretsManagerApiClient
+	)
+
+	managedTags := []iamtypes.Tag{
+		{Key: awsSdk.String(tags.RedHatManaged), Value: awsSdk.String(tags.True)},
+		{Key: awsSdk.String(tags.OperatorNamespace), Value: awsSdk.String("openshift-ingress-operator")},
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIamAPI = mocks.NewMockIamApiClient(mockCtrl)
+		mockS3API = mocks.NewMockS3ApiClient(mockCtrl)
+		mockSmAPI = mocks.NewMockSecretsManagerApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mockIamAPI,
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mockS3API,
+			mockSmAPI,
+			mocks.NewMockKmsApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Lists the resources created by ROSA", func() {
+		trustPolicy := fmt.Sprintf(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow",
+			"Action": "sts:AssumeRoleWithWebIdentity", "Principal": {"Federated": "%s"}}]}`, providerARN)
+		mockIamAPI.EXPECT().ListRoles(gomock.Any(), gomock.Any(), gomock.Any()).Return(&iam.ListRolesOutput{
+			Roles: []iamtypes.Role{
+				{
+					RoleName:                 awsSdk.String("test-openshift-ingress-operator-cloud-credentials"),
+					Arn:                      awsSdk.String("arn:aws:iam::123456789012:role/test-openshift-ingress"),
+					AssumeRolePolicyDocument: awsSdk.String(url.QueryEscape(trustPolicy)),
+				},
+				{RoleName: awsSdk.String("test-Installer-Role")},
+				{RoleName: awsSdk.String("other-openshift-ingress-operator-cloud-credentials")},
+			},
+		}, nil)
+		mockIamAPI.EXPECT().ListRoleTags(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, input *iam.ListRoleTagsInput, _ ...func(*iam.Options)) (*iam.ListRoleTagsOutput, error) {
+				if awsSdk.ToString(input.RoleName) == "other-openshift-ingress-operator-cloud-credentials" {
+					return &iam.ListRoleTagsOutput{}, nil
+				}
+				return &iam.ListRoleTagsOutput{Tags: managedTags}, nil
+			}).Times(2)
+		mockIamAPI.EXPECT().ListAttachedRolePolicies(gomock.Any(), gomock.Any()).Return(
+			&iam.ListAttachedRolePoliciesOutput{
+				AttachedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: awsSdk.String(policyARN)}},
+			}, nil)
+		mockIamAPI.EXPECT().ListPolicies(gomock.Any(), gomock.Any(), gomock.Any()).Return(&iam.ListPoliciesOutput{
+			Policies: []iamtypes.Policy{
+				{
+					PolicyName:      awsSdk.String("test-openshift-ingress-operator-cloud-credentials"),
+					Arn:             awsSdk.String(policyARN),
+					AttachmentCount: awsSdk.Int32(1),
+				},
+			},
+		}, nil)
+		mockIamAPI.EXPECT().ListPolicyTags(gomock.Any(), gomock.Any()).Return(
+			&iam.ListPolicyTagsOutput{Tags: managedTags}, nil)
+		mockIamAPI.EXPECT().ListOpenIDConnectProviders(gomock.Any(), gomock.Any()).Return(
+			&iam.ListOpenIDConnectProvidersOutput{
+				OpenIDConnectProviderList: []iamtypes.OpenIDConnectProviderListEntry{
+					{Arn: awsSdk.String(providerARN)},
+				},
+			}, nil)
+		mockIamAPI.EXPECT().GetOpenIDConnectProvider(gomock.Any(), gomock.Any()).Return(
+			&iam.GetOpenIDConnectProviderOutput{Url: awsSdk.String("oidc.example.com/abc")}, nil)
+		mockS3API.EXPECT().ListBuckets(gomock.Any(), gomock.Any()).Return(&s3.ListBucketsOutput{
+			Buckets: []s3types.Bucket{
+				{Name: awsSdk.String("test-oidc-abcd")},
+				{Name: awsSdk.String("other-oidc-wxyz")},
+				{Name: awsSdk.String("logs")},
+			},
+		}, nil)
+		mockS3API.EXPECT().GetBucketTagging(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, input *s3.GetBucketTaggingInput, _ ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+				if awsSdk.ToString(input.Bucket) == "other-oidc-wxyz" {
+					return nil, fmt.Errorf("PermanentRedirect")
+				}
+				return &s3.GetBucketTaggingOutput{TagSet: []s3types.Tag{
+					{Key: awsSdk.String(tags.RedHatManaged), Value: awsSdk.String(tags.True)},
+				}}, nil
+			}).Times(2)
+		mockSmAPI.EXPECT().ListSecrets(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&secretsmanager.ListSecretsOutput{
+				SecretList: []secretsmanagertypes.SecretListEntry{
+					{
+						Name: awsSdk.String("rosa-private-key-test-oidc-abcd"),
+						ARN:  awsSdk.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key"),
+						Tags: []secretsmanagertypes.Tag{
+							{Key: awsSdk.String(tags.RedHatManaged), Value: awsSdk.String(tags.True)},
+						},
+					},
+				},
+			}, nil)
+
+		resources, err := client.ListManagedResources()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(HaveLen(5))
+		Expect(resources[0].Type).To(Equal(OperatorRoleResource))
+		Expect(resources[0].OIDCProviders).To(Equal([]string{providerARN}))
+		Expect(resources[0].AttachedPolicies).To(Equal([]string{policyARN}))
+		Expect(resources[1].Type).To(Equal(OperatorPolicyResource))
+		Expect(resources[1].AttachmentCount).To(Equal(int32(1)))
+		Expect(resources[2].Type).To(Equal(OIDCProviderResource))
+		Expect(resources[2].IsRedHatManaged()).To(BeFalse())
+		Expect(resources[3].Type).To(Equal(OIDCBucketResource))
+		Expect(resources[3].Name).To(Equal("test-oidc-abcd"))
+		Expect(resources[4].Type).To(Equal(OIDCSecretResource))
+	})
+})
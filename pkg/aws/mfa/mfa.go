@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mfa asks for the MFA token code required to assume the role of AWS profiles that have
+// the 'mfa_serial' setting.
+package mfa
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+// TokenEnv is the environment variable that contains the MFA token code, for when the code can't
+// be asked interactively.
+const TokenEnv = "ROSA_MFA_TOKEN"
+
+// TokenProvider returns the MFA token code from the ROSA_MFA_TOKEN environment variable, or asks
+// for it in the terminal. The question is written to the standard error so that it doesn't mix with
+// the output of the command.
+func TokenProvider() (string, error) {
+	if token := strings.TrimSpace(os.Getenv(TokenEnv)); token != "" {
+		return token, nil
+	}
+	if !isTerminal(os.Stdin) {
+		return "", fmt.Errorf("The AWS profile requires an MFA token code, set it with the %s "+
+			"environment variable", TokenEnv)
+	}
+	var token string
+	err := survey.AskOne(
+		&survey.Password{
+			Message: "MFA token code:",
+			Help: "The code is asked again when the credentials expire, or in every command when the " +
+				"configuration isn't kept in an OS keyring, selected with the " + properties.KeyringEnvKey +
+				" environment variable, as then the credentials aren't cached.",
+		},
+		&token,
+		survey.WithValidator(survey.Required),
+		survey.WithStdio(os.Stdin, os.Stderr, os.Stderr),
+	)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(token), nil
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -140,6 +140,46 @@ func (mr *MockOrganizationsApiClientMockRecorder) DeleteResourcePolicy(ctx, para
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourcePolicy", reflect.TypeOf((*MockOrganizationsApiClient)(nil).DeleteResourcePolicy), varargs...)
 }
 
+// DescribePolicy mocks base method.
+func (m *MockOrganizationsApiClient) DescribePolicy(ctx context.Context, params *organizations.DescribePolicyInput, optFns ...func(*organizations.Options)) (*organizations.DescribePolicyOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribePolicy", varargs...)
+	ret0, _ := ret[0].(*organizations.DescribePolicyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribePolicy indicates an expected call of DescribePolicy.
+func (mr *MockOrganizationsApiClientMockRecorder) DescribePolicy(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribePolicy", reflect.TypeOf((*MockOrganizationsApiClient)(nil).DescribePolicy), varargs...)
+}
+
+// ListParents mocks base method.
+func (m *MockOrganizationsApiClient) ListParents(ctx context.Context, params *organizations.ListParentsInput, optFns ...func(*organizations.Options)) (*organizations.ListParentsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListParents", varargs...)
+	ret0, _ := ret[0].(*organizations.ListParentsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParents indicates an expected call of ListParents.
+func (mr *MockOrganizationsApiClientMockRecorder) ListParents(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParents", reflect.TypeOf((*MockOrganizationsApiClient)(nil).ListParents), varargs...)
+}
+
 // ListPolicies mocks base method.
 func (m *MockOrganizationsApiClient) ListPolicies(ctx context.Context, params *organizations.ListPoliciesInput, optFns ...func(*organizations.Options)) (*organizations.ListPoliciesOutput, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +200,26 @@ func (mr *MockOrganizationsApiClientMockRecorder) ListPolicies(ctx, params any,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPolicies", reflect.TypeOf((*MockOrganizationsApiClient)(nil).ListPolicies), varargs...)
 }
 
+// ListPoliciesForTarget mocks base method.
+func (m *MockOrganizationsApiClient) ListPoliciesForTarget(ctx context.Context, params *organizations.ListPoliciesForTargetInput, optFns ...func(*organizations.Options)) (*organizations.ListPoliciesForTargetOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPoliciesForTarget", varargs...)
+	ret0, _ := ret[0].(*organizations.ListPoliciesForTargetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPoliciesForTarget indicates an expected call of ListPoliciesForTarget.
+func (mr *MockOrganizationsApiClientMockRecorder) ListPoliciesForTarget(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPoliciesForTarget", reflect.TypeOf((*MockOrganizationsApiClient)(nil).ListPoliciesForTarget), varargs...)
+}
+
 // ListTagsForResource mocks base method.
 func (m *MockOrganizationsApiClient) ListTagsForResource(ctx context.Context, params *organizations.ListTagsForResourceInput, optFns ...func(*organizations.Options)) (*organizations.ListTagsForResourceOutput, error) {
 	m.ctrl.T.Helper()
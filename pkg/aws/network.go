@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// NetworkTemplatePath is the path of the CloudFormation template used by 'rosa create network'.
+const NetworkTemplatePath = "templates/cloudformation/rosa_network.json"
+
+// networkStackWaitDur is how long to wait for the network stacks to be created or deleted. NAT
+// gateways and VPC endpoints take several minutes each.
+const networkStackWaitDur = 30 * time.Minute
+
+// NetworkStack is a CloudFormation stack created with the network template.
+type NetworkStack struct {
+	Name             string
+	Status           string
+	VpcID            string
+	PrivateSubnetIDs []string
+	PublicSubnetIDs  []string
+	CreationTime     time.Time
+}
+
+// CreateNetworkStack creates a stack with the network template and the given parameters, and waits
+// till it is created. The stack and all its resources are tagged with the given tags, and with the
+// tag that identifies the network stacks.
+func (c *awsClient) CreateNetworkStack(stackName string, parameters map[string]string,
+	stackTags map[string]string) (*NetworkStack, error) {
+	templateBody, err := readCloudFormationTemplate(NetworkTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	input := &cloudformation.CreateStackInput{
+		StackName:    aws.String(stackName),
+		TemplateBody: aws.String(templateBody),
+		Tags: []cloudformationtypes.Tag{{
+			Key:   aws.String(tags.NetworkStack),
+			Value: aws.String(tags.True),
+		}},
+	}
+	for _, key := range sortedMapKeys(parameters) {
+		input.Parameters = append(input.Parameters, cloudformationtypes.Parameter{
+			ParameterKey:   aws.String(key),
+			ParameterValue: aws.String(parameters[key]),
+		})
+	}
+	for _, key := range sortedMapKeys(stackTags) {
+		input.Tags = append(input.Tags, cloudformationtypes.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(stackTags[key]),
+		})
+	}
+	_, err = c.cfClient.CreateStack(context.Background(), input)
+	if err != nil {
+		return nil, err
+	}
+	waiter := cloudformation.NewStackCreateCompleteWaiter(c.cfClient)
+	err = waiter.Wait(context.Background(), buildDescribeStacksInput(stackName), networkStackWaitDur)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to wait for stack '%s' to be created, check its events in the "+
+			"CloudFormation console: %v", stackName, err)
+	}
+	return c.GetNetworkStack(stackName)
+}
+
+// GetNetworkStack returns the network stack with the given name, or nil if it doesn't exist. It
+// fails if the stack exists but wasn't created by 'rosa create network'.
+func (c *awsClient) GetNetworkStack(stackName string) (*NetworkStack, error) {
+	output, err := c.cfClient.DescribeStacks(context.Background(), buildDescribeStacksInput(stackName))
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" &&
+			strings.Contains(apiErr.ErrorMessage(), "does not exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(output.Stacks) == 0 {
+		return nil, nil
+	}
+	stack := output.Stacks[0]
+	isNetwork := false
+	for _, tag := range stack.Tags {
+		if aws.ToString(tag.Key) == tags.NetworkStack && aws.ToString(tag.Value) == tags.True {
+			isNetwork = true
+		}
+	}
+	if !isNetwork {
+		return nil, fmt.Errorf("Stack '%s' wasn't created by 'rosa create network'", stackName)
+	}
+	return networkStackFromOutputs(stack), nil
+}
+
+// DeleteNetworkStack deletes the network stack with the given name and waits till it is deleted.
+func (c *awsClient) DeleteNetworkStack(stackName string) error {
+	_, err := c.cfClient.DeleteStack(context.Background(), &cloudformation.DeleteStackInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		return err
+	}
+	waiter := cloudformation.NewStackDeleteCompleteWaiter(c.cfClient)
+	err = waiter.Wait(context.Background(), buildDescribeStacksInput(stackName), networkStackWaitDur)
+	if err != nil {
+		return fmt.Errorf("Failed to wait for stack '%s' to be deleted, check its events in the "+
+			"CloudFormation console: %v", stackName, err)
+	}
+	return nil
+}
+
+func networkStackFromOutputs(stack cloudformationtypes.Stack) *NetworkStack {
+	result := &NetworkStack{
+		Name:         aws.ToString(stack.StackName),
+		Status:       string(stack.StackStatus),
+		CreationTime: aws.ToTime(stack.CreationTime),
+	}
+	for _, output := range stack.Outputs {
+		value := aws.ToString(output.OutputValue)
+		switch aws.ToString(output.OutputKey) {
+		case "VpcId":
+			result.VpcID = value
+		case "PrivateSubnetIds":
+			result.PrivateSubnetIDs = splitOutputList(value)
+		case "PublicSubnetIds":
+			result.PublicSubnetIDs = splitOutputList(value)
+		}
+	}
+	return result
+}
+
+func splitOutputList(value string) []string {
+	result := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func sortedMapKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
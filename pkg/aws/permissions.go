@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
@@ -79,3 +83,146 @@ func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTS
 
 	return true, nil
 }
+
+// DeniedAction is an action that a principal isn't allowed to perform, according to the IAM policy
+// simulator.
+type DeniedAction struct {
+	Action string `json:"action"`
+	// Decision is 'explicitDeny' when a policy denies the action, or 'implicitDeny' when no policy
+	// allows it
+	Decision string `json:"decision"`
+	// DeniedBy contains the policies that deny the action
+	DeniedBy []string `json:"denied_by,omitempty"`
+}
+
+// SimulatePrincipalPermissions uses the IAM policy simulator to find the actions that the principal
+// isn't allowed to perform. The simulator takes into account the policies attached to the principal,
+// its permissions boundary and, when the account is part of an organization, the service control
+// policies. Actions can't contain wildcards.
+func (c *awsClient) SimulatePrincipalPermissions(principalARN string, actions []string,
+	params *SimulateParams) ([]DeniedAction, error) {
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalARN),
+		ActionNames:     actions,
+		ContextEntries:  []iamtypes.ContextEntry{},
+	}
+	if params != nil && params.Region != "" {
+		input.ContextEntries = append(input.ContextEntries, iamtypes.ContextEntry{
+			ContextKeyName:   aws.String("aws:RequestedRegion"),
+			ContextKeyType:   iamtypes.ContextKeyTypeEnumStringList,
+			ContextKeyValues: []string{params.Region},
+		})
+	}
+
+	denied := []DeniedAction{}
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(c.iamClient, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("Error simulating policy: %v", err)
+		}
+		for _, result := range output.EvaluationResults {
+			if result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed {
+				continue
+			}
+			denied = append(denied, DeniedAction{
+				Action:   aws.ToString(result.EvalActionName),
+				Decision: string(result.EvalDecision),
+				DeniedBy: deniedBy(result),
+			})
+		}
+	}
+	return denied, nil
+}
+
+// deniedBy returns the policies that caused the simulator to deny the action. It is empty when the
+// action is denied only because no policy allows it.
+func deniedBy(result iamtypes.EvaluationResult) []string {
+	policies := []string{}
+	seen := map[string]bool{}
+	if result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeExplicitDeny {
+		for _, statement := range result.MatchedStatements {
+			policy := fmt.Sprintf("%s (%s)", aws.ToString(statement.SourcePolicyId), statement.SourcePolicyType)
+			if !seen[policy] {
+				seen[policy] = true
+				policies = append(policies, policy)
+			}
+		}
+	}
+	if result.OrganizationsDecisionDetail != nil && !result.OrganizationsDecisionDetail.AllowedByOrganizations {
+		policies = append(policies, "service control policy")
+	}
+	if result.PermissionsBoundaryDecisionDetail != nil &&
+		!result.PermissionsBoundaryDecisionDetail.AllowedByPermissionsBoundary {
+		policies = append(policies, "permissions boundary")
+	}
+	return policies
+}
+
+// ServiceControlPolicy is a service control policy of the organization that applies to the account.
+type ServiceControlPolicy struct {
+	ID   string
+	Name string
+	// Target is the root, organizational unit or account that the policy is attached to
+	Target   string
+	Document string
+}
+
+// ListServiceControlPolicies returns the service control policies attached to the account and to
+// all the organizational units and root above it. They can only be read from the management account
+// of the organization, or from an account that is a delegated administrator.
+func (c *awsClient) ListServiceControlPolicies() ([]ServiceControlPolicy, error) {
+	creator, err := c.GetCreator()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []string{creator.AccountID}
+	for child := creator.AccountID; ; {
+		output, err := c.orgClient.ListParents(context.Background(), &organizations.ListParentsInput{
+			ChildId: aws.String(child),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(output.Parents) == 0 {
+			break
+		}
+		parent := output.Parents[0]
+		targets = append(targets, aws.ToString(parent.Id))
+		if parent.Type == orgtypes.ParentTypeRoot {
+			break
+		}
+		child = aws.ToString(parent.Id)
+	}
+
+	policies := []ServiceControlPolicy{}
+	for _, target := range targets {
+		paginator := organizations.NewListPoliciesForTargetPaginator(c.orgClient,
+			&organizations.ListPoliciesForTargetInput{
+				TargetId: aws.String(target),
+				Filter:   orgtypes.PolicyTypeServiceControlPolicy,
+			})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			for _, summary := range output.Policies {
+				policy, err := c.orgClient.DescribePolicy(context.Background(), &organizations.DescribePolicyInput{
+					PolicyId: summary.Id,
+				})
+				if err != nil {
+					return nil, err
+				}
+				policies = append(policies, ServiceControlPolicy{
+					ID:       aws.ToString(summary.Id),
+					Name:     aws.ToString(summary.Name),
+					Target:   target,
+					Document: aws.ToString(policy.Policy.Content),
+				})
+			}
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("SimulatePrincipalPermissions", func() {
+	const roleARN = "arn:aws:iam::123456789012:role/test-Installer-Role"
+
+	var (
+		client     Client
+		mockCtrl   *gomock.Controller
+		mockIamAPI *mocks.MockIamApiClient
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIamAPI = mocks.NewMockIamApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mockIamAPI,
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockKmsApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Returns the denied actions with the policies that deny them", func() {
+		mockIamAPI.EXPECT().SimulatePrincipalPolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *iam.SimulatePrincipalPolicyInput,
+				_ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+				Expect(awsSdk.ToString(input.PolicySourceArn)).To(Equal(roleARN))
+				Expect(input.ActionNames).To(Equal([]string{"ec2:RunInstances", "iam:CreateUser",
+					"s3:CreateBucket", "route53:CreateHostedZone"}))
+				Expect(input.ContextEntries).To(HaveLen(1))
+				Expect(input.ContextEntries[0].ContextKeyValues).To(Equal([]string{"us-east-1"}))
+				return &iam.SimulatePrincipalPolicyOutput{
+					EvaluationResults: []iamtypes.EvaluationResult{
+						{
+							EvalActionName: awsSdk.String("ec2:RunInstances"),
+							EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeAllowed,
+						},
+						{
+							EvalActionName: awsSdk.String("iam:CreateUser"),
+							EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeExplicitDeny,
+							MatchedStatements: []iamtypes.Statement{
+								{
+									SourcePolicyId:   awsSdk.String("DenyIAM"),
+									SourcePolicyType: iamtypes.PolicySourceTypeAwsManaged,
+								},
+							},
+						},
+						{
+							EvalActionName: awsSdk.String("s3:CreateBucket"),
+							EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+							OrganizationsDecisionDetail: &iamtypes.OrganizationsDecisionDetail{
+								AllowedByOrganizations: false,
+							},
+							PermissionsBoundaryDecisionDetail: &iamtypes.PermissionsBoundaryDecisionDetail{
+								AllowedByPermissionsBoundary: false,
+							},
+						},
+						{
+							EvalActionName: awsSdk.String("route53:CreateHostedZone"),
+							EvalDecision:   iamtypes.PolicyEvaluationDecisionTypeImplicitDeny,
+						},
+					},
+				}, nil
+			})
+
+		denied, err := client.SimulatePrincipalPermissions(roleARN,
+			[]string{"ec2:RunInstances", "iam:CreateUser", "s3:CreateBucket", "route53:CreateHostedZone"},
+			&SimulateParams{Region: "us-east-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(denied).To(Equal([]DeniedAction{
+			{
+				Action:   "iam:CreateUser",
+				Decision: "explicitDeny",
+				DeniedBy: []string{"DenyIAM (aws-managed)"},
+			},
+			{
+				Action:   "s3:CreateBucket",
+				Decision: "implicitDeny",
+				DeniedBy: []string{"service control policy", "permissions boundary"},
+			},
+			{
+				Action:   "route53:CreateHostedZone",
+				Decision: "implicitDeny",
+				DeniedBy: []string{},
+			},
+		}))
+	})
+
+	It("Fails when the simulation fails", func() {
+		mockIamAPI.EXPECT().SimulatePrincipalPolicy(gomock.Any(), gomock.Any()).Return(nil,
+			fmt.Errorf("AccessDenied"))
+		_, err := client.SimulatePrincipalPermissions(roleARN, []string{"ec2:RunInstances"}, nil)
+		Expect(err).To(MatchError("Error simulating policy: AccessDenied"))
+	})
+})
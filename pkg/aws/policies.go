@@ -62,6 +62,8 @@ type Role struct {
 	Admin         string `json:"Admin,omitempty"`
 	ManagedPolicy bool   `json:"ManagedPolicy,omitempty"`
 	ClusterID     string `json:"ClusterID,omitempty"`
+	// HostedCPPolicies is true for account roles with the AWS managed policies of hosted control planes
+	HostedCPPolicies bool `json:"HostedCPPolicies,omitempty"`
 }
 
 type OperatorRoleDetail struct {
@@ -262,6 +264,14 @@ func (c *awsClient) createRole(name string, policy string, permissionsBoundary s
 		}
 		return "", err
 	}
+	if output.Role == nil {
+		// The request isn't sent in dry-run mode, return the ARN that the role would have
+		creator, err := c.GetCreator()
+		if err != nil {
+			return "", err
+		}
+		return GetRoleARN(creator.AccountID, name, path, creator.Partition), nil
+	}
 	return aws.ToString(output.Role.Arn), nil
 }
 
@@ -395,6 +405,10 @@ func (c *awsClient) createPolicy(policyArn string, document string, tagList map[
 	if err != nil {
 		return "", err
 	}
+	if output.Policy == nil {
+		// The request isn't sent in dry-run mode, the policy would have the requested ARN
+		return policyArn, nil
+	}
 	return aws.ToString(output.Policy.Arn), nil
 }
 
@@ -829,6 +843,12 @@ func (c *awsClient) mapToAccountRole(version string, role iamtypes.Role) (Role,
 			if aws.ToString(tag.Value) == tags.True {
 				accountRole.ManagedPolicy = true
 			}
+		case tags.HypershiftPolicies:
+			if aws.ToString(tag.Value) == tags.True {
+				accountRole.HostedCPPolicies = true
+			}
+		case tags.RolePrefix:
+			accountRole.RolePrefix = aws.ToString(tag.Value)
 		}
 	}
 
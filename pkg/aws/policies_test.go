@@ -3,13 +3,20 @@ package aws
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 
 	gomock "go.uber.org/mock/gomock"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
@@ -17,8 +24,15 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws/mocks"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/dryrun"
 )
 
+type httpClientFunc func(*http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
 var _ = Describe("ListOperatorRoles", func() {
 	var (
 		client     awsClient
@@ -254,6 +268,7 @@ var _ = Describe("Is Policy Compatible", func() {
 			mocks.NewMockOrganizationsApiClient(mockCtrl),
 			mockS3API,
 			mockSecretsManagerAPI,
+			mocks.NewMockKmsApiClient(mockCtrl),
 			mockSTSApi,
 			mockCfAPI,
 			mocks.NewMockServiceQuotasApiClient(mockCtrl),
@@ -412,6 +427,118 @@ var _ = Describe("DeleteRole Validation", func() {
 	})
 })
 
+var _ = Describe("Dry run", func() {
+	var (
+		client     awsClient
+		mockSTSApi *mocks.MockStsApiClient
+		mockCtrl   *gomock.Controller
+		sentToIAM  int
+		sentToSM   int
+	)
+
+	BeforeEach(func() {
+		dryrun.SetEnabled(true)
+		DeferCleanup(dryrun.SetEnabled, false)
+		DeferCleanup(dryrun.Reset)
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockSTSApi = mocks.NewMockStsApiClient(mockCtrl)
+		sentToIAM = 0
+		sentToSM = 0
+		client = awsClient{
+			cfg: aws.Config{Region: "us-east-1"},
+			iamClient: iam.New(iam.Options{
+				Region:      "us-east-1",
+				Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+				APIOptions:  []func(*middleware.Stack) error{dryrun.AddMiddleware},
+				HTTPClient: httpClientFunc(func(request *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(request.Body)
+					if err != nil {
+						return nil, err
+					}
+					if strings.Contains(string(body), "Action=ListAccessKeys") {
+						// Reading the existing keys is sent also in dry-run mode:
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{},
+							Body: io.NopCloser(strings.NewReader("<ListAccessKeysResponse><ListAccessKeysResult>" +
+								"<AccessKeyMetadata/><IsTruncated>false</IsTruncated>" +
+								"</ListAccessKeysResult></ListAccessKeysResponse>")),
+						}, nil
+					}
+					sentToIAM++
+					return nil, fmt.Errorf("unreachable")
+				}),
+				RetryMaxAttempts: 1,
+			}),
+			smClient: secretsmanager.New(secretsmanager.Options{
+				Region:      "us-east-1",
+				Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+				APIOptions:  []func(*middleware.Stack) error{dryrun.AddMiddleware},
+				HTTPClient: httpClientFunc(func(_ *http.Request) (*http.Response, error) {
+					sentToSM++
+					return nil, fmt.Errorf("unreachable")
+				}),
+				RetryMaxAttempts: 1,
+			}),
+			stsClient: mockSTSApi,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Returns the ARN that the role would have", func() {
+		mockSTSApi.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+			Arn: aws.String("arn:aws:iam::123456789012:user/admin"),
+		}, nil)
+		roleARN, err := client.createRole("ManagedOpenShift-Installer-Role", "{}", "", nil, "/rosa/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleARN).To(Equal("arn:aws:iam::123456789012:role/rosa/ManagedOpenShift-Installer-Role"))
+		Expect(sentToIAM).To(BeZero())
+		Expect(dryrun.Operations()).To(HaveLen(1))
+	})
+
+	It("Returns the ARN that the policy would have", func() {
+		policyARN := "arn:aws:iam::123456789012:policy/rosa/ManagedOpenShift-Installer-Role-Policy"
+		result, err := client.createPolicy(policyARN, "{}", nil, "/rosa/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(policyARN))
+		Expect(sentToIAM).To(BeZero())
+		Expect(dryrun.Operations()).To(HaveLen(1))
+	})
+
+	It("Returns the ARN that the OIDC provider would have", func() {
+		mockSTSApi.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+			Arn: aws.String("arn:aws:iam::123456789012:user/admin"),
+		}, nil)
+		providerARN, err := client.CreateOpenIDConnectProvider("https://oidc.example.com/abc", "thumbprint", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerARN).To(Equal("arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"))
+		Expect(sentToIAM).To(BeZero())
+		Expect(dryrun.Operations()).To(HaveLen(1))
+	})
+
+	It("Returns the ARN that the secret would have", func() {
+		mockSTSApi.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{
+			Arn: aws.String("arn:aws:iam::123456789012:user/admin"),
+		}, nil)
+		secretARN, err := client.CreateSecretInSecretsManager("rosa-private-key", "private-key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secretARN).To(Equal("arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key"))
+		Expect(sentToSM).To(BeZero())
+		Expect(dryrun.Operations()).To(HaveLen(1))
+	})
+
+	It("Returns placeholder access keys without validating them", func() {
+		accessKey, err := client.GetAWSAccessKeys()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*accessKey).To(Equal(dryRunAccessKey))
+		Expect(sentToIAM).To(BeZero())
+		Expect(dryrun.Operations()).To(HaveLen(1))
+	})
+})
+
 var _ = Describe("Cluster Roles/Policies", func() {
 
 	var (
@@ -43,8 +43,17 @@ func Profile() string {
 	if awsProfile != "" {
 		return awsProfile
 	}
-	return ""
+	return defaultProfile
+}
+
+// SetDefault sets the profile used when neither the flag nor the AWS_PROFILE environment variable
+// are set, like the one of the selected configuration context.
+func SetDefault(value string) {
+	defaultProfile = value
 }
 
 // profile is a string flag that indicates which AWS profile is being used.
 var profile string
+
+// defaultProfile is the profile used when the flag and the environment variable are not set.
+var defaultProfile string
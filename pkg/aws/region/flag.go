@@ -45,8 +45,17 @@ func Region() string {
 	if helper.HandleEscapedEmptyString(awsRegion) != "" {
 		return awsRegion
 	}
-	return ""
+	return defaultRegion
+}
+
+// SetDefault sets the region used when neither the flag nor the AWS_REGION environment variable
+// are set, like the one of the selected configuration context.
+func SetDefault(value string) {
+	defaultRegion = value
 }
 
 // region is a string flag that indicates which AWS region is being used.
 var region string
+
+// defaultRegion is the region used when the flag and the environment variable are not set.
+var defaultRegion string
@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sso logs in to AWS IAM Identity Center (SSO) with the device authorization flow, like
+// 'aws sso login' does, so that the AWS profiles that use SSO work without the AWS CLI.
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc/types"
+)
+
+const (
+	clientName      = "rosa-cli"
+	clientType      = "public"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	accessScope     = "sso:account:access"
+
+	defaultInterval  = 5 * time.Second
+	slowDownInterval = 5 * time.Second
+)
+
+// Settings are the SSO settings of an AWS profile.
+type Settings struct {
+	StartURL string
+	Region   string
+	// Session is the name of the 'sso-session' section used by the profile, empty for profiles
+	// with the legacy settings
+	Session string
+}
+
+// cacheKey returns the key that the AWS SDK uses to find the cached token of the settings.
+func (s Settings) cacheKey() string {
+	if s.Session != "" {
+		return s.Session
+	}
+	return s.StartURL
+}
+
+// ProfileSettings returns the SSO settings of the given AWS profile.
+func ProfileSettings(ctx context.Context, profile string) (Settings, error) {
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	shared, err := config.LoadSharedConfigProfile(ctx, profile)
+	if err != nil {
+		return Settings{}, err
+	}
+	return settingsFromConfig(profile, shared)
+}
+
+func settingsFromConfig(profile string, shared config.SharedConfig) (Settings, error) {
+	var settings Settings
+	if shared.SSOSession != nil {
+		settings = Settings{
+			StartURL: shared.SSOSession.SSOStartURL,
+			Region:   shared.SSOSession.SSORegion,
+			Session:  shared.SSOSession.Name,
+		}
+	} else {
+		settings = Settings{
+			StartURL: shared.SSOStartURL,
+			Region:   shared.SSORegion,
+		}
+	}
+	if settings.StartURL == "" || settings.Region == "" {
+		return Settings{}, fmt.Errorf("AWS profile '%s' isn't configured to use SSO", profile)
+	}
+	return settings, nil
+}
+
+// Login runs the device authorization flow with the given settings and saves the token where the
+// AWS SDK looks for it. The prompt function is called with the URL that the user has to open to
+// approve the login, and the code that the page should show.
+func Login(ctx context.Context, settings Settings, prompt func(url string, code string)) error {
+	client := ssooidc.New(ssooidc.Options{
+		Region: settings.Region,
+	})
+	return login(ctx, client, settings, prompt, time.Sleep)
+}
+
+func login(ctx context.Context, client *ssooidc.Client, settings Settings,
+	prompt func(url string, code string), sleep func(time.Duration)) error {
+	registerInput := &ssooidc.RegisterClientInput{
+		ClientName: aws.String(clientName),
+		ClientType: aws.String(clientType),
+	}
+	// Only tokens of 'sso-session' configurations can be refreshed:
+	if settings.Session != "" {
+		registerInput.Scopes = []string{accessScope}
+	}
+	registration, err := client.RegisterClient(ctx, registerInput)
+	if err != nil {
+		return fmt.Errorf("Failed to register client: %v", err)
+	}
+	authorization, err := client.StartDeviceAuthorization(ctx, &ssooidc.StartDeviceAuthorizationInput{
+		ClientId:     registration.ClientId,
+		ClientSecret: registration.ClientSecret,
+		StartUrl:     aws.String(settings.StartURL),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to start device authorization: %v", err)
+	}
+	verificationURL := aws.ToString(authorization.VerificationUriComplete)
+	if verificationURL == "" {
+		verificationURL = aws.ToString(authorization.VerificationUri)
+	}
+	prompt(verificationURL, aws.ToString(authorization.UserCode))
+
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	deadline := time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+	for {
+		sleep(interval)
+		token, err := client.CreateToken(ctx, &ssooidc.CreateTokenInput{
+			ClientId:     registration.ClientId,
+			ClientSecret: registration.ClientSecret,
+			DeviceCode:   authorization.DeviceCode,
+			GrantType:    aws.String(deviceGrantType),
+		})
+		var pending *types.AuthorizationPendingException
+		var slowDown *types.SlowDownException
+		switch {
+		case err == nil:
+			return saveToken(settings, registration, token)
+		case errors.As(err, &pending):
+			if time.Now().After(deadline) {
+				return fmt.Errorf("The device authorization expired before the login was approved")
+			}
+		case errors.As(err, &slowDown):
+			interval += slowDownInterval
+		default:
+			return fmt.Errorf("Failed to create token: %v", err)
+		}
+	}
+}
+
+// cachedToken is the format of the token files of the AWS SDK and CLI.
+type cachedToken struct {
+	AccessToken           string `json:"accessToken"`
+	ExpiresAt             string `json:"expiresAt"`
+	RefreshToken          string `json:"refreshToken,omitempty"`
+	ClientID              string `json:"clientId,omitempty"`
+	ClientSecret          string `json:"clientSecret,omitempty"`
+	RegistrationExpiresAt string `json:"registrationExpiresAt,omitempty"`
+	Region                string `json:"region"`
+	StartURL              string `json:"startUrl"`
+}
+
+func saveToken(settings Settings, registration *ssooidc.RegisterClientOutput,
+	token *ssooidc.CreateTokenOutput) error {
+	file, err := ssocreds.StandardCachedTokenFilepath(settings.cacheKey())
+	if err != nil {
+		return err
+	}
+	cached := cachedToken{
+		AccessToken: aws.ToString(token.AccessToken),
+		ExpiresAt: time.Now().Add(time.Duration(token.ExpiresIn) * time.Second).
+			UTC().Format(time.RFC3339),
+		Region:   settings.Region,
+		StartURL: settings.StartURL,
+	}
+	if settings.Session != "" {
+		cached.RefreshToken = aws.ToString(token.RefreshToken)
+		cached.ClientID = aws.ToString(registration.ClientId)
+		cached.ClientSecret = aws.ToString(registration.ClientSecret)
+		cached.RegistrationExpiresAt = time.Unix(registration.ClientSecretExpiresAt, 0).
+			UTC().Format(time.RFC3339)
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0600)
+}
@@ -0,0 +1,128 @@
+package sso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Settings", func() {
+	It("Uses the sso-session section of the profile", func() {
+		settings, err := settingsFromConfig("dev", config.SharedConfig{
+			SSOSession: &config.SSOSession{
+				Name:        "my-sso",
+				SSORegion:   "us-east-1",
+				SSOStartURL: "https://example.awsapps.com/start",
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(settings).To(Equal(Settings{
+			StartURL: "https://example.awsapps.com/start",
+			Region:   "us-east-1",
+			Session:  "my-sso",
+		}))
+		Expect(settings.cacheKey()).To(Equal("my-sso"))
+	})
+
+	It("Uses the legacy settings of the profile", func() {
+		settings, err := settingsFromConfig("dev", config.SharedConfig{
+			SSORegion:   "us-east-1",
+			SSOStartURL: "https://example.awsapps.com/start",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(settings.Session).To(BeEmpty())
+		Expect(settings.cacheKey()).To(Equal("https://example.awsapps.com/start"))
+	})
+
+	It("Fails for profiles without SSO", func() {
+		_, err := settingsFromConfig("dev", config.SharedConfig{})
+		Expect(err).To(MatchError("AWS profile 'dev' isn't configured to use SSO"))
+	})
+})
+
+var _ = Describe("Login", func() {
+	var (
+		server      *httptest.Server
+		tokenCalls  int
+		promptURL   string
+		promptCode  string
+		sleeps      []time.Duration
+		settings    Settings
+		oidcRequest map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("HOME", GinkgoT().TempDir())
+		tokenCalls = 0
+		sleeps = nil
+		settings = Settings{
+			StartURL: "https://example.awsapps.com/start",
+			Region:   "us-east-1",
+			Session:  "my-sso",
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			oidcRequest = map[string]interface{}{}
+			Expect(json.NewDecoder(r.Body).Decode(&oidcRequest)).To(Succeed())
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/client/register":
+				fmt.Fprint(w, `{"clientId": "my-client", "clientSecret": "my-secret",
+					"clientSecretExpiresAt": 4102444800}`)
+			case "/device_authorization":
+				Expect(oidcRequest["startUrl"]).To(Equal(settings.StartURL))
+				fmt.Fprint(w, `{"deviceCode": "my-device", "userCode": "ABCD-EFGH", "interval": 1,
+					"expiresIn": 600, "verificationUriComplete": "https://device.example.com/?code=ABCD-EFGH"}`)
+			case "/token":
+				tokenCalls++
+				Expect(oidcRequest["deviceCode"]).To(Equal("my-device"))
+				if tokenCalls == 1 {
+					w.Header().Set("X-Amzn-ErrorType", "AuthorizationPendingException")
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error": "authorization_pending"}`)
+					return
+				}
+				fmt.Fprint(w, `{"accessToken": "my-token", "expiresIn": 3600, "refreshToken": "my-refresh"}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	It("Polls until the login is approved and saves the token for the AWS SDK", func() {
+		client := ssooidc.New(ssooidc.Options{
+			Region:       settings.Region,
+			BaseEndpoint: aws.String(server.URL),
+		})
+		err := login(context.Background(), client, settings, func(url string, code string) {
+			promptURL = url
+			promptCode = code
+		}, func(d time.Duration) {
+			sleeps = append(sleeps, d)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(promptURL).To(Equal("https://device.example.com/?code=ABCD-EFGH"))
+		Expect(promptCode).To(Equal("ABCD-EFGH"))
+		Expect(sleeps).To(Equal([]time.Duration{time.Second, time.Second}))
+
+		file, err := ssocreds.StandardCachedTokenFilepath("my-sso")
+		Expect(err).NotTo(HaveOccurred())
+		info, err := os.Stat(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		token, err := ssocreds.NewSSOTokenProvider(client, file).RetrieveBearerToken(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.Value).To(Equal("my-token"))
+	})
+})
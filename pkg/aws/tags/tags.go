@@ -55,3 +55,6 @@ const OperatorName = "operator_name"
 const InUse = "in_use"
 
 const True = "true"
+
+// NetworkStack tags the CloudFormation stacks created by 'rosa create network', and their resources
+const NetworkStack = prefix + "network"
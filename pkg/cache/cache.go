@@ -22,6 +22,7 @@ const (
 type RosaCache interface {
 	Set(k string, x interface{}, d time.Time)
 	Get(k string) (interface{}, bool)
+	Delete(k string)
 	Items() map[string]Item
 	Dir() (string, error)
 }
@@ -79,6 +80,12 @@ func (c *rosaCache) Get(k string) (interface{}, bool) {
 	return item.Object, true
 }
 
+func (c *rosaCache) Delete(k string) {
+	c.mu.Lock()
+	delete(c.items, k)
+	c.mu.Unlock()
+}
+
 func (c *rosaCache) Items() map[string]Item {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--no-cache' command line option.
+
+package cache
+
+import (
+	"github.com/spf13/pflag"
+)
+
+const NoCacheFlagName = "no-cache"
+
+// AddNoCacheFlag adds the no-cache flag to the given set of command line flags.
+func AddNoCacheFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&disabled,
+		NoCacheFlagName,
+		false,
+		"Don't use the cached OCM reference data, like versions and regions, or the cached temporary AWS "+
+			"credentials, and don't update the cache.",
+	)
+}
+
+// Disabled returns a boolean flag that indicates if the cache is disabled.
+func Disabled() bool {
+	return disabled
+}
+
+func SetDisabled(value bool) {
+	disabled = value
+}
+
+// disabled is a boolean flag that indicates that the cache is disabled.
+var disabled bool
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 )
 
 const (
@@ -20,6 +21,9 @@ type RosaCacheService interface {
 	LoadCache() (RosaCache, error)
 	Get(key string) (interface{}, bool)
 	Set(key string, value []string) error
+	SetItem(key string, value interface{}, expiration time.Time) error
+	Items() map[string]Item
+	Delete(keys ...string) error
 }
 
 var _ RosaCacheService = &rosaCacheService{}
@@ -83,6 +87,46 @@ func (r rosaCacheService) Set(key string, value []string) error {
 	return r.saveCache()
 }
 
+// SetItem saves a value of any type that was registered with gob. The cache file is read again
+// before saving it, so that values saved by other commands running at the same time are kept.
+func (r rosaCacheService) SetItem(key string, value interface{}, expiration time.Time) error {
+	_, err := r.LoadCache()
+	if err != nil {
+		return err
+	}
+	r.Cache.Set(key, value, expiration)
+	return r.saveCache()
+}
+
+func (r rosaCacheService) Items() map[string]Item {
+	return r.Cache.Items()
+}
+
+func (r rosaCacheService) Delete(keys ...string) error {
+	for _, key := range keys {
+		r.Cache.Delete(key)
+	}
+	return r.saveCache()
+}
+
+// Path returns the location of the cache file.
+func Path() (string, error) {
+	return NewRosaCache(RosaCacheSpec{}).Dir()
+}
+
+// Clear removes the cache file.
+func Clear() error {
+	filePath, err := Path()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing cache file: %v", err)
+	}
+	return nil
+}
+
 func (r rosaCacheService) saveCache() error {
 	filePath, err := r.Cache.Dir()
 	if err != nil {
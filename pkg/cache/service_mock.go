@@ -11,6 +11,7 @@ package cache
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -38,6 +39,24 @@ func (m *MockRosaCacheService) EXPECT() *MockRosaCacheServiceMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockRosaCacheService) Delete(keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRosaCacheServiceMockRecorder) Delete(keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRosaCacheService)(nil).Delete), keys...)
+}
+
 // Get mocks base method.
 func (m *MockRosaCacheService) Get(key string) (any, bool) {
 	m.ctrl.T.Helper()
@@ -53,6 +72,20 @@ func (mr *MockRosaCacheServiceMockRecorder) Get(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRosaCacheService)(nil).Get), key)
 }
 
+// Items mocks base method.
+func (m *MockRosaCacheService) Items() map[string]Item {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Items")
+	ret0, _ := ret[0].(map[string]Item)
+	return ret0
+}
+
+// Items indicates an expected call of Items.
+func (mr *MockRosaCacheServiceMockRecorder) Items() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Items", reflect.TypeOf((*MockRosaCacheService)(nil).Items))
+}
+
 // LoadCache mocks base method.
 func (m *MockRosaCacheService) LoadCache() (RosaCache, error) {
 	m.ctrl.T.Helper()
@@ -81,3 +114,17 @@ func (mr *MockRosaCacheServiceMockRecorder) Set(key, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRosaCacheService)(nil).Set), key, value)
 }
+
+// SetItem mocks base method.
+func (m *MockRosaCacheService) SetItem(key string, value any, expiration time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItem", key, value, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetItem indicates an expected call of SetItem.
+func (mr *MockRosaCacheServiceMockRecorder) SetItem(key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItem", reflect.TypeOf((*MockRosaCacheService)(nil).SetItem), key, value, expiration)
+}
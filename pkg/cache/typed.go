@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the typed layer of the cache used for the OCM reference data, like versions
+// and regions, that changes rarely but is requested by many commands.
+
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Kinds of cached values, with their times to live.
+const (
+	VersionsKind     = "versions"
+	RegionsKind      = "regions"
+	MachineTypesKind = "machine-types"
+	GatesKind        = "gates"
+	PoliciesKind     = "policies"
+
+	VersionsTTL     = time.Hour
+	RegionsTTL      = 24 * time.Hour
+	MachineTypesTTL = 24 * time.Hour
+	GatesTTL        = time.Hour
+	PoliciesTTL     = 6 * time.Hour
+
+	// RevalidationPeriod is how long values that have an ETag or Last-Modified validator are
+	// kept after they expire, so that they can be revalidated instead of downloaded again.
+	RevalidationPeriod = 7 * 24 * time.Hour
+)
+
+// ErrNotModified is returned by fetch functions when the server answered that the cached value
+// is still valid.
+var ErrNotModified = errors.New("not modified")
+
+func init() {
+	gob.Register(TypedItem{})
+}
+
+// Validator contains the ETag and Last-Modified headers of the response that returned a cached
+// value, used to ask the server if the value changed.
+type Validator struct {
+	ETag         string
+	LastModified string
+}
+
+// NewValidator returns the validator of the given response headers.
+func NewValidator(header http.Header) Validator {
+	return Validator{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+}
+
+func (v Validator) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// Header returns the headers of a conditional request that is answered with 304 Not Modified if
+// the value didn't change.
+func (v Validator) Header() map[string]string {
+	header := map[string]string{}
+	if v.ETag != "" {
+		header["If-None-Match"] = v.ETag
+	}
+	if v.LastModified != "" {
+		header["If-Modified-Since"] = v.LastModified
+	}
+	return header
+}
+
+// TypedItem is a cached value of the typed layer, encoded by the codec of its kind.
+type TypedItem struct {
+	Kind      string
+	Data      []byte
+	Validator Validator
+	Stored    time.Time
+	Expires   time.Time
+}
+
+// Fresh returns true if the value can be used without asking the server.
+func (e TypedItem) Fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// Codec encodes and decodes cached values. The signatures are those of the marshal and unmarshal
+// functions of the lists of the OCM SDK, like cmv1.MarshalVersionList.
+type Codec[T any] struct {
+	Marshal   func(value T, writer io.Writer) error
+	Unmarshal func(source interface{}) (T, error)
+}
+
+// JSONCodec returns a codec for values that can be encoded with the encoding/json package.
+func JSONCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Marshal: func(value T, writer io.Writer) error {
+			return json.NewEncoder(writer).Encode(value)
+		},
+		Unmarshal: func(source interface{}) (value T, err error) {
+			err = json.Unmarshal(source.([]byte), &value)
+			return
+		},
+	}
+}
+
+// FetchFunc gets a value from the server. If the validator isn't empty the request should be
+// conditional, and ErrNotModified returned if the cached value is still valid. The returned
+// validator is saved with the value.
+type FetchFunc[T any] func(validator Validator) (value T, updated Validator, err error)
+
+// Typed is the cache of one kind of values.
+type Typed[T any] struct {
+	Kind  string
+	TTL   time.Duration
+	Codec Codec[T]
+}
+
+// Fetch returns the cached value of the key if it is fresh. Otherwise it calls the fetch function
+// and saves the result. The cache is skipped if the service is nil or the '--no-cache' option is
+// used. Failures to save values aren't reported, as the cache is only an optimization.
+func (t Typed[T]) Fetch(service RosaCacheService, key string, fetch FetchFunc[T]) (T, error) {
+	if service == nil || Disabled() {
+		value, _, err := fetch(Validator{})
+		return value, err
+	}
+
+	key = t.Kind + ":" + key
+	entry, cached := t.entry(service, key)
+	if cached && entry.Fresh() {
+		value, err := t.Codec.Unmarshal(entry.Data)
+		if err == nil {
+			return value, nil
+		}
+		// Entries that can't be decoded, for example written by another version, are replaced:
+		cached = false
+	}
+
+	validator := Validator{}
+	if cached {
+		validator = entry.Validator
+	}
+	value, updated, err := fetch(validator)
+	if errors.Is(err, ErrNotModified) && cached {
+		entry.Expires = time.Now().Add(t.TTL)
+		_ = service.SetItem(key, entry, expiration(entry))
+		return t.Codec.Unmarshal(entry.Data)
+	}
+	if err != nil {
+		return value, err
+	}
+
+	var buffer bytes.Buffer
+	if t.Codec.Marshal(value, &buffer) == nil {
+		now := time.Now()
+		entry = TypedItem{
+			Kind:      t.Kind,
+			Data:      buffer.Bytes(),
+			Validator: updated,
+			Stored:    now,
+			Expires:   now.Add(t.TTL),
+		}
+		_ = service.SetItem(key, entry, expiration(entry))
+	}
+	return value, nil
+}
+
+// entry returns the cached entry of the key, if it exists.
+func (t Typed[T]) entry(service RosaCacheService, key string) (TypedItem, bool) {
+	object, found := service.Get(key)
+	if !found {
+		return TypedItem{}, false
+	}
+	entry, ok := object.(TypedItem)
+	return entry, ok && entry.Kind == t.Kind
+}
+
+// expiration returns the time when the entry is removed from the cache file.
+func expiration(entry TypedItem) time.Time {
+	if entry.Validator.IsZero() {
+		return entry.Expires
+	}
+	return entry.Expires.Add(RevalidationPeriod)
+}
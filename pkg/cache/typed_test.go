@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/constants"
+)
+
+var _ = Describe("Typed", func() {
+	var (
+		service RosaCacheService
+		typed   Typed[[]string]
+		calls   []Validator
+	)
+
+	fetch := func(value []string, updated Validator, err error) FetchFunc[[]string] {
+		return func(validator Validator) ([]string, Validator, error) {
+			calls = append(calls, validator)
+			return value, updated, err
+		}
+	}
+
+	BeforeEach(func() {
+		tmpdir, err := os.MkdirTemp("", "rosa-cache-*")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, tmpdir)
+		DeferCleanup(os.Setenv, constants.OcmConfig, os.Getenv(constants.OcmConfig))
+		os.Setenv(constants.OcmConfig, tmpdir)
+
+		service, err = NewRosaCacheService()
+		Expect(err).NotTo(HaveOccurred())
+		typed = Typed[[]string]{Kind: VersionsKind, TTL: time.Hour, Codec: JSONCodec[[]string]()}
+		calls = nil
+	})
+
+	It("returns fresh values without fetching them", func() {
+		value, err := typed.Fetch(service, "key", fetch([]string{"4.15.0"}, Validator{}, nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.15.0"}))
+
+		// A new service reads the values saved by the previous one:
+		service, err = NewRosaCacheService()
+		Expect(err).NotTo(HaveOccurred())
+		value, err = typed.Fetch(service, "key", fetch([]string{"4.16.0"}, Validator{}, nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.15.0"}))
+		Expect(calls).To(HaveLen(1))
+	})
+
+	It("revalidates expired values that have a validator", func() {
+		validator := NewValidator(http.Header{"Etag": []string{`"v1"`}})
+		typed.TTL = -time.Minute
+		_, err := typed.Fetch(service, "key", fetch([]string{"4.15.0"}, validator, nil))
+		Expect(err).NotTo(HaveOccurred())
+
+		value, err := typed.Fetch(service, "key", fetch(nil, Validator{}, ErrNotModified))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.15.0"}))
+		Expect(calls).To(Equal([]Validator{{}, validator}))
+		Expect(calls[1].Header()).To(Equal(map[string]string{"If-None-Match": `"v1"`}))
+	})
+
+	It("doesn't keep expired values that don't have a validator", func() {
+		typed.TTL = -time.Minute
+		_, err := typed.Fetch(service, "key", fetch([]string{"4.15.0"}, Validator{}, nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(service.Items()).To(BeEmpty())
+	})
+
+	It("skips the cache when it is disabled", func() {
+		SetDisabled(true)
+		DeferCleanup(SetDisabled, false)
+		for i := 0; i < 2; i++ {
+			_, err := typed.Fetch(service, "key", fetch([]string{"4.15.0"}, Validator{}, nil))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(calls).To(HaveLen(2))
+		Expect(service.Items()).To(BeEmpty())
+	})
+
+	It("removes the cache file", func() {
+		_, err := typed.Fetch(service, "key", fetch([]string{"4.15.0"}, Validator{}, nil))
+		Expect(err).NotTo(HaveOccurred())
+		path, err := Path()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(BeAnExistingFile())
+		Expect(Clear()).To(Succeed())
+		Expect(path).NotTo(BeAnExistingFile())
+	})
+})
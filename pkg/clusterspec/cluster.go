@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Prefix of the tags that are added to AWS resources by OCM and must not be repeated when the
+// cluster is created again from the spec.
+const redHatTagPrefix = "red-hat-"
+
+// FromCluster builds the spec that creates a cluster with the same options as the given one.
+// Options that are generated by the service, like the cluster ID or the API URL, are not included.
+func FromCluster(cluster *cmv1.Cluster) *ClusterSpec {
+	spec := NewClusterSpec()
+	isHostedCP := cluster.Hypershift().Enabled()
+
+	spec.Set("cluster-name", cluster.Name())
+	spec.Set("domain-prefix", cluster.DomainPrefix())
+	spec.Set("region", cluster.Region().ID())
+	spec.Set("version", cluster.Version().RawID())
+	spec.Set("channel-group", cluster.Version().ChannelGroup())
+	spec.Set("hosted-cp", isHostedCP)
+	spec.Set("multi-az", cluster.MultiAZ())
+	spec.Set("private", cluster.API().Listening() == cmv1.ListeningMethodInternal)
+	spec.Set("private-link", cluster.AWS().PrivateLink())
+	spec.Set("fips", cluster.FIPS())
+	spec.Set("etcd-encryption", cluster.EtcdEncryption())
+	spec.Set("disable-workload-monitoring", cluster.DisableUserWorkloadMonitoring())
+	if tokens := cluster.AWS().Ec2MetadataHttpTokens(); tokens != cmv1.Ec2MetadataHttpTokensOptional {
+		spec.Set("ec2-metadata-http-tokens", string(tokens))
+	}
+
+	// STS
+	sts := cluster.AWS().STS()
+	if sts.RoleARN() != "" {
+		spec.Set("sts", true)
+		spec.Set("role-arn", sts.RoleARN())
+		spec.Set("external-id", sts.ExternalID())
+		spec.Set("support-role-arn", sts.SupportRoleARN())
+		spec.Set("worker-iam-role-arn", sts.InstanceIAMRoles().WorkerRoleARN())
+		if !isHostedCP {
+			spec.Set("controlplane-iam-role-arn", sts.InstanceIAMRoles().MasterRoleARN())
+		}
+		spec.Set("operator-roles-prefix", sts.OperatorRolePrefix())
+		spec.Set("oidc-config-id", sts.OidcConfig().ID())
+		spec.Set("permissions-boundary", sts.PermissionBoundary())
+	} else {
+		spec.Set("non-sts", true)
+	}
+
+	// Encryption
+	spec.Set("kms-key-arn", cluster.AWS().KMSKeyArn())
+	spec.Set("enable-customer-managed-key", cluster.AWS().KMSKeyArn() != "")
+	spec.Set("etcd-encryption-kms-arn", cluster.AWS().EtcdEncryption().KMSKeyARN())
+
+	// Networking
+	spec.Set("subnet-ids", cluster.AWS().SubnetIDs())
+	if len(cluster.AWS().SubnetIDs()) == 0 {
+		spec.Set("availability-zones", cluster.Nodes().AvailabilityZones())
+	}
+	spec.Set("network-type", cluster.Network().Type())
+	spec.Set("machine-cidr", cluster.Network().MachineCIDR())
+	spec.Set("service-cidr", cluster.Network().ServiceCIDR())
+	spec.Set("pod-cidr", cluster.Network().PodCIDR())
+	spec.Set("host-prefix", cluster.Network().HostPrefix())
+	spec.Set("private-hosted-zone-id", cluster.AWS().PrivateHostedZoneID())
+	spec.Set("shared-vpc-role-arn", cluster.AWS().PrivateHostedZoneRoleARN())
+	if cluster.AWS().PrivateHostedZoneRoleARN() != "" {
+		spec.Set("base-domain", cluster.DNS().BaseDomain())
+	}
+	spec.Set("additional-allowed-principals", cluster.AWS().AdditionalAllowedPrincipals())
+	spec.Set("additional-compute-security-group-ids", cluster.AWS().AdditionalComputeSecurityGroupIds())
+	spec.Set("additional-infra-security-group-ids", cluster.AWS().AdditionalInfraSecurityGroupIds())
+	spec.Set("additional-control-plane-security-group-ids",
+		cluster.AWS().AdditionalControlPlaneSecurityGroupIds())
+
+	// Proxy
+	spec.Set("http-proxy", cluster.Proxy().HTTPProxy())
+	spec.Set("https-proxy", cluster.Proxy().HTTPSProxy())
+	if cluster.Proxy().NoProxy() != "" {
+		spec.Set("no-proxy", strings.Split(cluster.Proxy().NoProxy(), ","))
+	}
+
+	// Compute nodes
+	spec.Set("compute-machine-type", cluster.Nodes().ComputeMachineType().ID())
+	if autoscaling, ok := cluster.Nodes().GetAutoscaleCompute(); ok {
+		spec.Set("enable-autoscaling", true)
+		spec.Set("min-replicas", autoscaling.MinReplicas())
+		spec.Set("max-replicas", autoscaling.MaxReplicas())
+	} else {
+		spec.Set("replicas", cluster.Nodes().Compute())
+	}
+	spec.Set("worker-mp-labels", formatLabels(cluster.Nodes().ComputeLabels()))
+	if size := cluster.Nodes().ComputeRootVolume().AWS().Size(); size != 0 {
+		spec.Set("worker-disk-size", fmt.Sprintf("%dGiB", size))
+	}
+
+	// Miscellaneous
+	spec.Set("billing-account", cluster.AWS().BillingAccountID())
+	spec.Set("audit-log-arn", cluster.AWS().AuditLog().RoleArn())
+	spec.Set("tags", userTags(cluster.AWS().Tags()))
+
+	return spec
+}
+
+func formatLabels(labels map[string]string) string {
+	result := make([]string, 0, len(labels))
+	for key, value := range labels {
+		result = append(result, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(result)
+	return strings.Join(result, ",")
+}
+
+func userTags(tags map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range tags {
+		if strings.HasPrefix(key, redHatTagPrefix) {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,311 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the declarative cluster spec file format used by the '--from-file' option
+// of 'rosa create cluster' and the '-o spec' option of 'rosa describe cluster'.
+
+package clusterspec
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/input"
+)
+
+const (
+	APIVersion = "rosa.openshift.io/v1alpha1"
+	Kind       = "ClusterSpec"
+
+	FromFileFlag = "from-file"
+	OutputFormat = "spec"
+)
+
+// Options that only make sense for a single invocation of the command and are therefore
+// rejected when found in a spec file.
+var invocationOnlyFlags = map[string]bool{
+	FromFileFlag:  true,
+	"dry-run":     true,
+	"help":        true,
+	"interactive": true,
+	"output":      true,
+	"watch":       true,
+	"yes":         true,
+}
+
+// ClusterSpec is the versioned representation of the options of 'rosa create cluster'. The keys
+// of the spec are the names of the command line flags, so every option that can be given on the
+// command line can also be given in the file.
+type ClusterSpec struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Spec       map[string]interface{} `json:"spec"`
+}
+
+// NewClusterSpec returns an empty spec of the current version.
+func NewClusterSpec() *ClusterSpec {
+	return &ClusterSpec{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Spec:       map[string]interface{}{},
+	}
+}
+
+// Load reads a spec from a YAML or JSON file.
+func Load(path string) (*ClusterSpec, error) {
+	contents, err := input.UnmarshalInputFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return fromMap(contents)
+}
+
+// Parse reads a spec from YAML or JSON contents.
+func Parse(data []byte) (*ClusterSpec, error) {
+	var contents map[string]interface{}
+	err := yaml.Unmarshal(data, &contents)
+	if err != nil {
+		return nil, err
+	}
+	return fromMap(contents)
+}
+
+func fromMap(contents map[string]interface{}) (*ClusterSpec, error) {
+	spec := &ClusterSpec{}
+	for key, value := range contents {
+		switch key {
+		case "apiVersion":
+			spec.APIVersion = fmt.Sprint(value)
+		case "kind":
+			spec.Kind = fmt.Sprint(value)
+		case "spec":
+			if value == nil {
+				continue
+			}
+			options, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Expected 'spec' to be a map of options, got '%v'", value)
+			}
+			spec.Spec = options
+		default:
+			return nil, fmt.Errorf("Unknown field '%s' in cluster spec", key)
+		}
+	}
+	if spec.APIVersion != APIVersion {
+		return nil, fmt.Errorf("Unsupported cluster spec apiVersion '%s'. Supported version is '%s'",
+			spec.APIVersion, APIVersion)
+	}
+	if spec.Kind != Kind {
+		return nil, fmt.Errorf("Unsupported cluster spec kind '%s'. Expected '%s'", spec.Kind, Kind)
+	}
+	if spec.Spec == nil {
+		spec.Spec = map[string]interface{}{}
+	}
+	return spec, nil
+}
+
+// Validate checks that every option of the spec is a known flag of the given flag set and that its
+// value can be parsed for the type of that flag. It does not modify the flag set.
+func (s *ClusterSpec) Validate(flags *pflag.FlagSet) error {
+	var errs []string
+	for _, name := range s.optionNames() {
+		values, err := s.flagValues(flags, name)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		flagType := flags.Lookup(name).Value.Type()
+		for _, value := range values {
+			err = validateValue(flagType, value)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Invalid value '%s' for option '%s': %v", value, name, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Cluster spec is not valid:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// Apply sets the flags of the given flag set from the options of the spec. Flags that have already
+// been set on the command line are left untouched, so they take precedence over the file.
+func (s *ClusterSpec) Apply(flags *pflag.FlagSet) error {
+	err := s.Validate(flags)
+	if err != nil {
+		return err
+	}
+	for _, name := range s.optionNames() {
+		if flags.Changed(name) {
+			continue
+		}
+		values, err := s.flagValues(flags, name)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			err = flags.Set(name, value)
+			if err != nil {
+				return fmt.Errorf("Failed to set option '%s' from cluster spec: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyFile loads the spec in the given file and applies it to the flag set.
+func ApplyFile(path string, flags *pflag.FlagSet) error {
+	spec, err := Load(path)
+	if err != nil {
+		return err
+	}
+	return spec.Apply(flags)
+}
+
+// Set adds an option to the spec, skipping zero values so that the emitted spec only contains
+// the options that differ from the defaults.
+func (s *ClusterSpec) Set(name string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return
+		}
+	case bool:
+		if !v {
+			return
+		}
+	case int:
+		if v == 0 {
+			return
+		}
+	case []string:
+		if len(v) == 0 {
+			return
+		}
+	case map[string]string:
+		if len(v) == 0 {
+			return
+		}
+	case nil:
+		return
+	}
+	s.Spec[name] = value
+}
+
+// Marshal returns the YAML representation of the spec.
+func (s *ClusterSpec) Marshal() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+func (s *ClusterSpec) optionNames() []string {
+	names := make([]string, 0, len(s.Spec))
+	for name := range s.Spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// flagValues converts the value of an option of the spec into the list of strings that need to be
+// passed to the flag to set it, as if it had been given on the command line.
+func (s *ClusterSpec) flagValues(flags *pflag.FlagSet, name string) ([]string, error) {
+	if invocationOnlyFlags[name] {
+		return nil, fmt.Errorf("Option '%s' is not supported in a cluster spec", name)
+	}
+	flag := flags.Lookup(name)
+	if flag == nil {
+		return nil, fmt.Errorf("Unknown option '%s'", name)
+	}
+	isList := strings.HasSuffix(flag.Value.Type(), "Slice") || strings.HasSuffix(flag.Value.Type(), "Array")
+
+	switch value := s.Spec[name].(type) {
+	case []interface{}:
+		if !isList {
+			return nil, fmt.Errorf("Option '%s' does not accept a list of values", name)
+		}
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			result = append(result, scalarString(item))
+		}
+		return result, nil
+	case []string:
+		if !isList {
+			return nil, fmt.Errorf("Option '%s' does not accept a list of values", name)
+		}
+		return value, nil
+	case map[string]interface{}:
+		if !isList {
+			return nil, fmt.Errorf("Option '%s' does not accept a map of values", name)
+		}
+		result := make([]string, 0, len(value))
+		for k, v := range value {
+			result = append(result, fmt.Sprintf("%s:%s", k, scalarString(v)))
+		}
+		sort.Strings(result)
+		return result, nil
+	case map[string]string:
+		if !isList {
+			return nil, fmt.Errorf("Option '%s' does not accept a map of values", name)
+		}
+		result := make([]string, 0, len(value))
+		for k, v := range value {
+			result = append(result, fmt.Sprintf("%s:%s", k, v))
+		}
+		sort.Strings(result)
+		return result, nil
+	default:
+		return []string{scalarString(value)}, nil
+	}
+}
+
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		// JSON and YAML numbers are decoded as floats, but all numeric options are integers
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func validateValue(flagType string, value string) error {
+	var err error
+	switch flagType {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int", "int32", "int64":
+		_, err = strconv.Atoi(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	case "ipNet":
+		_, _, err = net.ParseCIDR(value)
+	}
+	return err
+}
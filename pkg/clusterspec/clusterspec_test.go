@@ -0,0 +1,184 @@
+package clusterspec
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+)
+
+type testOptions struct {
+	clusterName string
+	hostedCP    bool
+	replicas    int
+	subnetIDs   []string
+	tags        []string
+	machineCIDR net.IPNet
+}
+
+func newTestFlags(options *testOptions) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&options.clusterName, "cluster-name", "", "")
+	flags.BoolVar(&options.hostedCP, "hosted-cp", false, "")
+	flags.IntVar(&options.replicas, "replicas", 2, "")
+	flags.StringSliceVar(&options.subnetIDs, "subnet-ids", nil, "")
+	flags.StringSliceVar(&options.tags, "tags", nil, "")
+	flags.IPNetVar(&options.machineCIDR, "machine-cidr", net.IPNet{}, "")
+	flags.Bool("dry-run", false, "")
+	return flags
+}
+
+var _ = Describe("Cluster spec", func() {
+	Context("Parse", func() {
+		It("Parses a YAML spec", func() {
+			spec, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterSpec
+spec:
+  cluster-name: foo
+  hosted-cp: true
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Spec).To(HaveKeyWithValue("cluster-name", "foo"))
+			Expect(spec.Spec).To(HaveKeyWithValue("hosted-cp", true))
+		})
+
+		It("Parses a JSON spec", func() {
+			spec, err := Parse([]byte(`{"apiVersion": "rosa.openshift.io/v1alpha1", "kind": "ClusterSpec", ` +
+				`"spec": {"replicas": 3}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Spec).To(HaveKeyWithValue("replicas", float64(3)))
+		})
+
+		It("Fails with an unsupported apiVersion", func() {
+			_, err := Parse([]byte("apiVersion: v2\nkind: ClusterSpec\n"))
+			Expect(err).To(MatchError("Unsupported cluster spec apiVersion 'v2'. " +
+				"Supported version is 'rosa.openshift.io/v1alpha1'"))
+		})
+
+		It("Fails with an unknown top level field", func() {
+			_, err := Parse([]byte("apiVersion: rosa.openshift.io/v1alpha1\nkind: ClusterSpec\nstatus: {}\n"))
+			Expect(err).To(MatchError("Unknown field 'status' in cluster spec"))
+		})
+	})
+
+	Context("Apply", func() {
+		var options *testOptions
+		var flags *pflag.FlagSet
+
+		BeforeEach(func() {
+			options = &testOptions{}
+			flags = newTestFlags(options)
+		})
+
+		It("Sets the flags from the spec", func() {
+			spec, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterSpec
+spec:
+  cluster-name: foo
+  hosted-cp: true
+  replicas: 3
+  subnet-ids: [subnet-1, subnet-2]
+  tags:
+    team: a
+    env: dev
+  machine-cidr: 10.0.0.0/16
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Apply(flags)).To(Succeed())
+			Expect(options.clusterName).To(Equal("foo"))
+			Expect(options.hostedCP).To(BeTrue())
+			Expect(options.replicas).To(Equal(3))
+			Expect(options.subnetIDs).To(Equal([]string{"subnet-1", "subnet-2"}))
+			Expect(options.tags).To(Equal([]string{"env:dev", "team:a"}))
+			Expect(options.machineCIDR.String()).To(Equal("10.0.0.0/16"))
+			Expect(flags.Changed("cluster-name")).To(BeTrue())
+		})
+
+		It("Gives precedence to the flags set on the command line", func() {
+			Expect(flags.Parse([]string{"--cluster-name", "bar", "--subnet-ids", "subnet-3"})).To(Succeed())
+			spec, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterSpec
+spec:
+  cluster-name: foo
+  subnet-ids: [subnet-1, subnet-2]
+  replicas: 3
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Apply(flags)).To(Succeed())
+			Expect(options.clusterName).To(Equal("bar"))
+			Expect(options.subnetIDs).To(Equal([]string{"subnet-3"}))
+			Expect(options.replicas).To(Equal(3))
+		})
+
+		It("Reports all invalid options without modifying the flags", func() {
+			spec, err := Parse([]byte(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterSpec
+spec:
+  cluster-name: foo
+  unknown: value
+  hosted-cp: maybe
+  replicas: [1, 2]
+  machine-cidr: 10.0.0.0
+  dry-run: true
+`))
+			Expect(err).NotTo(HaveOccurred())
+			err = spec.Apply(flags)
+			Expect(err).To(MatchError("Cluster spec is not valid:\n" +
+				"\tOption 'dry-run' is not supported in a cluster spec\n" +
+				"\tInvalid value 'maybe' for option 'hosted-cp': strconv.ParseBool: parsing \"maybe\": invalid syntax\n" +
+				"\tInvalid value '10.0.0.0' for option 'machine-cidr': invalid CIDR address: 10.0.0.0\n" +
+				"\tOption 'replicas' does not accept a list of values\n" +
+				"\tUnknown option 'unknown'"))
+			Expect(options.clusterName).To(BeEmpty())
+		})
+	})
+
+	Context("FromCluster", func() {
+		It("Builds a spec that can be applied again", func() {
+			cluster, err := cmv1.NewCluster().
+				ID("123").
+				Name("foo").
+				Region(cmv1.NewCloudRegion().ID("us-east-1")).
+				Hypershift(cmv1.NewHypershift().Enabled(true)).
+				AWS(cmv1.NewAWS().
+					SubnetIDs("subnet-1", "subnet-2").
+					Tags(map[string]string{"team": "a", "red-hat-managed": "true"})).
+				Nodes(cmv1.NewClusterNodes().Compute(3)).
+				Network(cmv1.NewNetwork().MachineCIDR("10.0.0.0/16")).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			spec := FromCluster(cluster)
+			Expect(spec.Spec).To(Equal(map[string]interface{}{
+				"cluster-name": "foo",
+				"region":       "us-east-1",
+				"hosted-cp":    true,
+				"non-sts":      true,
+				"subnet-ids":   []string{"subnet-1", "subnet-2"},
+				"machine-cidr": "10.0.0.0/16",
+				"replicas":     3,
+				"tags":         map[string]string{"team": "a"},
+			}))
+
+			data, err := spec.Marshal()
+			Expect(err).NotTo(HaveOccurred())
+			parsed, err := Parse(data)
+			Expect(err).NotTo(HaveOccurred())
+
+			options := &testOptions{}
+			flags := newTestFlags(options)
+			flags.String("region", "", "")
+			flags.Bool("non-sts", false, "")
+			Expect(parsed.Apply(flags)).To(Succeed())
+			Expect(options.clusterName).To(Equal("foo"))
+			Expect(options.replicas).To(Equal(3))
+			Expect(options.tags).To(Equal([]string{"team:a"}))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package clusterstore
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterStore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster store suite")
+}
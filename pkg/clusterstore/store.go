@@ -0,0 +1,255 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterstore contains the local JSON files that keep settings of clusters that OCM
+// doesn't store, like hibernation schedules and maintenance windows, at most one for each cluster.
+package clusterstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/openshift/rosa/pkg/output"
+)
+
+// Kind describes the items of a store and the file where they are saved.
+type Kind[T any] struct {
+	// Name is the singular name of the items used in the messages, like 'hibernation schedule'.
+	Name string
+
+	// Description is the plural name of the items used in the messages, like 'hibernation
+	// schedules'.
+	Description string
+
+	// Field is the field of the JSON document of the file that contains the items.
+	Field string
+
+	// PathEnv is the environment variable that overrides the location of the file, and FileName
+	// is the name of the file in the configuration directory of rosa.
+	PathEnv  string
+	FileName string
+
+	// ClusterID and ClusterName return the identifier and the name of the cluster of an item.
+	ClusterID   func(T) string
+	ClusterName func(T) string
+}
+
+// Path returns the location of the file.
+func (k *Kind[T]) Path() (string, error) {
+	if path := os.Getenv(k.PathEnv); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rosa", k.FileName), nil
+}
+
+// Load reads the items from the default file.
+func (k *Kind[T]) Load() (*Store[T], error) {
+	path, err := k.Path()
+	if err != nil {
+		return nil, err
+	}
+	return k.LoadFile(path)
+}
+
+// LoadFile reads the items from the given file. A file that doesn't exist has no items.
+func (k *Kind[T]) LoadFile(path string) (*Store[T], error) {
+	store := &Store[T]{kind: k, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, store)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse %s file '%s': %v", k.Description, path, err)
+	}
+	return store, nil
+}
+
+// Find loads the items from the default file and returns the one of the cluster with the given
+// identifier or name. It fails if the cluster has none.
+func (k *Kind[T]) Find(clusterKey string) (*Store[T], T, error) {
+	var none T
+	store, err := k.Load()
+	if err != nil {
+		return nil, none, err
+	}
+	if !store.Has(clusterKey) {
+		return nil, none, fmt.Errorf("Cluster '%s' has no %s", clusterKey, k.Name)
+	}
+	return store, store.Get(clusterKey), nil
+}
+
+// WriteDescription writes the item of the cluster with the given identifier or name, in the format
+// of the output flag if it was used, or else as returned by the given function.
+func (k *Kind[T]) WriteDescription(writer io.Writer, clusterKey string, describe func(T) string) error {
+	_, item, err := k.Find(clusterKey)
+	if err != nil {
+		return err
+	}
+	if output.HasFlag() {
+		return output.Print(item)
+	}
+	_, err = fmt.Fprint(writer, describe(item))
+	return err
+}
+
+// Store is the set of items saved in a file, at most one for each cluster, sorted by the name of
+// the cluster.
+type Store[T any] struct {
+	kind  *Kind[T]
+	path  string
+	Items []T
+}
+
+// Path returns the file of the items.
+func (s *Store[T]) Path() string {
+	return s.path
+}
+
+// Get returns the item of the cluster with the given identifier or name, or the zero value if it
+// has none.
+func (s *Store[T]) Get(clusterKey string) T {
+	for _, item := range s.Items {
+		if s.kind.ClusterID(item) == clusterKey {
+			return item
+		}
+	}
+	for _, item := range s.Items {
+		if s.kind.ClusterName(item) == clusterKey {
+			return item
+		}
+	}
+	var none T
+	return none
+}
+
+// Has returns if the cluster with the given identifier or name has an item.
+func (s *Store[T]) Has(clusterKey string) bool {
+	for _, item := range s.Items {
+		if s.kind.ClusterID(item) == clusterKey || s.kind.ClusterName(item) == clusterKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Set adds the item, replacing the one of the same cluster if there is one.
+func (s *Store[T]) Set(item T) {
+	for i, existing := range s.Items {
+		if s.kind.ClusterID(existing) == s.kind.ClusterID(item) {
+			s.Items[i] = item
+			return
+		}
+	}
+	s.Items = append(s.Items, item)
+	sort.SliceStable(s.Items, func(i, j int) bool {
+		return s.kind.ClusterName(s.Items[i]) < s.kind.ClusterName(s.Items[j])
+	})
+}
+
+// Delete removes the item of the cluster with the given identifier, and returns if it existed.
+func (s *Store[T]) Delete(clusterID string) bool {
+	for i, existing := range s.Items {
+		if s.kind.ClusterID(existing) == clusterID {
+			s.Items = append(s.Items[:i], s.Items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Save writes the items to the file. The file is replaced atomically so that the commands that
+// read it, like 'rosa schedule run', never read a partial file.
+func (s *Store[T]) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(s.path), 0700)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), s.path)
+}
+
+// Update loads the items again from the file, applies the given function to them, and saves them.
+// It is used to save changes of a long running process without overwriting the changes made by
+// other rosa commands in the meantime.
+func (s *Store[T]) Update(update func(*Store[T]) error) error {
+	current, err := s.kind.LoadFile(s.path)
+	if err != nil {
+		return err
+	}
+	err = update(current)
+	if err != nil {
+		return err
+	}
+	err = current.Save()
+	if err != nil {
+		return err
+	}
+	s.Items = current.Items
+	return nil
+}
+
+// MarshalJSON writes the items in the field of the kind of store.
+func (s *Store[T]) MarshalJSON() ([]byte, error) {
+	items := s.Items
+	if items == nil {
+		items = []T{}
+	}
+	return json.Marshal(map[string][]T{s.kind.Field: items})
+}
+
+// UnmarshalJSON reads the items from the field of the kind of store.
+func (s *Store[T]) UnmarshalJSON(data []byte) error {
+	var document map[string]json.RawMessage
+	err := json.Unmarshal(data, &document)
+	if err != nil {
+		return err
+	}
+	s.Items = nil
+	if field, ok := document[s.kind.Field]; ok {
+		return json.Unmarshal(field, &s.Items)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package clusterstore
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type item struct {
+	ClusterID   string `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+	Value       string `json:"value"`
+}
+
+var _ = Describe("Store", func() {
+	kind := &Kind[*item]{
+		Name:        "test item",
+		Description: "test items",
+		Field:       "items",
+		PathEnv:     "ROSA_TEST_ITEMS",
+		FileName:    "test-items.json",
+		ClusterID:   func(i *item) string { return i.ClusterID },
+		ClusterName: func(i *item) string { return i.ClusterName },
+	}
+
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "rosa", kind.FileName)
+	})
+
+	It("Uses the location of the environment variable", func() {
+		GinkgoT().Setenv(kind.PathEnv, path)
+		Expect(kind.Path()).To(Equal(path))
+		store, err := kind.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Path()).To(Equal(path))
+	})
+
+	It("Finds the item of a cluster", func() {
+		GinkgoT().Setenv(kind.PathEnv, path)
+		store, err := kind.Load()
+		Expect(err).NotTo(HaveOccurred())
+		store.Set(&item{ClusterID: "1", ClusterName: "a", Value: "x"})
+		Expect(store.Save()).To(Succeed())
+
+		_, found, err := kind.Find("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Value).To(Equal("x"))
+		_, _, err = kind.Find("b")
+		Expect(err).To(MatchError("Cluster 'b' has no test item"))
+	})
+
+	It("Has no items when the file doesn't exist", func() {
+		store, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Items).To(BeEmpty())
+		Expect(store.Get("a")).To(BeNil())
+	})
+
+	It("Saves and loads the items in the field of the kind", func() {
+		store, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		store.Set(&item{ClusterID: "2", ClusterName: "b", Value: "x"})
+		store.Set(&item{ClusterID: "1", ClusterName: "a", Value: "y"})
+		Expect(store.Save()).To(Succeed())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"items": [` +
+			`{"cluster_id": "1", "cluster_name": "a", "value": "y"},` +
+			`{"cluster_id": "2", "cluster_name": "b", "value": "x"}]}`))
+
+		loaded, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Items).To(HaveLen(2))
+		Expect(loaded.Items[0].ClusterName).To(Equal("a"))
+		Expect(loaded.Get("b").ClusterID).To(Equal("2"))
+		Expect(loaded.Get("1").ClusterName).To(Equal("a"))
+		Expect(loaded.Get("c")).To(BeNil())
+	})
+
+	It("Replaces the item of the same cluster", func() {
+		store, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		store.Set(&item{ClusterID: "1", ClusterName: "a", Value: "x"})
+		store.Set(&item{ClusterID: "1", ClusterName: "a", Value: "y"})
+		Expect(store.Items).To(HaveLen(1))
+		Expect(store.Get("a").Value).To(Equal("y"))
+		Expect(store.Delete("1")).To(BeTrue())
+		Expect(store.Delete("1")).To(BeFalse())
+	})
+
+	It("Updates the items saved in the meantime", func() {
+		store, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		store.Set(&item{ClusterID: "1", ClusterName: "a", Value: "x"})
+		Expect(store.Save()).To(Succeed())
+
+		other, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		other.Set(&item{ClusterID: "2", ClusterName: "b", Value: "x"})
+		Expect(other.Save()).To(Succeed())
+
+		Expect(store.Update(func(current *Store[*item]) error {
+			current.Get("a").Value = "y"
+			return nil
+		})).To(Succeed())
+		Expect(store.Items).To(HaveLen(2))
+
+		loaded, err := kind.LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Items).To(HaveLen(2))
+		Expect(loaded.Get("a").Value).To(Equal("y"))
+	})
+
+	It("Fails with a corrupted file", func() {
+		Expect(os.MkdirAll(filepath.Dir(path), 0700)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("{"), 0600)).To(Succeed())
+		_, err := kind.LoadFile(path)
+		Expect(err).To(MatchError(ContainSubstring("Failed to parse test items file")))
+	})
+})
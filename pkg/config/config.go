@@ -98,19 +98,50 @@ func GetAllowedConfigProperties() []string {
 	return allowedProperties
 }
 
-// Loads the configuration from the OS keyring if requested, load from the configuration file if not
+// Loads the configuration from the OS keyring if requested, load from the configuration file if not.
+// If a context is selected, it returns the configuration of that context, or nil if the context
+// doesn't exist yet.
 func Load() (cfg *Config, err error) {
-	if keyring, ok := IsKeyringManaged(); ok {
-		return loadFromOS(keyring)
+	keyring, _ := IsKeyringManaged()
+	doc, err := readDocument(keyring)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	name := doc.selectedContext()
+	if name == "" {
+		return &doc.Config, nil
+	}
+	context, ok := doc.Contexts[name]
+	if !ok {
+		// Like a configuration file that doesn't exist, logging in creates the context
+		return nil, nil
+	}
+	cfg = new(Config)
+	*cfg = context.Config
+	if context.Keyring != "" && context.Keyring != keyring {
+		credentials, err := loadFromOS(context.Keyring)
+		if err != nil {
+			return nil, err
+		}
+		if credentials != nil && credentials.Contexts[name] != nil {
+			cfg.copyCredentials(&credentials.Contexts[name].Config)
+		}
 	}
+	return cfg, nil
+}
 
+func readDocument(keyring string) (*document, error) {
+	if keyring != "" {
+		return loadFromOS(keyring)
+	}
 	return loadFromFile()
 }
 
 // Loads the configuration from the OS keyring. If the configuration doesn't exist
 // it will return an empty configuration object.
-func loadFromOS(keyring string) (cfg *Config, err error) {
-	cfg = &Config{}
+func loadFromOS(keyring string) (doc *document, err error) {
+	doc = &document{}
 
 	data, err := GetConfigFromKeyring(keyring)
 	if err != nil {
@@ -120,24 +151,24 @@ func loadFromOS(keyring string) (cfg *Config, err error) {
 	if len(data) == 0 {
 		return nil, nil
 	}
-	err = json.Unmarshal(data, cfg)
+	err = json.Unmarshal(data, doc)
 	if err != nil {
 		// Treat the config as empty if it can't be unmarshalled, it is invalid
 		return nil, nil
 	}
-	return cfg, nil
+	return doc, nil
 }
 
 // Loads the configuration from the configuration file. If the configuration file doesn't exist
 // it will return an empty configuration object.
-func loadFromFile() (cfg *Config, err error) {
+func loadFromFile() (doc *document, err error) {
 	file, err := Location()
 	if err != nil {
 		return
 	}
 	_, err = os.Stat(file)
 	if os.IsNotExist(err) {
-		cfg = nil
+		doc = nil
 		err = nil
 		return
 	}
@@ -151,8 +182,8 @@ func loadFromFile() (cfg *Config, err error) {
 		err = fmt.Errorf("Failed to read config file '%s': %v", file, err)
 		return
 	}
-	cfg = new(Config)
-	err = json.Unmarshal(data, cfg)
+	doc = new(document)
+	err = json.Unmarshal(data, doc)
 	if err != nil {
 		err = fmt.Errorf("Failed to parse config file '%s': %v", file, err)
 		return
@@ -160,19 +191,58 @@ func loadFromFile() (cfg *Config, err error) {
 	return
 }
 
-// Save saves the given configuration to the configuration file.
+// Save saves the given configuration to the configuration file, or to the selected context of
+// the configuration file if a context is selected.
 func Save(cfg *Config) error {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	keyring, _ := IsKeyringManaged()
+	doc, err := readDocument(keyring)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		doc = &document{}
+	}
+
+	name := doc.selectedContext()
+	if name == "" {
+		doc.Config = *cfg
+		return writeDocument(keyring, doc)
+	}
+	context, ok := doc.Contexts[name]
+	if !ok {
+		// Logging in with a context that doesn't exist yet creates it
+		context = &NamedContext{}
+		if doc.Contexts == nil {
+			doc.Contexts = map[string]*NamedContext{}
+		}
+		doc.Contexts[name] = context
+	}
+	context.Config = *cfg
+	if context.Keyring != "" && context.Keyring != keyring {
+		err = saveCredentials(context.Keyring, name, cfg)
+		if err != nil {
+			return err
+		}
+		context.Config.clearCredentials()
+	}
+	return writeDocument(keyring, doc)
+}
+
+func writeDocument(keyring string, doc *document) error {
 	file, err := Location()
 	if err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	data, err := json.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return fmt.Errorf("can't marshal config: %v", err)
 	}
 
-	if keyring, ok := IsKeyringManaged(); ok {
+	if keyring != "" {
 		err := UpsertConfigToKeyring(keyring, data)
 		if err != nil {
 			return fmt.Errorf("can't save config to OS keyring [%s]: %v", keyring, err)
@@ -192,9 +262,36 @@ func Save(cfg *Config) error {
 	return nil
 }
 
-// Remove removes the configuration file.
+// Remove removes the configuration file. If the file contains contexts, it only removes the
+// credentials of the selected context, or of the top level configuration if no context is
+// selected.
 func Remove() error {
-	if keyring, ok := IsKeyringManaged(); ok {
+	keyring, _ := IsKeyringManaged()
+	doc, err := readDocument(keyring)
+	if err != nil {
+		return err
+	}
+	if doc != nil && len(doc.Contexts) > 0 {
+		name := doc.selectedContext()
+		if name == "" {
+			doc.Config = Config{}
+			return writeDocument(keyring, doc)
+		}
+		context, ok := doc.Contexts[name]
+		if !ok {
+			return nil
+		}
+		context.Config.clearCredentials()
+		if context.Keyring != "" && context.Keyring != keyring {
+			err = saveCredentials(context.Keyring, name, &Config{})
+			if err != nil {
+				return err
+			}
+		}
+		return writeDocument(keyring, doc)
+	}
+
+	if keyring != "" {
 		err := RemoveConfigFromKeyring(keyring)
 		if err != nil {
 			return fmt.Errorf("can't remove configuration from keyring [%s]: %w", keyring, err)
@@ -186,6 +186,7 @@ var _ = Describe("Config Keyring", func() {
 		Context(properties.KeyringEnvKey+" is set", func() {
 			BeforeEach(func() {
 				os.Setenv(properties.KeyringEnvKey, "keyring")
+				GetConfigFromKeyring = (&mockSpy{}).MockGetConfigFromKeyring
 			})
 
 			AfterEach(func() {
@@ -223,6 +224,7 @@ var _ = Describe("Config Keyring", func() {
 		Context(properties.KeyringEnvKey+" is set", func() {
 			BeforeEach(func() {
 				os.Setenv(properties.KeyringEnvKey, "keyring")
+				GetConfigFromKeyring = (&mockSpy{}).MockGetConfigFromKeyring
 			})
 
 			AfterEach(func() {
@@ -0,0 +1,229 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to manage named contexts, that allow switching
+// between environments and organizations without logging out and in again.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	ContextFlagName = "context"
+	ContextEnvKey   = "ROSA_CONTEXT"
+)
+
+// NamedContext is a named configuration, with its own OCM URL and credentials, and the AWS profile and
+// region used by default when the context is selected.
+type NamedContext struct {
+	Config
+	AWSProfile string `json:"aws_profile,omitempty"`
+	AWSRegion  string `json:"aws_region,omitempty"`
+
+	// Keyring is the OS keyring where the credentials of the context are stored. If it is empty
+	// they are stored with the rest of the configuration.
+	Keyring string `json:"keyring,omitempty"`
+}
+
+// document is the content of the configuration file or keyring. The settings at the top level
+// are used when no context is selected, so files written by previous versions keep working.
+type document struct {
+	Config
+	CurrentContext string                   `json:"current_context,omitempty"`
+	Contexts       map[string]*NamedContext `json:"contexts,omitempty"`
+
+	// CredentialsCacheKey is the key that encrypts the cached AWS credentials, only saved when the
+	// configuration is managed by a keyring.
+	CredentialsCacheKey []byte `json:"aws_credentials_cache_key,omitempty"`
+}
+
+// contextName is the value of the '--context' command line option.
+var contextName string
+
+// AddContextFlag adds the '--context' flag to the given set of command line flags.
+func AddContextFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&contextName,
+		ContextFlagName,
+		"",
+		fmt.Sprintf("Use the given context of the configuration instead of the current one. "+
+			"Can also be set with the %s environment variable.", ContextEnvKey),
+	)
+}
+
+// SetContextName selects the context used by Load and Save, like the '--context' flag.
+func SetContextName(name string) {
+	contextName = name
+}
+
+// ContextName returns the name of the context selected with the '--context' command line option
+// or the environment, if any.
+func ContextName() string {
+	if contextName != "" {
+		return contextName
+	}
+	return os.Getenv(ContextEnvKey)
+}
+
+func (d *document) selectedContext() string {
+	if name := ContextName(); name != "" {
+		return name
+	}
+	return d.CurrentContext
+}
+
+// CurrentContext returns the name and settings of the selected context, or nil if no context is
+// selected or it doesn't exist yet.
+func CurrentContext() (string, *NamedContext, error) {
+	contexts, current, err := LoadContexts()
+	if err != nil || contexts[current] == nil {
+		return "", nil, err
+	}
+	return current, contexts[current], nil
+}
+
+// LoadContexts returns the contexts of the configuration and the name of the selected one.
+func LoadContexts() (map[string]*NamedContext, string, error) {
+	keyring, _ := IsKeyringManaged()
+	doc, err := readDocument(keyring)
+	if err != nil {
+		return nil, "", err
+	}
+	if doc == nil {
+		return map[string]*NamedContext{}, ContextName(), nil
+	}
+	if doc.Contexts == nil {
+		doc.Contexts = map[string]*NamedContext{}
+	}
+	return doc.Contexts, doc.selectedContext(), nil
+}
+
+// ContextNames returns the sorted names of the given contexts.
+func ContextNames(contexts map[string]*NamedContext) []string {
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseContext makes the given context the current one.
+func UseContext(name string) error {
+	return updateDocument(func(doc *document) error {
+		if _, ok := doc.Contexts[name]; !ok {
+			return fmt.Errorf("Context '%s' doesn't exist", name)
+		}
+		doc.CurrentContext = name
+		return nil
+	})
+}
+
+// SetContext creates the given context, or changes its settings if it exists, using the given
+// function.
+func SetContext(name string, update func(*NamedContext)) error {
+	return updateDocument(func(doc *document) error {
+		context, ok := doc.Contexts[name]
+		if !ok {
+			context = &NamedContext{}
+			if doc.Contexts == nil {
+				doc.Contexts = map[string]*NamedContext{}
+			}
+			doc.Contexts[name] = context
+		}
+		update(context)
+		return nil
+	})
+}
+
+// DeleteContext deletes the given context and its credentials.
+func DeleteContext(name string) error {
+	return updateDocument(func(doc *document) error {
+		context, ok := doc.Contexts[name]
+		if !ok {
+			return fmt.Errorf("Context '%s' doesn't exist", name)
+		}
+		keyring, _ := IsKeyringManaged()
+		if context.Keyring != "" && context.Keyring != keyring {
+			err := saveCredentials(context.Keyring, name, nil)
+			if err != nil {
+				return err
+			}
+		}
+		delete(doc.Contexts, name)
+		if doc.CurrentContext == name {
+			doc.CurrentContext = ""
+		}
+		return nil
+	})
+}
+
+func updateDocument(update func(*document) error) error {
+	keyring, _ := IsKeyringManaged()
+	doc, err := readDocument(keyring)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		doc = &document{}
+	}
+	err = update(doc)
+	if err != nil {
+		return err
+	}
+	return writeDocument(keyring, doc)
+}
+
+// saveCredentials saves the credentials of a context to the given keyring, or removes them if the
+// configuration is nil. The keyring contains a document like the configuration file, so that it
+// can be shared with the configuration managed by the keyring.
+func saveCredentials(keyring string, name string, cfg *Config) error {
+	doc, err := loadFromOS(keyring)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		doc = &document{}
+	}
+	if doc.Contexts == nil {
+		doc.Contexts = map[string]*NamedContext{}
+	}
+	if cfg == nil {
+		delete(doc.Contexts, name)
+	} else {
+		credentials := &NamedContext{}
+		credentials.copyCredentials(cfg)
+		doc.Contexts[name] = credentials
+	}
+	return writeDocument(keyring, doc)
+}
+
+func (c *Config) copyCredentials(from *Config) {
+	c.AccessToken = from.AccessToken
+	c.RefreshToken = from.RefreshToken
+	c.ClientID = from.ClientID
+	c.ClientSecret = from.ClientSecret
+}
+
+func (c *Config) clearCredentials() {
+	c.copyCredentials(&Config{})
+}
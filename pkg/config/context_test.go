@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Contexts", Ordered, func() {
+	When("Contexts are used", Ordered, func() {
+		var tmpdir string
+		var err error
+
+		BeforeAll(func() {
+			tmpdir, err = os.MkdirTemp("/tmp", ".ocm-config-*")
+			Expect(err).To(BeNil())
+			os.Setenv("OCM_CONFIG", tmpdir+"/ocm_config.json")
+			Expect(Save(&Config{URL: "top", AccessToken: "top_token"})).To(Succeed())
+		})
+
+		AfterAll(func() {
+			SetContextName("")
+			os.Setenv("OCM_CONFIG", "")
+		})
+
+		It("Returns nil for a context that doesn't exist yet", func() {
+			SetContextName("stage")
+			myconf, err := Load()
+			Expect(err).To(BeNil())
+			Expect(myconf).To(BeNil())
+		})
+
+		It("Saves and loads the config of the selected context", func() {
+			SetContextName("stage")
+			Expect(Save(&Config{URL: "stage", AccessToken: "stage_token"})).To(Succeed())
+			myconf, err := Load()
+			Expect(err).To(BeNil())
+			Expect(myconf.URL).To(Equal("stage"))
+			Expect(myconf.AccessToken).To(Equal("stage_token"))
+
+			SetContextName("")
+			myconf, err = Load()
+			Expect(err).To(BeNil())
+			Expect(myconf.URL).To(Equal("top"))
+			Expect(myconf.AccessToken).To(Equal("top_token"))
+		})
+
+		It("Uses the current context when no context is selected", func() {
+			SetContextName("")
+			Expect(SetContext("stage", func(context *NamedContext) {
+				context.AWSRegion = "us-west-2"
+			})).To(Succeed())
+			Expect(UseContext("stage")).To(Succeed())
+			name, context, err := CurrentContext()
+			Expect(err).To(BeNil())
+			Expect(name).To(Equal("stage"))
+			Expect(context.URL).To(Equal("stage"))
+			Expect(context.AWSRegion).To(Equal("us-west-2"))
+		})
+
+		It("Fails to use a context that doesn't exist", func() {
+			Expect(UseContext("prod")).To(MatchError("Context 'prod' doesn't exist"))
+		})
+
+		It("Removes the credentials of the context and keeps the others", func() {
+			Expect(Remove()).To(Succeed())
+			myconf, err := Load()
+			Expect(err).To(BeNil())
+			Expect(myconf.URL).To(Equal("stage"))
+			Expect(myconf.AccessToken).To(BeEmpty())
+
+			SetContextName("other")
+			Expect(Save(&Config{URL: "other"})).To(Succeed())
+			contexts, current, err := LoadContexts()
+			Expect(err).To(BeNil())
+			Expect(current).To(Equal("other"))
+			Expect(ContextNames(contexts)).To(Equal([]string{"other", "stage"}))
+			SetContextName("")
+		})
+
+		It("Deletes a context", func() {
+			Expect(DeleteContext("stage")).To(Succeed())
+			contexts, current, err := LoadContexts()
+			Expect(err).To(BeNil())
+			Expect(current).To(BeEmpty())
+			Expect(ContextNames(contexts)).To(Equal([]string{"other"}))
+			myconf, err := Load()
+			Expect(err).To(BeNil())
+			Expect(myconf.URL).To(Equal("top"))
+		})
+	})
+})
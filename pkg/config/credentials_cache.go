@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to keep the key of the cache of AWS credentials in the OS
+// keyring, away from the encrypted files.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+// ErrNoKeyring is returned when the key of the credentials cache is needed but the configuration
+// isn't managed by an OS keyring.
+var ErrNoKeyring = fmt.Errorf("the configuration isn't managed by an OS keyring, set the %s "+
+	"environment variable to use one", properties.KeyringEnvKey)
+
+// GetCredentialsCacheKey returns the key that encrypts the cached AWS credentials, or nil if it
+// hasn't been saved yet.
+func GetCredentialsCacheKey() ([]byte, error) {
+	keyring, ok := IsKeyringManaged()
+	if !ok {
+		return nil, ErrNoKeyring
+	}
+	doc, err := readDocument(keyring)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	return doc.CredentialsCacheKey, nil
+}
+
+// SaveCredentialsCacheKey saves the key that encrypts the cached AWS credentials to the OS keyring,
+// or removes it if the key is nil.
+func SaveCredentialsCacheKey(key []byte) error {
+	keyring, ok := IsKeyringManaged()
+	if !ok {
+		if key == nil {
+			return nil
+		}
+		return ErrNoKeyring
+	}
+	doc, err := readDocument(keyring)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		if key == nil {
+			return nil
+		}
+		doc = &document{}
+	}
+	doc.CredentialsCacheKey = key
+	return writeDocument(keyring, doc)
+}
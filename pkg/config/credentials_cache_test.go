@@ -0,0 +1,58 @@
+package config
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+var _ = Describe("Credentials cache key", func() {
+	var stored []byte
+
+	BeforeEach(func() {
+		stored = nil
+		get, upsert := GetConfigFromKeyring, UpsertConfigToKeyring
+		GetConfigFromKeyring = func(string) ([]byte, error) {
+			return stored, nil
+		}
+		UpsertConfigToKeyring = func(_ string, data []byte) error {
+			stored = data
+			return nil
+		}
+		DeferCleanup(func() {
+			GetConfigFromKeyring, UpsertConfigToKeyring = get, upsert
+		})
+	})
+
+	It("Is kept in the keyring with the rest of the configuration", func() {
+		GinkgoT().Setenv(properties.KeyringEnvKey, "keyring")
+		Expect(Save(&Config{AccessToken: "token"})).To(Succeed())
+
+		key, err := GetCredentialsCacheKey()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(BeNil())
+
+		Expect(SaveCredentialsCacheKey([]byte("key"))).To(Succeed())
+		key, err = GetCredentialsCacheKey()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal([]byte("key")))
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.AccessToken).To(Equal("token"))
+
+		Expect(SaveCredentialsCacheKey(nil)).To(Succeed())
+		key, err = GetCredentialsCacheKey()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(BeNil())
+	})
+
+	It("Isn't saved without a keyring", func() {
+		GinkgoT().Setenv(properties.KeyringEnvKey, "")
+		_, err := GetCredentialsCacheKey()
+		Expect(err).To(MatchError(ErrNoKeyring))
+		Expect(SaveCredentialsCacheKey([]byte("key"))).To(MatchError(ErrNoKeyring))
+		Expect(SaveCredentialsCacheKey(nil)).To(Succeed())
+		Expect(stored).To(BeNil())
+	})
+})
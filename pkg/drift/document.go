@@ -0,0 +1,392 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that compare policy documents.
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// statement is a statement of a policy document. The types of the aws package can't be used
+// because they don't contain the conditions, and because most elements of a statement can be
+// either a single value or a list of values.
+type statement struct {
+	Effect      string                            `json:"Effect"`
+	Principal   interface{}                       `json:"Principal,omitempty"`
+	Action      interface{}                       `json:"Action,omitempty"`
+	NotAction   interface{}                       `json:"NotAction,omitempty"`
+	Resource    interface{}                       `json:"Resource,omitempty"`
+	NotResource interface{}                       `json:"NotResource,omitempty"`
+	Condition   map[string]map[string]interface{} `json:"Condition,omitempty"`
+}
+
+func parseStatements(document string) ([]statement, error) {
+	var parsed struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	err := json.Unmarshal([]byte(document), &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy document: %v", err)
+	}
+	if len(parsed.Statement) == 0 {
+		return nil, nil
+	}
+	statements := []statement{}
+	err = json.Unmarshal(parsed.Statement, &statements)
+	if err != nil {
+		// A document with a single statement doesn't need to put it in a list:
+		single := statement{}
+		err = json.Unmarshal(parsed.Statement, &single)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy document: %v", err)
+		}
+		statements = append(statements, single)
+	}
+	return statements, nil
+}
+
+// values returns the values of an element of a statement that can be either a single string or a
+// list of strings.
+func values(element interface{}) []string {
+	switch value := element.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{value}
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			result = append(result, fmt.Sprint(item))
+		}
+		return result
+	default:
+		return []string{fmt.Sprint(value)}
+	}
+}
+
+// canonicalCondition returns the condition as a string that is the same for equivalent conditions,
+// regardless of the order of the keys and values, and of single values written as lists.
+func canonicalCondition(condition map[string]map[string]interface{}) string {
+	if len(condition) == 0 {
+		return ""
+	}
+	canonical := map[string]map[string][]string{}
+	for operator, keys := range condition {
+		canonical[operator] = map[string][]string{}
+		for key, value := range keys {
+			list := values(value)
+			sort.Strings(list)
+			canonical[operator][key] = list
+		}
+	}
+	// Maps are marshalled with sorted keys, so the result is stable:
+	result, _ := json.Marshal(canonical)
+	return string(result)
+}
+
+// Permission is a single action allowed or denied on a single resource. Documents are compared
+// permission by permission, because the same permissions can be grouped in statements in many
+// different ways.
+type Permission struct {
+	Effect    string `json:"effect"`
+	Action    string `json:"action"`
+	Resource  string `json:"resource"`
+	Condition string `json:"condition,omitempty"`
+}
+
+func (p Permission) String() string {
+	result := fmt.Sprintf("%s %s on %s", p.Effect, p.Action, p.Resource)
+	if p.Condition != "" {
+		result += fmt.Sprintf(" when %s", p.Condition)
+	}
+	return result
+}
+
+// coveredBy returns true if the given permission grants, or denies, at least the same as this one.
+func (p Permission) coveredBy(other Permission) bool {
+	return strings.EqualFold(p.Effect, other.Effect) &&
+		matchAction(other.Action, p.Action) &&
+		matchWildcard(other.Resource, p.Resource, false) &&
+		(other.Condition == "" || other.Condition == p.Condition)
+}
+
+// The negated elements can only be compared as a whole, so they are kept as a single permission
+// with a prefix that makes them different from the regular ones.
+const negation = "NOT "
+
+func permissions(document string) ([]Permission, error) {
+	statements, err := parseStatements(document)
+	if err != nil {
+		return nil, err
+	}
+	result := []Permission{}
+	for _, statement := range statements {
+		actions := values(statement.Action)
+		if statement.NotAction != nil {
+			actions = []string{negation + strings.Join(values(statement.NotAction), ",")}
+		}
+		resources := values(statement.Resource)
+		if statement.NotResource != nil {
+			resources = []string{negation + strings.Join(values(statement.NotResource), ",")}
+		}
+		if len(resources) == 0 {
+			resources = []string{"*"}
+		}
+		condition := canonicalCondition(statement.Condition)
+		for _, action := range actions {
+			for _, resource := range resources {
+				result = append(result, Permission{
+					Effect:    statement.Effect,
+					Action:    action,
+					Resource:  resource,
+					Condition: condition,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// ComparePermissions compares the permissions of the expected policy document with the ones of
+// the actual document. It returns the expected permissions that the actual document doesn't
+// grant, and the permissions of the actual document that the expected one doesn't contain.
+// Wildcards are taken into account, so a document that allows 'ec2:*' doesn't miss the permission
+// to 'ec2:RunInstances', but it has an extra permission.
+func ComparePermissions(expected string, actual string) ([]Permission, []Permission, error) {
+	expectedPermissions, err := permissions(expected)
+	if err != nil {
+		return nil, nil, err
+	}
+	actualPermissions, err := permissions(actual)
+	if err != nil {
+		return nil, nil, err
+	}
+	return uncovered(expectedPermissions, actualPermissions), uncovered(actualPermissions, expectedPermissions), nil
+}
+
+// uncovered returns the permissions that aren't covered by any of the others.
+func uncovered(permissions []Permission, others []Permission) []Permission {
+	result := []Permission{}
+	seen := map[Permission]bool{}
+	for _, permission := range permissions {
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+		covered := false
+		for _, other := range others {
+			if permission.coveredBy(other) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, permission)
+		}
+	}
+	return result
+}
+
+// matchAction matches the action with the pattern. Actions are case insensitive.
+func matchAction(pattern string, action string) bool {
+	if strings.HasPrefix(pattern, negation) || strings.HasPrefix(action, negation) {
+		return pattern == action
+	}
+	return matchWildcard(pattern, action, true)
+}
+
+// matchWildcard matches the value with an IAM pattern, where '*' matches any sequence of characters
+// and '?' matches any single character.
+func matchWildcard(pattern string, value string, ignoreCase bool) bool {
+	if strings.HasPrefix(pattern, negation) || strings.HasPrefix(value, negation) {
+		return pattern == value
+	}
+	if ignoreCase {
+		pattern = strings.ToLower(pattern)
+		value = strings.ToLower(value)
+	}
+	// Iterative matching that backtracks to the last star, as in the usual glob implementations:
+	p, v := 0, 0
+	star, mark := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star = p
+			mark = v
+			p++
+		case star != -1:
+			p = star + 1
+			mark++
+			v = mark
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// trustEntry is a single principal allowed, or denied, to perform a single action by a trust
+// policy.
+type trustEntry struct {
+	Effect    string
+	Principal string
+	Action    string
+	Condition string
+}
+
+func (e trustEntry) String() string {
+	result := fmt.Sprintf("%s %s to %s", e.Effect, e.Principal, e.Action)
+	if e.Condition != "" {
+		result += fmt.Sprintf(" when %s", e.Condition)
+	}
+	return result
+}
+
+func principals(principal interface{}) []string {
+	if text, ok := principal.(string); ok {
+		return []string{text}
+	}
+	result := []string{}
+	if kinds, ok := principal.(map[string]interface{}); ok {
+		for kind, value := range kinds {
+			for _, item := range values(value) {
+				result = append(result, fmt.Sprintf("%s:%s", kind, item))
+			}
+		}
+	}
+	return result
+}
+
+func trustEntries(document string) ([]trustEntry, error) {
+	statements, err := parseStatements(document)
+	if err != nil {
+		return nil, err
+	}
+	result := []trustEntry{}
+	for _, statement := range statements {
+		condition := canonicalCondition(statement.Condition)
+		for _, principal := range principals(statement.Principal) {
+			for _, action := range values(statement.Action) {
+				result = append(result, trustEntry{
+					Effect:    statement.Effect,
+					Principal: principal,
+					Action:    action,
+					Condition: condition,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+const oidcProviderResource = ":oidc-provider/"
+
+// oidcIssuers returns the OIDC issuers trusted by the entries, that is the paths of the federated
+// principals that are OIDC providers.
+func oidcIssuers(entries []trustEntry) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		index := strings.Index(entry.Principal, oidcProviderResource)
+		if !strings.HasPrefix(entry.Principal, "Federated:") || index == -1 {
+			continue
+		}
+		issuer := entry.Principal[index+len(oidcProviderResource):]
+		if !seen[issuer] {
+			seen[issuer] = true
+			result = append(result, issuer)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// CompareTrustPolicies compares the expected trust policy with the actual one, and returns the
+// findings. When the actual policy trusts a different OIDC issuer the finding says so, and the
+// rest of the policy is compared as if it trusted the expected issuer, so that the conditions on
+// the service accounts aren't reported again.
+func CompareTrustPolicies(expected string, actual string) ([]Finding, error) {
+	expectedEntries, err := trustEntries(expected)
+	if err != nil {
+		return nil, err
+	}
+	actualEntries, err := trustEntries(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []Finding{}
+	expectedIssuers := oidcIssuers(expectedEntries)
+	actualIssuers := oidcIssuers(actualEntries)
+	if strings.Join(expectedIssuers, ",") != strings.Join(actualIssuers, ",") {
+		findings = append(findings, Finding{
+			Kind:     WrongOIDCIssuer,
+			Expected: strings.Join(expectedIssuers, ", "),
+			Actual:   strings.Join(actualIssuers, ", "),
+		})
+		if len(expectedIssuers) == 1 && len(actualIssuers) == 1 {
+			actualEntries, err = trustEntries(strings.ReplaceAll(actual, actualIssuers[0], expectedIssuers[0]))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, entry := range difference(expectedEntries, actualEntries) {
+		findings = append(findings, Finding{
+			Kind:     TrustPolicyMismatch,
+			Expected: entry.String(),
+		})
+	}
+	for _, entry := range difference(actualEntries, expectedEntries) {
+		findings = append(findings, Finding{
+			Kind:   TrustPolicyMismatch,
+			Actual: entry.String(),
+		})
+	}
+	return findings, nil
+}
+
+// difference returns the entries that aren't in the others. Trust policies are compared exactly,
+// as any difference changes who can assume the role.
+func difference(entries []trustEntry, others []trustEntry) []trustEntry {
+	present := map[trustEntry]bool{}
+	for _, other := range others {
+		present[other] = true
+	}
+	result := []trustEntry{}
+	for _, entry := range entries {
+		if !present[entry] {
+			result = append(result, entry)
+			present[entry] = true
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
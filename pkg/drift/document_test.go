@@ -0,0 +1,137 @@
+package drift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy documents", func() {
+	Context("ComparePermissions", func() {
+		It("Returns no differences for equivalent documents grouped differently", func() {
+			expected := `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Action": ["ec2:RunInstances", "ec2:DescribeInstances"], "Resource": "*"}
+			]}`
+			actual := `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Action": "ec2:describeinstances", "Resource": ["*"]},
+				{"Effect": "Allow", "Action": "ec2:RunInstances", "Resource": "*"}
+			]}`
+			missing, extra, err := ComparePermissions(expected, actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+			Expect(extra).To(BeEmpty())
+		})
+
+		It("Returns the permissions removed and added by hand", func() {
+			expected := `{"Statement": [
+				{"Effect": "Allow", "Action": ["ec2:RunInstances", "ec2:DescribeInstances"], "Resource": "*"}
+			]}`
+			actual := `{"Statement": [
+				{"Effect": "Allow", "Action": ["ec2:DescribeInstances", "s3:GetObject"], "Resource": "*"}
+			]}`
+			missing, extra, err := ComparePermissions(expected, actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(ConsistOf(Permission{Effect: "Allow", Action: "ec2:RunInstances", Resource: "*"}))
+			Expect(extra).To(ConsistOf(Permission{Effect: "Allow", Action: "s3:GetObject", Resource: "*"}))
+		})
+
+		It("Takes wildcards into account", func() {
+			expected := `{"Statement": {"Effect": "Allow", "Action": "ec2:RunInstances",
+				"Resource": "arn:aws:ec2:*:*:instance/i-1"}}`
+			actual := `{"Statement": {"Effect": "Allow", "Action": "ec2:*", "Resource": "arn:aws:ec2:*:*:instance/*"}}`
+			missing, extra, err := ComparePermissions(expected, actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+			Expect(extra).To(ConsistOf(Permission{Effect: "Allow", Action: "ec2:*",
+				Resource: "arn:aws:ec2:*:*:instance/*"}))
+		})
+
+		It("Reports permissions whose conditions were changed", func() {
+			expected := `{"Statement": {"Effect": "Allow", "Action": "kms:Decrypt", "Resource": "*",
+				"Condition": {"StringEquals": {"aws:ResourceTag/red-hat": ["true"]}}}}`
+			same := `{"Statement": {"Effect": "Allow", "Action": "kms:Decrypt", "Resource": "*",
+				"Condition": {"StringEquals": {"aws:ResourceTag/red-hat": "true"}}}}`
+			changed := `{"Statement": {"Effect": "Allow", "Action": "kms:Decrypt", "Resource": "*",
+				"Condition": {"StringEquals": {"aws:ResourceTag/red-hat": "false"}}}}`
+			missing, extra, err := ComparePermissions(expected, same)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+			Expect(extra).To(BeEmpty())
+			missing, extra, err = ComparePermissions(expected, changed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(HaveLen(1))
+			Expect(extra).To(HaveLen(1))
+		})
+
+		It("Fails for invalid documents", func() {
+			_, _, err := ComparePermissions(`{"Statement": []}`, `not json`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("matchWildcard", func() {
+		It("Matches stars and question marks", func() {
+			Expect(matchWildcard("*", "anything", false)).To(BeTrue())
+			Expect(matchWildcard("ec2:Describe*", "ec2:DescribeInstances", false)).To(BeTrue())
+			Expect(matchWildcard("ec2:Describe*", "ec2:RunInstances", false)).To(BeFalse())
+			Expect(matchWildcard("a*b*c", "axxbyyc", false)).To(BeTrue())
+			Expect(matchWildcard("a*b*c", "axxbyy", false)).To(BeFalse())
+			Expect(matchWildcard("i-?", "i-1", false)).To(BeTrue())
+			Expect(matchWildcard("EC2:*", "ec2:RunInstances", true)).To(BeTrue())
+			Expect(matchWildcard("EC2:*", "ec2:RunInstances", false)).To(BeFalse())
+		})
+	})
+
+	Context("CompareTrustPolicies", func() {
+		trustPolicy := func(issuer string, serviceAccount string) string {
+			return `{"Version": "2012-10-17", "Statement": [{
+				"Effect": "Allow",
+				"Principal": {"Federated": "arn:aws:iam::123456789012:oidc-provider/` + issuer + `"},
+				"Action": "sts:AssumeRoleWithWebIdentity",
+				"Condition": {"StringEquals": {"` + issuer + `:sub": ["` + serviceAccount + `"]}}
+			}]}`
+		}
+
+		It("Returns no findings for the same policy", func() {
+			findings, err := CompareTrustPolicies(trustPolicy("oidc.example.com/abc", "system:serviceaccount:a:b"),
+				trustPolicy("oidc.example.com/abc", "system:serviceaccount:a:b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(BeEmpty())
+		})
+
+		It("Reports a wrong OIDC issuer only once", func() {
+			findings, err := CompareTrustPolicies(trustPolicy("oidc.example.com/abc", "system:serviceaccount:a:b"),
+				trustPolicy("oidc.example.com/xyz", "system:serviceaccount:a:b"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(ConsistOf(Finding{
+				Kind:     WrongOIDCIssuer,
+				Expected: "oidc.example.com/abc",
+				Actual:   "oidc.example.com/xyz",
+			}))
+		})
+
+		It("Reports changes of the service accounts", func() {
+			findings, err := CompareTrustPolicies(trustPolicy("oidc.example.com/abc", "system:serviceaccount:a:b"),
+				trustPolicy("oidc.example.com/abc", "system:serviceaccount:a:c"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Kind).To(Equal(TrustPolicyMismatch))
+			Expect(findings[0].Expected).To(ContainSubstring("system:serviceaccount:a:b"))
+			Expect(findings[1].Kind).To(Equal(TrustPolicyMismatch))
+			Expect(findings[1].Actual).To(ContainSubstring("system:serviceaccount:a:c"))
+		})
+
+		It("Reports principals added by hand", func() {
+			expected := `{"Statement": [{"Effect": "Allow", "Action": "sts:AssumeRole",
+				"Principal": {"AWS": "arn:aws:iam::710019948333:role/RH-Managed-OpenShift-Installer"}}]}`
+			actual := `{"Statement": [{"Effect": "Allow", "Action": "sts:AssumeRole",
+				"Principal": {"AWS": ["arn:aws:iam::710019948333:role/RH-Managed-OpenShift-Installer",
+				"arn:aws:iam::111111111111:root"]}}]}`
+			findings, err := CompareTrustPolicies(expected, actual)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(ConsistOf(Finding{
+				Kind:   TrustPolicyMismatch,
+				Actual: "Allow AWS:arn:aws:iam::111111111111:root to sts:AssumeRole",
+			}))
+		})
+	})
+})
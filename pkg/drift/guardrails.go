@@ -0,0 +1,293 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that find the actions granted by the ROSA policies that the
+// permissions boundary of the roles, or the service control policies of the organization, deny.
+
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// Guardrail is a policy that limits what the policies attached to a role can grant.
+type Guardrail struct {
+	// Name describes the policy in messages, for example "the permissions boundary 'arn:...'"
+	Name string
+	// Level groups the policies that together have to allow an action, like the service control
+	// policies attached to the same organizational unit. It is used in messages too.
+	Level    string
+	Document string
+}
+
+// DeniedActions are the actions granted by a ROSA policy that a guardrail denies, or doesn't allow.
+type DeniedActions struct {
+	Policy  string   `json:"policy"`
+	Reason  string   `json:"reason"`
+	Actions []string `json:"actions"`
+}
+
+// coverage is how much of an action, that may contain wildcards, a statement applies to.
+type coverage int
+
+const (
+	notCovered coverage = iota
+	partiallyCovered
+	covered
+)
+
+// FindDeniedActions returns, for each of the given policy documents, the actions allowed by the
+// policy that the guardrails deny or don't allow. Only the actions are evaluated: the resources
+// and conditions of the statements that allow actions are ignored, and the statements that deny
+// actions with conditions are reported as denying them.
+func FindDeniedActions(policies map[string]string, guardrails []Guardrail) ([]DeniedActions, error) {
+	levels := []string{}
+	allows := map[string][]statement{}
+	type deny struct {
+		guardrail  Guardrail
+		statements []statement
+	}
+	denies := []deny{}
+	for _, guardrail := range guardrails {
+		statements, err := parseStatements(guardrail.Document)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse %s: %v", guardrail.Name, err)
+		}
+		if _, ok := allows[guardrail.Level]; !ok {
+			levels = append(levels, guardrail.Level)
+			allows[guardrail.Level] = []statement{}
+		}
+		denied := deny{guardrail: guardrail}
+		for _, item := range statements {
+			if strings.EqualFold(item.Effect, "Allow") {
+				allows[guardrail.Level] = append(allows[guardrail.Level], item)
+			} else {
+				denied.statements = append(denied.statements, item)
+			}
+		}
+		denies = append(denies, denied)
+	}
+
+	result := []DeniedActions{}
+	for _, name := range sortedKeys(policies) {
+		actions, err := allowedActions(policies[name])
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse policy '%s': %v", name, err)
+		}
+		reasons := map[string][]string{}
+		add := func(reason string, action string) {
+			reasons[reason] = append(reasons[reason], action)
+		}
+		for _, action := range actions {
+			for _, level := range levels {
+				switch bestCoverage(allows[level], action) {
+				case notCovered:
+					add(fmt.Sprintf("not allowed by %s", level), action)
+				case partiallyCovered:
+					add(fmt.Sprintf("only partially allowed by %s", level), action)
+				}
+			}
+			for _, denied := range denies {
+				switch bestCoverage(denied.statements, action) {
+				case covered:
+					add(fmt.Sprintf("denied by %s", denied.guardrail.Name), action)
+				case partiallyCovered:
+					add(fmt.Sprintf("partially denied by %s", denied.guardrail.Name), action)
+				}
+			}
+		}
+		for _, reason := range sortedKeys(reasons) {
+			result = append(result, DeniedActions{
+				Policy:  name,
+				Reason:  reason,
+				Actions: reasons[reason],
+			})
+		}
+	}
+	return result, nil
+}
+
+// allowedActions returns the actions, sorted and without duplicates, allowed by the document.
+func allowedActions(document string) ([]string, error) {
+	statements, err := parseStatements(document)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	actions := []string{}
+	for _, item := range statements {
+		if !strings.EqualFold(item.Effect, "Allow") {
+			continue
+		}
+		for _, action := range values(item.Action) {
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, action)
+			}
+		}
+	}
+	sort.Strings(actions)
+	return actions, nil
+}
+
+func bestCoverage(statements []statement, action string) coverage {
+	best := notCovered
+	for _, item := range statements {
+		if current := statementCoverage(item, action); current > best {
+			best = current
+		}
+	}
+	return best
+}
+
+// statementCoverage returns how much of the action the statement applies to. An action with
+// wildcards, like 'ec2:Describe*', is only partially covered by a statement for
+// 'ec2:DescribeInstances'.
+func statementCoverage(item statement, action string) coverage {
+	if item.NotAction != nil {
+		result := covered
+		for _, pattern := range values(item.NotAction) {
+			if matchAction(pattern, action) {
+				return notCovered
+			}
+			if matchAction(action, pattern) {
+				result = partiallyCovered
+			}
+		}
+		return result
+	}
+	result := notCovered
+	for _, pattern := range values(item.Action) {
+		if matchAction(pattern, action) {
+			return covered
+		}
+		if matchAction(action, pattern) {
+			result = partiallyCovered
+		}
+	}
+	return result
+}
+
+// Preflight checks, before the roles are created, that their permissions boundary and the service
+// control policies of the organization don't deny the actions that the ROSA policies grant.
+type Preflight struct {
+	AWSClient aws.Client
+	Partition string
+	// Policies are the policies returned by OCM for the type of role
+	Policies map[string]*cmv1.AWSSTSPolicy
+}
+
+// Boundary returns the guardrail of the given permissions boundary.
+func (p *Preflight) Boundary(permissionsBoundary string) (Guardrail, error) {
+	document, err := p.AWSClient.GetDefaultPolicyDocument(permissionsBoundary)
+	if err != nil {
+		return Guardrail{}, fmt.Errorf("Failed to get the document of permissions boundary '%s': %v",
+			permissionsBoundary, err)
+	}
+	name := fmt.Sprintf("the permissions boundary '%s'", permissionsBoundary)
+	return Guardrail{
+		Name:     name,
+		Level:    name,
+		Document: document,
+	}, nil
+}
+
+// ServiceControlPolicies returns the guardrails of the service control policies of the account.
+func (p *Preflight) ServiceControlPolicies() ([]Guardrail, error) {
+	scps, err := p.AWSClient.ListServiceControlPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the service control policies: %v", err)
+	}
+	guardrails := []Guardrail{}
+	for _, scp := range scps {
+		guardrails = append(guardrails, Guardrail{
+			Name:     fmt.Sprintf("the service control policy '%s' (%s)", scp.Name, scp.ID),
+			Level:    fmt.Sprintf("the service control policies attached to '%s'", scp.Target),
+			Document: scp.Document,
+		})
+	}
+	return guardrails, nil
+}
+
+// Documents returns the documents of the policies with the given keys. The documents of the AWS
+// managed policies are read from AWS.
+func (p *Preflight) Documents(keys []string) (map[string]string, error) {
+	documents := map[string]string{}
+	for _, key := range keys {
+		policy, ok := p.Policies[key]
+		if !ok {
+			continue
+		}
+		if policy.ARN() != "" {
+			document, err := p.AWSClient.GetDefaultPolicyDocument(policy.ARN())
+			if err != nil {
+				return nil, fmt.Errorf("Failed to get the document of policy '%s': %v", policy.ARN(), err)
+			}
+			documents[key] = document
+			continue
+		}
+		documents[key] = aws.InterpolatePolicyDocument(p.Partition, policy.Details(), map[string]string{
+			"partition": p.Partition,
+		})
+	}
+	return documents, nil
+}
+
+// Warn checks the policies with the given keys and writes a warning for each group of actions
+// that will be effectively denied. The check never stops the creation of the roles, so errors
+// are reported as warnings too.
+func (p *Preflight) Warn(r *reporter.Object, permissionsBoundary string, keys []string) {
+	guardrails := []Guardrail{}
+	if permissionsBoundary != "" {
+		boundary, err := p.Boundary(permissionsBoundary)
+		if err != nil {
+			r.Warnf("Skipping the permissions pre-flight check: %v", err)
+			return
+		}
+		guardrails = append(guardrails, boundary)
+	}
+	// The service control policies can only be read from the management account of the
+	// organization, or from a delegated administrator account, so failing to read them is expected:
+	scps, err := p.ServiceControlPolicies()
+	if err != nil {
+		r.Debugf("Service control policies aren't checked: %v", err)
+	}
+	guardrails = append(guardrails, scps...)
+	if len(guardrails) == 0 {
+		return
+	}
+	documents, err := p.Documents(keys)
+	if err != nil {
+		r.Warnf("Skipping the permissions pre-flight check: %v", err)
+		return
+	}
+	denied, err := FindDeniedActions(documents, guardrails)
+	if err != nil {
+		r.Warnf("Skipping the permissions pre-flight check: %v", err)
+		return
+	}
+	for _, item := range denied {
+		r.Warnf("Actions of policy '%s' %s, the roles won't be able to use them: %s",
+			item.Policy, item.Reason, strings.Join(item.Actions, ", "))
+	}
+}
@@ -0,0 +1,127 @@
+package drift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Guardrails", func() {
+	Context("FindDeniedActions", func() {
+		policies := map[string]string{
+			"installer": `{"Statement": [
+				{"Effect": "Allow", "Action": ["ec2:RunInstances", "ec2:Describe*", "iam:PassRole"], "Resource": "*"}
+			]}`,
+		}
+
+		It("Returns nothing when the guardrails allow every action", func() {
+			boundary := Guardrail{
+				Name:     "the permissions boundary 'pb'",
+				Level:    "the permissions boundary 'pb'",
+				Document: `{"Statement": {"Effect": "Allow", "Action": ["ec2:*", "iam:*"], "Resource": "*"}}`,
+			}
+			denied, err := FindDeniedActions(policies, []Guardrail{boundary})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(BeEmpty())
+		})
+
+		It("Reports the actions that the permissions boundary doesn't allow", func() {
+			boundary := Guardrail{
+				Name:  "the permissions boundary 'pb'",
+				Level: "the permissions boundary 'pb'",
+				Document: `{"Statement": {"Effect": "Allow", "Action": ["ec2:RunInstances", "ec2:DescribeInstances"],
+					"Resource": "*"}}`,
+			}
+			denied, err := FindDeniedActions(policies, []Guardrail{boundary})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(ConsistOf(
+				DeniedActions{
+					Policy:  "installer",
+					Reason:  "not allowed by the permissions boundary 'pb'",
+					Actions: []string{"iam:PassRole"},
+				},
+				DeniedActions{
+					Policy:  "installer",
+					Reason:  "only partially allowed by the permissions boundary 'pb'",
+					Actions: []string{"ec2:Describe*"},
+				},
+			))
+		})
+
+		It("Reports the actions denied explicitly", func() {
+			scp := Guardrail{
+				Name:  "the service control policy 'deny-iam' (p-1)",
+				Level: "the service control policies attached to 'r-1'",
+				Document: `{"Statement": [
+					{"Effect": "Allow", "Action": "*", "Resource": "*"},
+					{"Effect": "Deny", "Action": ["iam:*", "ec2:DescribeRegions"], "Resource": "*"}
+				]}`,
+			}
+			denied, err := FindDeniedActions(policies, []Guardrail{scp})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(ConsistOf(
+				DeniedActions{
+					Policy:  "installer",
+					Reason:  "denied by the service control policy 'deny-iam' (p-1)",
+					Actions: []string{"iam:PassRole"},
+				},
+				DeniedActions{
+					Policy:  "installer",
+					Reason:  "partially denied by the service control policy 'deny-iam' (p-1)",
+					Actions: []string{"ec2:Describe*"},
+				},
+			))
+		})
+
+		It("Takes NotAction into account", func() {
+			scp := Guardrail{
+				Name:  "the service control policy 'only-ec2' (p-2)",
+				Level: "the service control policies attached to 'ou-1'",
+				Document: `{"Statement": [
+					{"Effect": "Allow", "Action": "*", "Resource": "*"},
+					{"Effect": "Deny", "NotAction": "ec2:*", "Resource": "*"}
+				]}`,
+			}
+			denied, err := FindDeniedActions(policies, []Guardrail{scp})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(ConsistOf(DeniedActions{
+				Policy:  "installer",
+				Reason:  "denied by the service control policy 'only-ec2' (p-2)",
+				Actions: []string{"iam:PassRole"},
+			}))
+		})
+
+		It("Requires every level of service control policies to allow the actions", func() {
+			root := Guardrail{
+				Name:     "the service control policy 'FullAWSAccess' (p-FullAWSAccess)",
+				Level:    "the service control policies attached to 'r-1'",
+				Document: `{"Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`,
+			}
+			unit := Guardrail{
+				Name:     "the service control policy 'ec2-only' (p-3)",
+				Level:    "the service control policies attached to 'ou-1'",
+				Document: `{"Statement": {"Effect": "Allow", "Action": "ec2:*", "Resource": "*"}}`,
+			}
+			other := Guardrail{
+				Name:     "the service control policy 'iam-only' (p-4)",
+				Level:    "the service control policies attached to 'ou-1'",
+				Document: `{"Statement": {"Effect": "Allow", "Action": "iam:PassRole", "Resource": "*"}}`,
+			}
+			denied, err := FindDeniedActions(policies, []Guardrail{root, unit})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(ConsistOf(DeniedActions{
+				Policy:  "installer",
+				Reason:  "not allowed by the service control policies attached to 'ou-1'",
+				Actions: []string{"iam:PassRole"},
+			}))
+			denied, err = FindDeniedActions(policies, []Guardrail{root, unit, other})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(BeEmpty())
+		})
+
+		It("Fails with invalid documents", func() {
+			_, err := FindDeniedActions(policies, []Guardrail{{Name: "the permissions boundary 'pb'",
+				Level: "the permissions boundary 'pb'", Document: "{"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
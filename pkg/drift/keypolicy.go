@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that check that the key policy of a KMS key allows roles to use
+// the key, and that add the statements that are missing.
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// KeyGrant is a set of actions that a role has to be allowed to perform on a KMS key.
+type KeyGrant struct {
+	// Sid is the identifier of the statement that allows the actions, when it has to be added
+	Sid     string
+	RoleARN string
+	Actions []string
+}
+
+// keyPolicyStatement is the statement added to a key policy for a grant. It is a separate type from
+// statement so that the elements are written in the usual order.
+type keyPolicyStatement struct {
+	Sid       string            `json:"Sid,omitempty"`
+	Effect    string            `json:"Effect"`
+	Principal map[string]string `json:"Principal"`
+	Action    []string          `json:"Action"`
+	Resource  string            `json:"Resource"`
+}
+
+func (g KeyGrant) statement(sid string) keyPolicyStatement {
+	return keyPolicyStatement{
+		Sid:       sid,
+		Effect:    "Allow",
+		Principal: map[string]string{"AWS": g.RoleARN},
+		Action:    g.Actions,
+		Resource:  "*",
+	}
+}
+
+// MissingKeyGrants returns the grants that the key policy doesn't give, with only the actions that
+// are missing. An action is given by the statements that allow it to the role ARN, or to any
+// principal. Statements that allow the actions to the root of the account aren't enough, because
+// they only delegate the permissions to the IAM policies of the role. The conditions and the deny
+// statements aren't evaluated.
+func MissingKeyGrants(policy string, grants []KeyGrant) ([]KeyGrant, error) {
+	statements, err := parseStatements(policy)
+	if err != nil {
+		return nil, err
+	}
+	result := []KeyGrant{}
+	for _, grant := range grants {
+		missing := []string{}
+		for _, action := range grant.Actions {
+			if !keyPolicyAllows(statements, grant.RoleARN, action) {
+				missing = append(missing, action)
+			}
+		}
+		if len(missing) > 0 {
+			result = append(result, KeyGrant{
+				Sid:     grant.Sid,
+				RoleARN: grant.RoleARN,
+				Actions: missing,
+			})
+		}
+	}
+	return result, nil
+}
+
+func keyPolicyAllows(statements []statement, roleARN string, action string) bool {
+	for _, item := range statements {
+		if !strings.EqualFold(item.Effect, "Allow") || statementCoverage(item, action) != covered {
+			continue
+		}
+		for _, principal := range principals(item.Principal) {
+			if principal == "*" || principal == "AWS:*" || strings.EqualFold(principal, "AWS:"+roleARN) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddKeyGrants returns the key policy with a statement added for each of the grants. The existing
+// statements are preserved as they are.
+func AddKeyGrants(policy string, grants []KeyGrant) (string, error) {
+	document := map[string]interface{}{}
+	err := json.Unmarshal([]byte(policy), &document)
+	if err != nil {
+		return "", fmt.Errorf("invalid policy document: %v", err)
+	}
+	statements := []interface{}{}
+	switch value := document["Statement"].(type) {
+	case nil:
+	case []interface{}:
+		statements = value
+	default:
+		statements = append(statements, value)
+	}
+	sids := map[string]bool{}
+	for _, item := range statements {
+		if object, ok := item.(map[string]interface{}); ok {
+			sids[fmt.Sprint(object["Sid"])] = true
+		}
+	}
+	for _, grant := range grants {
+		// Statement identifiers have to be unique in key policies:
+		sid := grant.Sid
+		for i := 2; sids[sid]; i++ {
+			sid = fmt.Sprintf("%s %d", grant.Sid, i)
+		}
+		sids[sid] = true
+		statements = append(statements, grant.statement(sid))
+	}
+	document["Statement"] = statements
+	result, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// KeyGrantStatements returns the statements that give the grants, formatted to be added by hand to
+// the 'Statement' list of a key policy.
+func KeyGrantStatements(grants []KeyGrant) string {
+	statements := []keyPolicyStatement{}
+	for _, grant := range grants {
+		statements = append(statements, grant.statement(grant.Sid))
+	}
+	result, _ := json.MarshalIndent(statements, "", "  ")
+	return string(result)
+}
@@ -0,0 +1,84 @@
+package drift
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key policies", func() {
+	const installer = "arn:aws:iam::123456789012:role/test-Installer-Role"
+	const support = "arn:aws:iam::123456789012:role/test-Support-Role"
+
+	grants := []KeyGrant{
+		{Sid: "ROSA installer role", RoleARN: installer, Actions: []string{"kms:Decrypt", "kms:CreateGrant"}},
+		{Sid: "ROSA support role", RoleARN: support, Actions: []string{"kms:DescribeKey"}},
+	}
+
+	Context("MissingKeyGrants", func() {
+		It("Returns nothing when the key policy allows every action", func() {
+			policy := `{"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": ["` + installer + `"]}, "Action": "kms:*", "Resource": "*"},
+				{"Effect": "Allow", "Principal": {"AWS": "` + support + `"}, "Action": "kms:Describe*", "Resource": "*"}
+			]}`
+			missing, err := MissingKeyGrants(policy, grants)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+		})
+
+		It("Returns the actions that aren't allowed to the roles", func() {
+			policy := `{"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*",
+					"Resource": "*"},
+				{"Effect": "Allow", "Principal": {"AWS": "` + installer + `"}, "Action": "kms:Decrypt", "Resource": "*"}
+			]}`
+			missing, err := MissingKeyGrants(policy, grants)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(Equal([]KeyGrant{
+				{Sid: "ROSA installer role", RoleARN: installer, Actions: []string{"kms:CreateGrant"}},
+				{Sid: "ROSA support role", RoleARN: support, Actions: []string{"kms:DescribeKey"}},
+			}))
+		})
+
+		It("Accepts statements for any principal", func() {
+			policy := `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "kms:*", "Resource": "*"}}`
+			missing, err := MissingKeyGrants(policy, grants)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+		})
+	})
+
+	Context("AddKeyGrants", func() {
+		It("Adds the statements keeping the existing ones", func() {
+			policy := `{"Version": "2012-10-17", "Id": "key-default-1", "Statement": {"Sid": "ROSA support role",
+				"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*",
+				"Resource": "*"}}`
+			updated, err := AddKeyGrants(policy, grants)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(MatchJSON(`{"Version": "2012-10-17", "Id": "key-default-1", "Statement": [
+				{"Sid": "ROSA support role", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+					"Action": "kms:*", "Resource": "*"},
+				{"Sid": "ROSA installer role", "Effect": "Allow", "Principal": {"AWS": "` + installer + `"},
+					"Action": ["kms:Decrypt", "kms:CreateGrant"], "Resource": "*"},
+				{"Sid": "ROSA support role 2", "Effect": "Allow", "Principal": {"AWS": "` + support + `"},
+					"Action": ["kms:DescribeKey"], "Resource": "*"}
+			]}`))
+			missing, err := MissingKeyGrants(updated, grants)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+		})
+
+		It("Fails with invalid documents", func() {
+			_, err := AddKeyGrants("{", grants)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("KeyGrantStatements", func() {
+		It("Returns the statements of the grants", func() {
+			Expect(KeyGrantStatements(grants[1:])).To(MatchJSON(`[
+				{"Sid": "ROSA support role", "Effect": "Allow", "Principal": {"AWS": "` + support + `"},
+					"Action": ["kms:DescribeKey"], "Resource": "*"}
+			]`))
+		})
+	})
+})
@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift compares the account and operator roles that exist in the AWS account with the
+// roles that OCM expects, and reports the differences, for example permissions that were removed
+// from a policy by hand, or a trust policy that points to the wrong OIDC issuer.
+package drift
+
+// FindingKind is the kind of difference between a role and what OCM expects.
+type FindingKind string
+
+const (
+	// MissingRole means that the role doesn't exist.
+	MissingRole FindingKind = "MissingRole"
+	// MissingPolicy means that a permission policy isn't attached to the role.
+	MissingPolicy FindingKind = "MissingPolicy"
+	// ExtraPolicy means that a policy that OCM doesn't know about is attached to the role.
+	ExtraPolicy FindingKind = "ExtraPolicy"
+	// MissingPermission means that a permission of the OCM policy isn't granted by the attached policy.
+	MissingPermission FindingKind = "MissingPermission"
+	// ExtraPermission means that the attached policy contains a statement that isn't in the OCM policy.
+	ExtraPermission FindingKind = "ExtraPermission"
+	// TrustPolicyMismatch means that the trust policy of the role doesn't match the OCM policy.
+	TrustPolicyMismatch FindingKind = "TrustPolicyMismatch"
+	// WrongOIDCIssuer means that the trust policy of an operator role trusts a different OIDC provider
+	// than the one of the cluster.
+	WrongOIDCIssuer FindingKind = "WrongOIDCIssuer"
+	// StaleVersionTag means that the version tag of the role or policy is older than the current
+	// version of the OCM policies.
+	StaleVersionTag FindingKind = "StaleVersionTag"
+	// TagMismatch means that a tag that ROSA uses to find the role is missing or has a wrong value.
+	TagMismatch FindingKind = "TagMismatch"
+)
+
+// Finding is a single difference between a role and what OCM expects.
+type Finding struct {
+	Kind FindingKind `json:"kind"`
+	// Policy is the ARN of the policy the finding is about, if any
+	Policy   string `json:"policy,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// RoleResult contains the findings of a role. A role without findings hasn't drifted.
+type RoleResult struct {
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	ARN      string    `json:"arn,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+func (r *RoleResult) add(finding Finding) {
+	r.Findings = append(r.Findings, finding)
+}
+
+// Result contains the findings of all the verified roles.
+type Result struct {
+	Roles []*RoleResult `json:"roles"`
+}
+
+// HasDrift returns true if any of the roles has drifted.
+func (r *Result) HasDrift() bool {
+	for _, role := range r.Roles {
+		if len(role.Findings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the total number of findings.
+func (r *Result) Count() int {
+	count := 0
+	for _, role := range r.Roles {
+		count += len(role.Findings)
+	}
+	return count
+}
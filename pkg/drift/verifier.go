@@ -0,0 +1,434 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	awsutil "github.com/aws/aws-sdk-go-v2/aws"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	awserr "github.com/openshift-online/ocm-common/pkg/aws/errors"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// OperatorRoleType is the type of the results of operator roles.
+const OperatorRoleType = "Operator"
+
+// Verifier compares the roles of the AWS account with the policies that OCM expects.
+type Verifier struct {
+	AWSClient aws.Client
+	Partition string
+	AccountID string
+	// JumpAccount is the Red Hat account that the account roles trust
+	JumpAccount string
+	// AccountRolePolicies and OperatorRolePolicies are the policies returned by OCM for each type of role
+	AccountRolePolicies  map[string]*cmv1.AWSSTSPolicy
+	OperatorRolePolicies map[string]*cmv1.AWSSTSPolicy
+	// Version is the current version of the policies. Roles and policies tagged with an older
+	// version are reported as stale.
+	Version string
+}
+
+// VerifyAccountRoles verifies the classic and hosted CP account roles with the given prefix. A set
+// of roles where none of the roles exist isn't reported, as users usually create only one of them.
+func (v *Verifier) VerifyAccountRoles(prefix string) (*Result, error) {
+	result := &Result{}
+	for _, hostedCP := range []bool{false, true} {
+		roles := aws.AccountRoles
+		if hostedCP {
+			roles = aws.HCPAccountRoles
+		}
+		results := []*RoleResult{}
+		found := false
+		for _, file := range sortedKeys(roles) {
+			roleResult, err := v.verifyAccountRole(common.GetRoleName(prefix, roles[file].Name), file, hostedCP,
+				prefix)
+			if err != nil {
+				return nil, err
+			}
+			if roleResult.ARN != "" {
+				found = true
+			}
+			results = append(results, roleResult)
+		}
+		if found {
+			result.Roles = append(result.Roles, results...)
+		}
+	}
+	if len(result.Roles) == 0 {
+		return nil, fmt.Errorf("There are no account roles with prefix '%s'", prefix)
+	}
+	return result, nil
+}
+
+// VerifyCluster verifies the account roles and the operator roles used by the cluster. The
+// credential requests are the ones returned by OCM for the topology of the cluster.
+func (v *Verifier) VerifyCluster(cluster *cmv1.Cluster, credRequests map[string]*cmv1.STSOperator) (*Result, error) {
+	result := &Result{}
+	hostedCP := aws.IsHostedCP(cluster)
+	roles := aws.AccountRoles
+	if hostedCP {
+		roles = aws.HCPAccountRoles
+	}
+	arns := aws.GetAccountRolesArnsMap(cluster)
+	for _, file := range sortedKeys(roles) {
+		arn := arns[aws.AccountRoles[file].Name]
+		if arn == "" {
+			continue
+		}
+		name, err := aws.GetResourceIdFromARN(arn)
+		if err != nil {
+			return nil, err
+		}
+		// The prefix tag can only be checked when the role follows the naming convention:
+		prefix := ""
+		suffix := "-" + roles[file].Name
+		if strings.HasSuffix(name, suffix) {
+			prefix = strings.TrimSuffix(name, suffix)
+		}
+		roleResult, err := v.verifyAccountRole(name, file, hostedCP, prefix)
+		if err != nil {
+			return nil, err
+		}
+		result.Roles = append(result.Roles, roleResult)
+	}
+
+	operatorResults, err := v.verifyOperatorRoles(cluster, credRequests)
+	if err != nil {
+		return nil, err
+	}
+	result.Roles = append(result.Roles, operatorResults...)
+	return result, nil
+}
+
+func (v *Verifier) verifyAccountRole(name string, file string, hostedCP bool, prefix string) (*RoleResult, error) {
+	result := &RoleResult{
+		Name: name,
+		Type: aws.AccountRoles[file].Name,
+	}
+	role, err := v.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		result.add(Finding{Kind: MissingRole})
+		return result, nil
+	}
+	result.ARN = awsutil.ToString(role.Arn)
+
+	trustPolicy := aws.InterpolatePolicyDocument(v.Partition,
+		aws.GetPolicyDetails(v.AccountRolePolicies, fmt.Sprintf("sts_%s_trust_policy", file)),
+		map[string]string{
+			"partition":      v.Partition,
+			"aws_account_id": v.JumpAccount,
+		})
+	err = v.verifyTrustPolicy(result, role, trustPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	roleTags := tagsMap(role.Tags)
+	managedPolicies := hostedCP || roleTags[common.ManagedPolicies] == tags.True
+	expectedTags := map[string]string{
+		tags.RedHatManaged: tags.True,
+		tags.RoleType:      file,
+	}
+	if prefix != "" {
+		expectedTags[tags.RolePrefix] = prefix
+	}
+	if hostedCP {
+		expectedTags[common.ManagedPolicies] = tags.True
+		expectedTags[tags.HypershiftPolicies] = tags.True
+	}
+	verifyTags(result, roleTags, expectedTags)
+
+	// The policies of the roles with managed policies are updated by AWS, so their version tag
+	// doesn't need to be updated:
+	if !managedPolicies {
+		verifyVersionTag(result, "", role.Tags, v.Version)
+	}
+
+	// The documents of the managed policies are maintained by AWS, so only the attachment is checked:
+	policies := map[string]string{}
+	if hostedCP {
+		arn, err := aws.GetManagedPolicyARN(v.AccountRolePolicies, fmt.Sprintf("sts_hcp_%s_permission_policy", file))
+		if err != nil {
+			return nil, err
+		}
+		policies[arn] = ""
+	} else if managedPolicies {
+		for _, key := range aws.GetAccountRolePolicyKeys(file) {
+			arn, err := aws.GetManagedPolicyARN(v.AccountRolePolicies, key)
+			if err != nil {
+				return nil, err
+			}
+			policies[arn] = ""
+		}
+	} else {
+		arn := aws.GetPolicyARN(v.Partition, v.AccountID, name, awsutil.ToString(role.Path))
+		policies[arn] = aws.InterpolatePolicyDocument(v.Partition,
+			aws.GetPolicyDetails(v.AccountRolePolicies, fmt.Sprintf("sts_%s_permission_policy", file)),
+			map[string]string{
+				"partition": v.Partition,
+			})
+	}
+	err = v.verifyPolicies(result, policies)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v *Verifier) verifyOperatorRoles(cluster *cmv1.Cluster,
+	credRequests map[string]*cmv1.STSOperator) ([]*RoleResult, error) {
+	results := []*RoleResult{}
+	managedPolicies := cluster.AWS().STS().ManagedPolicies()
+	hostedCPPolicies := aws.IsHostedCPManagedPolicies(cluster)
+	sharedVpcRoleArn := cluster.AWS().PrivateHostedZoneRoleARN()
+
+	// The prefix and path of the unmanaged operator policies are the ones of the installer role:
+	prefix := ""
+	path := ""
+	if !managedPolicies {
+		var err error
+		prefix, err = aws.GetOperatorRolePolicyPrefixFromCluster(cluster, v.AWSClient)
+		if err != nil {
+			return nil, err
+		}
+		path, err = aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, credRequest := range sortedKeys(credRequests) {
+		operator := credRequests[credRequest]
+		if cluster.Version() != nil && operator.MinVersion() != "" {
+			supported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(cluster.Version().ID()),
+				operator.MinVersion())
+			if err != nil {
+				return nil, err
+			}
+			if !supported {
+				continue
+			}
+		}
+		name, _ := aws.FindOperatorRoleNameBySTSOperator(cluster, operator)
+		if name == "" {
+			continue
+		}
+		result := &RoleResult{
+			Name: name,
+			Type: OperatorRoleType,
+		}
+		results = append(results, result)
+		role, err := v.getRole(name)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			result.add(Finding{Kind: MissingRole})
+			continue
+		}
+		result.ARN = awsutil.ToString(role.Arn)
+
+		trustPolicy, err := aws.GenerateOperatorRolePolicyDoc(v.Partition, cluster, v.AccountID, operator,
+			aws.GetPolicyDetails(v.OperatorRolePolicies, "operator_iam_role_policy"))
+		if err != nil {
+			return nil, err
+		}
+		err = v.verifyTrustPolicy(result, role, trustPolicy)
+		if err != nil {
+			return nil, err
+		}
+
+		expectedTags := map[string]string{
+			tags.OperatorNamespace: operator.Namespace(),
+			tags.OperatorName:      operator.Name(),
+			tags.RedHatManaged:     tags.True,
+		}
+		if managedPolicies {
+			expectedTags[common.ManagedPolicies] = tags.True
+		}
+		if hostedCPPolicies {
+			expectedTags[tags.HypershiftPolicies] = tags.True
+		}
+		verifyTags(result, tagsMap(role.Tags), expectedTags)
+
+		policies := map[string]string{}
+		key := aws.GetOperatorPolicyKey(credRequest, hostedCPPolicies, sharedVpcRoleArn != "")
+		if managedPolicies {
+			arn, err := aws.GetManagedPolicyARN(v.OperatorRolePolicies, key)
+			if err != nil {
+				return nil, err
+			}
+			policies[arn] = ""
+		} else {
+			arn := aws.GetOperatorPolicyARN(v.Partition, v.AccountID, prefix, operator.Namespace(),
+				operator.Name(), path)
+			policies[arn] = aws.InterpolatePolicyDocument(v.Partition,
+				aws.GetPolicyDetails(v.OperatorRolePolicies, key),
+				map[string]string{
+					"shared_vpc_role_arn": sharedVpcRoleArn,
+				})
+		}
+		err = v.verifyPolicies(result, policies)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// getRole returns the role with the given name, or nil if it doesn't exist.
+func (v *Verifier) getRole(name string) (*iamtypes.Role, error) {
+	role, err := v.AWSClient.GetRoleByName(name)
+	if err != nil {
+		if awserr.IsNoSuchEntityException(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to get role '%s': %v", name, err)
+	}
+	return &role, nil
+}
+
+func (v *Verifier) verifyTrustPolicy(result *RoleResult, role *iamtypes.Role, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	actual, err := url.QueryUnescape(awsutil.ToString(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return fmt.Errorf("Failed to decode the trust policy of role '%s': %v", result.Name, err)
+	}
+	findings, err := CompareTrustPolicies(expected, actual)
+	if err != nil {
+		return fmt.Errorf("Failed to compare the trust policy of role '%s': %v", result.Name, err)
+	}
+	for _, finding := range findings {
+		result.add(finding)
+	}
+	return nil
+}
+
+// verifyPolicies verifies the policies attached to the role. The keys of the map are the ARNs of the
+// expected policies, and the values their documents, or an empty string if the document shouldn't
+// be compared.
+func (v *Verifier) verifyPolicies(result *RoleResult, policies map[string]string) error {
+	attached, err := v.AWSClient.ListAttachedRolePolicies(result.Name)
+	if err != nil {
+		return fmt.Errorf("Failed to list the policies attached to role '%s': %v", result.Name, err)
+	}
+	isAttached := map[string]bool{}
+	for _, arn := range attached {
+		isAttached[arn] = true
+		if _, ok := policies[arn]; !ok {
+			result.add(Finding{Kind: ExtraPolicy, Policy: arn})
+		}
+	}
+
+	for _, arn := range sortedKeys(policies) {
+		if !isAttached[arn] {
+			result.add(Finding{Kind: MissingPolicy, Policy: arn})
+			continue
+		}
+		expected := policies[arn]
+		if expected == "" {
+			continue
+		}
+		policy, err := v.AWSClient.IsPolicyExists(arn)
+		if err != nil {
+			return fmt.Errorf("Failed to get policy '%s': %v", arn, err)
+		}
+		if policy != nil && policy.Policy != nil {
+			verifyVersionTag(result, arn, policy.Policy.Tags, v.Version)
+		}
+
+		actual, err := v.AWSClient.GetDefaultPolicyDocument(arn)
+		if err != nil {
+			return fmt.Errorf("Failed to get the document of policy '%s': %v", arn, err)
+		}
+		missing, extra, err := ComparePermissions(expected, actual)
+		if err != nil {
+			return fmt.Errorf("Failed to compare the document of policy '%s': %v", arn, err)
+		}
+		for _, permission := range missing {
+			result.add(Finding{Kind: MissingPermission, Policy: arn, Expected: permission.String()})
+		}
+		for _, permission := range extra {
+			result.add(Finding{Kind: ExtraPermission, Policy: arn, Actual: permission.String()})
+		}
+	}
+	return nil
+}
+
+func verifyTags(result *RoleResult, actual map[string]string, expected map[string]string) {
+	for _, key := range sortedKeys(expected) {
+		value, ok := actual[key]
+		if ok && value == expected[key] {
+			continue
+		}
+		finding := Finding{
+			Kind:     TagMismatch,
+			Expected: fmt.Sprintf("%s=%s", key, expected[key]),
+		}
+		if ok {
+			finding.Actual = fmt.Sprintf("%s=%s", key, value)
+		}
+		result.add(finding)
+	}
+}
+
+func verifyVersionTag(result *RoleResult, policy string, iamTags []iamtypes.Tag, version string) {
+	if version == "" {
+		return
+	}
+	compatible, err := common.HasCompatibleVersionTags(iamTags, version)
+	if err == nil && compatible {
+		return
+	}
+	result.add(Finding{
+		Kind:     StaleVersionTag,
+		Policy:   policy,
+		Expected: version,
+		Actual:   tagsMap(iamTags)[common.OpenShiftVersion],
+	})
+}
+
+func tagsMap(iamTags []iamtypes.Tag) map[string]string {
+	result := map[string]string{}
+	for _, tag := range iamTags {
+		result[awsutil.ToString(tag.Key)] = awsutil.ToString(tag.Value)
+	}
+	return result
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
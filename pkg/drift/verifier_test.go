@@ -0,0 +1,193 @@
+package drift
+
+import (
+	"fmt"
+	"net/url"
+
+	"go.uber.org/mock/gomock"
+
+	awsutil "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	mock "github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+var _ = Describe("Verifier", func() {
+	const (
+		accountID   = "123456789012"
+		jumpAccount = "710019948333"
+		prefix      = "test"
+		version     = "4.16"
+	)
+
+	var (
+		awsClient *mock.MockClient
+		verifier  *Verifier
+		roles     map[string]iamtypes.Role
+		attached  map[string][]string
+		documents map[string]string
+	)
+
+	trustPolicy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "sts:AssumeRole",
+		"Principal": {"AWS": "arn:%{partition}:iam::%{aws_account_id}:role/RH-Managed-OpenShift-Installer"}}]}`
+	permissionPolicy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow",
+		"Action": ["ec2:DescribeInstances", "ec2:RunInstances"], "Resource": "*"}]}`
+
+	addRole := func(file string, roleVersion string) {
+		name := fmt.Sprintf("%s-%s-Role", prefix, mock.AccountRoles[file].Name)
+		trust := mock.InterpolatePolicyDocument("aws", trustPolicy, map[string]string{
+			"partition":      "aws",
+			"aws_account_id": jumpAccount,
+		})
+		roles[name] = iamtypes.Role{
+			Arn:                      awsutil.String(fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, name)),
+			Path:                     awsutil.String("/"),
+			AssumeRolePolicyDocument: awsutil.String(url.QueryEscape(trust)),
+			Tags: []iamtypes.Tag{
+				{Key: awsutil.String(tags.RedHatManaged), Value: awsutil.String(tags.True)},
+				{Key: awsutil.String(tags.RoleType), Value: awsutil.String(file)},
+				{Key: awsutil.String(tags.RolePrefix), Value: awsutil.String(prefix)},
+				{Key: awsutil.String(common.OpenShiftVersion), Value: awsutil.String(roleVersion)},
+			},
+		}
+		policyARN := fmt.Sprintf("arn:aws:iam::%s:policy/%s-Policy", accountID, name)
+		attached[name] = []string{policyARN}
+		documents[policyARN] = permissionPolicy
+	}
+
+	BeforeEach(func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		awsClient = mock.NewMockClient(mockCtrl)
+		roles = map[string]iamtypes.Role{}
+		attached = map[string][]string{}
+		documents = map[string]string{}
+
+		policies := map[string]*cmv1.AWSSTSPolicy{}
+		for file := range mock.AccountRoles {
+			for _, key := range []string{
+				fmt.Sprintf("sts_%s_trust_policy", file),
+				fmt.Sprintf("sts_%s_permission_policy", file),
+			} {
+				details := permissionPolicy
+				if key == fmt.Sprintf("sts_%s_trust_policy", file) {
+					details = trustPolicy
+				}
+				policy, err := cmv1.NewAWSSTSPolicy().ID(key).Details(details).Build()
+				Expect(err).NotTo(HaveOccurred())
+				policies[key] = policy
+			}
+		}
+		verifier = &Verifier{
+			AWSClient:           awsClient,
+			Partition:           "aws",
+			AccountID:           accountID,
+			JumpAccount:         jumpAccount,
+			AccountRolePolicies: policies,
+			Version:             version,
+		}
+
+		awsClient.EXPECT().GetRoleByName(gomock.Any()).DoAndReturn(func(name string) (iamtypes.Role, error) {
+			role, ok := roles[name]
+			if !ok {
+				return iamtypes.Role{}, &iamtypes.NoSuchEntityException{}
+			}
+			return role, nil
+		}).AnyTimes()
+		awsClient.EXPECT().ListAttachedRolePolicies(gomock.Any()).DoAndReturn(func(name string) ([]string, error) {
+			return attached[name], nil
+		}).AnyTimes()
+		awsClient.EXPECT().IsPolicyExists(gomock.Any()).DoAndReturn(func(arn string) (*iam.GetPolicyOutput, error) {
+			return &iam.GetPolicyOutput{
+				Policy: &iamtypes.Policy{
+					Arn: awsutil.String(arn),
+					Tags: []iamtypes.Tag{
+						{Key: awsutil.String(common.OpenShiftVersion), Value: awsutil.String(version)},
+					},
+				},
+			}, nil
+		}).AnyTimes()
+		awsClient.EXPECT().GetDefaultPolicyDocument(gomock.Any()).DoAndReturn(func(arn string) (string, error) {
+			return documents[arn], nil
+		}).AnyTimes()
+	})
+
+	It("Reports no drift for roles that match the policies", func() {
+		for file := range mock.AccountRoles {
+			addRole(file, version)
+		}
+		result, err := verifier.VerifyAccountRoles(prefix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Roles).To(HaveLen(4))
+		Expect(result.HasDrift()).To(BeFalse())
+	})
+
+	It("Fails when there are no roles with the prefix", func() {
+		_, err := verifier.VerifyAccountRoles(prefix)
+		Expect(err).To(MatchError("There are no account roles with prefix 'test'"))
+	})
+
+	It("Reports the roles that were changed by hand", func() {
+		for file := range mock.AccountRoles {
+			addRole(file, version)
+		}
+		delete(roles, "test-Support-Role")
+		addRole(mock.InstallerAccountRole, "4.12")
+		workerPolicy := attached["test-Worker-Role"][0]
+		documents[workerPolicy] = `{"Statement": [{"Effect": "Allow", "Action": "ec2:DescribeInstances",
+			"Resource": "*"}]}`
+		attached["test-ControlPlane-Role"] = append(attached["test-ControlPlane-Role"],
+			"arn:aws:iam::aws:policy/AdministratorAccess")
+
+		result, err := verifier.VerifyAccountRoles(prefix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.HasDrift()).To(BeTrue())
+		Expect(result.Count()).To(Equal(4))
+
+		findings := map[string][]Finding{}
+		for _, role := range result.Roles {
+			findings[role.Name] = role.Findings
+		}
+		Expect(findings["test-Support-Role"]).To(ConsistOf(Finding{Kind: MissingRole}))
+		Expect(findings["test-Installer-Role"]).To(ConsistOf(Finding{
+			Kind:     StaleVersionTag,
+			Expected: version,
+			Actual:   "4.12",
+		}))
+		Expect(findings["test-Worker-Role"]).To(ConsistOf(Finding{
+			Kind:     MissingPermission,
+			Policy:   workerPolicy,
+			Expected: "Allow ec2:RunInstances on *",
+		}))
+		Expect(findings["test-ControlPlane-Role"]).To(ConsistOf(Finding{
+			Kind:   ExtraPolicy,
+			Policy: "arn:aws:iam::aws:policy/AdministratorAccess",
+		}))
+	})
+
+	It("Reports wrong tags", func() {
+		for file := range mock.AccountRoles {
+			addRole(file, version)
+		}
+		role := roles["test-Worker-Role"]
+		role.Tags = role.Tags[1:]
+		roles["test-Worker-Role"] = role
+
+		result, err := verifier.VerifyAccountRoles(prefix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Count()).To(Equal(1))
+		for _, role := range result.Roles {
+			if role.Name == "test-Worker-Role" {
+				Expect(role.Findings).To(ConsistOf(Finding{
+					Kind:     TagMismatch,
+					Expected: "red-hat-managed=true",
+				}))
+			}
+		}
+	})
+})
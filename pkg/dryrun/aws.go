@@ -0,0 +1,221 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/openshift/rosa/pkg/aws/commandbuilder"
+)
+
+// Prefixes of the names of the AWS operations that don't change resources. Operations that start
+// with any other prefix are recorded instead of being sent.
+var readOperationPrefixes = []string{
+	"Assume",
+	"Describe",
+	"Get",
+	"Head",
+	"List",
+	"Lookup",
+	"Search",
+	"Simulate",
+}
+
+// Names of the AWS services in the AWS CLI, when they aren't the lower case service identifier.
+var cliServiceNames = map[string]string{
+	"S3":              string(commandbuilder.S3Api),
+	"Secrets Manager": string(commandbuilder.SM),
+	"Service Quotas":  "service-quotas",
+}
+
+type skipKey struct{}
+
+// AddMiddleware adds to the given stack the middleware that records the AWS API calls that change
+// resources when the dry-run mode is enabled. It is meant to be used as an API option of the
+// AWS configuration.
+func AddMiddleware(stack *middleware.Stack) error {
+	err := stack.Initialize.Add(middleware.InitializeMiddlewareFunc("DryRunRecorder", recordOperation),
+		middleware.After)
+	if err != nil {
+		return err
+	}
+	return stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("DryRunSkipper", skipOperation),
+		middleware.After)
+}
+
+func recordOperation(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+	middleware.InitializeOutput, middleware.Metadata, error) {
+	operation := awsmiddleware.GetOperationName(ctx)
+	if !enabled || isReadOperation(operation) {
+		return next.HandleInitialize(ctx, in)
+	}
+	record(AWSCommand(awsmiddleware.GetServiceID(ctx), operation, in.Parameters))
+	ctx = middleware.WithStackValue(ctx, skipKey{}, true)
+	return next.HandleInitialize(ctx, in)
+}
+
+// skipOperation replaces the response of the recorded operations with an empty successful
+// response, so that the request is never sent and the output of the operation is empty.
+func skipOperation(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
+	middleware.DeserializeOutput, middleware.Metadata, error) {
+	if skip, _ := middleware.GetStackValue(ctx, skipKey{}).(bool); !skip {
+		return next.HandleDeserialize(ctx, in)
+	}
+	return middleware.DeserializeOutput{
+		RawResponse: &smithyhttp.Response{
+			Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("")),
+			},
+		},
+	}, middleware.Metadata{}, nil
+}
+
+func isReadOperation(operation string) bool {
+	for _, prefix := range readOperationPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AWSCommand returns the AWS CLI command equivalent to calling the given operation with the given
+// input.
+func AWSCommand(serviceID string, operation string, input interface{}) string {
+	service, ok := cliServiceNames[serviceID]
+	if !ok {
+		service = strings.ToLower(strings.ReplaceAll(serviceID, " ", ""))
+	}
+	builder := (&commandbuilder.CommandBuilder{}).
+		SetService(commandbuilder.Service(service)).
+		SetCommand(commandbuilder.Command(kebabCase(operation)))
+
+	value := reflect.Indirect(reflect.ValueOf(input))
+	if value.Kind() != reflect.Struct {
+		return builder.Build()
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		param := commandbuilder.Param(kebabCase(field.Name))
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		switch fieldValue.Kind() {
+		case reflect.String:
+			builder.AddParam(param, quote(fieldValue.String()))
+		case reflect.Bool:
+			if fieldValue.Bool() {
+				builder.AddParamNoValue(param)
+			} else {
+				builder.AddParamNoValue("no-" + param)
+			}
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			builder.AddParam(param, fmt.Sprint(fieldValue.Int()))
+		case reflect.Slice, reflect.Map, reflect.Struct:
+			if fieldValue.Kind() != reflect.Struct && fieldValue.Len() == 0 {
+				continue
+			}
+			if field.Name == "Tags" {
+				if tags, ok := tagsMap(fieldValue); ok {
+					builder.AddTags(tags)
+					continue
+				}
+			}
+			data, err := json.Marshal(fieldValue.Interface())
+			if err != nil {
+				continue
+			}
+			builder.AddParam(param, quote(string(data)))
+		}
+	}
+	return builder.Build()
+}
+
+// tagsMap converts a list of AWS tags, which are structs with 'Key' and 'Value' string fields, to
+// a map.
+func tagsMap(value reflect.Value) (map[string]string, bool) {
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+	result := map[string]string{}
+	for i := 0; i < value.Len(); i++ {
+		tag := reflect.Indirect(value.Index(i))
+		if tag.Kind() != reflect.Struct {
+			return nil, false
+		}
+		key := reflect.Indirect(tag.FieldByName("Key"))
+		val := reflect.Indirect(tag.FieldByName("Value"))
+		if key.Kind() != reflect.String || val.Kind() != reflect.String {
+			return nil, false
+		}
+		result[key.String()] = val.String()
+	}
+	return result, true
+}
+
+// kebabCase converts names like 'CreateOpenIDConnectProvider' to the AWS CLI format, like
+// 'create-open-id-connect-provider'.
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var result strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previousLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if previousLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				result.WriteRune('-')
+			}
+		}
+		result.WriteRune(unicode.ToLower(r))
+	}
+	return result.String()
+}
+
+// quote wraps the value in single quotes when it contains characters that the shell would
+// interpret.
+func quote(value string) string {
+	if value == "" {
+		return "''"
+	}
+	safe := strings.IndexFunc(value, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_./:=@,+", r))
+	}) == -1
+	if safe {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
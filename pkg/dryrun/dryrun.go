@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--dry-run' command line option. When it is
+// enabled the OCM and AWS clients don't send the requests that change resources, they record them
+// so that they can be reported as the plan of the command.
+
+package dryrun
+
+import (
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+const FlagName = "dry-run"
+
+// AddFlag adds the dry-run flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&enabled,
+		FlagName,
+		false,
+		"Show the OCM requests and AWS API calls that would change resources instead of sending them.",
+	)
+}
+
+// Enabled returns a boolean flag that indicates if the dry-run mode is enabled.
+func Enabled() bool {
+	return enabled
+}
+
+func SetEnabled(dryRunEnabled bool) {
+	enabled = dryRunEnabled
+}
+
+// enabled is a boolean flag that indicates that the dry-run mode is enabled.
+var enabled bool
+
+// Operations returns the requests that were recorded instead of being sent, in the order they
+// would have been sent.
+func Operations() []string {
+	lock.Lock()
+	defer lock.Unlock()
+	return append([]string(nil), operations...)
+}
+
+// Reset discards the recorded requests.
+func Reset() {
+	lock.Lock()
+	defer lock.Unlock()
+	operations = nil
+}
+
+func record(operation string) {
+	lock.Lock()
+	defer lock.Unlock()
+	operations = append(operations, operation)
+}
+
+var (
+	lock       sync.Mutex
+	operations []string
+)
@@ -0,0 +1,142 @@
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+type httpClientFunc func(*http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+var _ = Describe("Dry run", func() {
+	var sent []string
+
+	BeforeEach(func() {
+		sent = nil
+		SetEnabled(true)
+		DeferCleanup(SetEnabled, false)
+		DeferCleanup(Reset)
+	})
+
+	Context("OCM", func() {
+		var transport http.RoundTripper
+
+		BeforeEach(func() {
+			transport = TransportWrapper(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				sent = append(sent, request.Method+" "+request.URL.Path)
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+			}))
+		})
+
+		It("Sends the requests that only read data and the token requests", func() {
+			_, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters", nil))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = transport.RoundTrip(httptest.NewRequest(http.MethodPost, "/auth/realms/redhat-external/token", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(Equal([]string{
+				"GET /api/clusters_mgmt/v1/clusters",
+				"POST /auth/realms/redhat-external/token",
+			}))
+			Expect(Operations()).To(BeEmpty())
+		})
+
+		It("Records the requests that change resources", func() {
+			response, err := transport.RoundTrip(httptest.NewRequest(http.MethodPatch,
+				"/api/clusters_mgmt/v1/clusters/123/machine_pools/workers", strings.NewReader(`{"replicas":3}`)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			body, err := io.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"replicas":3}`))
+
+			response, err = transport.RoundTrip(httptest.NewRequest(http.MethodDelete,
+				"/api/clusters_mgmt/v1/clusters/123/identity_providers/456", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+			Expect(sent).To(BeEmpty())
+			Expect(Operations()).To(Equal([]string{
+				"PATCH /api/clusters_mgmt/v1/clusters/123/machine_pools/workers\n{\n  \"replicas\": 3\n}",
+				"DELETE /api/clusters_mgmt/v1/clusters/123/identity_providers/456",
+			}))
+		})
+
+		It("Sends every request when disabled", func() {
+			SetEnabled(false)
+			_, err := transport.RoundTrip(httptest.NewRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(HaveLen(1))
+			Expect(Operations()).To(BeEmpty())
+		})
+	})
+
+	Context("AWS", func() {
+		var client *iam.Client
+
+		BeforeEach(func() {
+			client = iam.New(iam.Options{
+				Region:      "us-east-1",
+				Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+				APIOptions:  []func(*middleware.Stack) error{AddMiddleware},
+				HTTPClient: httpClientFunc(func(request *http.Request) (*http.Response, error) {
+					sent = append(sent, request.URL.Host)
+					return nil, fmt.Errorf("unreachable")
+				}),
+				RetryMaxAttempts: 1,
+			})
+		})
+
+		It("Records the calls that change resources as AWS CLI commands", func() {
+			_, err := client.CreateRole(context.Background(), &iam.CreateRoleInput{
+				RoleName:                 aws.String("ManagedOpenShift-Installer-Role"),
+				AssumeRolePolicyDocument: aws.String(`{"Version": "2012-10-17"}`),
+				MaxSessionDuration:       aws.Int32(3600),
+				Tags: []iamtypes.Tag{
+					{Key: aws.String("rosa_role_type"), Value: aws.String("installer")},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent).To(BeEmpty())
+			Expect(Operations()).To(Equal([]string{"aws iam create-role \\\n" +
+				"\t--assume-role-policy-document '{\"Version\": \"2012-10-17\"}' \\\n" +
+				"\t--max-session-duration 3600 \\\n" +
+				"\t--role-name ManagedOpenShift-Installer-Role \\\n" +
+				"\t--tags Key=rosa_role_type,Value=installer",
+			}))
+		})
+
+		It("Sends the calls that only read data", func() {
+			_, err := client.GetRole(context.Background(), &iam.GetRoleInput{RoleName: aws.String("role")})
+			Expect(err).To(HaveOccurred())
+			Expect(sent).To(HaveLen(1))
+			Expect(Operations()).To(BeEmpty())
+		})
+	})
+
+	It("Converts operation names to the AWS CLI format", func() {
+		Expect(kebabCase("CreateOpenIDConnectProvider")).To(Equal("create-open-id-connect-provider"))
+		Expect(kebabCase("AttachRolePolicy")).To(Equal("attach-role-policy"))
+		Expect(kebabCase("CreateVpc")).To(Equal("create-vpc"))
+	})
+})
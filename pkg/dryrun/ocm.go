@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// TransportWrapper returns a round tripper that sends the requests that only read data to the next
+// one, and records the rest when the dry-run mode is enabled. Instead of the real response, the
+// recorded requests get a successful response that echoes their body.
+func TransportWrapper(next http.RoundTripper) http.RoundTripper {
+	return &roundTripper{next: next}
+}
+
+type roundTripper struct {
+	next http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Requests to get tokens don't go to the API, so they are always sent:
+	if !enabled || !strings.HasPrefix(request.URL.Path, "/api/") {
+		return t.next.RoundTrip(request)
+	}
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.next.RoundTrip(request)
+	}
+
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	operation := fmt.Sprintf("%s %s", request.Method, request.URL.RequestURI())
+	if len(body) > 0 {
+		var indented bytes.Buffer
+		if json.Indent(&indented, body, "", "  ") == nil {
+			body = indented.Bytes()
+		}
+		operation += "\n" + string(body)
+	}
+	record(operation)
+
+	status := http.StatusOK
+	switch request.Method {
+	case http.MethodPost:
+		status = http.StatusCreated
+	case http.MethodDelete:
+		status = http.StatusNoContent
+	}
+	if len(body) == 0 && status != http.StatusNoContent {
+		body = []byte("{}")
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      request.Proto,
+		ProtoMajor: request.ProtoMajor,
+		ProtoMinor: request.ProtoMinor,
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package fleet
+
+import (
+	"fmt"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// fakeClient simulates the clusters of OCM. The upgrades scheduled with it are completed calling
+// finish.
+type fakeClient struct {
+	clusters          map[string]*cmv1.Cluster
+	availableUpgrades map[string][]string
+	policies          map[string]*cmv1.UpgradePolicy
+	policyStates      map[string]cmv1.UpgradePolicyStateValue
+	gates             map[string][]*cmv1.VersionGate
+	acked             map[string][]string
+	reasons           map[string][]*cmv1.LimitedSupportReason
+	scheduleErr       error
+	scheduled         []string
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		clusters:          map[string]*cmv1.Cluster{},
+		availableUpgrades: map[string][]string{},
+		policies:          map[string]*cmv1.UpgradePolicy{},
+		policyStates:      map[string]cmv1.UpgradePolicyStateValue{},
+		gates:             map[string][]*cmv1.VersionGate{},
+		acked:             map[string][]string{},
+		reasons:           map[string][]*cmv1.LimitedSupportReason{},
+	}
+}
+
+func buildCluster(id string, name string, version string, state cmv1.ClusterState, hypershift bool,
+	availableUpgrades ...string) *cmv1.Cluster {
+	cluster, err := cmv1.NewCluster().
+		ID(id).
+		Name(name).
+		State(state).
+		Hypershift(cmv1.NewHypershift().Enabled(hypershift)).
+		Version(cmv1.NewVersion().
+			ID("openshift-v" + version).
+			RawID(version).
+			ChannelGroup("stable").
+			AvailableUpgrades(availableUpgrades...)).
+		Build()
+	Expect(err).NotTo(HaveOccurred())
+	return cluster
+}
+
+func (f *fakeClient) add(cluster *cmv1.Cluster) {
+	f.clusters[cluster.ID()] = cluster
+	if !cluster.Hypershift().Enabled() {
+		f.availableUpgrades[cluster.Version().ID()] = cluster.Version().AvailableUpgrades()
+	}
+}
+
+func (f *fakeClient) all() []*cmv1.Cluster {
+	result := []*cmv1.Cluster{}
+	for _, cluster := range f.clusters {
+		result = append(result, cluster)
+	}
+	return result
+}
+
+// finish completes the scheduled upgrade of the cluster.
+func (f *fakeClient) finish(id string) {
+	policy := f.policies[id]
+	cluster := f.clusters[id]
+	f.clusters[id] = buildCluster(id, cluster.Name(), policy.Version(), cmv1.ClusterStateReady,
+		cluster.Hypershift().Enabled())
+	delete(f.policies, id)
+	delete(f.policyStates, id)
+}
+
+func (f *fakeClient) GetClusterByID(clusterKey string, _ *aws.Creator) (*cmv1.Cluster, error) {
+	cluster, ok := f.clusters[clusterKey]
+	if !ok {
+		return nil, fmt.Errorf("There is no cluster with identifier '%s'", clusterKey)
+	}
+	return cluster, nil
+}
+
+func (f *fakeClient) GetAvailableUpgrades(versionID string) ([]string, error) {
+	return f.availableUpgrades[versionID], nil
+}
+
+func (f *fakeClient) GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState,
+	error) {
+	policy, ok := f.policies[clusterID]
+	if !ok {
+		return nil, nil, nil
+	}
+	state, err := cmv1.NewUpgradePolicyState().Value(f.policyStates[clusterID]).Build()
+	return policy, state, err
+}
+
+func (f *fakeClient) GetControlPlaneScheduledUpgrade(clusterID string) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	policy, ok := f.policies[clusterID]
+	if !ok {
+		return nil, nil
+	}
+	return cmv1.NewControlPlaneUpgradePolicy().
+		Version(policy.Version()).
+		State(cmv1.NewUpgradePolicyState().Value(f.policyStates[clusterID])).
+		Build()
+}
+
+func (f *fakeClient) GetMissingGateAgreementsClassic(clusterID string,
+	_ *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error) {
+	return f.missingGates(clusterID), nil
+}
+
+func (f *fakeClient) GetMissingGateAgreementsHypershift(clusterID string,
+	_ *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error) {
+	return f.missingGates(clusterID), nil
+}
+
+func (f *fakeClient) missingGates(clusterID string) []*cmv1.VersionGate {
+	result := []*cmv1.VersionGate{}
+	for _, gate := range f.gates[clusterID] {
+		acked := false
+		for _, id := range f.acked[clusterID] {
+			acked = acked || id == gate.ID()
+		}
+		if !acked {
+			result = append(result, gate)
+		}
+	}
+	return result
+}
+
+func (f *fakeClient) AckVersionGate(clusterID string, gateID string) error {
+	f.acked[clusterID] = append(f.acked[clusterID], gateID)
+	return nil
+}
+
+func (f *fakeClient) ScheduleUpgrade(clusterID string, upgradePolicy *cmv1.UpgradePolicy) error {
+	if f.scheduleErr != nil {
+		return f.scheduleErr
+	}
+	f.scheduled = append(f.scheduled, clusterID)
+	f.policies[clusterID] = upgradePolicy
+	f.policyStates[clusterID] = cmv1.UpgradePolicyStateValueScheduled
+	return nil
+}
+
+func (f *fakeClient) ScheduleHypershiftControlPlaneUpgrade(clusterID string,
+	upgradePolicy *cmv1.ControlPlaneUpgradePolicy) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	policy, err := cmv1.NewUpgradePolicy().Version(upgradePolicy.Version()).Build()
+	if err != nil {
+		return nil, err
+	}
+	return upgradePolicy, f.ScheduleUpgrade(clusterID, policy)
+}
+
+func (f *fakeClient) GetLimitedSupportReasons(clusterID string) ([]*cmv1.LimitedSupportReason, error) {
+	return f.reasons[clusterID], nil
+}
+
+func limitedSupportReason(id string, summary string) *cmv1.LimitedSupportReason {
+	reason, err := cmv1.NewLimitedSupportReason().ID(id).Summary(summary).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return reason
+}
+
+func versionGate(id string, stsOnly bool) *cmv1.VersionGate {
+	gate, err := cmv1.NewVersionGate().ID(id).Description("Gate " + id).STSOnly(stsOnly).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return gate
+}
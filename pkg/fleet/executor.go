@@ -0,0 +1,363 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+const (
+	// ScheduleDelay is how long after being scheduled the upgrades start, the same default as the
+	// upgrades scheduled with 'rosa upgrade cluster'.
+	ScheduleDelay = 10 * time.Minute
+
+	DefaultPollInterval = time.Minute
+	DefaultTimeout      = 4 * time.Hour
+)
+
+// Executor runs the upgrades of a plan, one wave after the other. It halts the rollout when the
+// upgrade of a cluster can't be scheduled, fails, doesn't finish in time, or puts the cluster in
+// limited support.
+type Executor struct {
+	Client   Client
+	Reporter *reporter.Object
+
+	// Save is called every time that the plan changes, to record the progress.
+	Save func(*Plan) error
+
+	PollInterval time.Duration
+	// Timeout is how long the upgrade of a cluster can take since it was scheduled.
+	Timeout time.Duration
+
+	Now   func() time.Time
+	Sleep func(time.Duration)
+}
+
+// NewExecutor creates an executor with the default poll interval and timeout.
+func NewExecutor(client Client, r *reporter.Object, save func(*Plan) error) *Executor {
+	return &Executor{
+		Client:       client,
+		Reporter:     r,
+		Save:         save,
+		PollInterval: DefaultPollInterval,
+		Timeout:      DefaultTimeout,
+		Now:          time.Now,
+		Sleep:        time.Sleep,
+	}
+}
+
+// Run upgrades the clusters of the plan that aren't upgraded yet. It returns an error if the
+// rollout halts.
+func (e *Executor) Run(plan *Plan) error {
+	if !plan.Approved {
+		return fmt.Errorf("The upgrade plan isn't approved")
+	}
+	if plan.Halted {
+		return fmt.Errorf("The upgrade plan is halted: %s", plan.HaltReason)
+	}
+	for _, wave := range plan.Waves {
+		if wave.Done() {
+			continue
+		}
+		e.Reporter.Infof("Upgrading the %d clusters of wave '%s' to version '%s'",
+			len(wave.Clusters), wave.Name, plan.Version)
+		err := e.runWave(plan, wave)
+		if err != nil {
+			return err
+		}
+		if plan.Halted {
+			return fmt.Errorf("Halted the upgrade of the clusters: %s", plan.HaltReason)
+		}
+		e.Reporter.Infof("Finished wave '%s'", wave.Name)
+	}
+	return nil
+}
+
+func (e *Executor) runWave(plan *Plan, wave *Wave) error {
+	for {
+		active := 0
+		for _, cluster := range wave.Clusters {
+			if cluster.Status == StatusScheduled {
+				active++
+			}
+		}
+		for _, cluster := range wave.Clusters {
+			if active >= plan.MaxParallel {
+				break
+			}
+			if cluster.Status != StatusPending {
+				continue
+			}
+			e.schedule(plan, cluster)
+			if cluster.Status == StatusScheduled {
+				active++
+			}
+			err := e.update(plan, cluster)
+			if err != nil || plan.Halted {
+				return err
+			}
+		}
+
+		for _, cluster := range wave.Clusters {
+			if cluster.Status != StatusScheduled {
+				continue
+			}
+			changed := e.check(plan, cluster)
+			if !changed {
+				continue
+			}
+			err := e.update(plan, cluster)
+			if err != nil || plan.Halted {
+				return err
+			}
+		}
+
+		if wave.Done() {
+			return nil
+		}
+		e.Sleep(e.PollInterval)
+	}
+}
+
+// update saves the plan after a change of the status of the cluster, and halts the rollout if
+// the upgrade of the cluster failed.
+func (e *Executor) update(plan *Plan, cluster *ClusterUpgrade) error {
+	switch cluster.Status {
+	case StatusFailed:
+		plan.Halted = true
+		plan.HaltReason = fmt.Sprintf("Upgrade of cluster '%s' failed: %s", cluster.Name, cluster.Message)
+		e.Reporter.Errorf("Failed to upgrade cluster '%s': %s", cluster.Name, cluster.Message)
+	case StatusScheduled:
+		e.Reporter.Infof("Scheduled upgrade of cluster '%s' to version '%s'", cluster.Name, plan.Version)
+	case StatusCompleted:
+		e.Reporter.Infof("Upgraded cluster '%s' to version '%s'", cluster.Name, plan.Version)
+	}
+	err := e.Save(plan)
+	if err != nil {
+		return fmt.Errorf("Failed to save the upgrade plan: %v", err)
+	}
+	return nil
+}
+
+func (e *Executor) fail(cluster *ClusterUpgrade, format string, args ...interface{}) {
+	now := e.Now()
+	cluster.Status = StatusFailed
+	cluster.Message = fmt.Sprintf(format, args...)
+	cluster.FinishedAt = &now
+}
+
+// schedule creates the upgrade policy of the cluster, after acknowledging the version gates that
+// were approved with the plan. The upgrade has to start in the maintenance window of the cluster,
+// unless the window is overridden.
+func (e *Executor) schedule(plan *Plan, cluster *ClusterUpgrade) {
+	current, err := e.Client.GetClusterByID(cluster.ID, nil)
+	if err != nil {
+		e.fail(cluster, "%v", err)
+		return
+	}
+	if current.Version().RawID() == plan.Version {
+		now := e.Now()
+		cluster.Status = StatusCompleted
+		cluster.Message = "Cluster was already upgraded"
+		cluster.FinishedAt = &now
+		return
+	}
+	if current.State() != cmv1.ClusterStateReady {
+		e.fail(cluster, "Cluster is in '%s' state", current.State())
+		return
+	}
+
+	reasons, err := e.Client.GetLimitedSupportReasons(cluster.ID)
+	if err != nil {
+		e.fail(cluster, "Failed to get the limited support reasons: %v", err)
+		return
+	}
+	cluster.LimitedSupportReasons = []string{}
+	for _, reason := range reasons {
+		cluster.LimitedSupportReasons = append(cluster.LimitedSupportReasons, reason.ID())
+	}
+
+	scheduled, err := scheduledVersion(e.Client, cluster.ID, cluster.Hypershift)
+	if err != nil {
+		e.fail(cluster, "Failed to get the scheduled upgrade: %v", err)
+		return
+	}
+	switch scheduled {
+	case "":
+	case plan.Version:
+		// The upgrade was scheduled by a previous run that was interrupted before saving the plan:
+		e.scheduled(cluster, "Upgrade was already scheduled")
+		return
+	default:
+		e.fail(cluster, "Cluster has an upgrade to version '%s' scheduled", scheduled)
+		return
+	}
+
+	nextRun := e.Now().Add(ScheduleDelay)
+	err = maintenance.CheckTime(e.Reporter, current, fmt.Sprintf("Upgrade to version '%s'", plan.Version), nextRun)
+	if err != nil {
+		e.fail(cluster, "%v", err)
+		return
+	}
+
+	gates, err := missingGates(e.Client, cluster.ID, cluster.Hypershift, plan.Version)
+	if err != nil {
+		e.fail(cluster, "Failed to get the version gates: %v", err)
+		return
+	}
+	approved := map[string]bool{}
+	for _, gate := range cluster.Gates {
+		approved[gate.ID] = true
+	}
+	for _, gate := range gates {
+		if !gate.STSOnly() && !approved[gate.ID()] {
+			e.fail(cluster, "Version gate '%s' wasn't acknowledged when the plan was approved: %s",
+				gate.ID(), gate.Description())
+			return
+		}
+	}
+	for _, gate := range gates {
+		err = e.Client.AckVersionGate(cluster.ID, gate.ID())
+		if err != nil {
+			e.fail(cluster, "Failed to acknowledge version gate '%s': %v", gate.ID(), err)
+			return
+		}
+	}
+
+	if cluster.Hypershift {
+		var policy *cmv1.ControlPlaneUpgradePolicy
+		policy, err = cmv1.NewControlPlaneUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeControlPlane).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version(plan.Version).
+			NextRun(nextRun).
+			Build()
+		if err == nil {
+			_, err = e.Client.ScheduleHypershiftControlPlaneUpgrade(cluster.ID, policy)
+		}
+	} else {
+		var policy *cmv1.UpgradePolicy
+		policy, err = cmv1.NewUpgradePolicy().
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version(plan.Version).
+			NextRun(nextRun).
+			Build()
+		if err == nil {
+			err = e.Client.ScheduleUpgrade(cluster.ID, policy)
+		}
+	}
+	if err != nil {
+		e.fail(cluster, "Failed to schedule the upgrade: %v", err)
+		return
+	}
+	e.scheduled(cluster, fmt.Sprintf("Upgrade starts at %s", nextRun.UTC().Format("2006-01-02 15:04 MST")))
+}
+
+func (e *Executor) scheduled(cluster *ClusterUpgrade, message string) {
+	now := e.Now()
+	cluster.Status = StatusScheduled
+	cluster.Message = message
+	cluster.ScheduledAt = &now
+}
+
+// check updates the status of a scheduled upgrade, and returns if it changed. Failures to get the
+// status are reported and retried later, they don't fail the upgrade.
+func (e *Executor) check(plan *Plan, cluster *ClusterUpgrade) bool {
+	state, description, pending, err := e.policyState(cluster, plan.Version)
+	if err != nil {
+		e.Reporter.Warnf("Failed to get the upgrade status of cluster '%s': %v", cluster.Name, err)
+		return false
+	}
+	if pending && state != cmv1.UpgradePolicyStateValueCompleted {
+		if state == cmv1.UpgradePolicyStateValueFailed || state == cmv1.UpgradePolicyStateValueCancelled {
+			e.fail(cluster, "Upgrade policy is in '%s' state: %s", state, description)
+			return true
+		}
+		if cluster.ScheduledAt != nil && e.Now().Sub(*cluster.ScheduledAt) > e.Timeout {
+			e.fail(cluster, "Upgrade didn't finish in %s, it is in '%s' state", e.Timeout, state)
+			return true
+		}
+		message := fmt.Sprintf("Upgrade is in '%s' state", state)
+		if message == cluster.Message {
+			return false
+		}
+		cluster.Message = message
+		e.Reporter.Debugf("Upgrade of cluster '%s' is in '%s' state", cluster.Name, state)
+		return true
+	}
+
+	// The upgrade policies are removed once the upgrade finishes, so the version of the cluster
+	// tells if it succeeded:
+	current, err := e.Client.GetClusterByID(cluster.ID, nil)
+	if err != nil {
+		e.Reporter.Warnf("Failed to get cluster '%s': %v", cluster.Name, err)
+		return false
+	}
+	if current.Version().RawID() != plan.Version {
+		e.fail(cluster, "Upgrade policy was removed but the cluster is in version '%s'", current.Version().RawID())
+		return true
+	}
+	reasons, err := e.Client.GetLimitedSupportReasons(cluster.ID)
+	if err != nil {
+		e.Reporter.Warnf("Failed to get the limited support reasons of cluster '%s': %v", cluster.Name, err)
+		return false
+	}
+	known := map[string]bool{}
+	for _, id := range cluster.LimitedSupportReasons {
+		known[id] = true
+	}
+	summaries := []string{}
+	for _, reason := range reasons {
+		if !known[reason.ID()] {
+			summaries = append(summaries, reason.Summary())
+		}
+	}
+	if len(summaries) > 0 {
+		e.fail(cluster, "Cluster is in limited support after the upgrade: %s", strings.Join(summaries, "; "))
+		return true
+	}
+	now := e.Now()
+	cluster.Status = StatusCompleted
+	cluster.Message = ""
+	cluster.FinishedAt = &now
+	return true
+}
+
+// policyState returns the state of the upgrade policy of the cluster to the version, and false if
+// there is no such policy.
+func (e *Executor) policyState(cluster *ClusterUpgrade,
+	version string) (cmv1.UpgradePolicyStateValue, string, bool, error) {
+	if cluster.Hypershift {
+		policy, err := e.Client.GetControlPlaneScheduledUpgrade(cluster.ID)
+		if err != nil || policy == nil || policy.Version() != version {
+			return "", "", false, err
+		}
+		return policy.State().Value(), policy.State().Description(), true, nil
+	}
+	policy, state, err := e.Client.GetScheduledUpgrade(cluster.ID)
+	if err != nil || policy == nil || policy.Version() != version {
+		return "", "", false, err
+	}
+	return state.Value(), state.Description(), true, nil
+}
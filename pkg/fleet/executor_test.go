@@ -0,0 +1,192 @@
+package fleet
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/maintenance"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+var _ = Describe("Executor", func() {
+	var client *fakeClient
+	var executor *Executor
+	var plan *Plan
+	var now time.Time
+	var saves int
+	// onSleep simulates the progress of the upgrades while the executor waits:
+	var onSleep func()
+
+	BeforeEach(func() {
+		GinkgoT().Setenv(maintenance.PathEnv, filepath.Join(GinkgoT().TempDir(), "windows.json"))
+		client = newFakeClient()
+		for i := 1; i <= 5; i++ {
+			client.add(buildCluster(fmt.Sprintf("id-%d", i), fmt.Sprintf("dev-%d", i), "4.14.12",
+				cmv1.ClusterStateReady, i == 5, "4.15.3"))
+		}
+		now = time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+		saves = 0
+		onSleep = func() {
+			for id := range client.policies {
+				client.finish(id)
+			}
+		}
+		executor = NewExecutor(client, reporter.CreateReporter(), func(*Plan) error {
+			saves++
+			return nil
+		})
+		executor.Now = func() time.Time { return now }
+		executor.Sleep = func(duration time.Duration) {
+			now = now.Add(duration)
+			onSleep()
+		}
+		var err error
+		plan, err = NewPlan(client, client.all(),
+			Spec{Version: "4.15.3", Canary: 1, BatchSize: 2, MaxParallel: 1}, now)
+		Expect(err).NotTo(HaveOccurred())
+		plan.Approve(now)
+	})
+
+	It("Doesn't run plans that aren't approved", func() {
+		plan.Approved = false
+		Expect(executor.Run(plan)).To(MatchError(ContainSubstring("isn't approved")))
+	})
+
+	It("Upgrades the clusters wave after wave", func() {
+		order := []string{}
+		onSleep = func() {
+			for id := range client.policies {
+				order = append(order, id)
+				client.finish(id)
+			}
+		}
+		Expect(executor.Run(plan)).To(Succeed())
+		Expect(plan.Count(StatusCompleted)).To(Equal(5))
+		Expect(plan.Done()).To(BeTrue())
+		// With one parallel upgrade the clusters are upgraded one at a time, in the order of the plan:
+		Expect(order).To(Equal([]string{"id-1", "id-2", "id-3", "id-4", "id-5"}))
+		Expect(client.clusters["id-5"].Version().RawID()).To(Equal("4.15.3"))
+		Expect(saves).To(BeNumerically(">=", 10))
+	})
+
+	It("Upgrades the clusters of a wave in parallel", func() {
+		plan.MaxParallel = 2
+		scheduledAtOnce := 0
+		onSleep = func() {
+			if len(client.policies) > scheduledAtOnce {
+				scheduledAtOnce = len(client.policies)
+			}
+			for id := range client.policies {
+				client.finish(id)
+			}
+		}
+		Expect(executor.Run(plan)).To(Succeed())
+		Expect(scheduledAtOnce).To(Equal(2))
+	})
+
+	It("Acknowledges the approved gates", func() {
+		client.gates["id-1"] = []*cmv1.VersionGate{versionGate("g1", false), versionGate("g2", true)}
+		plan.Waves[0].Clusters[0].Gates = []Gate{{ID: "g1"}}
+		Expect(executor.Run(plan)).To(Succeed())
+		Expect(client.acked["id-1"]).To(ConsistOf("g1", "g2"))
+	})
+
+	It("Halts when a gate wasn't approved", func() {
+		client.gates["id-1"] = []*cmv1.VersionGate{versionGate("g1", false)}
+		err := executor.Run(plan)
+		Expect(err).To(MatchError(ContainSubstring("Halted")))
+		Expect(plan.Halted).To(BeTrue())
+		Expect(plan.Waves[0].Clusters[0].Message).To(ContainSubstring("Version gate 'g1'"))
+		Expect(client.scheduled).To(BeEmpty())
+	})
+
+	It("Halts when the upgrade policy fails", func() {
+		onSleep = func() {
+			for id := range client.policies {
+				client.policyStates[id] = cmv1.UpgradePolicyStateValueFailed
+			}
+		}
+		err := executor.Run(plan)
+		Expect(err).To(MatchError(ContainSubstring("Upgrade of cluster 'dev-1' failed")))
+		Expect(plan.Waves[0].Clusters[0].Status).To(Equal(StatusFailed))
+		Expect(plan.Waves[1].Clusters[0].Status).To(Equal(StatusPending))
+	})
+
+	It("Halts when the upgrade doesn't finish in time", func() {
+		executor.Timeout = 30 * time.Minute
+		onSleep = func() {}
+		err := executor.Run(plan)
+		Expect(err).To(HaveOccurred())
+		Expect(plan.Waves[0].Clusters[0].Message).To(ContainSubstring("didn't finish in 30m0s"))
+	})
+
+	It("Halts when the cluster is in limited support after the upgrade", func() {
+		client.reasons["id-1"] = []*cmv1.LimitedSupportReason{limitedSupportReason("old", "Old reason")}
+		onSleep = func() {
+			for id := range client.policies {
+				client.finish(id)
+				client.reasons[id] = append(client.reasons[id], limitedSupportReason("new", "Nodes not ready"))
+			}
+		}
+		err := executor.Run(plan)
+		Expect(err).To(HaveOccurred())
+		Expect(plan.Waves[0].Clusters[0].Message).To(Equal(
+			"Cluster is in limited support after the upgrade: Nodes not ready"))
+	})
+
+	It("Halts when the upgrade can't be scheduled", func() {
+		client.scheduleErr = fmt.Errorf("boom")
+		err := executor.Run(plan)
+		Expect(err).To(HaveOccurred())
+		Expect(plan.HaltReason).To(ContainSubstring("Failed to schedule the upgrade: boom"))
+	})
+
+	Context("Maintenance windows", func() {
+		BeforeEach(func() {
+			store, err := maintenance.Load()
+			Expect(err).NotTo(HaveOccurred())
+			store.Set(&maintenance.Window{ClusterID: "id-2", ClusterName: "dev-2", Days: []string{"sat", "sun"},
+				Timezone: "UTC"})
+			Expect(store.Save()).To(Succeed())
+			maintenance.SetOverridden(false)
+			DeferCleanup(maintenance.SetOverridden, false)
+		})
+
+		It("Halts when the upgrade would start outside the window", func() {
+			err := executor.Run(plan)
+			Expect(err).To(MatchError(ContainSubstring("Halted")))
+			Expect(plan.Waves[1].Clusters[0].Status).To(Equal(StatusFailed))
+			Expect(plan.HaltReason).To(ContainSubstring("Upgrade to version '4.15.3' isn't allowed by the " +
+				"maintenance window of cluster 'dev-2'"))
+			Expect(client.scheduled).To(Equal([]string{"id-1"}))
+		})
+
+		It("Upgrades outside the window when it is overridden", func() {
+			maintenance.SetOverridden(true)
+			Expect(executor.Run(plan)).To(Succeed())
+			Expect(plan.Count(StatusCompleted)).To(Equal(5))
+		})
+	})
+
+	It("Resumes an interrupted rollout", func() {
+		// The first cluster was upgraded and the second scheduled before the interruption:
+		client.finish("id-1")
+		plan.Waves[0].Clusters[0].Status = StatusCompleted
+		Expect(client.ScheduleUpgrade("id-2", buildPolicy("4.15.3"))).To(Succeed())
+		client.scheduled = nil
+		Expect(executor.Run(plan)).To(Succeed())
+		Expect(plan.Count(StatusCompleted)).To(Equal(5))
+		Expect(client.scheduled).To(Equal([]string{"id-3", "id-4", "id-5"}))
+	})
+})
+
+func buildPolicy(version string) *cmv1.UpgradePolicy {
+	policy, err := cmv1.NewUpgradePolicy().Version(version).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return policy
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// PrintPlan returns the description of the plan, with the waves and the status of each cluster,
+// the skipped clusters and the version gates that approving the plan acknowledges.
+func PrintPlan(plan *Plan) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Version:\t%s\n", plan.Version)
+	fmt.Fprintf(writer, "Selection:\t%s\n", describeSelection(plan.Selection))
+	fmt.Fprintf(writer, "Clusters:\t%d to upgrade, %d skipped\n", len(plan.Clusters()), len(plan.Skipped))
+	fmt.Fprintf(writer, "Maximum parallel upgrades:\t%d\n", plan.MaxParallel)
+	status := "Waiting for approval"
+	switch {
+	case plan.Halted:
+		status = fmt.Sprintf("Halted: %s", plan.HaltReason)
+	case plan.Approved && plan.Done():
+		status = "Finished"
+	case plan.Approved:
+		status = "Approved"
+	}
+	fmt.Fprintf(writer, "Status:\t%s\n", status)
+	writer.Flush()
+
+	if len(plan.Waves) > 0 {
+		fmt.Fprintln(&buffer)
+		writer = tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(writer, "WAVE\tCLUSTER\tID\tFROM\tSTATUS\tMESSAGE\n")
+		for _, wave := range plan.Waves {
+			for _, cluster := range wave.Clusters {
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					wave.Name, cluster.Name, cluster.ID, cluster.FromVersion, cluster.Status, cluster.Message)
+			}
+		}
+		writer.Flush()
+	}
+
+	if len(plan.Skipped) > 0 {
+		fmt.Fprintf(&buffer, "\nSkipped clusters:\n")
+		writer = tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(writer, "CLUSTER\tID\tREASON\n")
+		for _, skipped := range plan.Skipped {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", skipped.Name, skipped.ID, skipped.Reason)
+		}
+		writer.Flush()
+	}
+
+	gates := plan.Gates()
+	if len(gates) > 0 {
+		fmt.Fprintf(&buffer, "\nApproving the plan acknowledges the following version gates:\n")
+		for _, gate := range gates {
+			fmt.Fprintf(&buffer, "  - %s\n", gate.Description)
+			if gate.URL != "" {
+				fmt.Fprintf(&buffer, "    URL: %s\n", gate.URL)
+			}
+		}
+	}
+	return buffer.String()
+}
+
+func describeSelection(selection Selection) string {
+	criteria := []string{}
+	if selection.Selector != "" {
+		criteria = append(criteria, fmt.Sprintf("tags '%s'", selection.Selector))
+	}
+	if selection.NamePattern != "" {
+		criteria = append(criteria, fmt.Sprintf("name '%s'", selection.NamePattern))
+	}
+	if selection.CurrentVersion != "" {
+		criteria = append(criteria, fmt.Sprintf("version '%s'", selection.CurrentVersion))
+	}
+	if selection.ChannelGroup != "" {
+		criteria = append(criteria, fmt.Sprintf("channel group '%s'", selection.ChannelGroup))
+	}
+	if len(criteria) == 0 {
+		return "All clusters"
+	}
+	return strings.Join(criteria, ", ")
+}
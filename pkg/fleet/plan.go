@@ -0,0 +1,437 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fleet contains the plans that upgrade many clusters in waves: first a few canary
+// clusters, then the rest in batches. The plans are saved to a file that records the progress of
+// each cluster, so that an interrupted or halted rollout can be resumed.
+package fleet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Status is the progress of the upgrade of a cluster.
+type Status string
+
+const (
+	// StatusPending is the status of the clusters whose upgrade isn't scheduled yet
+	StatusPending Status = "pending"
+	// StatusScheduled is the status of the clusters with an upgrade policy that isn't finished
+	StatusScheduled Status = "scheduled"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Client is the part of the OCM client used to plan and run the upgrades.
+type Client interface {
+	GetClusterByID(clusterKey string, creator *aws.Creator) (*cmv1.Cluster, error)
+	GetAvailableUpgrades(versionID string) ([]string, error)
+	GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error)
+	GetControlPlaneScheduledUpgrade(clusterID string) (*cmv1.ControlPlaneUpgradePolicy, error)
+	GetMissingGateAgreementsClassic(clusterID string, upgradePolicy *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error)
+	GetMissingGateAgreementsHypershift(clusterID string,
+		upgradePolicy *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error)
+	AckVersionGate(clusterID string, gateID string) error
+	ScheduleUpgrade(clusterID string, upgradePolicy *cmv1.UpgradePolicy) error
+	ScheduleHypershiftControlPlaneUpgrade(clusterID string,
+		upgradePolicy *cmv1.ControlPlaneUpgradePolicy) (*cmv1.ControlPlaneUpgradePolicy, error)
+	GetLimitedSupportReasons(clusterID string) ([]*cmv1.LimitedSupportReason, error)
+}
+
+// Gate is a version gate that has to be acknowledged to upgrade a cluster. Approving a plan
+// acknowledges the gates of its clusters.
+type Gate struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	URL         string `json:"url,omitempty"`
+}
+
+// ClusterUpgrade is the upgrade of a cluster of a plan.
+type ClusterUpgrade struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Hypershift  bool   `json:"hypershift,omitempty"`
+	FromVersion string `json:"from_version"`
+	Gates       []Gate `json:"gates,omitempty"`
+	Status      Status `json:"status"`
+	Message     string `json:"message,omitempty"`
+
+	// LimitedSupportReasons are the identifiers of the limited support reasons that the cluster
+	// had when the upgrade was scheduled, other reasons are caused by the upgrade.
+	LimitedSupportReasons []string   `json:"limited_support_reasons,omitempty"`
+	ScheduledAt           *time.Time `json:"scheduled_at,omitempty"`
+	FinishedAt            *time.Time `json:"finished_at,omitempty"`
+}
+
+// Wave is a set of clusters that are upgraded at the same time. A wave starts when all the
+// clusters of the previous one are upgraded.
+type Wave struct {
+	Name     string            `json:"name"`
+	Clusters []*ClusterUpgrade `json:"clusters"`
+}
+
+// SkippedCluster is a cluster that matches the selection of a plan but can't be upgraded.
+type SkippedCluster struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Plan is the upgrade of a set of clusters to a version.
+type Plan struct {
+	Version     string    `json:"version"`
+	Selection   Selection `json:"selection"`
+	Canary      int       `json:"canary"`
+	BatchSize   int       `json:"batch_size"`
+	MaxParallel int       `json:"max_parallel"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Approved   bool       `json:"approved"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	Halted     bool       `json:"halted,omitempty"`
+	HaltReason string     `json:"halt_reason,omitempty"`
+
+	Waves   []*Wave          `json:"waves"`
+	Skipped []SkippedCluster `json:"skipped,omitempty"`
+}
+
+// Spec are the parameters of a new plan.
+type Spec struct {
+	Selection
+	Version     string
+	Canary      int
+	BatchSize   int
+	MaxParallel int
+}
+
+// NewPlan returns the plan that upgrades the given clusters that match the selection of the spec.
+// The clusters that match the selection but can't be upgraded, because they aren't ready, can't
+// upgrade to the version, already have an upgrade scheduled or are in limited support, are
+// recorded in the plan as skipped.
+func NewPlan(client Client, clusters []*cmv1.Cluster, spec Spec, now time.Time) (*Plan, error) {
+	if spec.Version == "" {
+		return nil, fmt.Errorf("Expected a version to upgrade the clusters to")
+	}
+	if spec.Canary < 0 {
+		return nil, fmt.Errorf("Expected a number of canary clusters greater or equal to zero")
+	}
+	if spec.BatchSize < 1 {
+		return nil, fmt.Errorf("Expected a batch size greater than zero")
+	}
+	if spec.MaxParallel < 0 {
+		return nil, fmt.Errorf("Expected a maximum of parallel upgrades greater or equal to zero")
+	}
+	if spec.MaxParallel == 0 {
+		spec.MaxParallel = spec.BatchSize
+	}
+	err := spec.Selection.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Version:     spec.Version,
+		Selection:   spec.Selection,
+		Canary:      spec.Canary,
+		BatchSize:   spec.BatchSize,
+		MaxParallel: spec.MaxParallel,
+		CreatedAt:   now,
+		Waves:       []*Wave{},
+	}
+
+	sorted := append([]*cmv1.Cluster{}, clusters...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	upgrades := []*ClusterUpgrade{}
+	for _, cluster := range sorted {
+		matches, err := spec.Selection.Matches(cluster)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			continue
+		}
+		upgrade, reason, err := planCluster(client, cluster, spec.Version)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check cluster '%s': %v", cluster.Name(), err)
+		}
+		if reason != "" {
+			plan.Skipped = append(plan.Skipped, SkippedCluster{
+				ID:     cluster.ID(),
+				Name:   cluster.Name(),
+				Reason: reason,
+			})
+			continue
+		}
+		upgrades = append(upgrades, upgrade)
+	}
+
+	canary := spec.Canary
+	if canary > len(upgrades) {
+		canary = len(upgrades)
+	}
+	if canary > 0 {
+		plan.Waves = append(plan.Waves, &Wave{Name: "canary", Clusters: upgrades[:canary]})
+	}
+	for i, start := 1, canary; start < len(upgrades); i, start = i+1, start+spec.BatchSize {
+		end := start + spec.BatchSize
+		if end > len(upgrades) {
+			end = len(upgrades)
+		}
+		plan.Waves = append(plan.Waves, &Wave{Name: fmt.Sprintf("batch-%d", i), Clusters: upgrades[start:end]})
+	}
+	return plan, nil
+}
+
+// planCluster returns the upgrade of the cluster to the version, or the reason why it can't be
+// upgraded.
+func planCluster(client Client, cluster *cmv1.Cluster, version string) (*ClusterUpgrade, string, error) {
+	if cluster.State() != cmv1.ClusterStateReady {
+		return nil, fmt.Sprintf("Cluster is in '%s' state", cluster.State()), nil
+	}
+	currentVersion := cluster.Version().RawID()
+	if currentVersion == version {
+		return nil, fmt.Sprintf("Cluster is already in version '%s'", version), nil
+	}
+
+	hypershift := ocm.IsHyperShiftCluster(cluster)
+	var availableUpgrades []string
+	var err error
+	if hypershift {
+		availableUpgrades = ocm.GetAvailableUpgradesByCluster(cluster)
+	} else {
+		availableUpgrades, err = client.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if !helper.Contains(availableUpgrades, version) {
+		return nil, fmt.Sprintf("Version '%s' isn't an available upgrade from version '%s'",
+			version, currentVersion), nil
+	}
+
+	scheduled, err := scheduledVersion(client, cluster.ID(), hypershift)
+	if err != nil {
+		return nil, "", err
+	}
+	if scheduled != "" {
+		return nil, fmt.Sprintf("Cluster already has an upgrade to version '%s' scheduled", scheduled), nil
+	}
+
+	reasons, err := client.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return nil, "", err
+	}
+	if len(reasons) > 0 {
+		return nil, fmt.Sprintf("Cluster is in limited support: %s", reasons[0].Summary()), nil
+	}
+
+	gates, err := missingGates(client, cluster.ID(), hypershift, version)
+	if err != nil {
+		return nil, "", err
+	}
+	upgrade := &ClusterUpgrade{
+		ID:          cluster.ID(),
+		Name:        cluster.Name(),
+		Hypershift:  hypershift,
+		FromVersion: currentVersion,
+		Status:      StatusPending,
+	}
+	for _, gate := range gates {
+		if gate.STSOnly() {
+			continue
+		}
+		upgrade.Gates = append(upgrade.Gates, Gate{
+			ID:          gate.ID(),
+			Description: gate.Description(),
+			URL:         gate.DocumentationURL(),
+		})
+	}
+	return upgrade, "", nil
+}
+
+// scheduledVersion returns the version of the upgrade scheduled for the cluster, if any.
+func scheduledVersion(client Client, clusterID string, hypershift bool) (string, error) {
+	if hypershift {
+		policy, err := client.GetControlPlaneScheduledUpgrade(clusterID)
+		if err != nil || policy == nil {
+			return "", err
+		}
+		return policy.Version(), nil
+	}
+	policy, _, err := client.GetScheduledUpgrade(clusterID)
+	if err != nil || policy == nil {
+		return "", err
+	}
+	return policy.Version(), nil
+}
+
+func missingGates(client Client, clusterID string, hypershift bool, version string) ([]*cmv1.VersionGate, error) {
+	if hypershift {
+		policy, err := cmv1.NewControlPlaneUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeControlPlane).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version(version).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		return client.GetMissingGateAgreementsHypershift(clusterID, policy)
+	}
+	policy, err := cmv1.NewUpgradePolicy().
+		ScheduleType(cmv1.ScheduleTypeManual).
+		Version(version).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.GetMissingGateAgreementsClassic(clusterID, policy)
+}
+
+// Clusters returns the upgrades of all the clusters of the plan.
+func (p *Plan) Clusters() []*ClusterUpgrade {
+	result := []*ClusterUpgrade{}
+	for _, wave := range p.Waves {
+		result = append(result, wave.Clusters...)
+	}
+	return result
+}
+
+// Gates returns the gates that approving the plan acknowledges, without duplicates.
+func (p *Plan) Gates() []Gate {
+	result := []Gate{}
+	seen := map[string]bool{}
+	for _, cluster := range p.Clusters() {
+		for _, gate := range cluster.Gates {
+			if !seen[gate.ID] {
+				seen[gate.ID] = true
+				result = append(result, gate)
+			}
+		}
+	}
+	return result
+}
+
+// Approve records that the user approved the plan and the gates of its clusters.
+func (p *Plan) Approve(now time.Time) {
+	p.Approved = true
+	p.ApprovedAt = &now
+}
+
+// Resume clears the halt of the plan. When retryFailed is set, the clusters whose upgrade failed
+// are upgraded again, otherwise they are left as failed and the rollout continues without them.
+func (p *Plan) Resume(retryFailed bool) {
+	p.Halted = false
+	p.HaltReason = ""
+	if !retryFailed {
+		return
+	}
+	for _, cluster := range p.Clusters() {
+		if cluster.Status == StatusFailed {
+			cluster.Status = StatusPending
+			cluster.Message = ""
+			cluster.ScheduledAt = nil
+			cluster.FinishedAt = nil
+		}
+	}
+}
+
+// Done returns if all the clusters of the plan are completed or failed.
+func (p *Plan) Done() bool {
+	for _, wave := range p.Waves {
+		if !wave.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// Done returns if all the clusters of the wave are completed or failed.
+func (w *Wave) Done() bool {
+	for _, cluster := range w.Clusters {
+		if cluster.Status == StatusPending || cluster.Status == StatusScheduled {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of clusters of the plan with the given status.
+func (p *Plan) Count(status Status) int {
+	count := 0
+	for _, cluster := range p.Clusters() {
+		if cluster.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// LoadPlan reads the plan saved in the given file, or returns nil if the file doesn't exist.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	err = json.Unmarshal(data, plan)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse upgrade plan file '%s': %v", path, err)
+	}
+	return plan, nil
+}
+
+// Save writes the plan to the given file. The file is replaced atomically, so that it is never
+// left half written if rosa is interrupted.
+func (p *Plan) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	file, err := os.CreateTemp(dir, "."+strings.TrimPrefix(filepath.Base(path), ".")+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
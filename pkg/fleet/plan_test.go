@@ -0,0 +1,125 @@
+package fleet
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Plan", func() {
+	now := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	var client *fakeClient
+
+	BeforeEach(func() {
+		client = newFakeClient()
+		for i := 1; i <= 7; i++ {
+			client.add(buildCluster(fmt.Sprintf("id-%d", i), fmt.Sprintf("dev-%d", i), "4.14.12",
+				cmv1.ClusterStateReady, false, "4.14.13", "4.15.3"))
+		}
+	})
+
+	It("Splits the clusters in a canary and batches", func() {
+		plan, err := NewPlan(client, client.all(), Spec{Version: "4.15.3", Canary: 1, BatchSize: 3}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.MaxParallel).To(Equal(3))
+		Expect(plan.Waves).To(HaveLen(3))
+		Expect(plan.Waves[0].Name).To(Equal("canary"))
+		Expect(plan.Waves[0].Clusters).To(HaveLen(1))
+		Expect(plan.Waves[0].Clusters[0].Name).To(Equal("dev-1"))
+		Expect(plan.Waves[1].Name).To(Equal("batch-1"))
+		Expect(plan.Waves[1].Clusters).To(HaveLen(3))
+		Expect(plan.Waves[2].Name).To(Equal("batch-2"))
+		Expect(plan.Waves[2].Clusters).To(HaveLen(3))
+		Expect(plan.Count(StatusPending)).To(Equal(7))
+		Expect(plan.Approved).To(BeFalse())
+	})
+
+	It("Skips the clusters that can't be upgraded", func() {
+		client.add(buildCluster("id-1", "dev-1", "4.14.12", cmv1.ClusterStateHibernating, false, "4.15.3"))
+		client.add(buildCluster("id-2", "dev-2", "4.15.3", cmv1.ClusterStateReady, false))
+		client.add(buildCluster("id-3", "dev-3", "4.13.20", cmv1.ClusterStateReady, false, "4.14.12"))
+		client.policies["id-4"], _ = cmv1.NewUpgradePolicy().Version("4.14.13").Build()
+		client.reasons["id-5"] = []*cmv1.LimitedSupportReason{limitedSupportReason("r1", "Missing role")}
+		plan, err := NewPlan(client, client.all(), Spec{Version: "4.15.3", Canary: 1, BatchSize: 10}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Clusters()).To(HaveLen(2))
+		Expect(plan.Skipped).To(ConsistOf(
+			SkippedCluster{ID: "id-1", Name: "dev-1", Reason: "Cluster is in 'hibernating' state"},
+			SkippedCluster{ID: "id-2", Name: "dev-2", Reason: "Cluster is already in version '4.15.3'"},
+			SkippedCluster{ID: "id-3", Name: "dev-3",
+				Reason: "Version '4.15.3' isn't an available upgrade from version '4.13.20'"},
+			SkippedCluster{ID: "id-4", Name: "dev-4",
+				Reason: "Cluster already has an upgrade to version '4.14.13' scheduled"},
+			SkippedCluster{ID: "id-5", Name: "dev-5", Reason: "Cluster is in limited support: Missing role"},
+		))
+	})
+
+	It("Only includes the clusters that match the selection", func() {
+		plan, err := NewPlan(client, client.all(), Spec{
+			Selection: Selection{NamePattern: "dev-[12]"},
+			Version:   "4.15.3",
+			BatchSize: 10,
+		}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Waves).To(HaveLen(1))
+		Expect(plan.Waves[0].Name).To(Equal("batch-1"))
+		Expect(plan.Waves[0].Clusters).To(HaveLen(2))
+	})
+
+	It("Records the gates that have to be acknowledged", func() {
+		client.gates["id-1"] = []*cmv1.VersionGate{versionGate("g1", false), versionGate("g2", true)}
+		client.gates["id-2"] = []*cmv1.VersionGate{versionGate("g1", false)}
+		plan, err := NewPlan(client, client.all(), Spec{Version: "4.15.3", Canary: 1, BatchSize: 10}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Waves[0].Clusters[0].Gates).To(Equal([]Gate{{ID: "g1", Description: "Gate g1"}}))
+		Expect(plan.Gates()).To(HaveLen(1))
+		Expect(PrintPlan(plan)).To(ContainSubstring("acknowledges the following version gates:\n  - Gate g1\n"))
+	})
+
+	DescribeTable("Rejects invalid specs",
+		func(spec Spec, message string) {
+			_, err := NewPlan(client, client.all(), spec, now)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("No version", Spec{BatchSize: 1}, "Expected a version"),
+		Entry("No batch size", Spec{Version: "4.15.3"}, "batch size"),
+		Entry("Negative canary", Spec{Version: "4.15.3", BatchSize: 1, Canary: -1}, "canary"),
+		Entry("Invalid selector", Spec{Version: "4.15.3", BatchSize: 1, Selection: Selection{Selector: "="}},
+			"Selector"),
+	)
+
+	It("Saves and loads the plan", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "plan.json")
+		loaded, err := LoadPlan(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(BeNil())
+
+		plan, err := NewPlan(client, client.all(), Spec{Version: "4.15.3", Canary: 2, BatchSize: 10}, now)
+		Expect(err).NotTo(HaveOccurred())
+		plan.Approve(now)
+		Expect(plan.Save(path)).To(Succeed())
+		loaded, err = LoadPlan(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Approved).To(BeTrue())
+		Expect(loaded.Waves).To(HaveLen(2))
+		Expect(loaded.Clusters()).To(HaveLen(7))
+		Expect(loaded.Clusters()[0].Status).To(Equal(StatusPending))
+	})
+
+	It("Resumes a halted plan", func() {
+		plan, err := NewPlan(client, client.all(), Spec{Version: "4.15.3", Canary: 1, BatchSize: 10}, now)
+		Expect(err).NotTo(HaveOccurred())
+		plan.Halted = true
+		plan.HaltReason = "Failed"
+		plan.Waves[0].Clusters[0].Status = StatusFailed
+		plan.Resume(false)
+		Expect(plan.Halted).To(BeFalse())
+		Expect(plan.Count(StatusFailed)).To(Equal(1))
+		plan.Resume(true)
+		Expect(plan.Count(StatusFailed)).To(Equal(0))
+	})
+})
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Selection are the criteria that the clusters upgraded by a plan match. Empty criteria match
+// all the clusters.
+type Selection struct {
+	// Selector is a comma separated list of requirements on the tags of the clusters, like
+	// 'env=dev,team!=payments,canary'.
+	Selector string `json:"selector,omitempty"`
+	// NamePattern is a shell pattern that the names of the clusters match, like 'dev-*'.
+	NamePattern string `json:"name_pattern,omitempty"`
+	// CurrentVersion is the version of the clusters, or its prefix like '4.14'.
+	CurrentVersion string `json:"current_version,omitempty"`
+	ChannelGroup   string `json:"channel_group,omitempty"`
+}
+
+type requirement struct {
+	key      string
+	value    string
+	operator string
+}
+
+// Validate checks that the selector and the name pattern are valid.
+func (s Selection) Validate() error {
+	_, err := parseSelector(s.Selector)
+	if err != nil {
+		return err
+	}
+	_, err = path.Match(s.NamePattern, "")
+	if err != nil {
+		return fmt.Errorf("Name pattern '%s' isn't valid: %v", s.NamePattern, err)
+	}
+	return nil
+}
+
+// Matches returns if the cluster matches all the criteria.
+func (s Selection) Matches(cluster *cmv1.Cluster) (bool, error) {
+	if s.NamePattern != "" {
+		matched, err := path.Match(s.NamePattern, cluster.Name())
+		if err != nil {
+			return false, fmt.Errorf("Name pattern '%s' isn't valid: %v", s.NamePattern, err)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	if s.CurrentVersion != "" {
+		version := cluster.Version().RawID()
+		if version != s.CurrentVersion && !strings.HasPrefix(version, s.CurrentVersion+".") {
+			return false, nil
+		}
+	}
+	if s.ChannelGroup != "" && cluster.Version().ChannelGroup() != s.ChannelGroup {
+		return false, nil
+	}
+	requirements, err := parseSelector(s.Selector)
+	if err != nil {
+		return false, err
+	}
+	tags := cluster.AWS().Tags()
+	for _, requirement := range requirements {
+		value, ok := tags[requirement.key]
+		switch requirement.operator {
+		case "=":
+			if !ok || value != requirement.value {
+				return false, nil
+			}
+		case "!=":
+			if ok && value == requirement.value {
+				return false, nil
+			}
+		case "!":
+			if ok {
+				return false, nil
+			}
+		default:
+			if !ok {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// parseSelector parses a selector with the syntax of the Kubernetes equality based label
+// selectors: 'key=value', 'key==value', 'key!=value', 'key' and '!key'.
+func parseSelector(selector string) ([]requirement, error) {
+	requirements := []requirement{}
+	if strings.TrimSpace(selector) == "" {
+		return requirements, nil
+	}
+	for _, item := range strings.Split(selector, ",") {
+		item = strings.TrimSpace(item)
+		var result requirement
+		switch {
+		case strings.Contains(item, "!="):
+			parts := strings.SplitN(item, "!=", 2)
+			result = requirement{key: parts[0], value: parts[1], operator: "!="}
+		case strings.Contains(item, "=="):
+			parts := strings.SplitN(item, "==", 2)
+			result = requirement{key: parts[0], value: parts[1], operator: "="}
+		case strings.Contains(item, "="):
+			parts := strings.SplitN(item, "=", 2)
+			result = requirement{key: parts[0], value: parts[1], operator: "="}
+		case strings.HasPrefix(item, "!"):
+			result = requirement{key: strings.TrimPrefix(item, "!"), operator: "!"}
+		default:
+			result = requirement{key: item}
+		}
+		result.key = strings.TrimSpace(result.key)
+		result.value = strings.TrimSpace(result.value)
+		if result.key == "" || strings.ContainsAny(result.key, "!=") || strings.ContainsAny(result.value, "!=") {
+			return nil, fmt.Errorf("Selector '%s' isn't valid: requirement '%s' has to be like 'key=value', "+
+				"'key!=value', 'key' or '!key'", selector, item)
+		}
+		requirements = append(requirements, result)
+	}
+	return requirements, nil
+}
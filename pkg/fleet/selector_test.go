@@ -0,0 +1,52 @@
+package fleet
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Selection", func() {
+	cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.Name("dev-east-1")
+		c.Version(cmv1.NewVersion().RawID("4.14.12").ChannelGroup("stable"))
+		c.AWS(cmv1.NewAWS().Tags(map[string]string{"env": "dev", "team": "platform"}))
+	})
+
+	DescribeTable("Matches clusters",
+		func(selection Selection, expected bool) {
+			matches, err := selection.Matches(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(Equal(expected))
+		},
+		Entry("Empty selection", Selection{}, true),
+		Entry("Tag", Selection{Selector: "env=dev"}, true),
+		Entry("Double equals", Selection{Selector: "env==dev"}, true),
+		Entry("Several tags", Selection{Selector: "env=dev, team=platform"}, true),
+		Entry("Different tag value", Selection{Selector: "env=prod"}, false),
+		Entry("Not equal", Selection{Selector: "team!=payments"}, true),
+		Entry("Not equal to the value", Selection{Selector: "team!=platform"}, false),
+		Entry("Existence", Selection{Selector: "env"}, true),
+		Entry("Missing tag", Selection{Selector: "canary"}, false),
+		Entry("Absence", Selection{Selector: "!canary"}, true),
+		Entry("Name pattern", Selection{NamePattern: "dev-*"}, true),
+		Entry("Different name", Selection{NamePattern: "prod-*"}, false),
+		Entry("Version prefix", Selection{CurrentVersion: "4.14"}, true),
+		Entry("Exact version", Selection{CurrentVersion: "4.14.12"}, true),
+		Entry("Partial version number", Selection{CurrentVersion: "4.14.1"}, false),
+		Entry("Channel group", Selection{ChannelGroup: "stable"}, true),
+		Entry("Different channel group", Selection{ChannelGroup: "candidate"}, false),
+	)
+
+	DescribeTable("Rejects invalid selections",
+		func(selection Selection) {
+			Expect(selection.Validate()).NotTo(Succeed())
+		},
+		Entry("Empty key", Selection{Selector: "=dev"}),
+		Entry("Empty requirement", Selection{Selector: "env=dev,"}),
+		Entry("Invalid value", Selection{Selector: "env=dev=prod"}),
+		Entry("Invalid pattern", Selection{NamePattern: "dev-["}),
+	)
+})
@@ -141,6 +141,26 @@ func GetMinimalHostedMachinePoolVersion(controlPlaneVersion string) (string, err
 	return version, nil
 }
 
+// IsHostedMachinePoolVersionSkewSupported returns if a hosted machinepool in the version can run with
+// a control plane in the other version: it can't be newer than the control plane, nor more than
+// two minor versions older.
+func IsHostedMachinePoolVersionSkewSupported(controlPlaneVersion, machinePoolVersion string) (bool, error) {
+	cpVersion, err := version.NewVersion(strings.TrimPrefix(controlPlaneVersion, ocm.VersionPrefix))
+	if err != nil {
+		return false, err
+	}
+	mpVersion, err := version.NewVersion(strings.TrimPrefix(machinePoolVersion, ocm.VersionPrefix))
+	if err != nil {
+		return false, err
+	}
+	if mpVersion.Core().GreaterThan(cpVersion.Core()) {
+		return false, nil
+	}
+	cpSegments := cpVersion.Segments()
+	mpSegments := mpVersion.Segments()
+	return mpSegments[0] == cpSegments[0] && mpSegments[1] >= cpSegments[1]-MinorVersionsSupported, nil
+}
+
 func IsGreaterThanOrEqual(version1, version2 string) (bool, error) {
 	v1, err := version.NewVersion(strings.TrimPrefix(version1, ocm.VersionPrefix))
 	if err != nil {
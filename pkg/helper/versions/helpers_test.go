@@ -227,3 +227,22 @@ var _ = DescribeTable("IsGreaterThanOrEqual", func(version1, version2 string, ex
 	Entry("Invalid arg 1", "invalid", "openshift-v4.14.1", false, "Malformed version: invalid"),
 	Entry("Invalid arg 2", "openshift-v4.14.2", "invalid2", false, "Malformed version: invalid2"),
 )
+
+var _ = DescribeTable("IsHostedMachinePoolVersionSkewSupported", func(controlPlaneVersion,
+	machinePoolVersion string, expectedResult bool, expectedError string) {
+	result, err := IsHostedMachinePoolVersionSkewSupported(controlPlaneVersion, machinePoolVersion)
+	if expectedError != "" {
+		Expect(err.Error()).To(ContainSubstring(expectedError))
+	} else {
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(result).To(Equal(expectedResult))
+},
+	Entry("Same version", "4.15.3", "4.15.3", true, ""),
+	Entry("Older patch", "4.15.3", "4.15.1", true, ""),
+	Entry("Two minor versions older", "openshift-v4.15.3", "openshift-v4.13.0", true, ""),
+	Entry("Three minor versions older", "4.15.3", "4.12.30", false, ""),
+	Entry("Newer than the control plane", "4.15.3", "4.15.4", false, ""),
+	Entry("Nightly of the same version", "4.15.3", "4.15.3-0.nightly-2024-03-01-000000", true, ""),
+	Entry("Invalid control plane version", "invalid", "4.15.3", false, "Malformed version: invalid"),
+)
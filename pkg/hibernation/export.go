@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	ExportCron    = "cron"
+	ExportSystemd = "systemd"
+)
+
+// ExportFormats are the formats that 'rosa schedule run' can export the runner to.
+var ExportFormats = []string{ExportCron, ExportSystemd}
+
+// Export returns the configuration that runs the schedules periodically with the given rosa
+// executable. The cron entry runs them once every few minutes, and the systemd unit is a user
+// service that keeps 'rosa schedule run' running.
+func Export(format string, executable string) (string, error) {
+	environment := ""
+	if path := os.Getenv(PathEnv); path != "" {
+		environment = fmt.Sprintf("%s=%s", PathEnv, path)
+	}
+	switch format {
+	case ExportCron:
+		command := fmt.Sprintf("%s schedule run --once", executable)
+		if environment != "" {
+			command = fmt.Sprintf("%s %s", environment, command)
+		}
+		return fmt.Sprintf("# Runs the hibernation schedules of rosa every five minutes\n"+
+			"*/5 * * * * %s\n", command), nil
+	case ExportSystemd:
+		lines := []string{
+			"[Unit]",
+			"Description=Hibernation schedules of ROSA clusters",
+			"Wants=network-online.target",
+			"After=network-online.target",
+			"",
+			"[Service]",
+		}
+		if environment != "" {
+			lines = append(lines, fmt.Sprintf("Environment=%s", environment))
+		}
+		lines = append(lines,
+			fmt.Sprintf("ExecStart=%s schedule run", executable),
+			"Restart=on-failure",
+			"RestartSec=60",
+			"",
+			"[Install]",
+			"WantedBy=default.target",
+		)
+		return strings.Join(lines, "\n") + "\n", nil
+	}
+	return "", fmt.Errorf("Unknown export format '%s', allowed values are %s",
+		format, strings.Join(ExportFormats, ", "))
+}
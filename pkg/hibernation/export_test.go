@@ -0,0 +1,32 @@
+package hibernation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Export", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv(PathEnv, "")
+	})
+
+	It("Exports a cron entry", func() {
+		config, err := Export(ExportCron, "/usr/local/bin/rosa")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(ContainSubstring("*/5 * * * * /usr/local/bin/rosa schedule run --once\n"))
+	})
+
+	It("Exports a systemd unit with the schedules file", func() {
+		GinkgoT().Setenv(PathEnv, "/tmp/schedules.json")
+		config, err := Export(ExportSystemd, "/usr/local/bin/rosa")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(ContainSubstring("Environment=ROSA_HIBERNATION_SCHEDULES=/tmp/schedules.json\n"))
+		Expect(config).To(ContainSubstring("ExecStart=/usr/local/bin/rosa schedule run\n"))
+		Expect(config).To(ContainSubstring("WantedBy=default.target\n"))
+	})
+
+	It("Fails with an unknown format", func() {
+		_, err := Export("launchd", "rosa")
+		Expect(err).To(MatchError(ContainSubstring("allowed values are cron, systemd")))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+)
+
+// PrintSchedulesForTabularOutput returns the table of the given schedules, with the next time that
+// each one hibernates and resumes its cluster after the given time.
+func PrintSchedulesForTabularOutput(schedules []*Schedule, now time.Time) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "CLUSTER\tHIBERNATE\tRESUME\tTIMEZONE\tNEXT HIBERNATION\tNEXT RESUME\n")
+	for _, schedule := range schedules {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			schedule.ClusterName,
+			schedule.HibernateCron,
+			schedule.ResumeCron,
+			schedule.Timezone,
+			formatTime(schedule.Next(ActionHibernate, now), schedule.Timezone),
+			formatTime(schedule.Next(ActionResume, now), schedule.Timezone),
+		)
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+// PrintScheduleDescription returns the description of the schedule.
+func PrintScheduleDescription(schedule *Schedule, now time.Time) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Cluster ID:\t%s\n", schedule.ClusterID)
+	fmt.Fprintf(writer, "Cluster name:\t%s\n", schedule.ClusterName)
+	fmt.Fprintf(writer, "Hibernate:\t%s\n", schedule.HibernateCron)
+	fmt.Fprintf(writer, "Resume:\t%s\n", valueOrNone(schedule.ResumeCron))
+	fmt.Fprintf(writer, "Time zone:\t%s\n", schedule.Timezone)
+	fmt.Fprintf(writer, "Skip if upgrade pending:\t%t\n", schedule.SkipIfUpgradePending)
+	fmt.Fprintf(writer, "Next hibernation:\t%s\n",
+		valueOrNone(formatTime(schedule.Next(ActionHibernate, now), schedule.Timezone)))
+	fmt.Fprintf(writer, "Next resume:\t%s\n",
+		valueOrNone(formatTime(schedule.Next(ActionResume, now), schedule.Timezone)))
+	fmt.Fprintf(writer, "Created:\t%s\n", formatTime(schedule.CreatedAt, schedule.Timezone))
+	fmt.Fprintf(writer, "Last run:\t%s\n", valueOrNone(formatTime(schedule.LastRun, schedule.Timezone)))
+	if schedule.LastAction != ActionNone {
+		fmt.Fprintf(writer, "Last action:\t%s: %s\n", schedule.LastAction, schedule.LastResult)
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+func formatTime(value time.Time, timezone string) string {
+	if value.IsZero() {
+		return ""
+	}
+	if location, err := time.LoadLocation(timezone); err == nil {
+		value = value.In(location)
+	}
+	return value.Format(time.RFC3339)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// ClusterClient is the part of the OCM client used to run the schedules.
+type ClusterClient interface {
+	GetClusterByID(clusterKey string, creator *aws.Creator) (*cmv1.Cluster, error)
+	HibernateCluster(clusterID string) error
+	ResumeCluster(clusterID string) error
+	GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error)
+}
+
+// Result is the outcome of running a schedule.
+type Result struct {
+	Action Action
+	// Due is when the action was scheduled
+	Due time.Time
+	// Skipped is set when the action wasn't performed because the cluster wasn't in a state that
+	// allows it, Message says why.
+	Skipped bool
+	Message string
+	Err     error
+}
+
+// pendingUpgradeStates are the states of the upgrade policies of upgrades that haven't finished.
+var pendingUpgradeStates = map[cmv1.UpgradePolicyStateValue]bool{
+	cmv1.UpgradePolicyStateValuePending:   true,
+	cmv1.UpgradePolicyStateValueScheduled: true,
+	cmv1.UpgradePolicyStateValueStarted:   true,
+	cmv1.UpgradePolicyStateValueDelayed:   true,
+}
+
+// Run performs the action of the schedule that is due at the given time, if any, and records it in
+// the schedule. When the action fails the last run of the schedule isn't updated, so that it is
+// retried the next time.
+func Run(client ClusterClient, schedule *Schedule, now time.Time) Result {
+	action, due := schedule.Due(now)
+	result := Result{Action: action, Due: due}
+	if action == ActionNone {
+		schedule.LastRun = now
+		return result
+	}
+	result.Skipped, result.Message, result.Err = perform(client, schedule, action)
+	if result.Err != nil {
+		return result
+	}
+	schedule.LastRun = now
+	schedule.LastAction = action
+	schedule.LastResult = result.Message
+	return result
+}
+
+func perform(client ClusterClient, schedule *Schedule, action Action) (bool, string, error) {
+	cluster, err := client.GetClusterByID(schedule.ClusterID, nil)
+	if err != nil {
+		return false, "", err
+	}
+	switch action {
+	case ActionHibernate:
+		if cluster.State() == cmv1.ClusterStateHibernating || cluster.State() == cmv1.ClusterStatePoweringDown {
+			return true, "Cluster is already hibernating", nil
+		}
+		if cluster.State() != cmv1.ClusterStateReady {
+			return true, fmt.Sprintf("Cluster is in '%s' state, it can only be hibernated when it is '%s'",
+				cluster.State(), cmv1.ClusterStateReady), nil
+		}
+		if schedule.SkipIfUpgradePending {
+			policy, state, err := client.GetScheduledUpgrade(cluster.ID())
+			if err != nil {
+				return false, "", fmt.Errorf("Failed to get the scheduled upgrade: %v", err)
+			}
+			if policy != nil && state != nil && pendingUpgradeStates[state.Value()] {
+				return true, fmt.Sprintf("Cluster has an upgrade to version '%s' in '%s' state scheduled at %s",
+					policy.Version(), state.Value(), policy.NextRun().Format(time.RFC3339)), nil
+			}
+		}
+		err = client.HibernateCluster(cluster.ID())
+		if err != nil {
+			return false, "", err
+		}
+		return false, "Cluster is hibernating", nil
+	case ActionResume:
+		if cluster.State() == cmv1.ClusterStateReady || cluster.State() == cmv1.ClusterStateResuming {
+			return true, "Cluster is already running", nil
+		}
+		if cluster.State() != cmv1.ClusterStateHibernating {
+			return true, fmt.Sprintf("Cluster is in '%s' state, it can only be resumed when it is '%s'",
+				cluster.State(), cmv1.ClusterStateHibernating), nil
+		}
+		err = client.ResumeCluster(cluster.ID())
+		if err != nil {
+			return false, "", err
+		}
+		return false, "Cluster is resuming", nil
+	}
+	return false, "", fmt.Errorf("Unknown action '%s'", action)
+}
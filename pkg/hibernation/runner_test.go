@@ -0,0 +1,139 @@
+package hibernation
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+type fakeClusterClient struct {
+	cluster      *cmv1.Cluster
+	upgrade      *cmv1.UpgradePolicy
+	upgradeState *cmv1.UpgradePolicyState
+	err          error
+	hibernated   bool
+	resumed      bool
+}
+
+func (f *fakeClusterClient) GetClusterByID(_ string, _ *aws.Creator) (*cmv1.Cluster, error) {
+	return f.cluster, nil
+}
+
+func (f *fakeClusterClient) HibernateCluster(_ string) error {
+	f.hibernated = f.err == nil
+	return f.err
+}
+
+func (f *fakeClusterClient) ResumeCluster(_ string) error {
+	f.resumed = f.err == nil
+	return f.err
+}
+
+func (f *fakeClusterClient) GetScheduledUpgrade(_ string) (*cmv1.UpgradePolicy, *cmv1.UpgradePolicyState, error) {
+	return f.upgrade, f.upgradeState, nil
+}
+
+var _ = Describe("Run", func() {
+	createdAt := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	afterHibernation := time.Date(2024, 3, 4, 20, 1, 0, 0, time.UTC)
+	afterResume := time.Date(2024, 3, 5, 7, 1, 0, 0, time.UTC)
+
+	var schedule *Schedule
+	var client *fakeClusterClient
+
+	withState := func(state cmv1.ClusterState) {
+		client.cluster = test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(state)
+		})
+	}
+
+	BeforeEach(func() {
+		schedule = &Schedule{
+			ClusterID:     "123",
+			ClusterName:   "mycluster",
+			HibernateCron: "0 20 * * *",
+			ResumeCron:    "0 7 * * *",
+			Timezone:      "UTC",
+			CreatedAt:     createdAt,
+		}
+		client = &fakeClusterClient{}
+		withState(cmv1.ClusterStateReady)
+	})
+
+	It("Does nothing when no action is due", func() {
+		now := time.Date(2024, 3, 4, 19, 0, 0, 0, time.UTC)
+		result := Run(client, schedule, now)
+		Expect(result.Action).To(Equal(ActionNone))
+		Expect(client.hibernated).To(BeFalse())
+		Expect(schedule.LastRun).To(Equal(now))
+	})
+
+	It("Hibernates a ready cluster", func() {
+		result := Run(client, schedule, afterHibernation)
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Skipped).To(BeFalse())
+		Expect(client.hibernated).To(BeTrue())
+		Expect(schedule.LastAction).To(Equal(ActionHibernate))
+		Expect(schedule.LastRun).To(Equal(afterHibernation))
+
+		// It isn't hibernated again in the next run:
+		client.hibernated = false
+		result = Run(client, schedule, afterHibernation.Add(time.Minute))
+		Expect(result.Action).To(Equal(ActionNone))
+		Expect(client.hibernated).To(BeFalse())
+	})
+
+	It("Resumes a hibernating cluster", func() {
+		withState(cmv1.ClusterStateHibernating)
+		schedule.LastRun = afterHibernation
+		result := Run(client, schedule, afterResume)
+		Expect(result.Action).To(Equal(ActionResume))
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(client.resumed).To(BeTrue())
+	})
+
+	It("Skips clusters that aren't ready", func() {
+		withState(cmv1.ClusterStateInstalling)
+		result := Run(client, schedule, afterHibernation)
+		Expect(result.Skipped).To(BeTrue())
+		Expect(result.Message).To(ContainSubstring("'installing' state"))
+		Expect(client.hibernated).To(BeFalse())
+		Expect(schedule.LastRun).To(Equal(afterHibernation))
+	})
+
+	It("Skips hibernation when an upgrade is pending", func() {
+		schedule.SkipIfUpgradePending = true
+		client.upgrade, _ = cmv1.NewUpgradePolicy().Version("4.15.3").NextRun(afterResume).Build()
+		client.upgradeState, _ = cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueScheduled).Build()
+		result := Run(client, schedule, afterHibernation)
+		Expect(result.Skipped).To(BeTrue())
+		Expect(result.Message).To(ContainSubstring("upgrade to version '4.15.3'"))
+		Expect(client.hibernated).To(BeFalse())
+	})
+
+	It("Hibernates when the upgrade is pending but the guard is disabled", func() {
+		client.upgrade, _ = cmv1.NewUpgradePolicy().Version("4.15.3").NextRun(afterResume).Build()
+		client.upgradeState, _ = cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueScheduled).Build()
+		result := Run(client, schedule, afterHibernation)
+		Expect(result.Skipped).To(BeFalse())
+		Expect(client.hibernated).To(BeTrue())
+	})
+
+	It("Retries failed actions in the next run", func() {
+		client.err = fmt.Errorf("boom")
+		result := Run(client, schedule, afterHibernation)
+		Expect(result.Err).To(MatchError("boom"))
+		Expect(schedule.LastRun.IsZero()).To(BeTrue())
+
+		client.err = nil
+		result = Run(client, schedule, afterHibernation.Add(time.Minute))
+		Expect(result.Action).To(Equal(ActionHibernate))
+		Expect(client.hibernated).To(BeTrue())
+	})
+})
@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hibernation contains the schedules that hibernate and resume clusters periodically. The
+// schedules are kept in a local file, and executed by 'rosa schedule run'.
+package hibernation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Action is what a schedule does to its cluster.
+type Action string
+
+const (
+	ActionNone      Action = ""
+	ActionHibernate Action = "hibernate"
+	ActionResume    Action = "resume"
+)
+
+// DefaultTimezone is the time zone of the cron expressions when none is given.
+const DefaultTimezone = "UTC"
+
+// maxOccurrences limits the occurrences of a cron expression that are visited to find the last one
+// in a period, so that a schedule that wasn't run for a long time doesn't take long to evaluate.
+const maxOccurrences = 100000
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Schedule hibernates a cluster and optionally resumes it following cron expressions.
+type Schedule struct {
+	ClusterID   string `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+
+	// HibernateCron and ResumeCron are standard cron expressions with five fields, evaluated in
+	// the time zone of the schedule.
+	HibernateCron string `json:"hibernate_cron"`
+	ResumeCron    string `json:"resume_cron,omitempty"`
+	Timezone      string `json:"timezone"`
+
+	// SkipIfUpgradePending prevents hibernating the cluster while it has an upgrade scheduled or
+	// in progress.
+	SkipIfUpgradePending bool `json:"skip_if_upgrade_pending,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// LastRun is the last time that the schedule was evaluated, the occurrences of the cron
+	// expressions after it are still due.
+	LastRun    time.Time `json:"last_run,omitempty"`
+	LastAction Action    `json:"last_action,omitempty"`
+	LastResult string    `json:"last_result,omitempty"`
+}
+
+// ParseCron parses a cron expression in the given time zone.
+func ParseCron(expression string, timezone string) (cron.Schedule, error) {
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, fmt.Errorf("Time zone '%s' isn't valid: %v", timezone, err)
+	}
+	trimmed := strings.TrimSpace(expression)
+	if strings.HasPrefix(trimmed, "CRON_TZ=") || strings.HasPrefix(trimmed, "TZ=") {
+		return nil, fmt.Errorf("Cron expression '%s' can't contain a time zone, use the time zone option",
+			expression)
+	}
+	schedule, err := cronParser.Parse(fmt.Sprintf("CRON_TZ=%s %s", timezone, trimmed))
+	if err != nil {
+		return nil, fmt.Errorf("Cron expression '%s' isn't valid: %v", expression, err)
+	}
+	return schedule, nil
+}
+
+// Validate checks that the schedule has a cluster and valid cron expressions.
+func (s *Schedule) Validate() error {
+	if s.ClusterID == "" {
+		return fmt.Errorf("Schedule has no cluster")
+	}
+	if s.HibernateCron == "" {
+		return fmt.Errorf("Schedule of cluster '%s' has no cron expression to hibernate it", s.ClusterName)
+	}
+	_, err := ParseCron(s.HibernateCron, s.Timezone)
+	if err != nil {
+		return err
+	}
+	if s.ResumeCron == "" {
+		return nil
+	}
+	_, err = ParseCron(s.ResumeCron, s.Timezone)
+	if err != nil {
+		return err
+	}
+	if strings.Join(strings.Fields(s.HibernateCron), " ") == strings.Join(strings.Fields(s.ResumeCron), " ") {
+		return fmt.Errorf("Cron expressions to hibernate and to resume cluster '%s' are the same",
+			s.ClusterName)
+	}
+	return nil
+}
+
+func (s *Schedule) cron(action Action) string {
+	if action == ActionResume {
+		return s.ResumeCron
+	}
+	return s.HibernateCron
+}
+
+// Next returns the next time after the given one that the schedule performs the action, or the
+// zero time if it never does.
+func (s *Schedule) Next(action Action, after time.Time) time.Time {
+	expression := s.cron(action)
+	if expression == "" {
+		return time.Time{}
+	}
+	schedule, err := ParseCron(expression, s.Timezone)
+	if err != nil {
+		return time.Time{}
+	}
+	return schedule.Next(after)
+}
+
+// Due returns the action of the last occurrence of the cron expressions after the last run of the
+// schedule, or after its creation if it never ran, and up to the given time, and when it occurred.
+// When the cluster should have been both hibernated and resumed since then, only the last action
+// matters. If both occur at the same time the cluster is resumed.
+func (s *Schedule) Due(now time.Time) (Action, time.Time) {
+	since := s.LastRun
+	if since.IsZero() {
+		since = s.CreatedAt
+	}
+	action := ActionNone
+	var at time.Time
+	for _, candidate := range []Action{ActionHibernate, ActionResume} {
+		last := s.last(candidate, since, now)
+		if !last.IsZero() && !last.Before(at) {
+			action = candidate
+			at = last
+		}
+	}
+	return action, at
+}
+
+// last returns the last occurrence of the cron expression of the action after since and up to now.
+func (s *Schedule) last(action Action, since time.Time, now time.Time) time.Time {
+	expression := s.cron(action)
+	if expression == "" {
+		return time.Time{}
+	}
+	schedule, err := ParseCron(expression, s.Timezone)
+	if err != nil {
+		return time.Time{}
+	}
+	var last time.Time
+	next := schedule.Next(since)
+	for i := 0; i < maxOccurrences && !next.IsZero() && !next.After(now); i++ {
+		last = next
+		next = schedule.Next(next)
+	}
+	return last
+}
@@ -0,0 +1,107 @@
+package hibernation
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	newSchedule := func() *Schedule {
+		return &Schedule{
+			ClusterID:     "123",
+			ClusterName:   "mycluster",
+			HibernateCron: "0 20 * * 1-5",
+			ResumeCron:    "0 7 * * 1-5",
+			Timezone:      "Europe/Berlin",
+			// Monday 12:00 in Berlin:
+			CreatedAt: time.Date(2024, 3, 4, 12, 0, 0, 0, berlin),
+		}
+	}
+
+	Context("Validate", func() {
+		It("Accepts a valid schedule", func() {
+			Expect(newSchedule().Validate()).To(Succeed())
+		})
+
+		It("Accepts a schedule without resume", func() {
+			schedule := newSchedule()
+			schedule.ResumeCron = ""
+			Expect(schedule.Validate()).To(Succeed())
+		})
+
+		DescribeTable("Rejects invalid schedules",
+			func(modify func(*Schedule), message string) {
+				schedule := newSchedule()
+				modify(schedule)
+				err := schedule.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			},
+			Entry("Invalid cron", func(s *Schedule) { s.HibernateCron = "0 25 * * *" }, "isn't valid"),
+			Entry("Seconds field", func(s *Schedule) { s.ResumeCron = "0 0 7 * * 1-5" }, "isn't valid"),
+			Entry("Invalid time zone", func(s *Schedule) { s.Timezone = "Mars/Olympus" }, "Time zone"),
+			Entry("Time zone in cron", func(s *Schedule) { s.HibernateCron = "CRON_TZ=UTC 0 20 * * *" },
+				"can't contain a time zone"),
+			Entry("Same crons", func(s *Schedule) { s.ResumeCron = "0  20 * * 1-5" }, "are the same"),
+			Entry("No hibernate cron", func(s *Schedule) { s.HibernateCron = "" }, "no cron expression"),
+		)
+	})
+
+	Context("Next", func() {
+		It("Evaluates the cron expressions in the time zone of the schedule", func() {
+			schedule := newSchedule()
+			next := schedule.Next(ActionHibernate, schedule.CreatedAt)
+			Expect(next.Equal(time.Date(2024, 3, 4, 20, 0, 0, 0, berlin))).To(BeTrue())
+			Expect(next.UTC().Hour()).To(Equal(19))
+			// Friday evening to Monday morning:
+			next = schedule.Next(ActionResume, time.Date(2024, 3, 8, 20, 0, 0, 0, berlin))
+			Expect(next.Equal(time.Date(2024, 3, 11, 7, 0, 0, 0, berlin))).To(BeTrue())
+		})
+
+		It("Returns zero without resume cron", func() {
+			schedule := newSchedule()
+			schedule.ResumeCron = ""
+			Expect(schedule.Next(ActionResume, schedule.CreatedAt).IsZero()).To(BeTrue())
+		})
+	})
+
+	Context("Due", func() {
+		It("Has nothing to do before the first occurrence", func() {
+			schedule := newSchedule()
+			action, _ := schedule.Due(time.Date(2024, 3, 4, 19, 59, 0, 0, berlin))
+			Expect(action).To(Equal(ActionNone))
+		})
+
+		It("Hibernates after the hibernate occurrence", func() {
+			schedule := newSchedule()
+			action, at := schedule.Due(time.Date(2024, 3, 4, 20, 0, 30, 0, berlin))
+			Expect(action).To(Equal(ActionHibernate))
+			Expect(at.Equal(time.Date(2024, 3, 4, 20, 0, 0, 0, berlin))).To(BeTrue())
+		})
+
+		It("Uses the last occurrence when several are due", func() {
+			schedule := newSchedule()
+			action, at := schedule.Due(time.Date(2024, 3, 5, 8, 0, 0, 0, berlin))
+			Expect(action).To(Equal(ActionResume))
+			Expect(at.Equal(time.Date(2024, 3, 5, 7, 0, 0, 0, berlin))).To(BeTrue())
+		})
+
+		It("Only considers the occurrences after the last run", func() {
+			schedule := newSchedule()
+			schedule.LastRun = time.Date(2024, 3, 4, 20, 1, 0, 0, berlin)
+			action, _ := schedule.Due(time.Date(2024, 3, 4, 23, 0, 0, 0, berlin))
+			Expect(action).To(Equal(ActionNone))
+		})
+
+		It("Resumes when both occur at the same time", func() {
+			schedule := newSchedule()
+			schedule.HibernateCron = "0 7 * * *"
+			action, _ := schedule.Due(time.Date(2024, 3, 5, 7, 30, 0, 0, berlin))
+			Expect(action).To(Equal(ActionResume))
+		})
+	})
+})
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"io"
+	"time"
+
+	"github.com/openshift/rosa/pkg/clusterstore"
+)
+
+// PathEnv is the environment variable that overrides the location of the schedules file.
+const PathEnv = "ROSA_HIBERNATION_SCHEDULES"
+
+const fileName = "hibernation-schedules.json"
+
+var kind = &clusterstore.Kind[*Schedule]{
+	Name:        "hibernation schedule",
+	Description: "hibernation schedules",
+	Field:       "schedules",
+	PathEnv:     PathEnv,
+	FileName:    fileName,
+	ClusterID:   func(schedule *Schedule) string { return schedule.ClusterID },
+	ClusterName: func(schedule *Schedule) string { return schedule.ClusterName },
+}
+
+// Store is the set of schedules saved in a file, at most one for each cluster.
+type Store = clusterstore.Store[*Schedule]
+
+// Path returns the location of the schedules file.
+func Path() (string, error) {
+	return kind.Path()
+}
+
+// Load reads the schedules from the default file.
+func Load() (*Store, error) {
+	return kind.Load()
+}
+
+// LoadFile reads the schedules from the given file. A file that doesn't exist has no schedules.
+func LoadFile(path string) (*Store, error) {
+	return kind.LoadFile(path)
+}
+
+// Find loads the schedules from the default file and returns the one of the given cluster. It
+// fails if the cluster has no schedule.
+func Find(clusterKey string) (*Store, *Schedule, error) {
+	return kind.Find(clusterKey)
+}
+
+// WriteDescription writes the schedule of the given cluster, in the format of the output flag if it
+// was used.
+func WriteDescription(writer io.Writer, clusterKey string, now time.Time) error {
+	return kind.WriteDescription(writer, clusterKey, func(schedule *Schedule) string {
+		return PrintScheduleDescription(schedule, now)
+	})
+}
@@ -0,0 +1,27 @@
+package hibernation
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	It("Keeps the format of the schedules file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), fileName)
+		Expect(os.WriteFile(path, []byte(`{"schedules": [{"cluster_id": "1", "cluster_name": "a", `+
+			`"hibernate_cron": "0 20 * * *"}]}`), 0600)).To(Succeed())
+		store, err := LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Get("a").HibernateCron).To(Equal("0 20 * * *"))
+
+		store.Set(&Schedule{ClusterID: "2", ClusterName: "b", CreatedAt: time.Now()})
+		Expect(store.Save()).To(Succeed())
+		loaded, err := LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Items).To(HaveLen(2))
+	})
+})
@@ -12,6 +12,7 @@ import (
 	. "github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -27,7 +28,7 @@ func GetInstallerRoleArn(r *rosa.Runtime, cmd *cobra.Command,
 	roleARNs, err := findRoleARNs(aws.InstallerAccountRole, minMinorVersion)
 	if err != nil {
 		r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	spin.Stop()
 
@@ -59,7 +60,7 @@ func GetInstallerRoleArn(r *rosa.Runtime, cmd *cobra.Command,
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid role ARN: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.Code(err).ExitCode())
 			}
 		}
 	} else if len(roleARNs) == 1 {
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	. "github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -45,7 +46,7 @@ func GetSecurityGroupIds(r *rosa.Runtime, cmd *cobra.Command,
 	possibleSgs, err := r.AWSClient.GetSecurityGroupIds(targetVpcId)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem retrieving security groups for VPC '%s': %v", targetVpcId, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	securityGroupIds := []string{}
 	if len(possibleSgs) > 0 {
@@ -68,7 +69,7 @@ func GetSecurityGroupIds(r *rosa.Runtime, cmd *cobra.Command,
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected valid Security Group IDs: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 		for i, sg := range securityGroupIds {
 			securityGroupIds[i] = aws.ParseOption(sg)
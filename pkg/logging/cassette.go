@@ -0,0 +1,508 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains an implementation of the http.RoundTripper interface that records the
+// requests sent and the responses received to a cassette file, or replays the responses of a
+// cassette file without sending the requests. Cassettes are redacted like the messages sent to the
+// log, so that they can be attached to bug reports and used by offline tests.
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/c0b/go-ordered-json"
+)
+
+const (
+	// RecordEnvKey is the environment variable that contains the directory where the cassettes
+	// are recorded.
+	RecordEnvKey = "ROSA_RECORD"
+
+	// ReplayEnvKey is the environment variable that contains the directory of the cassettes that
+	// are replayed.
+	ReplayEnvKey = "ROSA_REPLAY"
+
+	// Names of the cassettes of the clients:
+	OCMCassette = "ocm"
+	AWSCassette = "aws"
+)
+
+// Interaction is a request and its response, as stored in a cassette. Cassettes contain one
+// interaction per line.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Headers whose values are replaced with the redaction string:
+var cassetteRedactedHeaders = map[string]bool{
+	"Authorization":        true,
+	"Cookie":               true,
+	"Set-Cookie":           true,
+	"X-Amz-Security-Token": true,
+}
+
+// Fields of JSON, form and XML bodies whose values are replaced with the redaction string:
+var cassetteRedactedFields = map[string]bool{
+	"client_secret":   true,
+	"password":        true,
+	"AccessKeyId":     true,
+	"SecretAccessKey": true,
+	"SessionToken":    true,
+	"access_key_id":   true,
+	"secret_key":      true,
+	"kubeconfig":      true,
+	"SecretString":    true,
+	"SecretBinary":    true,
+}
+
+// Fields that contain tokens are replaced with tokens that aren't signed and don't expire, so that
+// the OCM SDK accepts them when the cassette is replayed.
+var cassetteTokenFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+}
+
+var cassetteXMLFields = func() []*regexp.Regexp {
+	var result []*regexp.Regexp
+	for field := range cassetteRedactedFields {
+		result = append(result, regexp.MustCompile(fmt.Sprintf(`<%s>[^<]*</%s>`, field, field)))
+	}
+	return result
+}()
+
+// CassetteTransportWrapper returns a function that wraps transports so that they record to, or
+// replay from, the cassette with the given name in the directories given by the ROSA_RECORD and
+// ROSA_REPLAY environment variables. It returns nil if neither variable is set.
+func CassetteTransportWrapper(name string) func(http.RoundTripper) http.RoundTripper {
+	if dir := os.Getenv(ReplayEnvKey); dir != "" {
+		player := NewPlayer(filepath.Join(dir, name+".jsonl"))
+		return func(http.RoundTripper) http.RoundTripper {
+			return player
+		}
+	}
+	if dir := os.Getenv(RecordEnvKey); dir != "" {
+		path := filepath.Join(dir, name+".jsonl")
+		return func(next http.RoundTripper) http.RoundTripper {
+			return NewRecorder(path, next)
+		}
+	}
+	return nil
+}
+
+// ReplayEnabled returns true if the cassettes are replayed instead of sending requests.
+func ReplayEnabled() bool {
+	return os.Getenv(ReplayEnvKey) != ""
+}
+
+// CassettesEnabled returns true if the cassettes are recorded or replayed.
+func CassettesEnabled() bool {
+	return ReplayEnabled() || os.Getenv(RecordEnvKey) != ""
+}
+
+// FakeToken returns a token that isn't signed and doesn't expire, used instead of the real tokens
+// when cassettes are recorded and replayed.
+func FakeToken() string {
+	encode := base64.RawURLEncoding.EncodeToString
+	header := encode([]byte(`{"alg":"none","typ":"JWT"}`))
+	claims := encode([]byte(fmt.Sprintf(`{"typ":"Bearer","exp":%d}`,
+		time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC).Unix())))
+	return header + "." + claims + "."
+}
+
+// Recorder is a round tripper that appends the requests and responses to a cassette. Don't create
+// instances of this type directly; use the NewRecorder function instead.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+	lock sync.Mutex
+}
+
+// Make sure that we implement the http.RoundTripper interface:
+var _ http.RoundTripper = &Recorder{}
+
+// NewRecorder creates a round tripper that sends the requests to the next round tripper and
+// appends them and their responses to the cassette in the given file.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	return &Recorder{
+		path: path,
+		next: next,
+	}
+}
+
+// RoundTrip is the implementation of the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(request *http.Request) (response *http.Response, err error) {
+	requestBody, err := readBody(&request.Body)
+	if err != nil {
+		return
+	}
+	response, err = r.next.RoundTrip(request)
+	if err != nil {
+		return
+	}
+	responseBody, err := readBody(&response.Body)
+	if err != nil {
+		return
+	}
+
+	interaction := &Interaction{
+		Request:  recordRequest(request, requestBody),
+		Response: recordResponse(response, responseBody),
+	}
+	err = r.append(interaction)
+	if err != nil {
+		response.Body.Close()
+		return nil, fmt.Errorf("Failed to record %s %s: %v", request.Method, request.URL, err)
+	}
+	return
+}
+
+// append writes the interaction at the end of the cassette. The file is opened for each
+// interaction, so that the cassette is complete even if the command exits without closing it.
+func (r *Recorder) append(interaction *Interaction) error {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err = os.MkdirAll(filepath.Dir(r.path), 0700)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Player is a round tripper that returns the responses recorded in a cassette instead of sending
+// the requests. Don't create instances of this type directly; use the NewPlayer function instead.
+type Player struct {
+	path         string
+	load         sync.Once
+	loadErr      error
+	interactions []*Interaction
+	used         []bool
+	lock         sync.Mutex
+}
+
+// Make sure that we implement the http.RoundTripper interface:
+var _ http.RoundTripper = &Player{}
+
+// NewPlayer creates a round tripper that replays the cassette in the given file. The file is read
+// when the first request is sent.
+func NewPlayer(path string) *Player {
+	return &Player{
+		path: path,
+	}
+}
+
+// RoundTrip is the implementation of the http.RoundTripper interface. The response is the one of
+// the first interaction not replayed yet whose request has the same method, URL and body, or the
+// same method and URL if there is none, so that requests whose bodies contain generated values
+// are replayed in the recorded order.
+func (p *Player) RoundTrip(request *http.Request) (*http.Response, error) {
+	p.load.Do(func() {
+		p.interactions, p.loadErr = ReadCassette(p.path)
+		p.used = make([]bool, len(p.interactions))
+	})
+	if p.loadErr != nil {
+		return nil, p.loadErr
+	}
+	body, err := readBody(&request.Body)
+	if err != nil {
+		return nil, err
+	}
+	recorded := recordRequest(request, body)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	index := p.find(recorded, true)
+	if index == -1 {
+		index = p.find(recorded, false)
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("No recorded response in cassette '%s' for %s %s",
+			p.path, request.Method, request.URL)
+	}
+	p.used[index] = true
+
+	response := p.interactions[index].Response
+	header := response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       request,
+	}, nil
+}
+
+func (p *Player) find(request RecordedRequest, matchBody bool) int {
+	for i, interaction := range p.interactions {
+		if p.used[i] {
+			continue
+		}
+		recorded := interaction.Request
+		if recorded.Method != request.Method || recorded.URL != request.URL ||
+			recorded.Header.Get("X-Amz-Target") != request.Header.Get("X-Amz-Target") {
+			continue
+		}
+		if matchBody && recorded.Body != request.Body {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// ReadCassette reads the interactions of the cassette in the given file.
+func ReadCassette(path string) ([]*Interaction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open cassette: %v", err)
+	}
+	defer file.Close()
+	var result []*Interaction
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		interaction := &Interaction{}
+		err = json.Unmarshal(scanner.Bytes(), interaction)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse line %d of cassette '%s': %v", line, path, err)
+		}
+		result = append(result, interaction)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read cassette '%s': %v", path, err)
+	}
+	return result, nil
+}
+
+// readBody reads the complete body in memory and replaces it with a reader that reads it from
+// memory.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	err = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func recordRequest(request *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Header: redactHeader(request.Header),
+		Body:   redactBody(request.Header, body),
+	}
+}
+
+func recordResponse(response *http.Response, body []byte) RecordedResponse {
+	header := redactHeader(response.Header)
+	// The length changes when the body is redacted:
+	header.Del("Content-Length")
+	return RecordedResponse{
+		Status: response.StatusCode,
+		Header: header,
+		Body:   redactBody(response.Header, body),
+	}
+}
+
+func redactHeader(header http.Header) http.Header {
+	result := header.Clone()
+	if result == nil {
+		return http.Header{}
+	}
+	for name := range result {
+		if cassetteRedactedHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = []string{redactedReplacement}
+		}
+	}
+	// Dates and signatures change every time the request is sent:
+	result.Del("X-Amz-Date")
+	result.Del("Amz-Sdk-Invocation-Id")
+	result.Del("Amz-Sdk-Request")
+	return result
+}
+
+// redactBody removes the sensitive fields from the body, according to its content type.
+func redactBody(header http.Header, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		return redactForm(body)
+	case mediaType == "application/json" || strings.HasPrefix(mediaType, "application/x-amz-json"):
+		return redactJSON(body)
+	case strings.HasSuffix(mediaType, "xml"):
+		text := string(body)
+		for _, field := range cassetteXMLFields {
+			text = field.ReplaceAllStringFunc(text, func(match string) string {
+				name := match[1:strings.Index(match, ">")]
+				return fmt.Sprintf("<%s>%s</%s>", name, redactedReplacement, name)
+			})
+		}
+		return text
+	}
+	return string(body)
+}
+
+func redactForm(body []byte) string {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return string(body)
+	}
+	for name, values := range form {
+		for i := range values {
+			switch {
+			case cassetteTokenFields[name]:
+				values[i] = FakeToken()
+			case cassetteRedactedFields[name]:
+				values[i] = redactedReplacement
+			}
+		}
+	}
+	return form.Encode()
+}
+
+func redactJSON(body []byte) string {
+	parsed := ordered.NewOrderedMap()
+	err := json.Unmarshal(body, parsed)
+	if err != nil {
+		return string(body)
+	}
+	redactJSONValue(parsed)
+	data, err := json.Marshal(parsed)
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+func redactJSONValue(value interface{}) {
+	switch typed := value.(type) {
+	case *ordered.OrderedMap:
+		iterator := typed.EntriesIter()
+		for {
+			pair, ok := iterator()
+			if !ok {
+				break
+			}
+			switch {
+			case cassetteTokenFields[pair.Key]:
+				typed.Set(pair.Key, FakeToken())
+			case cassetteRedactedFields[pair.Key]:
+				typed.Set(pair.Key, redactedReplacement)
+			default:
+				redactJSONValue(pair.Value)
+			}
+		}
+	case []interface{}:
+		for _, item := range typed {
+			redactJSONValue(item)
+		}
+	}
+}
+
+// HTTPClient is the interface of the HTTP clients used by the AWS SDK.
+type HTTPClient interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// CassetteHTTPClient returns an HTTP client that records to, or replays from, the cassette with the
+// given name, like CassetteTransportWrapper. It returns the given client if recording and replaying
+// are disabled.
+func CassetteHTTPClient(name string, client HTTPClient) HTTPClient {
+	wrapper := CassetteTransportWrapper(name)
+	if wrapper == nil {
+		return client
+	}
+	return &cassetteHTTPClient{
+		transport: wrapper(roundTripperFunc(client.Do)),
+	}
+}
+
+type cassetteHTTPClient struct {
+	transport http.RoundTripper
+}
+
+func (c *cassetteHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	return c.transport.RoundTrip(request)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,170 @@
+package logging
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cassettes", func() {
+	var dir string
+	var sent []string
+
+	// server answers like OCM and AWS do, recording the requests that it receives:
+	server := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		var body []byte
+		if request.Body != nil {
+			body, _ = io.ReadAll(request.Body)
+		}
+		sent = append(sent, request.Method+" "+request.URL.String()+" "+string(body))
+		response := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+		}
+		switch request.URL.Host {
+		case "sso.redhat.com":
+			response.Header.Set("Content-Type", "application/json")
+			response.Body = io.NopCloser(strings.NewReader(
+				`{"access_token":"secret-access","refresh_token":"secret-refresh"}`))
+		case "secretsmanager.us-east-1.amazonaws.com":
+			response.Header.Set("Content-Type", "application/x-amz-json-1.1")
+			response.Body = io.NopCloser(strings.NewReader(`{"ARN":"arn:aws:secretsmanager:us-east-1:` +
+				`123456789012:secret:key","Name":"key"}`))
+		case "sts.amazonaws.com":
+			response.Header.Set("Content-Type", "text/xml")
+			response.Body = io.NopCloser(strings.NewReader(
+				`<Credentials><AccessKeyId>ASIA</AccessKeyId><SecretAccessKey>secret-key</SecretAccessKey>` +
+					`</Credentials>`))
+		default:
+			response.Header.Set("Content-Type", "application/json")
+			response.Body = io.NopCloser(strings.NewReader(`{"id":"` + string(body) + `"}`))
+		}
+		return response, nil
+	})
+
+	send := func(transport http.RoundTripper, method string, url string, body string) (int, string, error) {
+		request, err := http.NewRequest(method, url, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Authorization", "Bearer secret")
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		response, err := transport.RoundTrip(request)
+		if err != nil {
+			return 0, "", err
+		}
+		data, err := io.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return response.StatusCode, string(data), nil
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "rosa-cassettes-*")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		sent = nil
+	})
+
+	It("records redacted interactions", func() {
+		recorder := NewRecorder(filepath.Join(dir, OCMCassette+".jsonl"), server)
+		_, body, err := send(recorder, http.MethodPost, "https://sso.redhat.com/token", "client_secret=secret&grant_type=x")
+		Expect(err).NotTo(HaveOccurred())
+		// The response isn't changed when it is recorded:
+		Expect(body).To(ContainSubstring("secret-access"))
+		_, _, err = send(recorder, http.MethodPost, "https://sts.amazonaws.com/", "Action=AssumeRole")
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := os.ReadFile(filepath.Join(dir, OCMCassette+".jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("secret-"))
+		Expect(string(data)).To(ContainSubstring(FakeToken()))
+
+		interactions, err := ReadCassette(filepath.Join(dir, OCMCassette+".jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interactions).To(HaveLen(2))
+		Expect(interactions[0].Request.Header.Get("Authorization")).To(Equal(redactedReplacement))
+		Expect(interactions[0].Request.Body).To(Equal("client_secret=%2A%2A%2A&grant_type=x"))
+		Expect(interactions[1].Response.Body).To(Equal(
+			`<Credentials><AccessKeyId>***</AccessKeyId><SecretAccessKey>***</SecretAccessKey></Credentials>`))
+	})
+
+	It("records secrets without their values", func() {
+		path := filepath.Join(dir, AWSCassette+".jsonl")
+		client := secretsmanager.New(secretsmanager.Options{
+			Region:      "us-east-1",
+			Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+			HTTPClient:  &http.Client{Transport: NewRecorder(path, server)},
+		})
+		_, err := client.CreateSecret(context.Background(), &secretsmanager.CreateSecretInput{
+			Name:         aws.String("key"),
+			SecretString: aws.String("secret-private-key"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.PutSecretValue(context.Background(), &secretsmanager.PutSecretValueInput{
+			SecretId:     aws.String("key"),
+			SecretBinary: []byte("secret-binary-key"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent[0]).To(ContainSubstring("secret-private-key"))
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("secret-private-key"))
+		Expect(string(data)).NotTo(ContainSubstring(base64.StdEncoding.EncodeToString([]byte("secret-binary-key"))))
+
+		interactions, err := ReadCassette(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interactions).To(HaveLen(2))
+		Expect(interactions[0].Request.Body).To(ContainSubstring(`"SecretString":"***"`))
+		Expect(interactions[1].Request.Body).To(ContainSubstring(`"SecretBinary":"***"`))
+	})
+
+	It("replays interactions without sending requests", func() {
+		path := filepath.Join(dir, AWSCassette+".jsonl")
+		recorder := NewRecorder(path, server)
+		for _, body := range []string{"a", "b", "c"} {
+			_, _, err := send(recorder, http.MethodPost, "https://iam.amazonaws.com/", body)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(sent).To(HaveLen(3))
+
+		player := NewPlayer(path)
+		// Requests with the same body are matched first, then the rest in the recorded order:
+		for _, expected := range [][]string{{"b", "b"}, {"x", "a"}, {"c", "c"}} {
+			status, body, err := send(player, http.MethodPost, "https://iam.amazonaws.com/", expected[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal(`{"id":"` + expected[1] + `"}`))
+		}
+		_, _, err := send(player, http.MethodPost, "https://iam.amazonaws.com/", "a")
+		Expect(err).To(MatchError(ContainSubstring("No recorded response")))
+		Expect(sent).To(HaveLen(3))
+	})
+
+	It("fails to replay a cassette that doesn't exist", func() {
+		_, _, err := send(NewPlayer(filepath.Join(dir, "missing.jsonl")), http.MethodGet, "https://example.com", "")
+		Expect(err).To(MatchError(ContainSubstring("Failed to open cassette")))
+	})
+
+	It("wraps clients according to the environment", func() {
+		client := &http.Client{Transport: server}
+		Expect(CassetteHTTPClient(AWSCassette, client)).To(BeIdenticalTo(client))
+
+		DeferCleanup(os.Unsetenv, RecordEnvKey)
+		os.Setenv(RecordEnvKey, dir)
+		request, err := http.NewRequest(http.MethodGet, "https://ec2.amazonaws.com/", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = CassetteHTTPClient(AWSCassette, client).Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Join(dir, AWSCassette+".jsonl")).To(BeAnExistingFile())
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"slices"
@@ -27,6 +28,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/interactive/securitygroups"
 	. "github.com/openshift/rosa/pkg/kubeletconfig"
+	"github.com/openshift/rosa/pkg/maintenance"
 	"github.com/openshift/rosa/pkg/ocm"
 	ocmOutput "github.com/openshift/rosa/pkg/ocm/output"
 	"github.com/openshift/rosa/pkg/output"
@@ -1003,7 +1005,7 @@ func (m *machinePool) AddNodePool(cmd *cobra.Command, clusterKey string, cluster
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for max surge: %s", err)
-				os.Exit(1)
+				os.Exit(rprtr.Code(err).ExitCode())
 			}
 		}
 
@@ -1020,7 +1022,7 @@ func (m *machinePool) AddNodePool(cmd *cobra.Command, clusterKey string, cluster
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for max unavailable: %s", err)
-				os.Exit(1)
+				os.Exit(rprtr.Code(err).ExitCode())
 			}
 		}
 		if maxSurge != "" || maxUnavailable != "" {
@@ -1065,41 +1067,61 @@ func (m *machinePool) AddNodePool(cmd *cobra.Command, clusterKey string, cluster
 
 // ListMachinePools lists all machinepools (or, nodepools if hypershift) in a cluster
 func (m *machinePool) ListMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) error {
+	pools, err := loadMachinePools(r, clusterKey, cluster)
+	if err != nil {
+		return err
+	}
+
+	if output.HasFlag() {
+		return output.Print(pools)
+	}
+
+	writeMachinePools(os.Stdout, pools)
+	return nil
+}
+
+// WriteMachinePools writes the table of the machinepools (or, nodepools if hypershift) in a
+// cluster, and returns them
+func WriteMachinePools(out io.Writer, r *rosa.Runtime, clusterKey string,
+	cluster *cmv1.Cluster) (interface{}, error) {
+	pools, err := loadMachinePools(r, clusterKey, cluster)
+	if err != nil {
+		return nil, err
+	}
+	writeMachinePools(out, pools)
+	return pools, nil
+}
+
+func loadMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) (interface{}, error) {
 	// Load any existing machine pools for this cluster
 	r.Reporter.Debugf("Loading machine pools for cluster '%s'", clusterKey)
-	isHypershift := cluster.Hypershift().Enabled()
-	var err error
-	var machinePools []*cmv1.MachinePool
-	var nodePools []*cmv1.NodePool
-	if isHypershift {
-		nodePools, err = r.OCMClient.GetNodePools(cluster.ID())
-		if err != nil {
-			return err
-		}
-	} else {
-		machinePools, err = r.OCMClient.GetMachinePools(cluster.ID())
+	if cluster.Hypershift().Enabled() {
+		nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
 		if err != nil {
-			return err
+			return nil, err
 		}
+		return nodePools, nil
 	}
-
-	if output.HasFlag() {
-		if isHypershift {
-			return output.Print(nodePools)
-		}
-		return output.Print(machinePools)
+	machinePools, err := r.OCMClient.GetMachinePools(cluster.ID())
+	if err != nil {
+		return nil, err
 	}
+	return machinePools, nil
+}
 
+func writeMachinePools(out io.Writer, pools interface{}) {
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 
-	finalStringToOutput := getMachinePoolsString(machinePools)
-	if isHypershift {
-		finalStringToOutput = getNodePoolsString(nodePools)
+	var finalStringToOutput string
+	switch typed := pools.(type) {
+	case []*cmv1.NodePool:
+		finalStringToOutput = getNodePoolsString(typed)
+	case []*cmv1.MachinePool:
+		finalStringToOutput = getMachinePoolsString(typed)
 	}
 	fmt.Fprint(writer, finalStringToOutput)
 	writer.Flush()
-	return nil
 }
 
 // DescribeMachinePool describes either a machinepool, or, a nodepool (if hypershift)
@@ -1760,7 +1782,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 		err = ValidateKubeletConfig(inputKubeletConfig)
 		if err != nil {
 			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			os.Exit(rprtr.Code(err).ExitCode())
 		}
 		npBuilder.KubeletConfigs(inputKubeletConfig...)
 		isKubeletConfigSet = true
@@ -1819,7 +1841,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for max surge: %s", err)
-				os.Exit(1)
+				os.Exit(rprtr.Code(err).ExitCode())
 			}
 
 			maxUnavailable, err = interactive.GetString(interactive.Input{
@@ -1833,7 +1855,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for max unavailable: %s", err)
-				os.Exit(1)
+				os.Exit(rprtr.Code(err).ExitCode())
 			}
 		}
 
@@ -1854,6 +1876,14 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 		return fmt.Errorf("Failed to create machine pool for hosted cluster '%s': %v", clusterKey, err)
 	}
 
+	if isKubeletConfigSet && recreatesNodes(nodePool, update) {
+		err = maintenance.CheckTime(r.Reporter, cluster,
+			fmt.Sprintf("Recreating the nodes of machine pool '%s'", nodePool.ID()), time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
 	if isKubeletConfigSet && !promptForNodePoolNodeRecreate(nodePool, update, PromptToAcceptNodePoolNodeRecreate, r) {
 		return nil
 	}
@@ -1874,17 +1904,26 @@ func promptForNodePoolNodeRecreate(
 	original *cmv1.NodePool,
 	update *cmv1.NodePool,
 	promptFunc func(r *rosa.Runtime) bool, r *rosa.Runtime) bool {
-	if len(original.KubeletConfigs()) != len(update.KubeletConfigs()) {
+	if recreatesNodes(original, update) {
 		return promptFunc(r)
 	}
+	return true
+}
+
+// recreatesNodes returns if the update of the node pool changes its kubelet configs, which causes
+// its nodes to be recreated.
+func recreatesNodes(original *cmv1.NodePool, update *cmv1.NodePool) bool {
+	if len(original.KubeletConfigs()) != len(update.KubeletConfigs()) {
+		return true
+	}
 
 	for _, s := range update.KubeletConfigs() {
 		if !slices.Contains(original.KubeletConfigs(), s) {
-			return promptFunc(r)
+			return true
 		}
 	}
 
-	return true
+	return false
 }
 
 func getNodePoolReplicas(cmd *cobra.Command,
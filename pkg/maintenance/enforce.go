@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// OverrideFlag is the flag that allows changes outside of the maintenance window.
+const OverrideFlag = "override-window"
+
+var override bool
+
+// AddOverrideFlag adds the --override-window flag to the given set of command line flags.
+func AddOverrideFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&override,
+		OverrideFlag,
+		false,
+		"Proceed even if the change happens outside the maintenance window of the cluster, or in one "+
+			"of its freeze periods. See 'rosa create maintenance-window'.",
+	)
+}
+
+// Overridden returns if the maintenance window was overridden with the --override-window flag.
+func Overridden() bool {
+	return override
+}
+
+// SetOverridden changes the value of the --override-window flag.
+func SetOverridden(value bool) {
+	override = value
+}
+
+// CheckTime checks that the change, described by the action, made to the cluster at the given time
+// is allowed by its maintenance window, if it has one. When it isn't the change fails, unless the
+// window is overridden, then it is only reported as a warning.
+func CheckTime(r *reporter.Object, cluster *cmv1.Cluster, action string, at time.Time) error {
+	return check(r, cluster, action, func(window *Window) error {
+		return window.Check(at)
+	})
+}
+
+// CheckCron checks that all the occurrences of the cron expression, in UTC, of the change described
+// by the action are allowed by the maintenance window of the cluster, the same way as CheckTime.
+func CheckCron(r *reporter.Object, cluster *cmv1.Cluster, action string, expression string) error {
+	return check(r, cluster, action, func(window *Window) error {
+		return window.CheckCron(expression, time.Now())
+	})
+}
+
+func check(r *reporter.Object, cluster *cmv1.Cluster, action string, check func(*Window) error) error {
+	store, err := Load()
+	if err != nil {
+		return fmt.Errorf("Failed to load the maintenance windows: %v", err)
+	}
+	window := store.Get(cluster.ID())
+	if window == nil {
+		return nil
+	}
+	err = check(window)
+	if err == nil {
+		return nil
+	}
+	if override {
+		r.Warnf("%s isn't allowed by the maintenance window of cluster '%s': %v. Proceeding because of '--%s'",
+			action, cluster.Name(), err, OverrideFlag)
+		return nil
+	}
+	return fmt.Errorf("%s isn't allowed by the maintenance window of cluster '%s': %v. Use '--%s' to proceed "+
+		"anyway", action, cluster.Name(), err, OverrideFlag)
+}
@@ -0,0 +1,60 @@
+package maintenance
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+var _ = Describe("Enforce", func() {
+	var cluster *cmv1.Cluster
+	var r *reporter.Object
+	saturday := time.Date(2024, 3, 9, 23, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		path := filepath.Join(GinkgoT().TempDir(), fileName)
+		GinkgoT().Setenv(PathEnv, path)
+		SetOverridden(false)
+		DeferCleanup(SetOverridden, false)
+
+		var err error
+		cluster, err = cmv1.NewCluster().ID("123").Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		r = reporter.CreateReporter()
+
+		store, err := LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		store.Set(&Window{ClusterID: "123", ClusterName: "mycluster", Days: []string{"sat", "sun"}, Timezone: "UTC"})
+		Expect(store.Save()).To(Succeed())
+	})
+
+	It("Allows changes in the window", func() {
+		Expect(CheckTime(r, cluster, "Upgrade", saturday)).To(Succeed())
+	})
+
+	It("Refuses changes outside the window", func() {
+		Expect(CheckTime(r, cluster, "Upgrade", monday)).To(MatchError(
+			"Upgrade isn't allowed by the maintenance window of cluster 'mycluster': 2024-03-11 12:00 UTC is " +
+				"outside the maintenance window sat,sun all day UTC. Use '--override-window' to proceed anyway"))
+		Expect(CheckCron(r, cluster, "Upgrade", "0 12 * * 1")).To(MatchError(ContainSubstring(
+			"Use '--override-window'")))
+	})
+
+	It("Allows changes outside the window when overridden", func() {
+		SetOverridden(true)
+		Expect(CheckTime(r, cluster, "Upgrade", monday)).To(Succeed())
+		Expect(CheckCron(r, cluster, "Upgrade", "0 12 * * 1")).To(Succeed())
+	})
+
+	It("Allows changes to clusters without window", func() {
+		other, err := cmv1.NewCluster().ID("456").Name("other").Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(CheckTime(r, other, "Upgrade", monday)).To(Succeed())
+	})
+})
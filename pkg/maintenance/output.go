@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// PrintWindowsForTabularOutput returns the table of the given windows.
+func PrintWindowsForTabularOutput(windows []*Window) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "CLUSTER\tDAYS\tHOURS\tTIMEZONE\tFREEZES\n")
+	for _, window := range windows {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			window.ClusterName,
+			formatDays(window.Days),
+			formatHours(window),
+			window.Timezone,
+			formatFreezes(window.Freezes, ", "),
+		)
+	}
+	writer.Flush()
+	return buffer.String()
+}
+
+// PrintWindowDescription returns the description of the window, and if changes are allowed at the
+// given time.
+func PrintWindowDescription(window *Window, now time.Time) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(writer, "Cluster ID:\t%s\n", window.ClusterID)
+	fmt.Fprintf(writer, "Cluster name:\t%s\n", window.ClusterName)
+	fmt.Fprintf(writer, "Days:\t%s\n", formatDays(window.Days))
+	fmt.Fprintf(writer, "Hours:\t%s\n", formatHours(window))
+	fmt.Fprintf(writer, "Time zone:\t%s\n", window.Timezone)
+	fmt.Fprintf(writer, "Freezes:\t%s\n", valueOrNone(formatFreezes(window.Freezes, "\n\t")))
+	status := "Allowed"
+	if err := window.Check(now); err != nil {
+		status = fmt.Sprintf("Not allowed, %v", err)
+	}
+	fmt.Fprintf(writer, "Changes now:\t%s\n", status)
+	fmt.Fprintf(writer, "Created:\t%s\n", window.CreatedAt.Format(time.RFC3339))
+	writer.Flush()
+	return buffer.String()
+}
+
+func formatDays(days []string) string {
+	if len(days) == 0 || len(days) == len(Days) {
+		return "All"
+	}
+	return strings.Join(days, ",")
+}
+
+func formatHours(window *Window) string {
+	if window.StartTime == "" {
+		return "All day"
+	}
+	return fmt.Sprintf("%s-%s", window.StartTime, window.EndTime)
+}
+
+func formatFreezes(freezes []Freeze, separator string) string {
+	values := make([]string, len(freezes))
+	for i, freeze := range freezes {
+		values[i] = freeze.String()
+	}
+	return strings.Join(values, separator)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
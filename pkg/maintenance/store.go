@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"io"
+	"time"
+
+	"github.com/openshift/rosa/pkg/clusterstore"
+)
+
+// PathEnv is the environment variable that overrides the location of the windows file.
+const PathEnv = "ROSA_MAINTENANCE_WINDOWS"
+
+const fileName = "maintenance-windows.json"
+
+var kind = &clusterstore.Kind[*Window]{
+	Name:        "maintenance window",
+	Description: "maintenance windows",
+	Field:       "windows",
+	PathEnv:     PathEnv,
+	FileName:    fileName,
+	ClusterID:   func(window *Window) string { return window.ClusterID },
+	ClusterName: func(window *Window) string { return window.ClusterName },
+}
+
+// Store is the set of windows saved in a file, at most one for each cluster.
+type Store = clusterstore.Store[*Window]
+
+// Path returns the location of the windows file.
+func Path() (string, error) {
+	return kind.Path()
+}
+
+// Load reads the windows from the default file.
+func Load() (*Store, error) {
+	return kind.Load()
+}
+
+// LoadFile reads the windows from the given file. A file that doesn't exist has no windows.
+func LoadFile(path string) (*Store, error) {
+	return kind.LoadFile(path)
+}
+
+// Find loads the windows from the default file and returns the one of the given cluster. It fails
+// if the cluster has no window.
+func Find(clusterKey string) (*Store, *Window, error) {
+	return kind.Find(clusterKey)
+}
+
+// WriteDescription writes the window of the given cluster, in the format of the output flag if it
+// was used.
+func WriteDescription(writer io.Writer, clusterKey string, now time.Time) error {
+	return kind.WriteDescription(writer, clusterKey, func(window *Window) string {
+		return PrintWindowDescription(window, now)
+	})
+}
@@ -0,0 +1,28 @@
+package maintenance
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	It("Keeps the format of the windows file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), fileName)
+		Expect(os.WriteFile(path, []byte(`{"windows": [{"cluster_id": "1", "cluster_name": "a", `+
+			`"days": ["sat"], "timezone": "UTC", "freezes": [{"start": "2024-03-25", "end": "2024-04-05"}]}]}`),
+			0600)).To(Succeed())
+		store, err := LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Get("a").Days).To(Equal([]string{"sat"}))
+		Expect(store.Get("1").Freezes).To(HaveLen(1))
+
+		store.Set(&Window{ClusterID: "2", ClusterName: "b", Timezone: "UTC"})
+		Expect(store.Save()).To(Succeed())
+		loaded, err := LoadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Items).To(HaveLen(2))
+	})
+})
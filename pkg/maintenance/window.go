@@ -0,0 +1,317 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenance contains the maintenance windows of clusters, the days and hours when their
+// upgrades and other disruptive changes are allowed, and the freeze periods when they aren't. The
+// windows are kept in a local file and checked by the commands that schedule upgrades or recreate
+// nodes.
+package maintenance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultTimezone is the time zone of the window when none is given.
+const DefaultTimezone = "UTC"
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+)
+
+// cronHorizon and maxOccurrences limit the occurrences of a cron expression that are checked
+// against a window.
+const (
+	cronHorizon    = 366 * 24 * time.Hour
+	maxOccurrences = 10000
+)
+
+// Days are the abbreviations of the days of the week, in the order of time.Weekday.
+var Days = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Freeze is a period when no changes are allowed, such as the end of a quarter. The start and end
+// dates are inclusive and use the time zone of the window.
+type Freeze struct {
+	Name  string `json:"name,omitempty"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Window is the maintenance window of a cluster.
+type Window struct {
+	ClusterID   string `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+
+	// Days are the days of the week when changes are allowed, all of them when empty.
+	Days []string `json:"days,omitempty"`
+
+	// StartTime and EndTime are the times of the day, in 'HH:MM' format, when changes are allowed,
+	// all day when empty. When the end isn't after the start the window continues past midnight,
+	// and the days refer to the day when it starts.
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+	Timezone  string `json:"timezone"`
+
+	Freezes []Freeze `json:"freezes,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParseDays parses a list of days of the week, given by name or abbreviation, and returns their
+// abbreviations in order.
+func ParseDays(values []string) ([]string, error) {
+	var days []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		day := ""
+		for _, candidate := range Days {
+			if value == candidate || value == strings.ToLower(dayName(candidate)) {
+				day = candidate
+			}
+		}
+		if day == "" {
+			return nil, fmt.Errorf("Day '%s' isn't valid, expected one of '%s'", value,
+				strings.Join(Days, "', '"))
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b string) int {
+		return slices.Index(Days, a) - slices.Index(Days, b)
+	})
+	return days, nil
+}
+
+// ParseFreeze parses a freeze period in the format 'name=start/end', where the name and the end are
+// optional, for example 'end-of-quarter=2024-03-25/2024-04-05' or '2024-12-25'.
+func ParseFreeze(value string) (Freeze, error) {
+	freeze := Freeze{}
+	period := value
+	if name, rest, found := strings.Cut(value, "="); found {
+		freeze.Name = strings.TrimSpace(name)
+		period = rest
+	}
+	start, end, found := strings.Cut(period, "/")
+	freeze.Start = strings.TrimSpace(start)
+	freeze.End = freeze.Start
+	if found {
+		freeze.End = strings.TrimSpace(end)
+	}
+	err := freeze.Validate()
+	if err != nil {
+		return Freeze{}, fmt.Errorf("Freeze period '%s' isn't valid: %v", value, err)
+	}
+	return freeze, nil
+}
+
+// Validate checks that the dates of the freeze are valid and in order.
+func (f Freeze) Validate() error {
+	start, err := time.Parse(dateLayout, f.Start)
+	if err != nil {
+		return fmt.Errorf("start date '%s' should use the format 'yyyy-mm-dd'", f.Start)
+	}
+	end, err := time.Parse(dateLayout, f.End)
+	if err != nil {
+		return fmt.Errorf("end date '%s' should use the format 'yyyy-mm-dd'", f.End)
+	}
+	if end.Before(start) {
+		return fmt.Errorf("end date '%s' is before start date '%s'", f.End, f.Start)
+	}
+	return nil
+}
+
+// contains returns if the date of the given time, in its own location, is in the freeze.
+func (f Freeze) contains(t time.Time) bool {
+	date := t.Format(dateLayout)
+	return date >= f.Start && date <= f.End
+}
+
+// String returns the freeze in the same format that ParseFreeze accepts.
+func (f Freeze) String() string {
+	period := f.Start
+	if f.End != f.Start {
+		period = fmt.Sprintf("%s/%s", f.Start, f.End)
+	}
+	if f.Name == "" {
+		return period
+	}
+	return fmt.Sprintf("%s=%s", f.Name, period)
+}
+
+// Validate checks that the window has a cluster, valid days, times and time zone, and valid
+// freezes.
+func (w *Window) Validate() error {
+	if w.ClusterID == "" {
+		return fmt.Errorf("Maintenance window has no cluster")
+	}
+	_, err := w.location()
+	if err != nil {
+		return err
+	}
+	_, err = ParseDays(w.Days)
+	if err != nil {
+		return err
+	}
+	if (w.StartTime == "") != (w.EndTime == "") {
+		return fmt.Errorf("Maintenance window of cluster '%s' needs both a start and an end time",
+			w.ClusterName)
+	}
+	for _, value := range []string{w.StartTime, w.EndTime} {
+		if value == "" {
+			continue
+		}
+		_, err = parseTimeOfDay(value)
+		if err != nil {
+			return err
+		}
+	}
+	for _, freeze := range w.Freezes {
+		err = freeze.Validate()
+		if err != nil {
+			return fmt.Errorf("Freeze period '%s' isn't valid: %v", freeze, err)
+		}
+	}
+	return nil
+}
+
+// Check returns an error explaining why changes aren't allowed at the given time, or nil if they
+// are.
+func (w *Window) Check(t time.Time) error {
+	location, err := w.location()
+	if err != nil {
+		return err
+	}
+	local := t.In(location)
+	for _, freeze := range w.Freezes {
+		if freeze.contains(local) {
+			name := ""
+			if freeze.Name != "" {
+				name = fmt.Sprintf(" '%s'", freeze.Name)
+			}
+			return fmt.Errorf("%s is in freeze period%s from %s to %s", formatTime(local), name,
+				freeze.Start, freeze.End)
+		}
+	}
+	if !w.allows(local) {
+		return fmt.Errorf("%s is outside the maintenance window %s", formatTime(local), w.Summary())
+	}
+	return nil
+}
+
+// CheckCron checks that all the occurrences of the cron expression, in UTC, after the given time
+// are allowed by the window. Only the occurrences within a year are checked, as freezes are rarely
+// planned further ahead.
+func (w *Window) CheckCron(expression string, from time.Time) error {
+	schedule, err := cronParser.Parse(fmt.Sprintf("CRON_TZ=UTC %s", strings.TrimSpace(expression)))
+	if err != nil {
+		return fmt.Errorf("Schedule '%s' is not a valid cron expression", expression)
+	}
+	until := from.Add(cronHorizon)
+	next := schedule.Next(from)
+	for i := 0; i < maxOccurrences && !next.IsZero() && next.Before(until); i++ {
+		err = w.Check(next)
+		if err != nil {
+			return fmt.Errorf("the occurrence of schedule '%s' at %v", expression, err)
+		}
+		next = schedule.Next(next)
+	}
+	return nil
+}
+
+// Summary returns a short description of the days and hours of the window.
+func (w *Window) Summary() string {
+	days := "every day"
+	if len(w.Days) > 0 && len(w.Days) < len(Days) {
+		days = strings.Join(w.Days, ",")
+	}
+	hours := "all day"
+	if w.StartTime != "" {
+		hours = fmt.Sprintf("%s-%s", w.StartTime, w.EndTime)
+	}
+	return fmt.Sprintf("%s %s %s", days, hours, w.timezone())
+}
+
+// allows returns if the days and hours of the window contain the given time, that is already in
+// the time zone of the window.
+func (w *Window) allows(local time.Time) bool {
+	if w.StartTime == "" {
+		return w.allowsDay(local.Weekday())
+	}
+	start, err := parseTimeOfDay(w.StartTime)
+	if err != nil {
+		return false
+	}
+	end, err := parseTimeOfDay(w.EndTime)
+	if err != nil {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return w.allowsDay(local.Weekday()) && minute >= start && minute < end
+	}
+	// The window continues past midnight, the early hours belong to the window of the day before
+	if minute >= start {
+		return w.allowsDay(local.Weekday())
+	}
+	return minute < end && w.allowsDay((local.Weekday()+6)%7)
+}
+
+func (w *Window) allowsDay(day time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, Days[day])
+}
+
+func (w *Window) timezone() string {
+	if w.Timezone == "" {
+		return DefaultTimezone
+	}
+	return w.Timezone
+}
+
+func (w *Window) location() (*time.Location, error) {
+	location, err := time.LoadLocation(w.timezone())
+	if err != nil {
+		return nil, fmt.Errorf("Time zone '%s' isn't valid: %v", w.Timezone, err)
+	}
+	return location, nil
+}
+
+// parseTimeOfDay returns the minutes since midnight of a time in 'HH:MM' format.
+func parseTimeOfDay(value string) (int, error) {
+	parsed, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("Time '%s' should use the format 'HH:MM'", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func dayName(day string) string {
+	return time.Weekday(slices.Index(Days, day)).String()
+}
+
+func formatTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,161 @@
+package maintenance
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Window", func() {
+	var berlin *time.Location
+
+	BeforeEach(func() {
+		var err error
+		berlin, err = time.LoadLocation("Europe/Berlin")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	newWindow := func() *Window {
+		return &Window{
+			ClusterID:   "123",
+			ClusterName: "mycluster",
+			Days:        []string{"sat", "sun"},
+			StartTime:   "22:00",
+			EndTime:     "04:00",
+			Timezone:    "Europe/Berlin",
+			Freezes: []Freeze{
+				{Name: "end-of-quarter", Start: "2024-03-25", End: "2024-04-05"},
+			},
+		}
+	}
+
+	Context("ParseDays", func() {
+		It("Accepts names and abbreviations and sorts them", func() {
+			days, err := ParseDays([]string{"Saturday", " mon", "sat", ""})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(days).To(Equal([]string{"mon", "sat"}))
+		})
+
+		It("Rejects unknown days", func() {
+			_, err := ParseDays([]string{"funday"})
+			Expect(err).To(MatchError(ContainSubstring("Day 'funday' isn't valid")))
+		})
+	})
+
+	Context("ParseFreeze", func() {
+		DescribeTable("Parses freeze periods",
+			func(value string, expected Freeze) {
+				freeze, err := ParseFreeze(value)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(freeze).To(Equal(expected))
+				Expect(freeze.String()).To(Equal(value))
+			},
+			Entry("Named period", "end-of-quarter=2024-03-25/2024-04-05",
+				Freeze{Name: "end-of-quarter", Start: "2024-03-25", End: "2024-04-05"}),
+			Entry("Unnamed period", "2024-03-25/2024-04-05", Freeze{Start: "2024-03-25", End: "2024-04-05"}),
+			Entry("Single day", "christmas=2024-12-25", Freeze{Name: "christmas", Start: "2024-12-25", End: "2024-12-25"}),
+		)
+
+		DescribeTable("Rejects invalid freeze periods",
+			func(value string, message string) {
+				_, err := ParseFreeze(value)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("Invalid start", "eoq=2024-13-01", "start date"),
+			Entry("Invalid end", "eoq=2024-03-25/next week", "end date"),
+			Entry("End before start", "eoq=2024-04-05/2024-03-25", "is before start date"),
+		)
+	})
+
+	Context("Validate", func() {
+		It("Accepts a valid window", func() {
+			Expect(newWindow().Validate()).To(Succeed())
+		})
+
+		It("Accepts a window without days nor hours", func() {
+			window := &Window{ClusterID: "123", ClusterName: "mycluster"}
+			Expect(window.Validate()).To(Succeed())
+		})
+
+		DescribeTable("Rejects invalid windows",
+			func(modify func(*Window), message string) {
+				window := newWindow()
+				modify(window)
+				Expect(window.Validate()).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("No cluster", func(w *Window) { w.ClusterID = "" }, "no cluster"),
+			Entry("Invalid time zone", func(w *Window) { w.Timezone = "Mars/Olympus" }, "Time zone"),
+			Entry("Invalid day", func(w *Window) { w.Days = []string{"funday"} }, "Day"),
+			Entry("Invalid time", func(w *Window) { w.StartTime = "25:00" }, "format 'HH:MM'"),
+			Entry("Missing end time", func(w *Window) { w.EndTime = "" }, "both a start and an end time"),
+			Entry("Invalid freeze", func(w *Window) { w.Freezes[0].End = "2024-03-01" }, "Freeze period"),
+		)
+	})
+
+	Context("Check", func() {
+		DescribeTable("Allows the times in the window",
+			func(at time.Time) {
+				Expect(newWindow().Check(at)).To(Succeed())
+			},
+			Entry("Saturday night", time.Date(2024, 3, 9, 23, 0, 0, 0, time.UTC)),
+			Entry("Early Sunday from the window of Saturday", time.Date(2024, 3, 10, 1, 0, 0, 0, time.UTC)),
+			Entry("Early Monday from the window of Sunday", time.Date(2024, 3, 11, 2, 59, 0, 0, time.UTC)),
+		)
+
+		DescribeTable("Refuses the times outside the window",
+			func(at time.Time) {
+				Expect(newWindow().Check(at)).To(MatchError(ContainSubstring(
+					"is outside the maintenance window sat,sun 22:00-04:00 Europe/Berlin")))
+			},
+			Entry("Saturday afternoon", time.Date(2024, 3, 9, 15, 0, 0, 0, time.UTC)),
+			Entry("Early Saturday from the window of Friday", time.Date(2024, 3, 9, 1, 0, 0, 0, time.UTC)),
+			Entry("Monday after the end", time.Date(2024, 3, 11, 3, 0, 0, 0, time.UTC)),
+		)
+
+		It("Uses the time zone of the window", func() {
+			window := newWindow()
+			window.Days = nil
+			window.StartTime = "09:00"
+			window.EndTime = "17:00"
+			Expect(window.Check(time.Date(2024, 3, 4, 8, 30, 0, 0, time.UTC))).To(Succeed())
+			Expect(window.Check(time.Date(2024, 3, 4, 16, 30, 0, 0, time.UTC))).To(
+				MatchError("2024-03-04 17:30 CET is outside the maintenance window every day 09:00-17:00 " +
+					"Europe/Berlin"))
+		})
+
+		It("Refuses the times in a freeze", func() {
+			err := newWindow().Check(time.Date(2024, 3, 30, 23, 0, 0, 0, berlin))
+			Expect(err).To(MatchError("2024-03-30 23:00 CET is in freeze period 'end-of-quarter' from " +
+				"2024-03-25 to 2024-04-05"))
+		})
+
+		It("Allows all the times without days nor hours", func() {
+			window := &Window{ClusterID: "123", Timezone: "UTC"}
+			Expect(window.Check(time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC))).To(Succeed())
+		})
+	})
+
+	Context("CheckCron", func() {
+		It("Allows a schedule that always runs in the window", func() {
+			// Saturdays at 23:00 in winter, and Sundays at 00:00 in summer, in Berlin:
+			Expect(newWindow().CheckCron("0 22 * * 6", time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC))).To(Succeed())
+		})
+
+		It("Refuses a schedule that runs outside the window", func() {
+			err := newWindow().CheckCron("0 10 * * *", time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC))
+			Expect(err).To(MatchError(ContainSubstring(
+				"the occurrence of schedule '0 10 * * *' at 2024-03-04 11:00 CET is outside")))
+		})
+
+		It("Refuses a schedule that runs in a freeze", func() {
+			err := newWindow().CheckCron("0 22 * * 6", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+			Expect(err).To(MatchError(ContainSubstring("at 2024-03-30 23:00 CET is in freeze period")))
+		})
+
+		It("Rejects invalid cron expressions", func() {
+			err := newWindow().CheckCron("0 25 * * *", time.Now())
+			Expect(err).To(MatchError("Schedule '0 25 * * *' is not a valid cron expression"))
+		})
+	})
+})
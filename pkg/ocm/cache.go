@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/cache"
+)
+
+// Caches of the reference data that is requested repeatedly, for example by the interactive mode
+// of the create cluster command.
+var (
+	versionsCache = cache.Typed[[]*cmv1.Version]{
+		Kind: cache.VersionsKind,
+		TTL:  cache.VersionsTTL,
+		Codec: cache.Codec[[]*cmv1.Version]{
+			Marshal:   cmv1.MarshalVersionList,
+			Unmarshal: cmv1.UnmarshalVersionList,
+		},
+	}
+	regionsCache = cache.Typed[[]*cmv1.CloudRegion]{
+		Kind: cache.RegionsKind,
+		TTL:  cache.RegionsTTL,
+		Codec: cache.Codec[[]*cmv1.CloudRegion]{
+			Marshal:   cmv1.MarshalCloudRegionList,
+			Unmarshal: cmv1.UnmarshalCloudRegionList,
+		},
+	}
+	machineTypesCache = cache.Typed[[]*cmv1.MachineType]{
+		Kind: cache.MachineTypesKind,
+		TTL:  cache.MachineTypesTTL,
+		Codec: cache.Codec[[]*cmv1.MachineType]{
+			Marshal:   cmv1.MarshalMachineTypeList,
+			Unmarshal: cmv1.UnmarshalMachineTypeList,
+		},
+	}
+	gatesCache = cache.Typed[[]*cmv1.VersionGate]{
+		Kind: cache.GatesKind,
+		TTL:  cache.GatesTTL,
+		Codec: cache.Codec[[]*cmv1.VersionGate]{
+			Marshal:   cmv1.MarshalVersionGateList,
+			Unmarshal: cmv1.UnmarshalVersionGateList,
+		},
+	}
+	policiesCache = cache.Typed[[]*cmv1.AWSSTSPolicy]{
+		Kind: cache.PoliciesKind,
+		TTL:  cache.PoliciesTTL,
+		Codec: cache.Codec[[]*cmv1.AWSSTSPolicy]{
+			Marshal:   cmv1.MarshalAWSSTSPolicyList,
+			Unmarshal: cmv1.UnmarshalAWSSTSPolicyList,
+		},
+	}
+)
+
+// cacheKey returns the key of a cached value. It contains the URL of the OCM environment, so that
+// values of different environments aren't mixed.
+func (c *Client) cacheKey(parts ...string) string {
+	return c.ocm.URL() + "|" + strings.Join(parts, "|")
+}
+
+// cacheBodyKey returns a hash of the body of a request, used as part of the key of the values
+// that depend on it. Bodies may contain credentials, so they aren't used directly.
+func cacheBodyKey(marshal func(writer io.Writer) error) string {
+	hash := sha256.New()
+	err := marshal(hash)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseValidator returns the validator of a response, or ErrNotModified if the server answered
+// that the cached value didn't change.
+func responseValidator(status int, header http.Header) (cache.Validator, error) {
+	if status == http.StatusNotModified {
+		return cache.Validator{}, cache.ErrNotModified
+	}
+	return cache.NewValidator(header), nil
+}
+
+// listValidator returns the validator of the first page of a list response. Validators are only
+// used for lists that fit in one page, as they only tell if that page changed.
+func listValidator(status int, header http.Header, size int, pageSize int) (cache.Validator, error) {
+	validator, err := responseValidator(status, header)
+	if err != nil || size >= pageSize {
+		return cache.Validator{}, err
+	}
+	return validator, nil
+}
+
+// notModifiedTransportWrapper adds the JSON content type to the 304 Not Modified responses of the
+// conditional requests, as they usually don't have a content type and the SDK rejects responses
+// that aren't JSON.
+func notModifiedTransportWrapper(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		response, err := next.RoundTrip(request)
+		if err == nil && response.StatusCode == http.StatusNotModified &&
+			response.Header.Get("Content-Type") == "" {
+			response.Header.Set("Content-Type", "application/json")
+		}
+		return response, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
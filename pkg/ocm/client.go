@@ -25,7 +25,9 @@ import (
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/rosa/pkg/cache"
 	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/dryrun"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/logging"
@@ -34,6 +36,10 @@ import (
 
 type Client struct {
 	ocm *sdk.Connection
+
+	// cache of the reference data, like versions and regions. It is nil, disabling the cache, for
+	// clients created with NewClientWithConnection.
+	cache cache.RosaCacheService
 }
 
 // ClientBuilder contains the information and logic needed to build a connection to OCM. Don't
@@ -55,13 +61,13 @@ func NewClientWithConnection(connection *sdk.Connection) *Client {
 	}
 }
 
-func CreateNewClientOrExit(logger *logrus.Logger, reporter *reporter.Object) *Client {
+func CreateNewClientOrExit(logger *logrus.Logger, r *reporter.Object) *Client {
 	client, err := NewClient().
 		Logger(logger).
 		Build()
 	if err != nil {
-		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		r.Errorf("Failed to create OCM connection: %v", err)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 
 	return client
@@ -89,8 +95,12 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 			err = fmt.Errorf("Failed to load config file: %v", err)
 			return nil, err
 		}
+		if b.cfg == nil && logging.ReplayEnabled() {
+			b.cfg = &config.Config{}
+		}
 		if b.cfg == nil {
-			err = fmt.Errorf("Not logged in, run the 'rosa login' command")
+			err = reporter.WithCode(reporter.ErrorCodeAuth,
+				fmt.Errorf("Not logged in, run the 'rosa login' command"))
 			return nil, err
 		}
 	}
@@ -131,6 +141,13 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 	if b.cfg.URL != "" {
 		builder.URL(b.cfg.URL)
 	}
+	if logging.ReplayEnabled() {
+		// Requests aren't sent when replaying cassettes, so the tokens don't need to be valid:
+		cfg := *b.cfg
+		cfg.AccessToken = logging.FakeToken()
+		cfg.RefreshToken = ""
+		b.cfg = &cfg
+	}
 	tokens := make([]string, 0, 2)
 	if b.cfg.AccessToken != "" {
 		tokens = append(tokens, b.cfg.AccessToken)
@@ -142,6 +159,12 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 		builder.Tokens(tokens...)
 	}
 	builder.Insecure(b.cfg.Insecure)
+	builder.TransportWrapper(dryrun.TransportWrapper)
+	builder.TransportWrapper(notModifiedTransportWrapper)
+	if wrapper := logging.CassetteTransportWrapper(logging.OCMCassette); wrapper != nil {
+		// The last wrapper is the closest to the network:
+		builder.TransportWrapper(wrapper)
+	}
 
 	// Create the connection:
 	conn, err := builder.Build()
@@ -151,14 +174,25 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 	_, _, err = conn.Tokens(10 * time.Minute)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid_grant") {
-			return nil, fmt.Errorf("your authorization token needs to be updated. " +
-				"Please login again using rosa login")
+			return nil, reporter.WithCode(reporter.ErrorCodeAuth,
+				fmt.Errorf("your authorization token needs to be updated. "+
+					"Please login again using rosa login"))
 		}
-		return nil, fmt.Errorf("error creating connection. Not able to get authentication token: %s", err)
+		return nil, reporter.WithCode(reporter.ErrorCodeAuth,
+			fmt.Errorf("error creating connection. Not able to get authentication token: %s", err))
 	}
-	return &Client{
+	client := &Client{
 		ocm: conn,
-	}, nil
+	}
+	// Cached values would be missing from the cassettes:
+	if !cache.Disabled() && !logging.CassettesEnabled() {
+		client.cache, err = cache.NewRosaCacheService()
+		if err != nil {
+			// The cache is rewritten when values are saved
+			b.logger.Debugf("Failed to load the cache: %v", err)
+		}
+	}
+	return client, nil
 }
 
 func (c *Client) Close() error {
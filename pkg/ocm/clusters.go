@@ -356,7 +356,9 @@ func (c *Client) GetCluster(clusterKey string, creator *aws.Creator) (*cmv1.Clus
 	case 0:
 		return nil, errors.NotFound.Errorf("There is no cluster with identifier or name '%s'", clusterKey)
 	case 1:
-		return response.Items().Slice()[0], nil
+		cluster := response.Items().Slice()[0]
+		rprtr.SetClusterID(cluster.ID())
+		return cluster, nil
 	default:
 		return nil, fmt.Errorf("There are %d clusters with identifier or name '%s'", response.Total(), clusterKey)
 	}
@@ -401,6 +403,17 @@ func (c *Client) GetClusterByID(clusterKey string, creator *aws.Creator) (*cmv1.
 	}
 }
 
+// FetchClusterByID gets the cluster with the given identifier directly, instead of searching the
+// clusters of the AWS account, so that the type of the error tells a cluster that doesn't exist
+// (not found) from one that the current organization can't see (forbidden).
+func (c *Client) FetchClusterByID(clusterID string) (*cmv1.Cluster, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Get().Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
 func (c *Client) GetClusterUsingSubscription(clusterKey string, creator *aws.Creator) (*amv1.Subscription, error) {
 	query := fmt.Sprintf("(plan.id = 'MOA' OR plan.id = 'MOA-HostedControlPlane')"+
 		" AND (display_name  = '%s' OR cluster_id = '%s') AND status = 'Deprovisioned'", clusterKey, clusterKey)
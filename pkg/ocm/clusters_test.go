@@ -1,9 +1,16 @@
 package ocm
 
 import (
+	"net/http"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	errors "github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/aws"
 )
@@ -93,3 +100,43 @@ var _ = Context("List Clusters", func() {
 
 	})
 })
+
+var _ = Describe("Fetch cluster by ID", func() {
+	var apiServer *ghttp.Server
+	var ocmClient *Client
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		connection, err := sdk.NewConnectionBuilder().
+			Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+			URL(apiServer.URL()).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		ocmClient = &Client{ocm: connection}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(ocmClient.Close()).To(Succeed())
+	})
+
+	It("Gets the cluster", func() {
+		apiServer.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+			RespondWithJSON(http.StatusOK, `{"kind": "Cluster", "id": "123", "name": "mycluster"}`),
+		))
+		cluster, err := ocmClient.FetchClusterByID("123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.Name()).To(Equal("mycluster"))
+	})
+
+	DescribeTable("Tells missing clusters from clusters that can't be seen",
+		func(status int, errorType errors.ErrorType) {
+			apiServer.AppendHandlers(RespondWithJSON(status, `{"kind": "Error", "reason": "Failed"}`))
+			_, err := ocmClient.FetchClusterByID("123")
+			Expect(errors.GetType(err)).To(Equal(errorType))
+		},
+		Entry("Not found", http.StatusNotFound, errors.NotFound),
+		Entry("Forbidden", http.StatusForbidden, errors.Forbidden),
+	)
+})
@@ -20,6 +20,8 @@ import (
 	"fmt"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/cache"
 )
 
 func (c *Client) ListStsGates(version string) (stsVersionGates []*cmv1.VersionGate, err error) {
@@ -53,6 +55,14 @@ func (c *Client) ListOcpGates(version string) (stsVersionGates []*cmv1.VersionGa
 }
 
 func (c *Client) ListAllOcpGates(version string) (versionGates []*cmv1.VersionGate, err error) {
+	return gatesCache.Fetch(c.cache, c.cacheKey(version),
+		func(validator cache.Validator) ([]*cmv1.VersionGate, cache.Validator, error) {
+			return c.listOcpGates(version, validator)
+		})
+}
+
+func (c *Client) listOcpGates(version string,
+	validator cache.Validator) (versionGates []*cmv1.VersionGate, updated cache.Validator, err error) {
 	versionGatesRequest := c.ocm.ClustersMgmt().V1().VersionGates()
 
 	page := 1
@@ -61,14 +71,25 @@ func (c *Client) ListAllOcpGates(version string) (versionGates []*cmv1.VersionGa
 	query := fmt.Sprintf("version_raw_id_prefix = '%s'", version)
 
 	for {
-		response, err := versionGatesRequest.List().
+		request := versionGatesRequest.List().
 			Page(page).
 			Size(size).
-			Search(query).
-			Send()
+			Search(query)
+		if page == 1 {
+			for name, value := range validator.Header() {
+				request.Header(name, value)
+			}
+		}
+		response, err := request.Send()
 
 		if err != nil {
-			return nil, handleErr(response.Error(), err)
+			return nil, updated, handleErr(response.Error(), err)
+		}
+		if page == 1 {
+			updated, err = listValidator(response.Status(), response.Header(), response.Size(), size)
+			if err != nil {
+				return nil, updated, err
+			}
 		}
 
 		versionGates = append(versionGates, response.Items().Slice()...)
@@ -42,6 +42,7 @@ import (
 	errors "github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cache"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/reporter"
@@ -557,18 +558,32 @@ func (c *Client) GetPolicies(policyType string) (map[string]*cmv1.AWSSTSPolicy,
 
 	m := make(map[string]*cmv1.AWSSTSPolicy)
 
-	stmt := c.ocm.ClustersMgmt().V1().AWSInquiries().STSPolicies().List()
-	if policyType != "" {
-		stmt = stmt.Search(query)
-	}
-	accountRolePoliciesResponse, err := stmt.Send()
+	policies, err := policiesCache.Fetch(c.cache, c.cacheKey(policyType),
+		func(validator cache.Validator) ([]*cmv1.AWSSTSPolicy, cache.Validator, error) {
+			stmt := c.ocm.ClustersMgmt().V1().AWSInquiries().STSPolicies().List()
+			if policyType != "" {
+				stmt = stmt.Search(query)
+			}
+			for name, value := range validator.Header() {
+				stmt = stmt.Header(name, value)
+			}
+			accountRolePoliciesResponse, err := stmt.Send()
+			if err != nil {
+				return nil, cache.Validator{}, handleErr(accountRolePoliciesResponse.Error(), err)
+			}
+			// The policies are returned in a single page:
+			updated, err := responseValidator(accountRolePoliciesResponse.Status(), accountRolePoliciesResponse.Header())
+			if err != nil {
+				return nil, updated, err
+			}
+			return accountRolePoliciesResponse.Items().Slice(), updated, nil
+		})
 	if err != nil {
-		return m, handleErr(accountRolePoliciesResponse.Error(), err)
+		return m, err
 	}
-	accountRolePoliciesResponse.Items().Each(func(awsPolicy *cmv1.AWSSTSPolicy) bool {
+	for _, awsPolicy := range policies {
 		m[awsPolicy.ID()] = awsPolicy
-		return true
-	})
+	}
 	return m, nil
 }
 
@@ -63,6 +63,19 @@ func (c *Client) CreateIdentityProvider(clusterID string, idp *cmv1.IdentityProv
 	return response.Body(), nil
 }
 
+func (c *Client) UpdateIdentityProvider(clusterID string, idpID string,
+	idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		Update().Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
 func (c *Client) GetHTPasswdUserList(clusterID, htpasswdIDPId string) (*cmv1.HTPasswdUserList, error) {
 	listResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 		IdentityProviders().IdentityProvider(htpasswdIDPId).HtpasswdUsers().List().Send()
@@ -19,22 +19,47 @@ package ocm
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cache"
 )
 
 const AcceleratedComputing = "accelerated_computing"
 
 func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderData) (MachineTypeList, error) {
+	key := c.cacheKey("region", cacheBodyKey(func(writer io.Writer) error {
+		return cmv1.MarshalCloudProviderData(cloudProviderData, writer)
+	}))
+	items, err := machineTypesCache.Fetch(c.cache, key,
+		func(cache.Validator) ([]*cmv1.MachineType, cache.Validator, error) {
+			items, err := c.searchMachineTypes(cloudProviderData)
+			return items, cache.Validator{}, err
+		})
+	if err != nil {
+		return MachineTypeList{}, err
+	}
+
+	var machineTypes MachineTypeList
+	for _, item := range items {
+		machineTypes.Items = append(machineTypes.Items, &MachineType{
+			MachineType: item,
+		})
+	}
+
+	return machineTypes, nil
+}
+
+func (c *Client) searchMachineTypes(cloudProviderData *cmv1.CloudProviderData) ([]*cmv1.MachineType, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().MachineTypes()
 	page := 1
 	size := 100
 
-	var machineTypes MachineTypeList
+	var machineTypes []*cmv1.MachineType
 	for {
 		response, err := collection.Search().
 			Parameter("order", "category asc").
@@ -43,15 +68,10 @@ func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderDa
 			Size(size).
 			Send()
 		if err != nil {
-			return MachineTypeList{}, err
+			return nil, err
 		}
 
-		response.Items().Each(func(item *cmv1.MachineType) bool {
-			machineTypes.Items = append(machineTypes.Items, &MachineType{
-				MachineType: item,
-			})
-			return true
-		})
+		machineTypes = append(machineTypes, response.Items().Slice()...)
 
 		if response.Size() < size {
 			break
@@ -63,31 +83,51 @@ func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderDa
 }
 
 func (c *Client) GetMachineTypes() (machineTypes MachineTypeList, err error) {
+	items, err := machineTypesCache.Fetch(c.cache, c.cacheKey("aws"), c.listMachineTypes)
+	if err != nil {
+		return MachineTypeList{}, err
+	}
+	for _, item := range items {
+		machineTypes.Items = append(machineTypes.Items, &MachineType{
+			MachineType: item,
+		})
+	}
+	return
+}
+
+func (c *Client) listMachineTypes(
+	validator cache.Validator) (machineTypes []*cmv1.MachineType, updated cache.Validator, err error) {
 	collection := c.ocm.ClustersMgmt().V1().MachineTypes()
 	page := 1
 	size := 100
 	for {
 		var response *cmv1.MachineTypesListResponse
-		response, err := collection.List().
+		request := collection.List().
 			Search("cloud_provider.id = 'aws'").
 			Order("category asc").
 			Page(page).
-			Size(size).
-			Send()
+			Size(size)
+		if page == 1 {
+			for name, value := range validator.Header() {
+				request.Header(name, value)
+			}
+		}
+		response, err := request.Send()
 		if err != nil {
 			errMsg := response.Error().Reason()
 			if errMsg == "" {
 				errMsg = err.Error()
 			}
-			return MachineTypeList{}, errors.New(errMsg)
+			return nil, updated, errors.New(errMsg)
+		}
+		if page == 1 {
+			updated, err = listValidator(response.Status(), response.Header(), response.Size(), size)
+			if err != nil {
+				return nil, updated, err
+			}
 		}
 
-		response.Items().Each(func(item *cmv1.MachineType) bool {
-			machineTypes.Items = append(machineTypes.Items, &MachineType{
-				MachineType: item,
-			})
-			return true
-		})
+		machineTypes = append(machineTypes, response.Items().Slice()...)
 
 		if response.Size() < size {
 			break
@@ -19,12 +19,14 @@ package ocm
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/cache"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/logging"
 )
@@ -49,6 +51,17 @@ func (c *Client) GetFilteredRegionsByVersion(roleARN string, version string,
 }
 
 func (c *Client) getFilteredRegions(cloudProviderData *cmv1.CloudProviderData) ([]*cmv1.CloudRegion, error) {
+	key := c.cacheKey("inquiry", cacheBodyKey(func(writer io.Writer) error {
+		return cmv1.MarshalCloudProviderData(cloudProviderData, writer)
+	}))
+	return regionsCache.Fetch(c.cache, key,
+		func(cache.Validator) ([]*cmv1.CloudRegion, cache.Validator, error) {
+			regions, err := c.searchFilteredRegions(cloudProviderData)
+			return regions, cache.Validator{}, err
+		})
+}
+
+func (c *Client) searchFilteredRegions(cloudProviderData *cmv1.CloudProviderData) ([]*cmv1.CloudRegion, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().Regions()
 	page := 1
 	size := 100
@@ -116,6 +129,17 @@ func (c *Client) GetRegions(roleARN string, externalID string) (regions []*cmv1.
 		return nil, fmt.Errorf("Failed to build AWS credentials for user '%s': %v", aws.AdminUserName, err)
 	}
 
+	key := c.cacheKey("available", cacheBodyKey(func(writer io.Writer) error {
+		return cmv1.MarshalAWS(awsCredentials, writer)
+	}))
+	return regionsCache.Fetch(c.cache, key,
+		func(cache.Validator) ([]*cmv1.CloudRegion, cache.Validator, error) {
+			regions, err := c.searchAvailableRegions(awsCredentials)
+			return regions, cache.Validator{}, err
+		})
+}
+
+func (c *Client) searchAvailableRegions(awsCredentials *cmv1.AWS) (regions []*cmv1.CloudRegion, err error) {
 	collection := c.ocm.ClustersMgmt().V1().
 		CloudProviders().
 		CloudProvider("aws").
@@ -25,6 +25,8 @@ import (
 	ver "github.com/hashicorp/go-version"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
+
+	"github.com/openshift/rosa/pkg/cache"
 )
 
 const (
@@ -69,6 +71,35 @@ func (c *Client) GetVersions(channelGroup string, defaultFirst bool) (versions [
 
 func (c *Client) GetVersionsWithProduct(product string, channelGroup string,
 	defaultFirst bool) (versions []*cmv1.Version, err error) {
+	versions, err = versionsCache.Fetch(c.cache, c.cacheKey(product, channelGroup),
+		func(validator cache.Validator) ([]*cmv1.Version, cache.Validator, error) {
+			return c.listVersions(product, channelGroup, validator)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort list in descending order
+	sort.Slice(versions, func(i, j int) bool {
+		if defaultFirst && versions[i].Default() {
+			return true
+		}
+		if defaultFirst && versions[j].Default() {
+			return false
+		}
+		a, erra := ver.NewVersion(versions[i].RawID())
+		b, errb := ver.NewVersion(versions[j].RawID())
+		if erra != nil || errb != nil {
+			return false
+		}
+		return a.GreaterThan(b)
+	})
+
+	return
+}
+
+func (c *Client) listVersions(product string, channelGroup string,
+	validator cache.Validator) (versions []*cmv1.Version, updated cache.Validator, err error) {
 	collection := c.ocm.ClustersMgmt().V1().Versions()
 	page := 1
 	size := 100
@@ -87,9 +118,20 @@ func (c *Client) GetVersionsWithProduct(product string, channelGroup string,
 		if product != "" {
 			request.Parameter("product", product)
 		}
+		if page == 1 {
+			for name, value := range validator.Header() {
+				request.Header(name, value)
+			}
+		}
 		response, err = request.Send()
 		if err != nil {
-			return nil, handleErr(response.Error(), err)
+			return nil, updated, handleErr(response.Error(), err)
+		}
+		if page == 1 {
+			updated, err = listValidator(response.Status(), response.Header(), response.Size(), size)
+			if err != nil {
+				return nil, updated, err
+			}
 		}
 		versions = append(versions, response.Items().Slice()...)
 		if response.Size() < size {
@@ -97,23 +139,6 @@ func (c *Client) GetVersionsWithProduct(product string, channelGroup string,
 		}
 		page++
 	}
-
-	// Sort list in descending order
-	sort.Slice(versions, func(i, j int) bool {
-		if defaultFirst && versions[i].Default() {
-			return true
-		}
-		if defaultFirst && versions[j].Default() {
-			return false
-		}
-		a, erra := ver.NewVersion(versions[i].RawID())
-		b, errb := ver.NewVersion(versions[j].RawID())
-		if erra != nil || errb != nil {
-			return false
-		}
-		return a.GreaterThan(b)
-	})
-
 	return
 }
 
@@ -3,6 +3,7 @@ package ocm
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
@@ -14,6 +15,8 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift-online/ocm-sdk-go/logging"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/cache"
 )
 
 // nolint
@@ -88,6 +91,7 @@ var _ = Describe("Get version list", func() {
 				Logger(logger).
 				Tokens(accessToken).
 				URL(apiServer.URL()).
+				TransportWrapper(notModifiedTransportWrapper).
 				Build()
 			// Initialize client object
 			Expect(err).To(BeNil())
@@ -139,6 +143,51 @@ var _ = Describe("Get version list", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(vs).To(BeFalse())
 		})
+
+		It("Caches the version list and revalidates it when it expires", func() {
+			tmpdir, err := os.MkdirTemp("/tmp", ".ocm-cache-*")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, tmpdir)
+			DeferCleanup(os.Setenv, "OCM_CONFIG", os.Getenv("OCM_CONFIG"))
+			os.Setenv("OCM_CONFIG", tmpdir)
+			ocmClient.cache, err = cache.NewRosaCacheService()
+			Expect(err).ToNot(HaveOccurred())
+
+			apiServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, VersionsListResponse, http.Header{
+					"Content-Type": []string{"application/json"},
+					"Etag":         []string{`"v1"`},
+				}),
+			)
+			vs, err := ocmClient.GetVersionsWithProduct("", DefaultChannelGroup, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vs).To(HaveLen(1))
+
+			// Fresh values are returned without sending requests:
+			vs, err = ocmClient.GetVersionsWithProduct("", DefaultChannelGroup, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vs).To(HaveLen(1))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(1))
+
+			// Expired values are revalidated:
+			key := versionsCache.Kind + ":" + ocmClient.cacheKey("", DefaultChannelGroup)
+			object, found := ocmClient.cache.Get(key)
+			Expect(found).To(BeTrue())
+			item := object.(cache.TypedItem)
+			item.Expires = time.Now().Add(-time.Minute)
+			Expect(ocmClient.cache.SetItem(key, item, time.Now().Add(time.Hour))).To(Succeed())
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("If-None-Match", `"v1"`),
+					ghttp.RespondWith(http.StatusNotModified, nil),
+				),
+			)
+			vs, err = ocmClient.GetVersionsWithProduct("", DefaultChannelGroup, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vs).To(HaveLen(1))
+			Expect(vs[0].ID()).To(Equal("4.14.9"))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+		})
 	})
 })
 
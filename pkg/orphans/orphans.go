@@ -0,0 +1,252 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orphans finds the AWS resources that ROSA created for clusters and OIDC configurations
+// that no longer exist, for example the operator roles and OIDC provider of a cluster that was
+// deleted without '--mode auto'.
+package orphans
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// Orphan is a resource that isn't used by any cluster or OIDC configuration.
+type Orphan struct {
+	Type       aws.ManagedResourceType `json:"type"`
+	Name       string                  `json:"name"`
+	ARN        string                  `json:"arn,omitempty"`
+	CreateDate time.Time               `json:"create_date"`
+	Reason     string                  `json:"reason"`
+
+	// UnknownOwner is set when the resource belongs to a cluster that OCM didn't confirm to be
+	// deleted, for example because it belongs to a different organization. These resources are only
+	// reported, never deleted.
+	UnknownOwner bool `json:"unknown_owner,omitempty"`
+
+	Resource aws.ManagedResource `json:"-"`
+}
+
+// ClusterState is what OCM reports about a cluster that isn't in the list of clusters of the
+// account.
+type ClusterState int
+
+const (
+	// ClusterExists means that the cluster exists, even if it isn't in the list.
+	ClusterExists ClusterState = iota
+
+	// ClusterDeleted means that OCM confirmed that the cluster doesn't exist.
+	ClusterDeleted
+
+	// ClusterUnknown means that OCM didn't tell if the cluster exists, for example because it
+	// belongs to a different organization.
+	ClusterUnknown
+)
+
+// ClusterLookup returns the state of the cluster with the given identifier.
+type ClusterLookup func(clusterID string) ClusterState
+
+// Age returns how long ago the resource was created, in a short form like '3d' or '5h'.
+func (o *Orphan) Age(now time.Time) string {
+	if o.CreateDate.IsZero() {
+		return ""
+	}
+	age := now.Sub(o.CreateDate)
+	switch {
+	case age >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	}
+}
+
+// deletionOrder is the order in which the orphans can be deleted: policies can only be deleted
+// once the roles they are attached to are gone.
+var deletionOrder = map[aws.ManagedResourceType]int{
+	aws.OperatorRoleResource:   0,
+	aws.OperatorPolicyResource: 1,
+	aws.OIDCProviderResource:   2,
+	aws.OIDCSecretResource:     3,
+	aws.OIDCBucketResource:     4,
+}
+
+// Find cross-references the resources listed from the AWS account with the clusters and OIDC
+// configurations of the account in OCM, and returns the resources that none of them uses, in the
+// order in which they can be deleted. The resources tagged with a cluster that isn't in the list are
+// only orphans when the lookup confirms that the cluster was deleted, otherwise they are returned
+// with an unknown owner.
+func Find(resources []aws.ManagedResource, clusters []*cmv1.Cluster,
+	oidcConfigs []*cmv1.OidcConfig, lookup ClusterLookup) []*Orphan {
+	clusterIDs := map[string]bool{}
+	usedRoles := map[string]bool{}
+	issuers := map[string]bool{}
+	secrets := map[string]bool{}
+	for _, cluster := range clusters {
+		clusterIDs[cluster.ID()] = true
+		for _, role := range cluster.AWS().STS().OperatorIAMRoles() {
+			usedRoles[roleName(role.RoleARN())] = true
+		}
+		if cluster.AWS().STS().OIDCEndpointURL() != "" {
+			issuers[normalizeIssuer(cluster.AWS().STS().OIDCEndpointURL())] = true
+		}
+	}
+	for _, config := range oidcConfigs {
+		issuers[normalizeIssuer(config.IssuerUrl())] = true
+		if config.SecretArn() != "" {
+			secrets[config.SecretArn()] = true
+		}
+	}
+
+	orphans := []*Orphan{}
+	orphanRoles := map[string]bool{}
+	add := func(resource aws.ManagedResource, reason string) *Orphan {
+		orphan := &Orphan{
+			Type:       resource.Type,
+			Name:       resource.Name,
+			ARN:        resource.ARN,
+			CreateDate: resource.CreateDate,
+			Reason:     reason,
+			Resource:   resource,
+		}
+		orphans = append(orphans, orphan)
+		return orphan
+	}
+
+	// addTagged adds the resource tagged with a cluster that isn't in the list, and returns if the
+	// cluster is confirmed to be deleted:
+	states := map[string]ClusterState{}
+	addTagged := func(resource aws.ManagedResource, clusterID string) bool {
+		if clusterIDs[clusterID] {
+			return false
+		}
+		state, ok := states[clusterID]
+		if !ok {
+			state = lookup(clusterID)
+			states[clusterID] = state
+		}
+		switch state {
+		case ClusterDeleted:
+			add(resource, fmt.Sprintf("OCM reports that cluster '%s' doesn't exist", clusterID))
+			return true
+		case ClusterUnknown:
+			orphan := add(resource, fmt.Sprintf("unknown owner, OCM doesn't show cluster '%s' to the "+
+				"current organization", clusterID))
+			orphan.UnknownOwner = true
+		}
+		return false
+	}
+
+	for _, resource := range resources {
+		if resource.Type != aws.OperatorRoleResource || usedRoles[resource.Name] {
+			continue
+		}
+		if clusterID := resource.Tags[tags.ClusterID]; clusterID != "" {
+			if addTagged(resource, clusterID) {
+				orphanRoles[resource.ARN] = true
+			}
+			continue
+		}
+		// Operator roles created with a prefix instead of a cluster are used by the clusters of
+		// the OIDC configuration they trust:
+		if len(resource.OIDCProviders) == 0 {
+			continue
+		}
+		used := false
+		for _, provider := range resource.OIDCProviders {
+			if issuers[providerIssuer(provider)] {
+				used = true
+			}
+		}
+		if !used {
+			add(resource, "no cluster or OIDC config of the organization uses the OIDC provider it trusts")
+			orphanRoles[resource.ARN] = true
+		}
+	}
+
+	// A policy that is only attached to orphaned roles will be orphaned once they are deleted:
+	orphanAttachments := map[string]int32{}
+	for _, resource := range resources {
+		if resource.Type == aws.OperatorRoleResource && orphanRoles[resource.ARN] {
+			for _, policy := range resource.AttachedPolicies {
+				orphanAttachments[policy]++
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		switch resource.Type {
+		case aws.OperatorPolicyResource:
+			if resource.AttachmentCount == 0 {
+				add(resource, "not attached to any role")
+			} else if orphanAttachments[resource.ARN] >= resource.AttachmentCount {
+				add(resource, "only attached to orphaned operator roles")
+			}
+		case aws.OIDCProviderResource:
+			if !resource.IsRedHatManaged() || issuers[normalizeIssuer(resource.URL)] {
+				continue
+			}
+			if clusterID := resource.Tags[tags.ClusterID]; clusterID != "" {
+				addTagged(resource, clusterID)
+				continue
+			}
+			add(resource, "no cluster or OIDC config of the organization uses the provider")
+		case aws.OIDCBucketResource:
+			used := false
+			for issuer := range issuers {
+				if strings.Contains(issuer, resource.Name) {
+					used = true
+				}
+			}
+			if !used {
+				add(resource, "no OIDC config of the organization uses the bucket")
+			}
+		case aws.OIDCSecretResource:
+			if !secrets[resource.ARN] {
+				add(resource, "no OIDC config of the organization uses the secret")
+			}
+		}
+	}
+
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return deletionOrder[orphans[i].Type] < deletionOrder[orphans[j].Type]
+	})
+	return orphans
+}
+
+func roleName(roleARN string) string {
+	return roleARN[strings.LastIndex(roleARN, "/")+1:]
+}
+
+// providerIssuer returns the issuer of an OIDC provider ARN like
+// 'arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc'.
+func providerIssuer(providerARN string) string {
+	_, issuer, _ := strings.Cut(providerARN, ":oidc-provider/")
+	return normalizeIssuer(issuer)
+}
+
+func normalizeIssuer(issuer string) string {
+	issuer = strings.TrimPrefix(issuer, "https://")
+	return strings.TrimSuffix(issuer, "/")
+}
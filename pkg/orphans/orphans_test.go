@@ -0,0 +1,166 @@
+package orphans
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+var _ = Describe("Find", func() {
+	const (
+		liveIssuer  = "oidc.example.com/live"
+		staleIssuer = "oidc.example.com/stale"
+		policyARN   = "arn:aws:iam::123456789012:policy/test-openshift-ingress-operator-cloud-credentials"
+		secretARN   = "arn:aws:secretsmanager:us-east-1:123456789012:secret:rosa-private-key-test-oidc-abcd-xyz"
+	)
+
+	var (
+		clusters    []*cmv1.Cluster
+		oidcConfigs []*cmv1.OidcConfig
+		lookups     []string
+	)
+
+	// lookup knows that 'deleted-id' doesn't exist and can't tell about the other clusters:
+	lookup := func(clusterID string) ClusterState {
+		lookups = append(lookups, clusterID)
+		if clusterID == "deleted-id" {
+			return ClusterDeleted
+		}
+		return ClusterUnknown
+	}
+
+	role := func(name string, clusterID string, issuer string) aws.ManagedResource {
+		resourceTags := map[string]string{
+			tags.RedHatManaged:     tags.True,
+			tags.OperatorNamespace: "openshift-ingress-operator",
+		}
+		if clusterID != "" {
+			resourceTags[tags.ClusterID] = clusterID
+		}
+		return aws.ManagedResource{
+			Type:             aws.OperatorRoleResource,
+			Name:             name,
+			ARN:              "arn:aws:iam::123456789012:role/" + name,
+			Tags:             resourceTags,
+			OIDCProviders:    []string{"arn:aws:iam::123456789012:oidc-provider/" + issuer},
+			AttachedPolicies: []string{policyARN},
+		}
+	}
+
+	provider := func(issuer string, clusterID string) aws.ManagedResource {
+		resourceTags := map[string]string{tags.RedHatManaged: tags.True}
+		if clusterID != "" {
+			resourceTags[tags.ClusterID] = clusterID
+		}
+		return aws.ManagedResource{
+			Type: aws.OIDCProviderResource,
+			Name: issuer,
+			ARN:  "arn:aws:iam::123456789012:oidc-provider/" + issuer,
+			Tags: resourceTags,
+			URL:  issuer,
+		}
+	}
+
+	BeforeEach(func() {
+		cluster, err := cmv1.NewCluster().ID("live-id").AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			OIDCEndpointURL("https://" + liveIssuer).
+			OperatorIAMRoles(cmv1.NewOperatorIAMRole().
+				RoleARN("arn:aws:iam::123456789012:role/live-openshift-ingress-operator")))).Build()
+		Expect(err).NotTo(HaveOccurred())
+		clusters = []*cmv1.Cluster{cluster}
+		oidcConfig, err := cmv1.NewOidcConfig().IssuerUrl("https://test-oidc-abcd.s3.us-east-1.amazonaws.com").
+			SecretArn(secretARN).Build()
+		Expect(err).NotTo(HaveOccurred())
+		oidcConfigs = []*cmv1.OidcConfig{oidcConfig}
+		lookups = nil
+	})
+
+	It("Doesn't report the resources used by clusters and OIDC configs", func() {
+		orphans := Find([]aws.ManagedResource{
+			role("live-openshift-ingress-operator", "live-id", liveIssuer),
+			role("prefix-openshift-ingress-operator", "", liveIssuer),
+			{Type: aws.OperatorPolicyResource, ARN: policyARN, AttachmentCount: 2},
+			provider(liveIssuer, "live-id"),
+			provider("test-oidc-abcd.s3.us-east-1.amazonaws.com", ""),
+			{Type: aws.OIDCBucketResource, Name: "test-oidc-abcd"},
+			{Type: aws.OIDCSecretResource, ARN: secretARN},
+		}, clusters, oidcConfigs, lookup)
+		Expect(orphans).To(BeEmpty())
+	})
+
+	It("Reports the resources of deleted clusters and OIDC configs in deletion order", func() {
+		orphans := Find([]aws.ManagedResource{
+			{Type: aws.OIDCBucketResource, Name: "old-oidc-wxyz"},
+			provider(staleIssuer, "deleted-id"),
+			provider("oidc.example.com/unknown", ""),
+			{Type: aws.OperatorPolicyResource, ARN: policyARN, AttachmentCount: 2},
+			role("deleted-openshift-ingress-operator", "deleted-id", staleIssuer),
+			role("prefix-openshift-ingress-operator", "", staleIssuer),
+			{Type: aws.OIDCSecretResource, ARN: secretARN + "-old"},
+		}, clusters, oidcConfigs, lookup)
+
+		reasons := []string{}
+		for _, orphan := range orphans {
+			reasons = append(reasons, string(orphan.Type)+": "+orphan.Reason)
+		}
+		Expect(reasons).To(Equal([]string{
+			"OperatorRole: OCM reports that cluster 'deleted-id' doesn't exist",
+			"OperatorRole: no cluster or OIDC config of the organization uses the OIDC provider it trusts",
+			"OperatorPolicy: only attached to orphaned operator roles",
+			"OIDCProvider: OCM reports that cluster 'deleted-id' doesn't exist",
+			"OIDCProvider: no cluster or OIDC config of the organization uses the provider",
+			"Secret: no OIDC config of the organization uses the secret",
+			"S3Bucket: no OIDC config of the organization uses the bucket",
+		}))
+		for _, orphan := range orphans {
+			Expect(orphan.UnknownOwner).To(BeFalse())
+		}
+		Expect(lookups).To(Equal([]string{"deleted-id"}))
+	})
+
+	It("Reports the resources of clusters that OCM doesn't show with an unknown owner", func() {
+		orphans := Find([]aws.ManagedResource{
+			role("other-openshift-ingress-operator", "other-id", staleIssuer),
+			{Type: aws.OperatorPolicyResource, ARN: policyARN, AttachmentCount: 1},
+			provider(staleIssuer, "other-id"),
+		}, clusters, oidcConfigs, lookup)
+
+		Expect(orphans).To(HaveLen(2))
+		for _, orphan := range orphans {
+			Expect(orphan.UnknownOwner).To(BeTrue())
+			Expect(orphan.Reason).To(Equal("unknown owner, OCM doesn't show cluster 'other-id' to the " +
+				"current organization"))
+		}
+		// The policy is still attached to a role that may be in use:
+		Expect(orphans[0].Type).To(Equal(aws.OperatorRoleResource))
+		Expect(orphans[1].Type).To(Equal(aws.OIDCProviderResource))
+	})
+
+	It("Doesn't report the resources of clusters that exist outside of the list", func() {
+		orphans := Find([]aws.ManagedResource{
+			role("listed-openshift-ingress-operator", "unlisted-id", staleIssuer),
+		}, clusters, oidcConfigs, func(string) ClusterState {
+			return ClusterExists
+		})
+		Expect(orphans).To(BeEmpty())
+	})
+
+	It("Ignores OIDC providers that weren't created by ROSA", func() {
+		orphans := Find([]aws.ManagedResource{
+			{Type: aws.OIDCProviderResource, URL: "token.actions.githubusercontent.com"},
+		}, clusters, oidcConfigs, lookup)
+		Expect(orphans).To(BeEmpty())
+	})
+
+	It("Formats the age of the orphans", func() {
+		now := time.Now()
+		Expect((&Orphan{CreateDate: now.Add(-75 * time.Hour)}).Age(now)).To(Equal("3d"))
+		Expect((&Orphan{CreateDate: now.Add(-5 * time.Hour)}).Age(now)).To(Equal("5h"))
+		Expect((&Orphan{CreateDate: now.Add(-10 * time.Minute)}).Age(now)).To(Equal("10m"))
+	})
+})
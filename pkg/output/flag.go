@@ -27,13 +27,20 @@ import (
 const (
 	JSON           = "json"
 	YAML           = "yaml"
+	Table          = "table"
+	Wide           = "wide"
+	CSV            = "csv"
+	JSONPathFormat = "jsonpath"
+	GoTemplate     = "go-template"
+	CustomColumns  = "custom-columns"
 	FLAG_NAME      = "output"
 	FLAG_SHORTHAND = "o"
 )
 
 var o string
 
-var formats = []string{JSON, YAML}
+var formats = []string{JSON, YAML, Table, Wide, CSV, JSONPathFormat + "=<template>", GoTemplate + "=<template>",
+	CustomColumns + "=<HEADER:.path,...>"}
 
 // AddFlag adds the interactive flag to the given set of command line flags.
 func AddFlag(cmd *cobra.Command) {
@@ -49,7 +56,8 @@ func AddFlag(cmd *cobra.Command) {
 }
 
 func completion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	return formats, cobra.ShellCompDirectiveDefault
+	return []string{JSON, YAML, Table, Wide, CSV, JSONPathFormat + "=", GoTemplate + "=", CustomColumns + "="},
+		cobra.ShellCompDirectiveNoSpace
 }
 
 func HasFlag() bool {
@@ -0,0 +1,291 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the formatters that print the resources in the formats supported by the
+// '--output' command line option.
+
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"gitlab.com/c0b/go-ordered-json"
+)
+
+// Resource is what the formatters print: the JSON representation of a resource or list of
+// resources and, if the type of the resources has registered columns, their table.
+type Resource struct {
+	JSON    []byte
+	Headers []string
+	Wide    []bool
+	Rows    [][]string
+}
+
+// Formatter prints a resource. The argument is the text that follows the '=' in the value of the
+// flag, for example '{.id}' in 'jsonpath={.id}'.
+type Formatter func(writer io.Writer, resource *Resource, argument string) error
+
+var formatters = map[string]Formatter{}
+
+// RegisterFormatter makes a format available to the '--output' command line option.
+func RegisterFormatter(name string, formatter Formatter) {
+	formatters[name] = formatter
+}
+
+func init() {
+	RegisterFormatter(JSON, formatJSON)
+	RegisterFormatter(YAML, formatYAML)
+	RegisterFormatter(Table, formatTable(false))
+	RegisterFormatter(Wide, formatTable(true))
+	RegisterFormatter(CSV, formatCSV)
+	RegisterFormatter(JSONPathFormat, formatJSONPath)
+	RegisterFormatter(GoTemplate, formatGoTemplate)
+	RegisterFormatter(CustomColumns, formatCustomColumns)
+}
+
+// splitFormat splits a value of the flag like 'jsonpath={.id}' into the name of the format and its
+// argument.
+func splitFormat(value string) (string, string) {
+	name, argument, _ := strings.Cut(value, "=")
+	return name, argument
+}
+
+func formatJSON(writer io.Writer, resource *Resource, _ string) error {
+	return prettifyJSON(writer, resource.JSON)
+}
+
+func formatYAML(writer io.Writer, resource *Resource, _ string) error {
+	out, err := yaml.JSONToYAML(resource.JSON)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(out)
+	return err
+}
+
+func formatTable(wide bool) Formatter {
+	return func(writer io.Writer, resource *Resource, _ string) error {
+		headers, rows, err := resource.table(wide)
+		if err != nil {
+			return err
+		}
+		tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tabWriter, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tabWriter, strings.Join(row, "\t"))
+		}
+		return tabWriter.Flush()
+	}
+}
+
+func formatCSV(writer io.Writer, resource *Resource, _ string) error {
+	headers, rows, err := resource.table(true)
+	if err != nil {
+		return err
+	}
+	csvWriter := csv.NewWriter(writer)
+	err = csvWriter.Write(headers)
+	if err != nil {
+		return err
+	}
+	err = csvWriter.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
+func formatJSONPath(writer io.Writer, resource *Resource, argument string) error {
+	if argument == "" {
+		return fmt.Errorf("Format '%s' requires a template, for example '%s={.id}'", JSONPathFormat, JSONPathFormat)
+	}
+	jsonPath, err := ParseJSONPath(argument)
+	if err != nil {
+		return err
+	}
+	data, err := resource.data()
+	if err != nil {
+		return err
+	}
+	result, err := jsonPath.Execute(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(writer, result)
+	return err
+}
+
+func formatGoTemplate(writer io.Writer, resource *Resource, argument string) error {
+	if argument == "" {
+		return fmt.Errorf("Format '%s' requires a template, for example '%s={{.id}}'", GoTemplate, GoTemplate)
+	}
+	tmpl, err := template.New(GoTemplate).Parse(argument)
+	if err != nil {
+		return fmt.Errorf("Failed to parse template: %v", err)
+	}
+	data, err := resource.data()
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(writer, data)
+}
+
+func formatCustomColumns(writer io.Writer, resource *Resource, argument string) error {
+	if argument == "" {
+		return fmt.Errorf("Format '%s' requires the columns, for example '%s=ID:.id,NAME:.name'",
+			CustomColumns, CustomColumns)
+	}
+	var headers []string
+	var paths [][]string
+	for _, spec := range strings.Split(argument, ",") {
+		header, path, found := strings.Cut(spec, ":")
+		if !found || header == "" {
+			return fmt.Errorf("Invalid custom column '%s', expected 'HEADER:.path'", spec)
+		}
+		steps, err := parsePath(strings.Trim(path, "{}"))
+		if err != nil {
+			return err
+		}
+		headers = append(headers, header)
+		paths = append(paths, steps)
+	}
+
+	data, err := resource.data()
+	if err != nil {
+		return err
+	}
+	items, ok := data.([]interface{})
+	if !ok {
+		items = []interface{}{data}
+	}
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tabWriter, strings.Join(headers, "\t"))
+	for _, item := range items {
+		values := make([]string, len(paths))
+		for i, path := range paths {
+			var texts []string
+			for _, value := range evaluatePath(path, item) {
+				text, err := formatValue(value)
+				if err != nil {
+					return err
+				}
+				texts = append(texts, text)
+			}
+			values[i] = strings.Join(texts, ",")
+			if values[i] == "" {
+				values[i] = "<none>"
+			}
+		}
+		fmt.Fprintln(tabWriter, strings.Join(values, "\t"))
+	}
+	return tabWriter.Flush()
+}
+
+// data returns the JSON representation of the resource decoded into maps and slices.
+func (r *Resource) data() (interface{}, error) {
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(r.JSON))
+	decoder.UseNumber()
+	err := decoder.Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode resource: %v", err)
+	}
+	return data, nil
+}
+
+// table returns the headers and rows of the columns registered for the type of the resource,
+// including the wide ones if requested. Types without columns get a column for each field of the
+// resource with a simple value.
+func (r *Resource) table(wide bool) ([]string, [][]string, error) {
+	if r.Rows == nil {
+		return r.fieldsTable()
+	}
+	var headers []string
+	for i, header := range r.Headers {
+		if wide || !r.Wide[i] {
+			headers = append(headers, header)
+		}
+	}
+	rows := make([][]string, len(r.Rows))
+	for i, row := range r.Rows {
+		for j, value := range row {
+			if wide || !r.Wide[j] {
+				rows[i] = append(rows[i], value)
+			}
+		}
+	}
+	return headers, rows, nil
+}
+
+func (r *Resource) fieldsTable() ([]string, [][]string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(r.JSON, &items); err != nil {
+		items = []json.RawMessage{r.JSON}
+	}
+	var keys, headers []string
+	var rows [][]string
+	for i, item := range items {
+		object := ordered.NewOrderedMap()
+		err := json.Unmarshal(item, object)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to decode resource: %v", err)
+		}
+		if i == 0 {
+			next := object.EntriesIter()
+			for pair, ok := next(); ok; pair, ok = next() {
+				if isScalar(pair.Value) {
+					keys = append(keys, pair.Key)
+					headers = append(headers, strings.ToUpper(strings.ReplaceAll(pair.Key, "_", " ")))
+				}
+			}
+		}
+		row := make([]string, len(keys))
+		for j, key := range keys {
+			value := object.Get(key)
+			if isScalar(value) {
+				row[j], _ = formatValue(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows, nil
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case *ordered.OrderedMap, []interface{}, map[string]interface{}:
+		return false
+	}
+	return true
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Formatters", func() {
+	var clusters []*cmv1.Cluster
+
+	BeforeEach(func() {
+		first, err := cmv1.NewCluster().ID("123").Name("first").State(cmv1.ClusterStateReady).
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			Hypershift(cmv1.NewHypershift().Enabled(true)).Build()
+		Expect(err).NotTo(HaveOccurred())
+		second, err := cmv1.NewCluster().ID("456").Name("second").State(cmv1.ClusterStateInstalling).
+			Region(cmv1.NewCloudRegion().ID("us-west-2")).Build()
+		Expect(err).NotTo(HaveOccurred())
+		clusters = []*cmv1.Cluster{first, second}
+	})
+
+	format := func(resource interface{}, value string) (string, error) {
+		name, argument := splitFormat(value)
+		Expect(formatters).To(HaveKey(name))
+		printed, err := newResource(resource)
+		Expect(err).NotTo(HaveOccurred())
+		var b bytes.Buffer
+		err = formatters[name](&b, printed, argument)
+		return b.String(), err
+	}
+
+	It("Prints the columns of the type without the wide ones in the table format", func() {
+		out, err := format(clusters, Table)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("" +
+			"ID   NAME    STATE       TOPOLOGY\n" +
+			"123  first   ready       Hosted CP\n" +
+			"456  second  installing  Classic\n"))
+	})
+
+	It("Prints the wide columns in the wide and CSV formats", func() {
+		out, err := format(clusters, Wide)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("ID   NAME    STATE       TOPOLOGY   VERSION  REGION     MULTI-AZ  CREATED\n"))
+
+		out, err = format(clusters[0], CSV)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("ID,NAME,STATE,TOPOLOGY,VERSION,REGION,MULTI-AZ,CREATED\n" +
+			"123,first,ready,Hosted CP,,us-east-1,false,0001-01-01T00:00:00Z\n"))
+	})
+
+	It("Uses the fields of the resources for types without columns", func() {
+		roles := []aws.Role{
+			{RoleName: "installer", RoleARN: "arn:aws:iam::123:role/installer"},
+			{RoleName: "support", RoleARN: "arn:aws:iam::123:role/support", Linked: "Yes"},
+		}
+		out, err := format(roles, CSV)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("ROLENAME,ROLEARN\n" +
+			"installer,arn:aws:iam::123:role/installer\n" +
+			"support,arn:aws:iam::123:role/support\n"))
+	})
+
+	It("Extracts fields with JSONPath", func() {
+		out, err := format(clusters, "jsonpath={range [*]}{.id}{\"\\t\"}{.region.id}{\"\\n\"}{end}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("123\tus-east-1\n456\tus-west-2\n\n"))
+
+		out, err = format(clusters, "jsonpath={[*].name}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("first second\n"))
+
+		_, err = format(clusters, JSONPathFormat)
+		Expect(err).To(MatchError("Format 'jsonpath' requires a template, for example 'jsonpath={.id}'"))
+	})
+
+	It("Executes Go templates", func() {
+		out, err := format(clusters[0], "go-template={{.name}} in {{.region.id}}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("first in us-east-1"))
+	})
+
+	It("Prints custom columns", func() {
+		out, err := format(clusters, "custom-columns=ID:.id,REGION:.region.id,VERSION:.version.raw_id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("" +
+			"ID   REGION     VERSION\n" +
+			"123  us-east-1  <none>\n" +
+			"456  us-west-2  <none>\n"))
+
+		_, err = format(clusters, "custom-columns=.id")
+		Expect(err).To(MatchError("Invalid custom column '.id', expected 'HEADER:.path'"))
+	})
+})
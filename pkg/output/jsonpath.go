@@ -0,0 +1,250 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a JSONPath implementation compatible with the subset of the kubectl syntax
+// that is useful to extract fields of the resources: '{.a.b}', '{.a[0]}', '{.a[*].b}',
+// '{.a['b']}', string literals like '{"\n"}' and '{range .a[*]}...{end}'.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type jsonPathNode struct {
+	text    string
+	literal bool
+	path    []string
+	body    []*jsonPathNode
+}
+
+// JSONPath is a parsed JSONPath template.
+type JSONPath struct {
+	nodes []*jsonPathNode
+}
+
+// ParseJSONPath parses a template like '{.id} {.name}'.
+func ParseJSONPath(template string) (*JSONPath, error) {
+	nodes, rest, err := parseJSONPathNodes(template, false)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("Unexpected '{end}' in JSONPath template '%s'", template)
+	}
+	return &JSONPath{nodes: nodes}, nil
+}
+
+func parseJSONPathNodes(template string, inRange bool) ([]*jsonPathNode, string, error) {
+	var nodes []*jsonPathNode
+	for template != "" {
+		start := strings.Index(template, "{")
+		if start == -1 {
+			nodes = append(nodes, &jsonPathNode{text: template, literal: true})
+			return nodes, "", nil
+		}
+		if start > 0 {
+			nodes = append(nodes, &jsonPathNode{text: template[:start], literal: true})
+		}
+		end := strings.Index(template[start:], "}")
+		if end == -1 {
+			return nil, "", fmt.Errorf("Unclosed action in JSONPath template '%s'", template)
+		}
+		action := strings.TrimSpace(template[start+1 : start+end])
+		template = template[start+end+1:]
+
+		switch {
+		case action == "end":
+			if !inRange {
+				return nil, "", fmt.Errorf("Unexpected '{end}' in JSONPath template")
+			}
+			return nodes, template, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := parsePath(strings.TrimPrefix(action, "range "))
+			if err != nil {
+				return nil, "", err
+			}
+			var body []*jsonPathNode
+			body, template, err = parseJSONPathNodes(template, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, &jsonPathNode{path: path, body: body})
+		case strings.HasPrefix(action, `"`):
+			text, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, "", fmt.Errorf("Invalid string literal %s in JSONPath template", action)
+			}
+			nodes = append(nodes, &jsonPathNode{text: text, literal: true})
+		default:
+			path, err := parsePath(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, &jsonPathNode{path: path})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("Missing '{end}' in JSONPath template")
+	}
+	return nodes, "", nil
+}
+
+// parsePath splits a path like '.a[*].b' or "a['b'][0]" into the steps 'a', '*', 'b' or
+// 'a', 'b', '0'. Indexes are kept with their brackets to tell them apart from field names.
+func parsePath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	var steps []string
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end > 0 {
+				steps = append(steps, path[:end])
+			}
+			path = path[end:]
+		case '[':
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("Unclosed bracket in JSONPath '%s'", path)
+			}
+			index := strings.TrimSpace(path[1:end])
+			path = path[end+1:]
+			if strings.HasPrefix(index, "'") || strings.HasPrefix(index, `"`) {
+				steps = append(steps, strings.Trim(index, `'"`))
+				continue
+			}
+			if index != "*" {
+				if _, err := strconv.Atoi(index); err != nil {
+					return nil, fmt.Errorf("Invalid index '%s' in JSONPath", index)
+				}
+			}
+			steps = append(steps, "["+index+"]")
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			steps = append(steps, path[:end])
+			path = path[end:]
+		}
+	}
+	return steps, nil
+}
+
+// Execute evaluates the template against the given data, decoded from JSON.
+func (j *JSONPath) Execute(data interface{}) (string, error) {
+	var result strings.Builder
+	err := executeJSONPathNodes(&result, j.nodes, data)
+	if err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+func executeJSONPathNodes(result *strings.Builder, nodes []*jsonPathNode, data interface{}) error {
+	for _, node := range nodes {
+		if node.literal {
+			result.WriteString(node.text)
+			continue
+		}
+		values := evaluatePath(node.path, data)
+		if node.body != nil {
+			for _, value := range values {
+				err := executeJSONPathNodes(result, node.body, value)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		texts := make([]string, 0, len(values))
+		for _, value := range values {
+			text, err := formatValue(value)
+			if err != nil {
+				return err
+			}
+			texts = append(texts, text)
+		}
+		result.WriteString(strings.Join(texts, " "))
+	}
+	return nil
+}
+
+// evaluatePath returns the values selected by the path. Fields that don't exist select nothing.
+func evaluatePath(path []string, data interface{}) []interface{} {
+	values := []interface{}{data}
+	for _, step := range path {
+		var next []interface{}
+		for _, value := range values {
+			switch {
+			case step == "[*]" || step == "*":
+				switch typed := value.(type) {
+				case []interface{}:
+					next = append(next, typed...)
+				case map[string]interface{}:
+					for _, key := range sortedKeys(typed) {
+						next = append(next, typed[key])
+					}
+				}
+			case strings.HasPrefix(step, "["):
+				list, ok := value.([]interface{})
+				if !ok {
+					continue
+				}
+				index, _ := strconv.Atoi(strings.Trim(step, "[]"))
+				if index < 0 {
+					index += len(list)
+				}
+				if index >= 0 && index < len(list) {
+					next = append(next, list[index])
+				}
+			default:
+				object, ok := value.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if field, ok := object[step]; ok {
+					next = append(next, field)
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// formatValue returns strings as they are and the rest of the values in JSON.
+func formatValue(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case nil:
+		return "", nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
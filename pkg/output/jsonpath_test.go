@@ -0,0 +1,42 @@
+package output
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPath", func() {
+	data := map[string]interface{}{
+		"id": "123",
+		"nodes": []interface{}{
+			map[string]interface{}{"name": "a", "replicas": 2},
+			map[string]interface{}{"name": "b", "replicas": 3},
+		},
+		"labels": map[string]interface{}{"app.kubernetes.io/name": "rosa"},
+	}
+
+	DescribeTable("Evaluates templates",
+		func(template string, expected string) {
+			jsonPath, err := ParseJSONPath(template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jsonPath.Execute(data)).To(Equal(expected))
+		},
+		Entry("Fields", "id={.id}", "id=123"),
+		Entry("Indexes", "{.nodes[1].name} {.nodes[-1].replicas}", "b 3"),
+		Entry("Wildcards", "{.nodes[*].name}", "a b"),
+		Entry("Quoted fields", "{.labels['app.kubernetes.io/name']}", "rosa"),
+		Entry("Objects", "{.nodes[0]}", `{"name":"a","replicas":2}`),
+		Entry("Missing fields", "{.missing.field}", ""),
+		Entry("Ranges", `{range .nodes[*]}{.name}:{.replicas}{"\n"}{end}`, "a:2\nb:3\n"),
+	)
+
+	DescribeTable("Fails with invalid templates",
+		func(template string, message string) {
+			_, err := ParseJSONPath(template)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("Unclosed actions", "{.id", "Unclosed action in JSONPath template '{.id'"),
+		Entry("Missing end", "{range .nodes[*]}{.name}", "Missing '{end}' in JSONPath template"),
+		Entry("Invalid indexes", "{.nodes[a]}", "Invalid index 'a' in JSONPath"),
+	)
+})
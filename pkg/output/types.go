@@ -0,0 +1,334 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the registry of the types of resources that can be printed, with the functions
+// that convert them to JSON and the columns of their tables.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
+)
+
+// Column is a column of the table of a type of resource, used by the 'csv' and 'wide' formats.
+type Column[T any] struct {
+	Header string
+	// Wide columns are printed by the 'wide' and 'csv' formats, but not by the 'table' format
+	Wide  bool
+	Value func(T) string
+}
+
+// resourceType contains the functions registered for a type of resource, either a single object or
+// a list of objects.
+type resourceType struct {
+	marshal func(interface{}, io.Writer) error
+	headers []string
+	wide    []bool
+	rows    func(interface{}) [][]string
+}
+
+var resourceTypes = map[reflect.Type]*resourceType{}
+
+// RegisterType registers the functions that convert objects and lists of objects of type T to JSON,
+// and the columns of their tables. The marshal functions can be nil for types that can be encoded
+// with the standard JSON library.
+func RegisterType[T any](marshal func(T, io.Writer) error, marshalList func([]T, io.Writer) error,
+	columns ...Column[T]) {
+	headers := make([]string, len(columns))
+	wide := make([]bool, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+		wide[i] = column.Wide
+	}
+	row := func(object T) []string {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = column.Value(object)
+		}
+		return values
+	}
+
+	objectType := &resourceType{
+		headers: headers,
+		wide:    wide,
+		rows: func(resource interface{}) [][]string {
+			return [][]string{row(resource.(T))}
+		},
+	}
+	listType := &resourceType{
+		headers: headers,
+		wide:    wide,
+		rows: func(resource interface{}) [][]string {
+			list := resource.([]T)
+			rows := make([][]string, len(list))
+			for i, object := range list {
+				rows[i] = row(object)
+			}
+			return rows
+		},
+	}
+	if marshal != nil {
+		objectType.marshal = func(resource interface{}, writer io.Writer) error {
+			return marshal(resource.(T), writer)
+		}
+	}
+	if marshalList != nil {
+		listType.marshal = func(resource interface{}, writer io.Writer) error {
+			return marshalList(resource.([]T), writer)
+		}
+	}
+	if len(columns) == 0 {
+		objectType.rows = nil
+		listType.rows = nil
+	}
+	resourceTypes[reflect.TypeOf((*T)(nil)).Elem()] = objectType
+	resourceTypes[reflect.TypeOf(([]T)(nil))] = listType
+}
+
+func init() {
+	RegisterType(msv1.MarshalManagedService, msv1.MarshalManagedServiceList,
+		Column[*msv1.ManagedService]{Header: "SERVICE_ID", Value: (*msv1.ManagedService).ID},
+		Column[*msv1.ManagedService]{Header: "SERVICE", Value: func(s *msv1.ManagedService) string {
+			return s.Service()
+		}},
+		Column[*msv1.ManagedService]{Header: "SERVICE_STATE", Value: (*msv1.ManagedService).ServiceState},
+		Column[*msv1.ManagedService]{Header: "CLUSTER_NAME", Value: func(s *msv1.ManagedService) string {
+			return s.Cluster().Name()
+		}},
+	)
+	RegisterType(cmv1.MarshalCloudRegion, cmv1.MarshalCloudRegionList,
+		Column[*cmv1.CloudRegion]{Header: "ID", Value: (*cmv1.CloudRegion).ID},
+		Column[*cmv1.CloudRegion]{Header: "NAME", Value: (*cmv1.CloudRegion).DisplayName},
+		Column[*cmv1.CloudRegion]{Header: "MULTI-AZ SUPPORT", Value: func(r *cmv1.CloudRegion) string {
+			return fmt.Sprint(r.SupportsMultiAZ())
+		}},
+		Column[*cmv1.CloudRegion]{Header: "HOSTED-CP SUPPORT", Wide: true, Value: func(r *cmv1.CloudRegion) string {
+			return fmt.Sprint(r.SupportsHypershift())
+		}},
+	)
+	RegisterType(cmv1.MarshalCluster, cmv1.MarshalClusterList,
+		Column[*cmv1.Cluster]{Header: "ID", Value: (*cmv1.Cluster).ID},
+		Column[*cmv1.Cluster]{Header: "NAME", Value: (*cmv1.Cluster).Name},
+		Column[*cmv1.Cluster]{Header: "STATE", Value: func(c *cmv1.Cluster) string {
+			return string(c.State())
+		}},
+		Column[*cmv1.Cluster]{Header: "TOPOLOGY", Value: clusterTopology},
+		Column[*cmv1.Cluster]{Header: "VERSION", Wide: true, Value: func(c *cmv1.Cluster) string {
+			return c.OpenshiftVersion()
+		}},
+		Column[*cmv1.Cluster]{Header: "REGION", Wide: true, Value: func(c *cmv1.Cluster) string {
+			return c.Region().ID()
+		}},
+		Column[*cmv1.Cluster]{Header: "MULTI-AZ", Wide: true, Value: func(c *cmv1.Cluster) string {
+			return fmt.Sprint(c.MultiAZ())
+		}},
+		Column[*cmv1.Cluster]{Header: "CREATED", Wide: true, Value: func(c *cmv1.Cluster) string {
+			return c.CreationTimestamp().Format("2006-01-02T15:04:05Z07:00")
+		}},
+	)
+	RegisterType(cmv1.MarshalDNSDomain, cmv1.MarshalDNSDomainList,
+		Column[*cmv1.DNSDomain]{Header: "ID", Value: (*cmv1.DNSDomain).ID},
+		Column[*cmv1.DNSDomain]{Header: "CLUSTER ID", Value: func(d *cmv1.DNSDomain) string {
+			return d.Cluster().ID()
+		}},
+		Column[*cmv1.DNSDomain]{Header: "RESERVED TIME", Value: func(d *cmv1.DNSDomain) string {
+			return d.ReservedAtTimestamp().String()
+		}},
+		Column[*cmv1.DNSDomain]{Header: "USER DEFINED", Value: func(d *cmv1.DNSDomain) string {
+			return fmt.Sprint(d.UserDefined())
+		}},
+	)
+	RegisterType(cmv1.MarshalExternalAuth, cmv1.MarshalExternalAuthList,
+		Column[*cmv1.ExternalAuth]{Header: "NAME", Value: (*cmv1.ExternalAuth).ID},
+		Column[*cmv1.ExternalAuth]{Header: "ISSUER URL", Value: func(a *cmv1.ExternalAuth) string {
+			return a.Issuer().URL()
+		}},
+	)
+	RegisterType(cmv1.MarshalIdentityProvider, cmv1.MarshalIdentityProviderList,
+		Column[*cmv1.IdentityProvider]{Header: "NAME", Value: (*cmv1.IdentityProvider).Name},
+		Column[*cmv1.IdentityProvider]{Header: "TYPE", Value: func(i *cmv1.IdentityProvider) string {
+			return string(i.Type())
+		}},
+		Column[*cmv1.IdentityProvider]{Header: "MAPPING METHOD", Wide: true, Value: func(i *cmv1.IdentityProvider) string {
+			return string(i.MappingMethod())
+		}},
+	)
+	RegisterType(cmv1.MarshalIngress, cmv1.MarshalIngressList,
+		Column[*cmv1.Ingress]{Header: "ID", Value: (*cmv1.Ingress).ID},
+		Column[*cmv1.Ingress]{Header: "APPLICATION ROUTER", Value: (*cmv1.Ingress).DNSName},
+		Column[*cmv1.Ingress]{Header: "PRIVATE", Value: func(i *cmv1.Ingress) string {
+			return fmt.Sprint(i.Listening() == cmv1.ListeningMethodInternal)
+		}},
+		Column[*cmv1.Ingress]{Header: "DEFAULT", Value: func(i *cmv1.Ingress) string {
+			return fmt.Sprint(i.Default())
+		}},
+		Column[*cmv1.Ingress]{Header: "ROUTE SELECTORS", Value: func(i *cmv1.Ingress) string {
+			return joinMap(i.RouteSelectors())
+		}},
+		Column[*cmv1.Ingress]{Header: "LB-TYPE", Value: func(i *cmv1.Ingress) string {
+			return string(i.LoadBalancerType())
+		}},
+		Column[*cmv1.Ingress]{Header: "EXCLUDED NAMESPACES", Wide: true, Value: func(i *cmv1.Ingress) string {
+			return strings.Join(i.ExcludedNamespaces(), ", ")
+		}},
+		Column[*cmv1.Ingress]{Header: "WILDCARD POLICY", Wide: true, Value: func(i *cmv1.Ingress) string {
+			return string(i.RouteWildcardPolicy())
+		}},
+	)
+	RegisterType(cmv1.MarshalMachinePool, cmv1.MarshalMachinePoolList,
+		Column[*cmv1.MachinePool]{Header: "ID", Value: (*cmv1.MachinePool).ID},
+		Column[*cmv1.MachinePool]{Header: "AUTOSCALING", Value: func(m *cmv1.MachinePool) string {
+			return PrintBool(m.Autoscaling() != nil)
+		}},
+		Column[*cmv1.MachinePool]{Header: "REPLICAS", Value: func(m *cmv1.MachinePool) string {
+			if m.Autoscaling() != nil {
+				return fmt.Sprintf("%d-%d", m.Autoscaling().MinReplicas(), m.Autoscaling().MaxReplicas())
+			}
+			return fmt.Sprint(m.Replicas())
+		}},
+		Column[*cmv1.MachinePool]{Header: "INSTANCE TYPE", Value: (*cmv1.MachinePool).InstanceType},
+		Column[*cmv1.MachinePool]{Header: "LABELS", Value: func(m *cmv1.MachinePool) string {
+			return joinMap(m.Labels())
+		}},
+		Column[*cmv1.MachinePool]{Header: "AVAILABILITY ZONES", Wide: true, Value: func(m *cmv1.MachinePool) string {
+			return strings.Join(m.AvailabilityZones(), ", ")
+		}},
+		Column[*cmv1.MachinePool]{Header: "SUBNETS", Wide: true, Value: func(m *cmv1.MachinePool) string {
+			return strings.Join(m.Subnets(), ", ")
+		}},
+	)
+	RegisterType(cmv1.MarshalMachineType, cmv1.MarshalMachineTypeList,
+		Column[*cmv1.MachineType]{Header: "ID", Value: (*cmv1.MachineType).ID},
+		Column[*cmv1.MachineType]{Header: "CATEGORY", Value: func(m *cmv1.MachineType) string {
+			return string(m.Category())
+		}},
+		Column[*cmv1.MachineType]{Header: "CPU_CORES", Value: func(m *cmv1.MachineType) string {
+			return fmt.Sprint(m.CPU().Value())
+		}},
+		Column[*cmv1.MachineType]{Header: "MEMORY", Value: func(m *cmv1.MachineType) string {
+			return fmt.Sprint(m.Memory().Value())
+		}},
+	)
+	RegisterType(cmv1.MarshalNodePool, cmv1.MarshalNodePoolList,
+		Column[*cmv1.NodePool]{Header: "ID", Value: (*cmv1.NodePool).ID},
+		Column[*cmv1.NodePool]{Header: "AUTOSCALING", Value: func(n *cmv1.NodePool) string {
+			return PrintBool(n.Autoscaling() != nil)
+		}},
+		Column[*cmv1.NodePool]{Header: "REPLICAS", Value: func(n *cmv1.NodePool) string {
+			if n.Autoscaling() != nil {
+				return fmt.Sprintf("%d-%d", n.Autoscaling().MinReplica(), n.Autoscaling().MaxReplica())
+			}
+			return fmt.Sprint(n.Replicas())
+		}},
+		Column[*cmv1.NodePool]{Header: "INSTANCE TYPE", Value: func(n *cmv1.NodePool) string {
+			return n.AWSNodePool().InstanceType()
+		}},
+		Column[*cmv1.NodePool]{Header: "LABELS", Value: func(n *cmv1.NodePool) string {
+			return joinMap(n.Labels())
+		}},
+		Column[*cmv1.NodePool]{Header: "AVAILABILITY ZONE", Value: (*cmv1.NodePool).AvailabilityZone},
+		Column[*cmv1.NodePool]{Header: "SUBNET", Value: (*cmv1.NodePool).Subnet},
+		Column[*cmv1.NodePool]{Header: "VERSION", Wide: true, Value: func(n *cmv1.NodePool) string {
+			return n.Version().RawID()
+		}},
+		Column[*cmv1.NodePool]{Header: "AUTOREPAIR", Wide: true, Value: func(n *cmv1.NodePool) string {
+			return PrintBool(n.AutoRepair())
+		}},
+	)
+	RegisterType(cmv1.MarshalVersion, cmv1.MarshalVersionList,
+		Column[*cmv1.Version]{Header: "VERSION", Value: (*cmv1.Version).RawID},
+		Column[*cmv1.Version]{Header: "DEFAULT", Value: func(v *cmv1.Version) string {
+			return PrintBool(v.Default())
+		}},
+		Column[*cmv1.Version]{Header: "AVAILABLE UPGRADES", Value: func(v *cmv1.Version) string {
+			return strings.Join(v.AvailableUpgrades(), ", ")
+		}},
+		Column[*cmv1.Version]{Header: "CHANNEL GROUP", Wide: true, Value: (*cmv1.Version).ChannelGroup},
+		Column[*cmv1.Version]{Header: "END OF LIFE", Wide: true, Value: func(v *cmv1.Version) string {
+			return v.EndOfLifeTimestamp().Format("2006-01-02")
+		}},
+	)
+	RegisterType(cmv1.MarshalVersionGate, cmv1.MarshalVersionGateList,
+		Column[*cmv1.VersionGate]{Header: "ID", Value: (*cmv1.VersionGate).ID},
+		Column[*cmv1.VersionGate]{Header: "VERSION", Value: (*cmv1.VersionGate).VersionRawIDPrefix},
+		Column[*cmv1.VersionGate]{Header: "DESCRIPTION", Value: (*cmv1.VersionGate).Description},
+		Column[*cmv1.VersionGate]{Header: "DOCUMENTATION URL", Wide: true, Value: (*cmv1.VersionGate).DocumentationURL},
+	)
+	RegisterType(cmv1.MarshalOidcConfig, cmv1.MarshalOidcConfigList,
+		Column[*cmv1.OidcConfig]{Header: "ID", Value: (*cmv1.OidcConfig).ID},
+		Column[*cmv1.OidcConfig]{Header: "MANAGED", Value: func(o *cmv1.OidcConfig) string {
+			return fmt.Sprint(o.Managed())
+		}},
+		Column[*cmv1.OidcConfig]{Header: "ISSUER URL", Value: (*cmv1.OidcConfig).IssuerUrl},
+		Column[*cmv1.OidcConfig]{Header: "SECRET ARN", Value: (*cmv1.OidcConfig).SecretArn},
+	)
+	RegisterType(cmv1.MarshalBreakGlassCredential, cmv1.MarshalBreakGlassCredentialList,
+		Column[*cmv1.BreakGlassCredential]{Header: "ID", Value: (*cmv1.BreakGlassCredential).ID},
+		Column[*cmv1.BreakGlassCredential]{Header: "USERNAME", Value: (*cmv1.BreakGlassCredential).Username},
+		Column[*cmv1.BreakGlassCredential]{Header: "STATUS", Value: func(b *cmv1.BreakGlassCredential) string {
+			return string(b.Status())
+		}},
+	)
+	RegisterType(cmv1.MarshalTuningConfig, cmv1.MarshalTuningConfigList,
+		Column[*cmv1.TuningConfig]{Header: "ID", Value: (*cmv1.TuningConfig).ID},
+		Column[*cmv1.TuningConfig]{Header: "NAME", Value: (*cmv1.TuningConfig).Name},
+	)
+	RegisterType(cmv1.MarshalKubeletConfig, cmv1.MarshalKubeletConfigList,
+		Column[*cmv1.KubeletConfig]{Header: "ID", Value: (*cmv1.KubeletConfig).ID},
+		Column[*cmv1.KubeletConfig]{Header: "NAME", Value: (*cmv1.KubeletConfig).Name},
+		Column[*cmv1.KubeletConfig]{Header: "POD PIDS LIMIT", Value: func(k *cmv1.KubeletConfig) string {
+			return fmt.Sprint(k.PodPidsLimit())
+		}},
+	)
+	RegisterType(cmv1.MarshalClusterAutoscaler, cmv1.MarshalClusterAutoscalerList)
+	RegisterType(cmv1.MarshalUser, cmv1.MarshalUserList,
+		Column[*cmv1.User]{Header: "ID", Value: (*cmv1.User).ID},
+	)
+	RegisterType(cmv1.MarshalAddOnInstallation, cmv1.MarshalAddOnInstallationList,
+		Column[*cmv1.AddOnInstallation]{Header: "ID", Value: (*cmv1.AddOnInstallation).ID},
+		Column[*cmv1.AddOnInstallation]{Header: "STATE", Value: func(a *cmv1.AddOnInstallation) string {
+			return string(a.State())
+		}},
+	)
+	RegisterType(cmv1.MarshalSubnetNetworkVerification, cmv1.MarshalSubnetNetworkVerificationList,
+		Column[*cmv1.SubnetNetworkVerification]{Header: "ID", Value: (*cmv1.SubnetNetworkVerification).ID},
+		Column[*cmv1.SubnetNetworkVerification]{Header: "STATE", Value: (*cmv1.SubnetNetworkVerification).State},
+	)
+}
+
+func clusterTopology(cluster *cmv1.Cluster) string {
+	if cluster.Hypershift().Enabled() {
+		return "Hosted CP"
+	}
+	if cluster.AWS().STS().Enabled() {
+		return "Classic (STS)"
+	}
+	return "Classic"
+}
+
+func joinMap(values map[string]string) string {
+	pairs := make([]string, 0, len(values))
+	for _, key := range sortedKeys(values) {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, values[key]))
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reporter
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/smithy-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/zgalor/weberr"
+)
+
+// ErrorCode is the class of a failure. It is included in the error messages in JSON format and
+// determines the exit code of the process.
+type ErrorCode string
+
+const (
+	ErrorCodeGeneric       ErrorCode = "error"
+	ErrorCodeValidation    ErrorCode = "validation"
+	ErrorCodeAuth          ErrorCode = "auth"
+	ErrorCodeNotFound      ErrorCode = "not_found"
+	ErrorCodeAWSPermission ErrorCode = "aws_permission"
+	ErrorCodeOCMServer     ErrorCode = "ocm_server"
+	ErrorCodeTimeout       ErrorCode = "timeout"
+)
+
+var exitCodes = map[ErrorCode]int{
+	ErrorCodeGeneric:       1,
+	ErrorCodeValidation:    2,
+	ErrorCodeAuth:          3,
+	ErrorCodeNotFound:      4,
+	ErrorCodeAWSPermission: 5,
+	ErrorCodeOCMServer:     6,
+	ErrorCodeTimeout:       7,
+}
+
+// ExitCode returns the exit code of the process for failures of this class.
+func (c ErrorCode) ExitCode() int {
+	if code, ok := exitCodes[c]; ok {
+		return code
+	}
+	return exitCodes[ErrorCodeGeneric]
+}
+
+// AWS error codes returned when the credentials are missing or not valid
+var awsAuthErrorCodes = map[string]bool{
+	"AuthFailure":                 true,
+	"ExpiredToken":                true,
+	"IncompleteSignature":         true,
+	"InvalidClientTokenId":        true,
+	"SignatureDoesNotMatch":       true,
+	"UnrecognizedClientException": true,
+}
+
+// AWS error codes returned when the credentials don't allow the operation
+var awsPermissionErrorCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"Forbidden":             true,
+	"UnauthorizedOperation": true,
+}
+
+type codedError struct {
+	code ErrorCode
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// WithCode returns an error that wraps the given one and belongs to the given class of failures.
+func WithCode(code ErrorCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: code, err: err}
+}
+
+// Code returns the class of failure of the given error. Errors that were not explicitly classified
+// are classified according to the error returned by the OCM or AWS API, if any.
+func Code(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case awsAuthErrorCodes[apiErr.ErrorCode()]:
+			return ErrorCodeAuth
+		case awsPermissionErrorCodes[apiErr.ErrorCode()]:
+			return ErrorCodeAWSPermission
+		case apiErr.ErrorCode() == "NoSuchEntity" || strings.HasSuffix(apiErr.ErrorCode(), "NotFound"):
+			return ErrorCodeNotFound
+		}
+		return ErrorCodeGeneric
+	}
+
+	var ocmErr *ocmerrors.Error
+	if errors.As(err, &ocmErr) {
+		return statusCode(ocmErr.Status())
+	}
+	for wrapped := err; wrapped != nil; wrapped = errors.Unwrap(wrapped) {
+		if errType := weberr.GetType(wrapped); errType != weberr.NoType {
+			return statusCode(int(errType))
+		}
+	}
+	return ErrorCodeGeneric
+}
+
+// statusCode classifies the HTTP status code of a response of the OCM API.
+func statusCode(status int) ErrorCode {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorCodeAuth
+	case status == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrorCodeValidation
+	case status >= http.StatusInternalServerError:
+		return ErrorCodeOCMServer
+	}
+	return ErrorCodeGeneric
+}
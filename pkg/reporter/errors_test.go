@@ -0,0 +1,33 @@
+package reporter
+
+import (
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zgalor/weberr"
+)
+
+var _ = Describe("Error codes", func() {
+	DescribeTable("Classifies errors",
+		func(err error, code ErrorCode, exitCode int) {
+			Expect(Code(err)).To(Equal(code))
+			Expect(Code(err).ExitCode()).To(Equal(exitCode))
+		},
+		Entry("Unknown errors", fmt.Errorf("failed"), ErrorCodeGeneric, 1),
+		Entry("Explicitly classified errors",
+			fmt.Errorf("wrapped: %w", WithCode(ErrorCodeValidation, fmt.Errorf("invalid"))), ErrorCodeValidation, 2),
+		Entry("Timeouts", WithCode(ErrorCodeTimeout, fmt.Errorf("timed out")), ErrorCodeTimeout, 7),
+		Entry("OCM authentication errors", weberr.Unauthorized.Errorf("no"), ErrorCodeAuth, 3),
+		Entry("OCM not found errors",
+			fmt.Errorf("wrapped: %w", weberr.NotFound.Errorf("no")), ErrorCodeNotFound, 4),
+		Entry("OCM server errors", weberr.ServiceUnavailable.Errorf("no"), ErrorCodeOCMServer, 6),
+		Entry("AWS permission errors",
+			&smithy.GenericAPIError{Code: "AccessDenied"}, ErrorCodeAWSPermission, 5),
+		Entry("AWS credential errors",
+			&smithy.GenericAPIError{Code: "InvalidClientTokenId"}, ErrorCodeAuth, 3),
+		Entry("AWS not found errors",
+			&smithy.GenericAPIError{Code: "InvalidVpcID.NotFound"}, ErrorCodeNotFound, 4),
+	)
+})
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--log-format' command line option.
+
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+
+	// LogFormatEnvVar is the environment variable that selects the format of the messages when the
+	// flag isn't used.
+	LogFormatEnvVar = "ROSA_OUTPUT_FORMAT"
+)
+
+var logFormat string
+
+var formats = []string{TextFormat, JSONFormat}
+
+// AddFlag adds the log format flag to the given command.
+func AddFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&logFormat,
+		"log-format",
+		"",
+		fmt.Sprintf("Format of the informative, warning and error messages. Allowed options are %s. "+
+			"Defaults to the value of the %s environment variable, or '%s'.", formats, LogFormatEnvVar, TextFormat),
+	)
+
+	cmd.RegisterFlagCompletionFunc("log-format", formatCompletion)
+}
+
+func formatCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return formats, cobra.ShellCompDirectiveDefault
+}
+
+// LogFormat returns the format of the messages, either 'text' or 'json'. Values that aren't valid
+// are rejected by ValidateLogFormat, until then they are treated as 'text', so that the error can
+// be reported.
+func LogFormat() string {
+	if requestedLogFormat() == JSONFormat {
+		return JSONFormat
+	}
+	return TextFormat
+}
+
+// ValidateLogFormat checks that the format selected with the flag or the environment variable is
+// one of the allowed formats.
+func ValidateLogFormat() error {
+	format := requestedLogFormat()
+	if format == "" || slices.Contains(formats, format) {
+		return nil
+	}
+	source := "--log-format"
+	if logFormat == "" {
+		source = LogFormatEnvVar
+	}
+	return WithCode(ErrorCodeValidation, fmt.Errorf("Invalid value '%s' of '%s', allowed options are '%s'",
+		format, source, strings.Join(formats, "', '")))
+}
+
+func requestedLogFormat() string {
+	if logFormat != "" {
+		return logFormat
+	}
+	return os.Getenv(LogFormatEnvVar)
+}
+
+func SetLogFormat(format string) {
+	logFormat = format
+}
+
+// The command and the cluster that the messages refer to, included in the messages in JSON format
+var (
+	command   string
+	clusterID string
+)
+
+// SetCommand sets the command that is running, for example 'rosa edit cluster'.
+func SetCommand(value string) {
+	command = value
+}
+
+// SetClusterID sets the identifier of the cluster that the command is working on.
+func SetClusterID(value string) {
+	clusterID = value
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package reporter
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/openshift/rosa/pkg/color"
@@ -35,42 +37,78 @@ func (r *Object) Debugf(format string, args ...interface{}) {
 	if !debug.Enabled() {
 		return
 	}
+	if LogFormat() == JSONFormat {
+		r.printJSON(os.Stdout, "debug", fmt.Sprintf(format, args...), "")
+		return
+	}
 	r.Infof(format, args...)
 }
 
 // Infof prints an informative message with the given format and arguments.
 func (r *Object) Infof(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoColorPrefix, message)
-	} else {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoPrefix, message)
-	}
+	r.print(os.Stdout, "info", infoColorPrefix, infoPrefix, fmt.Sprintf(format, args...), "")
 }
 
 // Warnf prints an warning message with the given format and arguments.
 func (r *Object) Warnf(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", warnColorPrefix, message)
-	} else {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", warnPrefix, message)
-	}
+	r.print(os.Stderr, "warning", warnColorPrefix, warnPrefix, fmt.Sprintf(format, args...), "")
 }
 
 // Errorf prints an error message with the given format and arguments. It also return an error
 // containing the same information, which will be usually discarded, except when the caller needs to
-// report the error and also return it.
+// report the error and also return it. When one of the arguments is an error, the returned error
+// keeps its class of failure.
 func (r *Object) Errorf(format string, args ...interface{}) error {
 	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorColorPrefix, message)
-	} else {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorPrefix, message)
+	code := ErrorCodeGeneric
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			code = Code(err)
+			break
+		}
+	}
+	r.print(os.Stderr, "error", errorColorPrefix, errorPrefix, message, code)
+	if code != ErrorCodeGeneric {
+		return WithCode(code, errors.New(message))
 	}
 	return errors.New(message)
 }
 
+func (r *Object) print(stream io.Writer, level string, colorPrefix string, prefix string, message string,
+	code ErrorCode) {
+	switch {
+	case LogFormat() == JSONFormat:
+		r.printJSON(stream, level, message, code)
+	case color.UseColor():
+		_, _ = fmt.Fprintf(stream, "%s%s\n", colorPrefix, message)
+	default:
+		_, _ = fmt.Fprintf(stream, "%s%s\n", prefix, message)
+	}
+}
+
+// jsonMessage is the structure of the messages when the log format is JSON
+type jsonMessage struct {
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	Command   string    `json:"command,omitempty"`
+	ClusterID string    `json:"cluster_id,omitempty"`
+	Code      ErrorCode `json:"code,omitempty"`
+}
+
+func (r *Object) printJSON(stream io.Writer, level string, message string, code ErrorCode) {
+	data, err := json.Marshal(&jsonMessage{
+		Level:     level,
+		Message:   message,
+		Command:   command,
+		ClusterID: clusterID,
+		Code:      code,
+	})
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(stream, "%s\n", data)
+}
+
 // Message prefix using ANSI scape sequences to set colors:
 const (
 	infoColorPrefix  = "\033[0;36mI:\033[m "
@@ -7,6 +7,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/color"
 	"github.com/openshift/rosa/pkg/debug"
@@ -149,6 +150,90 @@ var _ = Describe("Reporter Tests", func() {
 			Expect(stdErr).To(BeEmpty())
 		})
 	})
+
+	Context("JSON", func() {
+		BeforeEach(func() {
+			SetLogFormat(JSONFormat)
+			SetCommand("rosa edit cluster")
+			SetClusterID("123")
+		})
+
+		AfterEach(func() {
+			SetLogFormat("")
+			SetCommand("")
+			SetClusterID("")
+		})
+
+		It("Prints messages as JSON lines", func() {
+			color.SetColor("always")
+
+			stdOut, stdErr := captureStdOutAndStdError(func() {
+				reporter.Infof("Hello World")
+				reporter.Warnf("Hello World")
+			})
+			Expect(stdOut).To(MatchJSON(`{"level":"info","message":"Hello World",` +
+				`"command":"rosa edit cluster","cluster_id":"123"}`))
+			Expect(stdErr).To(MatchJSON(`{"level":"warning","message":"Hello World",` +
+				`"command":"rosa edit cluster","cluster_id":"123"}`))
+		})
+
+		It("Includes the class of failure of the errors", func() {
+			var err error
+			stdOut, stdErr := captureStdOutAndStdError(func() {
+				err = reporter.Errorf("Failed: %v", weberr.NotFound.Errorf("Hello World"))
+			})
+			Expect(stdOut).To(BeEmpty())
+			Expect(stdErr).To(MatchJSON(`{"level":"error","message":"Failed: Hello World",` +
+				`"command":"rosa edit cluster","cluster_id":"123","code":"not_found"}`))
+			Expect(Code(err)).To(Equal(ErrorCodeNotFound))
+		})
+
+		It("Uses the environment variable when the flag isn't set", func() {
+			SetLogFormat("")
+			os.Setenv(LogFormatEnvVar, JSONFormat)
+			DeferCleanup(os.Unsetenv, LogFormatEnvVar)
+			Expect(LogFormat()).To(Equal(JSONFormat))
+		})
+	})
+
+	Context("Log format validation", func() {
+		AfterEach(func() {
+			SetLogFormat("")
+		})
+
+		It("Accepts the allowed formats and no format", func() {
+			Expect(ValidateLogFormat()).To(Succeed())
+			SetLogFormat(JSONFormat)
+			Expect(ValidateLogFormat()).To(Succeed())
+			SetLogFormat(TextFormat)
+			Expect(ValidateLogFormat()).To(Succeed())
+		})
+
+		It("Rejects an unknown format given with the flag", func() {
+			SetLogFormat("yaml")
+			err := ValidateLogFormat()
+			Expect(err).To(MatchError("Invalid value 'yaml' of '--log-format', allowed options are 'text', 'json'"))
+			Expect(Code(err)).To(Equal(ErrorCodeValidation))
+		})
+
+		It("Rejects an unknown format given with the environment variable", func() {
+			os.Setenv(LogFormatEnvVar, "JSON")
+			DeferCleanup(os.Unsetenv, LogFormatEnvVar)
+			Expect(ValidateLogFormat()).To(MatchError(ContainSubstring("'JSON' of '" + LogFormatEnvVar + "'")))
+		})
+	})
+
+	Context("Exit code", func() {
+		It("Depends on the class of the reported error", func() {
+			var notFound, generic error
+			captureStdOutAndStdError(func() {
+				notFound = reporter.Errorf("Failed: %v", weberr.NotFound.Errorf("Hello World"))
+				generic = reporter.Errorf("Failed: %s", "Hello World")
+			})
+			Expect(Code(notFound).ExitCode()).To(Equal(4))
+			Expect(Code(generic).ExitCode()).To(Equal(1))
+		})
+	})
 })
 
 func captureStdOutAndStdError(function func()) (string, string) {
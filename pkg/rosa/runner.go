@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/reporter"
 )
 
 // RuntimeVisitor are functions that configure the Runtime for a command.
@@ -15,7 +17,9 @@ type RuntimeVisitor func(ctx context.Context, runtime *Runtime, command *cobra.C
 type CommandRunner func(ctx context.Context, runtime *Runtime, command *cobra.Command, args []string) error
 
 // DefaultRunner is a centralised implementation of the default Cobra Command.run function that takes care
-// of instantiating several key resources on behalf of a command
+// of instantiating several key resources on behalf of a command. When the dry-run mode is enabled, the
+// requests that the command didn't send are reported once it finishes. The exit code of the process
+// depends on the class of failure of the error returned by the command.
 func DefaultRunner(visitor RuntimeVisitor, runner CommandRunner) func(command *cobra.Command, args []string) {
 	return func(command *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -28,8 +32,10 @@ func DefaultRunner(visitor RuntimeVisitor, runner CommandRunner) func(command *c
 
 		err := runner(ctx, r, command, args)
 		if err != nil {
-			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			r.Reporter.Errorf("%v", err)
+			// Exiting skips the deferred cleanup, that reports the dry run
+			r.Cleanup()
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 }
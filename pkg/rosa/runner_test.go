@@ -2,11 +2,15 @@ package rosa
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/dryrun"
 )
 
 func TestDefaultRunner(t *testing.T) {
@@ -45,4 +49,21 @@ var _ = Describe("Runner Tests", func() {
 
 		Expect(run).To(BeTrue())
 	})
+
+	It("Reports the requests that weren't sent in dry-run mode", func() {
+		dryrun.SetEnabled(true)
+		DeferCleanup(dryrun.SetEnabled, false)
+
+		runner := func(ctx context.Context, runtime *Runtime, command *cobra.Command, args []string) error {
+			_, err := dryrun.TransportWrapper(nil).RoundTrip(
+				httptest.NewRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dryrun.Operations()).To(HaveLen(1))
+			return nil
+		}
+
+		DefaultRunner(nil, runner)(nil, nil)
+
+		Expect(dryrun.Operations()).To(BeEmpty())
+	})
 })
@@ -1,7 +1,9 @@
 package rosa
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -9,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/dryrun"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/reporter"
@@ -47,7 +50,7 @@ func (r *Runtime) WithAWS() *Runtime {
 	err := r.OCMClient.ValidateAwsClientRegion()
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	if r.AWSClient == nil {
 		r.AWSClient = aws.CreateNewClientOrExit(r.Logger, r.Reporter)
@@ -57,13 +60,16 @@ func (r *Runtime) WithAWS() *Runtime {
 		r.Creator, err = r.AWSClient.GetCreator()
 		if err != nil {
 			r.Reporter.Errorf("Failed to get AWS creator: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.Code(err).ExitCode())
 		}
 	}
 	return r
 }
 
 func (r *Runtime) Cleanup() {
+	if dryrun.Enabled() {
+		r.ReportDryRun()
+	}
 	if r.OCMClient != nil {
 		if err := r.OCMClient.Close(); err != nil {
 			r.Reporter.Errorf("Failed to close OCM connection: %v", err)
@@ -71,12 +77,25 @@ func (r *Runtime) Cleanup() {
 	}
 }
 
+// ReportDryRun prints the OCM requests and AWS API calls that were not sent because the dry-run
+// mode is enabled, and discards them.
+func (r *Runtime) ReportDryRun() {
+	operations := dryrun.Operations()
+	dryrun.Reset()
+	if len(operations) == 0 {
+		r.Reporter.Infof("Dry run: the command doesn't change any resource")
+		return
+	}
+	r.Reporter.Infof("Dry run: the following requests were not sent:")
+	fmt.Printf("\n%s\n", strings.Join(operations, "\n\n"))
+}
+
 // Load the cluster key provided by the user into the runtime and return it
 func (r *Runtime) GetClusterKey() string {
 	clusterKey, err := ocm.GetClusterKey()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ErrorCodeValidation.ExitCode())
 	}
 	r.ClusterKey = clusterKey
 	return clusterKey
@@ -103,7 +122,7 @@ func (r *Runtime) FetchCluster() *cmv1.Cluster {
 	cluster, err := r.OCMClient.GetCluster(r.ClusterKey, r.Creator)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get cluster '%s': %v", r.ClusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.Code(err).ExitCode())
 	}
 	r.Cluster = cluster
 	return cluster
@@ -0,0 +1,66 @@
+package rosa
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// fetchClusterEnv is the environment variable that contains the URL of the OCM API when the test
+// binary runs as the process that fetches a cluster, as it exits instead of returning.
+const fetchClusterEnv = "ROSA_TEST_FETCH_CLUSTER_URL"
+
+func TestMain(m *testing.M) {
+	if url := os.Getenv(fetchClusterEnv); url != "" {
+		fetchCluster(url)
+	}
+	os.Exit(m.Run())
+}
+
+func fetchCluster(url string) {
+	connection, err := sdk.NewConnectionBuilder().
+		URL(url).
+		Tokens(logging.FakeToken()).
+		Build()
+	if err != nil {
+		os.Exit(100)
+	}
+	r := NewRuntime()
+	r.OCMClient = ocm.NewClientWithConnection(connection)
+	r.Creator = &aws.Creator{
+		ARN:       "fake",
+		AccountID: "123",
+	}
+	r.ClusterKey = "mycluster"
+	r.FetchCluster()
+	os.Exit(0)
+}
+
+var _ = Describe("Runtime", func() {
+	It("Exits with the code of the class of the error when the cluster doesn't exist", func() {
+		server := ghttp.NewServer()
+		DeferCleanup(server.Close)
+		server.AppendHandlers(ghttp.RespondWith(http.StatusOK,
+			`{"kind": "ClusterList", "page": 1, "size": 0, "total": 0, "items": []}`,
+			http.Header{"Content-Type": []string{"application/json"}}))
+
+		command := exec.Command(os.Args[0], "-test.run=^$")
+		command.Env = append(os.Environ(), fetchClusterEnv+"="+server.URL())
+		output, err := command.CombinedOutput()
+		var exitErr *exec.ExitError
+		Expect(err).To(BeAssignableToTypeOf(exitErr), string(output))
+		Expect(err.(*exec.ExitError).ExitCode()).To(Equal(reporter.ErrorCodeNotFound.ExitCode()), string(output))
+		Expect(string(output)).To(ContainSubstring("There is no cluster with identifier or name 'mycluster'"))
+	})
+})
@@ -0,0 +1,358 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/versions"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Status is the result of a check.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// OCMClient is the subset of the OCM client used by the checks.
+type OCMClient interface {
+	GetAvailableUpgrades(versionID string) ([]string, error)
+	GetMissingGateAgreementsClassic(clusterID string, policy *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error)
+	GetMissingGateAgreementsHypershift(clusterID string,
+		policy *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error)
+	FindMissingOperatorRolesForUpgrade(cluster *cmv1.Cluster, version string) (map[string]*cmv1.STSOperator, error)
+	GetNodePools(clusterID string) ([]*cmv1.NodePool, error)
+	IsVersionCloseToEol(daysAwayToCheck int, version string, channelGroup string) error
+	GetLimitedSupportReasons(clusterID string) ([]*cmv1.LimitedSupportReason, error)
+	GetClusterAddOns(cluster *cmv1.Cluster) ([]*ocm.ClusterAddOn, error)
+}
+
+// AWSClient is the subset of the AWS client used by the checks.
+type AWSClient interface {
+	IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster *cmv1.Cluster, version string) (bool, error)
+}
+
+// Check is the result of one of the checks of the checklist.
+type Check struct {
+	Name    string   `json:"name"`
+	Status  Status   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Checklist is the result of all the checks of the upgrade of a cluster to a version.
+type Checklist struct {
+	ClusterID   string  `json:"cluster_id"`
+	ClusterName string  `json:"cluster_name"`
+	FromVersion string  `json:"from_version"`
+	Version     string  `json:"version"`
+	Status      Status  `json:"status"`
+	Checks      []Check `json:"checks"`
+}
+
+// Count returns the number of checks with the status.
+func (c *Checklist) Count(status Status) int {
+	count := 0
+	for _, check := range c.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Checklist) add(check Check) {
+	c.Checks = append(c.Checks, check)
+	if check.Status == StatusFail || (check.Status == StatusWarn && c.Status != StatusFail) {
+		c.Status = check.Status
+	}
+}
+
+// Run runs all the checks of the upgrade of the cluster to the version, without scheduling
+// anything. Failures to run a check are reported as warnings, as the check couldn't be verified.
+func Run(ocmClient OCMClient, awsClient AWSClient, cluster *cmv1.Cluster, version string) *Checklist {
+	checklist := &Checklist{
+		ClusterID:   cluster.ID(),
+		ClusterName: cluster.Name(),
+		FromVersion: cluster.Version().RawID(),
+		Version:     version,
+		Status:      StatusPass,
+		Checks:      []Check{},
+	}
+	checklist.add(checkState(cluster))
+	available := checkAvailableUpgrade(ocmClient, cluster, version)
+	checklist.add(available)
+	if available.Status == StatusPass {
+		// The version gates of a version that isn't an available upgrade can't be checked:
+		checklist.add(checkGates(ocmClient, cluster, version))
+	}
+	if _, isSTS := cluster.AWS().STS().GetRoleARN(); isSTS {
+		checklist.add(checkAccountRoles(awsClient, cluster, version))
+		checklist.add(checkOperatorRoles(ocmClient, cluster, version))
+	}
+	if cluster.Hypershift().Enabled() {
+		checklist.add(checkNodePoolSkew(ocmClient, cluster, version))
+	}
+	checklist.add(checkEndOfLife(ocmClient, cluster, version))
+	checklist.add(checkLimitedSupport(ocmClient, cluster))
+	checklist.add(checkAddOns(ocmClient, cluster))
+	return checklist
+}
+
+func unverified(name string, format string, args ...interface{}) Check {
+	return Check{
+		Name:    name,
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("Couldn't be verified: %s", fmt.Sprintf(format, args...)),
+	}
+}
+
+func checkState(cluster *cmv1.Cluster) Check {
+	check := Check{Name: "Cluster state", Status: StatusPass, Message: "Cluster is ready"}
+	if cluster.State() != cmv1.ClusterStateReady {
+		check.Status = StatusFail
+		check.Message = fmt.Sprintf("Cluster is in '%s' state, only ready clusters can be upgraded",
+			cluster.State())
+	}
+	return check
+}
+
+func checkAvailableUpgrade(client OCMClient, cluster *cmv1.Cluster, version string) Check {
+	const name = "Available upgrade"
+	var availableUpgrades []string
+	if ocm.IsHyperShiftCluster(cluster) {
+		availableUpgrades = ocm.GetAvailableUpgradesByCluster(cluster)
+	} else {
+		var err error
+		availableUpgrades, err = client.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+		if err != nil {
+			return unverified(name, "failed to find available upgrades: %v", err)
+		}
+	}
+	for _, availableUpgrade := range availableUpgrades {
+		if availableUpgrade == version {
+			return Check{Name: name, Status: StatusPass,
+				Message: fmt.Sprintf("Version '%s' is an available upgrade", version)}
+		}
+	}
+	check := Check{
+		Name:    name,
+		Status:  StatusFail,
+		Message: fmt.Sprintf("Version '%s' isn't an available upgrade from version '%s'", version, cluster.Version().RawID()),
+	}
+	if len(availableUpgrades) > 0 {
+		check.Details = []string{fmt.Sprintf("Available upgrades: %s", strings.Join(availableUpgrades, ", "))}
+	}
+	return check
+}
+
+func checkGates(client OCMClient, cluster *cmv1.Cluster, version string) Check {
+	const name = "Version gates"
+	var gates []*cmv1.VersionGate
+	var err error
+	if cluster.Hypershift().Enabled() {
+		var policy *cmv1.ControlPlaneUpgradePolicy
+		policy, err = cmv1.NewControlPlaneUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeControlPlane).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version(version).
+			Build()
+		if err == nil {
+			gates, err = client.GetMissingGateAgreementsHypershift(cluster.ID(), policy)
+		}
+	} else {
+		var policy *cmv1.UpgradePolicy
+		policy, err = cmv1.NewUpgradePolicy().
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version(version).
+			Build()
+		if err == nil {
+			gates, err = client.GetMissingGateAgreementsClassic(cluster.ID(), policy)
+		}
+	}
+	if err != nil {
+		return unverified(name, "failed to check for missing gate agreements: %v", err)
+	}
+	check := Check{Name: name, Status: StatusPass, Details: []string{}}
+	for _, gate := range gates {
+		// STS only gates are acknowledged automatically when scheduling the upgrade:
+		if gate.STSOnly() {
+			continue
+		}
+		check.Status = StatusWarn
+		detail := gate.Description()
+		if gate.DocumentationURL() != "" {
+			detail = fmt.Sprintf("%s (%s)", detail, gate.DocumentationURL())
+		}
+		check.Details = append(check.Details, detail)
+	}
+	if check.Status == StatusPass {
+		check.Message = "No version gates need to be acknowledged"
+	} else {
+		check.Message = fmt.Sprintf("%d version gates have to be acknowledged when scheduling the upgrade",
+			len(check.Details))
+	}
+	return check
+}
+
+func checkAccountRoles(client AWSClient, cluster *cmv1.Cluster, version string) Check {
+	const name = "Account roles"
+	if cluster.AWS().STS().ManagedPolicies() {
+		return Check{Name: name, Status: StatusPass,
+			Message: "Account roles use managed policies that are updated by Red Hat"}
+	}
+	upgradeNeeded, err := client.IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster, version)
+	if err != nil {
+		return unverified(name, "failed to check the account role policies: %v", err)
+	}
+	if upgradeNeeded {
+		return Check{
+			Name:   name,
+			Status: StatusFail,
+			Message: fmt.Sprintf("Account role policies aren't compatible with version '%s', "+
+				"upgrade them with 'rosa upgrade account-roles'", version),
+		}
+	}
+	return Check{Name: name, Status: StatusPass,
+		Message: fmt.Sprintf("Account role policies are compatible with version '%s'", version)}
+}
+
+func checkOperatorRoles(client OCMClient, cluster *cmv1.Cluster, version string) Check {
+	const name = "Operator roles"
+	missingRoles, err := client.FindMissingOperatorRolesForUpgrade(cluster, version)
+	if err != nil {
+		return unverified(name, "failed to find the operator roles needed by the version: %v", err)
+	}
+	if len(missingRoles) == 0 {
+		return Check{Name: name, Status: StatusPass,
+			Message: fmt.Sprintf("Cluster has the operator roles needed by version '%s'", version)}
+	}
+	check := Check{
+		Name:   name,
+		Status: StatusFail,
+		Message: fmt.Sprintf("Version '%s' needs %d operator roles that the cluster doesn't have, "+
+			"create them with 'rosa upgrade operator-roles'", version, len(missingRoles)),
+		Details: []string{},
+	}
+	for _, operator := range missingRoles {
+		check.Details = append(check.Details, fmt.Sprintf("%s/%s", operator.Namespace(), operator.Name()))
+	}
+	sort.Strings(check.Details)
+	return check
+}
+
+// checkNodePoolSkew checks that the machine pools of a hosted control plane cluster stay within
+// the supported version skew once the control plane is upgraded.
+func checkNodePoolSkew(client OCMClient, cluster *cmv1.Cluster, version string) Check {
+	const name = "Machine pool version skew"
+	nodePools, err := client.GetNodePools(cluster.ID())
+	if err != nil {
+		return unverified(name, "failed to get the machine pools: %v", err)
+	}
+	check := Check{Name: name, Status: StatusPass, Details: []string{}}
+	for _, nodePool := range nodePools {
+		rawID := ocm.GetRawVersionId(nodePool.Version().ID())
+		supported, err := versions.IsHostedMachinePoolVersionSkewSupported(version, rawID)
+		if err != nil {
+			return unverified(name, "version of machine pool '%s' isn't valid: %v", nodePool.ID(), err)
+		}
+		if supported {
+			continue
+		}
+		check.Status = StatusFail
+		check.Details = append(check.Details, fmt.Sprintf("Machine pool '%s' is in version '%s'",
+			nodePool.ID(), rawID))
+	}
+	if check.Status == StatusPass {
+		check.Message = fmt.Sprintf("The %d machine pools stay within %d minor versions of the control plane",
+			len(nodePools), versions.MinorVersionsSupported)
+	} else {
+		check.Message = fmt.Sprintf("Machine pools more than %d minor versions behind version '%s' have to be "+
+			"upgraded first with 'rosa upgrade machinepool'", versions.MinorVersionsSupported, version)
+	}
+	return check
+}
+
+func checkEndOfLife(client OCMClient, cluster *cmv1.Cluster, version string) Check {
+	err := client.IsVersionCloseToEol(ocm.CloseToEolDays, version, cluster.Version().ChannelGroup())
+	if err != nil {
+		return Check{Name: "End of life", Status: StatusWarn, Message: err.Error()}
+	}
+	return Check{Name: "End of life", Status: StatusPass,
+		Message: fmt.Sprintf("Version '%s' is supported for more than %d days", version, ocm.CloseToEolDays)}
+}
+
+func checkLimitedSupport(client OCMClient, cluster *cmv1.Cluster) Check {
+	const name = "Limited support"
+	reasons, err := client.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return unverified(name, "failed to get the limited support reasons: %v", err)
+	}
+	if len(reasons) == 0 {
+		return Check{Name: name, Status: StatusPass, Message: "Cluster isn't in limited support"}
+	}
+	check := Check{
+		Name:    name,
+		Status:  StatusWarn,
+		Message: fmt.Sprintf("Cluster is in limited support for %d reasons", len(reasons)),
+		Details: []string{},
+	}
+	for _, reason := range reasons {
+		check.Details = append(check.Details, reason.Summary())
+	}
+	return check
+}
+
+func checkAddOns(client OCMClient, cluster *cmv1.Cluster) Check {
+	const name = "Add-ons"
+	addOns, err := client.GetClusterAddOns(cluster)
+	if err != nil {
+		return unverified(name, "failed to get the add-ons: %v", err)
+	}
+	check := Check{Name: name, Status: StatusPass, Details: []string{}}
+	for _, addOn := range addOns {
+		switch cmv1.AddOnInstallationState(addOn.State) {
+		case cmv1.AddOnInstallationStateFailed:
+			check.Status = StatusFail
+		case cmv1.AddOnInstallationStatePending, cmv1.AddOnInstallationStateInstalling,
+			cmv1.AddOnInstallationStateDeleting:
+			if check.Status == StatusPass {
+				check.Status = StatusWarn
+			}
+		default:
+			continue
+		}
+		check.Details = append(check.Details, fmt.Sprintf("Add-on '%s' is in '%s' state", addOn.ID, addOn.State))
+	}
+	switch check.Status {
+	case StatusPass:
+		check.Message = "No add-on is pending or failed"
+	case StatusWarn:
+		check.Message = "Some add-ons are still being installed or deleted"
+	case StatusFail:
+		check.Message = "Some add-ons failed, fix or remove them before upgrading"
+	}
+	return check
+}
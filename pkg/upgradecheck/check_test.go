@@ -0,0 +1,227 @@
+package upgradecheck
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+type fakeOCMClient struct {
+	availableUpgrades []string
+	gates             []*cmv1.VersionGate
+	missingRoles      map[string]*cmv1.STSOperator
+	nodePools         []*cmv1.NodePool
+	eolErr            error
+	reasons           []*cmv1.LimitedSupportReason
+	addOns            []*ocm.ClusterAddOn
+	addOnsErr         error
+}
+
+func (f *fakeOCMClient) GetAvailableUpgrades(string) ([]string, error) {
+	return f.availableUpgrades, nil
+}
+
+func (f *fakeOCMClient) GetMissingGateAgreementsClassic(string, *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error) {
+	return f.gates, nil
+}
+
+func (f *fakeOCMClient) GetMissingGateAgreementsHypershift(string,
+	*cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error) {
+	return f.gates, nil
+}
+
+func (f *fakeOCMClient) FindMissingOperatorRolesForUpgrade(*cmv1.Cluster,
+	string) (map[string]*cmv1.STSOperator, error) {
+	return f.missingRoles, nil
+}
+
+func (f *fakeOCMClient) GetNodePools(string) ([]*cmv1.NodePool, error) {
+	return f.nodePools, nil
+}
+
+func (f *fakeOCMClient) IsVersionCloseToEol(int, string, string) error {
+	return f.eolErr
+}
+
+func (f *fakeOCMClient) GetLimitedSupportReasons(string) ([]*cmv1.LimitedSupportReason, error) {
+	return f.reasons, nil
+}
+
+func (f *fakeOCMClient) GetClusterAddOns(*cmv1.Cluster) ([]*ocm.ClusterAddOn, error) {
+	return f.addOns, f.addOnsErr
+}
+
+type fakeAWSClient struct {
+	upgradeNeeded bool
+}
+
+func (f *fakeAWSClient) IsUpgradedNeededForAccountRolePoliciesUsingCluster(*cmv1.Cluster, string) (bool, error) {
+	return f.upgradeNeeded, nil
+}
+
+func buildCluster(hypershift bool, sts bool) *cmv1.Cluster {
+	awsBuilder := cmv1.NewAWS()
+	if sts {
+		awsBuilder.STS(cmv1.NewSTS().RoleARN("arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"))
+	}
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		Name("mycluster").
+		State(cmv1.ClusterStateReady).
+		AWS(awsBuilder).
+		Hypershift(cmv1.NewHypershift().Enabled(hypershift)).
+		Version(cmv1.NewVersion().
+			ID("openshift-v4.14.12").
+			RawID("4.14.12").
+			ChannelGroup("stable").
+			AvailableUpgrades("4.14.13", "4.15.3")).
+		Build()
+	Expect(err).NotTo(HaveOccurred())
+	return cluster
+}
+
+func buildNodePool(id string, version string) *cmv1.NodePool {
+	nodePool, err := cmv1.NewNodePool().ID(id).Version(cmv1.NewVersion().ID("openshift-v" + version)).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return nodePool
+}
+
+func find(checklist *Checklist, name string) Check {
+	for _, check := range checklist.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	Fail(fmt.Sprintf("Checklist has no check '%s'", name))
+	return Check{}
+}
+
+var _ = Describe("Run", func() {
+	var ocmClient *fakeOCMClient
+	var awsClient *fakeAWSClient
+
+	BeforeEach(func() {
+		ocmClient = &fakeOCMClient{availableUpgrades: []string{"4.14.13", "4.15.3"}}
+		awsClient = &fakeAWSClient{}
+	})
+
+	It("Passes when the cluster is ready to be upgraded", func() {
+		checklist := Run(ocmClient, awsClient, buildCluster(false, true), "4.15.3")
+		Expect(checklist.Status).To(Equal(StatusPass))
+		Expect(checklist.FromVersion).To(Equal("4.14.12"))
+		names := []string{}
+		for _, check := range checklist.Checks {
+			names = append(names, check.Name)
+		}
+		Expect(names).To(Equal([]string{"Cluster state", "Available upgrade", "Version gates", "Account roles",
+			"Operator roles", "End of life", "Limited support", "Add-ons"}))
+	})
+
+	It("Skips the role checks for clusters without STS", func() {
+		checklist := Run(ocmClient, awsClient, buildCluster(false, false), "4.15.3")
+		Expect(checklist.Checks).To(HaveLen(6))
+	})
+
+	It("Fails when the version isn't an available upgrade", func() {
+		checklist := Run(ocmClient, awsClient, buildCluster(false, false), "4.16.0")
+		Expect(checklist.Status).To(Equal(StatusFail))
+		check := find(checklist, "Available upgrade")
+		Expect(check.Status).To(Equal(StatusFail))
+		Expect(check.Details).To(Equal([]string{"Available upgrades: 4.14.13, 4.15.3"}))
+		// Without an available upgrade the gates can't be checked:
+		for _, check := range checklist.Checks {
+			Expect(check.Name).NotTo(Equal("Version gates"))
+		}
+	})
+
+	It("Warns about the gates that have to be acknowledged", func() {
+		gate, err := cmv1.NewVersionGate().ID("g1").Description("Removed APIs").
+			DocumentationURL("https://example.com").Build()
+		Expect(err).NotTo(HaveOccurred())
+		stsGate, err := cmv1.NewVersionGate().ID("g2").STSOnly(true).Build()
+		Expect(err).NotTo(HaveOccurred())
+		ocmClient.gates = []*cmv1.VersionGate{gate, stsGate}
+		checklist := Run(ocmClient, awsClient, buildCluster(false, true), "4.15.3")
+		Expect(checklist.Status).To(Equal(StatusWarn))
+		check := find(checklist, "Version gates")
+		Expect(check.Status).To(Equal(StatusWarn))
+		Expect(check.Details).To(Equal([]string{"Removed APIs (https://example.com)"}))
+	})
+
+	It("Fails when the roles aren't compatible", func() {
+		awsClient.upgradeNeeded = true
+		operator, err := cmv1.NewSTSOperator().Namespace("openshift-ingress").Name("cloud-credentials").Build()
+		Expect(err).NotTo(HaveOccurred())
+		ocmClient.missingRoles = map[string]*cmv1.STSOperator{"ingress": operator}
+		checklist := Run(ocmClient, awsClient, buildCluster(false, true), "4.15.3")
+		Expect(find(checklist, "Account roles").Status).To(Equal(StatusFail))
+		operatorRoles := find(checklist, "Operator roles")
+		Expect(operatorRoles.Status).To(Equal(StatusFail))
+		Expect(operatorRoles.Details).To(Equal([]string{"openshift-ingress/cloud-credentials"}))
+		Expect(checklist.Count(StatusFail)).To(Equal(2))
+	})
+
+	It("Checks the version skew of the machine pools of hosted control planes", func() {
+		ocmClient.nodePools = []*cmv1.NodePool{
+			buildNodePool("workers", "4.14.12"),
+			buildNodePool("old", "4.13.5"),
+			buildNodePool("older", "4.12.30"),
+		}
+		checklist := Run(ocmClient, awsClient, buildCluster(true, false), "4.15.3")
+		check := find(checklist, "Machine pool version skew")
+		Expect(check.Status).To(Equal(StatusFail))
+		Expect(check.Details).To(Equal([]string{"Machine pool 'older' is in version '4.12.30'"}))
+	})
+
+	It("Warns about the version end of life, limited support and pending add-ons", func() {
+		ocmClient.eolErr = fmt.Errorf("Version is close to end of life")
+		reason, err := cmv1.NewLimitedSupportReason().Summary("Missing role").Build()
+		Expect(err).NotTo(HaveOccurred())
+		ocmClient.reasons = []*cmv1.LimitedSupportReason{reason}
+		ocmClient.addOns = []*ocm.ClusterAddOn{
+			{ID: "a", State: "ready"},
+			{ID: "b", State: "installing"},
+			{ID: "c", State: "not installed"},
+		}
+		checklist := Run(ocmClient, awsClient, buildCluster(false, false), "4.15.3")
+		Expect(checklist.Status).To(Equal(StatusWarn))
+		Expect(find(checklist, "End of life").Message).To(Equal("Version is close to end of life"))
+		Expect(find(checklist, "Limited support").Details).To(Equal([]string{"Missing role"}))
+		Expect(find(checklist, "Add-ons").Details).To(Equal([]string{"Add-on 'b' is in 'installing' state"}))
+	})
+
+	It("Fails when an add-on failed", func() {
+		ocmClient.addOns = []*ocm.ClusterAddOn{{ID: "a", State: "failed"}, {ID: "b", State: "pending"}}
+		checklist := Run(ocmClient, awsClient, buildCluster(false, false), "4.15.3")
+		Expect(find(checklist, "Add-ons").Status).To(Equal(StatusFail))
+		Expect(checklist.Status).To(Equal(StatusFail))
+	})
+
+	It("Warns when a check can't be verified", func() {
+		ocmClient.addOnsErr = fmt.Errorf("boom")
+		checklist := Run(ocmClient, awsClient, buildCluster(false, false), "4.15.3")
+		check := find(checklist, "Add-ons")
+		Expect(check.Status).To(Equal(StatusWarn))
+		Expect(check.Message).To(Equal("Couldn't be verified: failed to get the add-ons: boom"))
+	})
+})
+
+var _ = Describe("PrintChecklist", func() {
+	It("Prints a checklist", func() {
+		checklist := &Checklist{ClusterName: "mycluster", FromVersion: "4.14.12", Version: "4.15.3", Status: StatusPass}
+		checklist.add(Check{Name: "Cluster state", Status: StatusPass, Message: "Cluster is ready"})
+		checklist.add(Check{Name: "Limited support", Status: StatusWarn, Message: "In limited support",
+			Details: []string{"Missing role"}})
+		Expect(checklist.Status).To(Equal(StatusWarn))
+		Expect(PrintChecklist(checklist)).To(Equal("Upgrade of cluster 'mycluster' from version '4.14.12' to " +
+			"version '4.15.3':\n\n" +
+			"  [PASS]  Cluster state    Cluster is ready\n" +
+			"  [WARN]  Limited support  In limited support\n" +
+			"                           - Missing role\n" +
+			"\n1 passed, 1 warnings, 0 failed\n"))
+	})
+})
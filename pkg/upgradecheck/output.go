@@ -0,0 +1,46 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecheck
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PrintChecklist returns the checklist of the checklist, one line per check followed by its details.
+func PrintChecklist(checklist *Checklist) string {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Upgrade of cluster '%s' from version '%s' to version '%s':\n\n",
+		checklist.ClusterName, checklist.FromVersion, checklist.Version)
+	width := 0
+	for _, check := range checklist.Checks {
+		if len(check.Name) > width {
+			width = len(check.Name)
+		}
+	}
+	for _, check := range checklist.Checks {
+		fmt.Fprintf(&buffer, "  [%s]  %-*s  %s\n", strings.ToUpper(string(check.Status)), width, check.Name,
+			check.Message)
+		for _, detail := range check.Details {
+			fmt.Fprintf(&buffer, "  %*s  - %s\n", width+8, "", detail)
+		}
+	}
+	fmt.Fprintf(&buffer, "\n%d passed, %d warnings, %d failed\n",
+		checklist.Count(StatusPass), checklist.Count(StatusWarn), checklist.Count(StatusFail))
+	return buffer.String()
+}
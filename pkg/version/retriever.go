@@ -67,6 +67,9 @@ func (r retriever) RetrieveLatestVersionFromMirror() (*goVer.Version, error) {
 }
 
 func (r retriever) RetrievePossibleVersionsFromCache() ([]string, bool) {
+	if cache.Disabled() {
+		return []string{}, false
+	}
 	cachedVersions, hasCachedVersions := r.cache.Get(cache.VersionCacheKey)
 	if !hasCachedVersions {
 		return []string{}, false
@@ -111,8 +114,10 @@ func (r retriever) RetrievePossibleVersionsFromMirror() ([]string, error) {
 			}
 		})
 	})
-	if err := r.cache.Set(cache.VersionCacheKey, possibleVersions); err != nil {
-		r.logger.Debugf("Failed to set possible versions in cache : %v", err)
+	if !cache.Disabled() {
+		if err := r.cache.Set(cache.VersionCacheKey, possibleVersions); err != nil {
+			r.logger.Debugf("Failed to set possible versions in cache : %v", err)
+		}
 	}
 	r.logger.Debugf("Versions available for download: %v", possibleVersions)
 	return possibleVersions, nil
@@ -0,0 +1,217 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	ClusterKind     = "cluster"
+	MachinePoolKind = "machinepool"
+	AddOnKind       = "addon"
+	UpgradeKind     = "upgrade"
+
+	// ReplicasCondition is met when the machine pool has the requested number of nodes.
+	ReplicasCondition = "replicas=current"
+
+	// CompletedCondition is met when the scheduled upgrade of the cluster finished.
+	CompletedCondition = "completed"
+)
+
+// Kinds are the kinds of resources that can be waited for.
+var Kinds = []string{ClusterKind, MachinePoolKind, AddOnKind, UpgradeKind}
+
+// NewClusterResource returns the cluster with the given identifier or name.
+func NewClusterResource(r *rosa.Runtime, clusterKey string) *Resource {
+	return &Resource{
+		Kind: ClusterKind,
+		Name: clusterKey,
+		Get: func() (interface{}, error) {
+			cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+			if errors.GetType(err) == errors.NotFound {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return cluster, nil
+		},
+		Failed: func(resource interface{}) string {
+			cluster := resource.(*cmv1.Cluster)
+			switch cluster.State() {
+			case cmv1.ClusterStateError:
+				return fmt.Sprintf("its state is '%s': %s", cluster.State(), cluster.Status().ProvisionErrorMessage())
+			case cmv1.ClusterStateUninstalling:
+				return fmt.Sprintf("its state is '%s'", cluster.State())
+			}
+			return ""
+		},
+	}
+}
+
+// NewMachinePoolResource returns the machine pool, or node pool for Hosted Control Plane
+// clusters, with the given identifier.
+func NewMachinePoolResource(r *rosa.Runtime, cluster *cmv1.Cluster, id string) *Resource {
+	if cluster.Hypershift().Enabled() {
+		return &Resource{
+			Kind: MachinePoolKind,
+			Name: id,
+			Get: func() (interface{}, error) {
+				nodePool, exists, err := r.OCMClient.GetNodePool(cluster.ID(), id)
+				if err != nil || !exists {
+					return nil, err
+				}
+				return nodePool, nil
+			},
+			Conditions: map[string]func(interface{}) (bool, error){
+				ReplicasCondition: func(resource interface{}) (bool, error) {
+					if resource == nil {
+						return false, nil
+					}
+					return hasCurrentReplicas(resource.(*cmv1.NodePool)), nil
+				},
+			},
+		}
+	}
+	return &Resource{
+		Kind: MachinePoolKind,
+		Name: id,
+		Get: func() (interface{}, error) {
+			machinePool, exists, err := r.OCMClient.GetMachinePool(cluster.ID(), id)
+			if err != nil || !exists {
+				return nil, err
+			}
+			return machinePool, nil
+		},
+		Conditions: map[string]func(interface{}) (bool, error){
+			ReplicasCondition: func(interface{}) (bool, error) {
+				return false, fmt.Errorf("Condition '%s' is only supported for Hosted Control Planes",
+					ReplicasCondition)
+			},
+		},
+	}
+}
+
+func hasCurrentReplicas(nodePool *cmv1.NodePool) bool {
+	current, ok := nodePool.Status().GetCurrentReplicas()
+	if !ok {
+		return false
+	}
+	if autoscaling, ok := nodePool.GetAutoscaling(); ok {
+		return current >= autoscaling.MinReplica() && current <= autoscaling.MaxReplica()
+	}
+	return current == nodePool.Replicas()
+}
+
+// NewAddOnResource returns the installation of the add-on with the given identifier.
+func NewAddOnResource(r *rosa.Runtime, cluster *cmv1.Cluster, id string) *Resource {
+	return &Resource{
+		Kind: AddOnKind,
+		Name: id,
+		Get: func() (interface{}, error) {
+			addOn, err := r.OCMClient.GetAddOnInstallation(cluster.ID(), id)
+			if errors.GetType(err) == errors.NotFound {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return addOn, nil
+		},
+		Failed: func(resource interface{}) string {
+			addOn := resource.(*cmv1.AddOnInstallation)
+			if addOn.State() == cmv1.AddOnInstallationStateFailed {
+				return fmt.Sprintf("its state is '%s': %s", addOn.State(), addOn.StateDescription())
+			}
+			return ""
+		},
+	}
+}
+
+// NewUpgradeResource returns the scheduled upgrade of the cluster, or of the control plane for
+// Hosted Control Plane clusters. Upgrades are removed once they are completed, so their absence only
+// means that they completed when they were seen before, otherwise there is no upgrade to wait for.
+func NewUpgradeResource(r *rosa.Runtime, cluster *cmv1.Cluster) *Resource {
+	seen := false
+	return &Resource{
+		Kind: UpgradeKind,
+		Name: cluster.Name(),
+		Get: func() (interface{}, error) {
+			if cluster.Hypershift().Enabled() {
+				policy, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+				if err != nil || policy == nil {
+					return nil, err
+				}
+				return upgrade(policy.ID(), policy.Version(), policy.NextRun(), policy.State()), nil
+			}
+			policy, state, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+			if err != nil || policy == nil {
+				return nil, err
+			}
+			return upgrade(policy.ID(), policy.Version(), policy.NextRun(), state), nil
+		},
+		Failed: func(resource interface{}) string {
+			state := resource.(map[string]interface{})["state"]
+			switch state {
+			case string(cmv1.UpgradePolicyStateValueFailed), string(cmv1.UpgradePolicyStateValueCancelled):
+				return fmt.Sprintf("its state is '%s'", state)
+			}
+			return ""
+		},
+		Conditions: map[string]func(interface{}) (bool, error){
+			CompletedCondition: func(resource interface{}) (bool, error) {
+				if resource == nil {
+					return seen, nil
+				}
+				seen = true
+				return resource.(map[string]interface{})["state"] == string(cmv1.UpgradePolicyStateValueCompleted), nil
+			},
+		},
+	}
+}
+
+// NewMachinePoolUpgradeResource returns the scheduled upgrade of the machine pool of a Hosted Control
+// Plane cluster. Upgrades are removed once they are completed.
+func NewMachinePoolUpgradeResource(r *rosa.Runtime, cluster *cmv1.Cluster, id string) *Resource {
+	resource := NewUpgradeResource(r, cluster)
+	resource.Name = id
+	resource.Get = func() (interface{}, error) {
+		_, policy, err := r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), cluster.Name(), id)
+		if err != nil || policy == nil {
+			return nil, err
+		}
+		return upgrade(policy.ID(), policy.Version(), policy.NextRun(), policy.State()), nil
+	}
+	return resource
+}
+
+// upgrade returns the fields of the upgrade policies that can be used in conditions.
+func upgrade(id string, version string, nextRun time.Time, state *cmv1.UpgradePolicyState) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       id,
+		"version":  version,
+		"next_run": nextRun.Format(time.RFC3339),
+		"state":    string(state.Value()),
+	}
+}
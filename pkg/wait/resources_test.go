@@ -0,0 +1,57 @@
+package wait
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+var _ = Describe("Upgrade resource", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		DeferCleanup(cancel)
+	})
+
+	// newUpgrade returns an upgrade resource whose policy goes through the given states, nil
+	// meaning that there is no policy, and stays in the last one.
+	newUpgrade := func(states ...interface{}) *Resource {
+		cluster, err := cmv1.NewCluster().ID("123").Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		resource := NewUpgradeResource(nil, cluster)
+		resource.Get = func() (interface{}, error) {
+			state := states[0]
+			if len(states) > 1 {
+				states = states[1:]
+			}
+			if state == nil {
+				return nil, nil
+			}
+			return map[string]interface{}{"id": "456", "state": state}, nil
+		}
+		return resource
+	}
+
+	It("Completes when the scheduled upgrade is removed", func() {
+		resource := newUpgrade("scheduled", "started", nil)
+		Expect(For(ctx, resource, CompletedCondition, time.Millisecond)).To(Succeed())
+	})
+
+	It("Completes when the scheduled upgrade is completed", func() {
+		resource := newUpgrade("started", string(cmv1.UpgradePolicyStateValueCompleted))
+		Expect(For(ctx, resource, CompletedCondition, time.Millisecond)).To(Succeed())
+	})
+
+	It("Fails when no upgrade is scheduled", func() {
+		err := For(ctx, newUpgrade(nil), CompletedCondition, time.Millisecond)
+		Expect(err).To(MatchError("The upgrade 'mycluster' doesn't exist"))
+		Expect(reporter.Code(err)).To(Equal(reporter.ErrorCodeNotFound))
+	})
+})
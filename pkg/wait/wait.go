@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used by the 'wait' command to poll a resource until it meets a
+// condition.
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/openshift-online/ocm-sdk-go/helpers"
+
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/watch"
+)
+
+// DeleteCondition is met when the resource doesn't exist.
+const DeleteCondition = "delete"
+
+// Resource is a resource that can be waited for.
+type Resource struct {
+	Kind string
+	Name string
+
+	// Get returns the current state of the resource, or nil if it doesn't exist.
+	Get func() (interface{}, error)
+
+	// Failed returns a description of the state of the resource if it is in a state that it
+	// doesn't leave without user intervention, like the 'error' state of clusters. It isn't
+	// checked when waiting for the deletion of the resource.
+	Failed func(resource interface{}) string
+
+	// Conditions that are specific to the kind of resource, like 'replicas=current' for machine
+	// pools. The functions are called with nil if the resource doesn't exist.
+	Conditions map[string]func(resource interface{}) (bool, error)
+}
+
+// For polls the resource every interval until it meets the condition. It fails if the context
+// expires first, or if the resource doesn't exist or reaches a failed state. The condition is
+// either 'delete', one of the conditions specific to the kind of resource, or a field and its value
+// like 'state=ready'.
+func For(ctx context.Context, resource *Resource, condition string, interval time.Duration) error {
+	met, err := resource.condition(condition)
+	if err != nil {
+		return reporter.WithCode(reporter.ErrorCodeValidation, err)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return fmt.Errorf("A timeout is required to wait for %s '%s'", resource.Kind, resource.Name)
+	}
+
+	var done, missing bool
+	var failure string
+	var conditionErr error
+	_, err = helpers.PollContext(
+		ctx,
+		interval,
+		[]int{http.StatusOK, http.StatusNotFound},
+		[]func(interface{}) bool{
+			func(result interface{}) bool {
+				current := result.(*snapshot).resource
+				done, conditionErr = met(current)
+				if done || conditionErr != nil {
+					return true
+				}
+				if condition == DeleteCondition {
+					return false
+				}
+				if current == nil {
+					missing = true
+					return true
+				}
+				if resource.Failed != nil {
+					failure = resource.Failed(current)
+				}
+				return failure != ""
+			},
+		},
+		func(context.Context) (int, interface{}, error) {
+			current, err := resource.Get()
+			if err != nil {
+				return 0, nil, err
+			}
+			// The result is never nil, as the predicates aren't called for nil results:
+			if current == nil {
+				return http.StatusNotFound, &snapshot{}, nil
+			}
+			return http.StatusOK, &snapshot{resource: current}, nil
+		},
+	)
+	switch {
+	case done:
+		return nil
+	case conditionErr != nil:
+		return conditionErr
+	case missing:
+		return reporter.WithCode(reporter.ErrorCodeNotFound,
+			fmt.Errorf("The %s '%s' doesn't exist", resource.Kind, resource.Name))
+	case failure != "":
+		return fmt.Errorf("The %s '%s' can't meet condition '%s': %s",
+			resource.Kind, resource.Name, condition, failure)
+	case err != nil && ctx.Err() == nil:
+		return err
+	}
+	return reporter.WithCode(reporter.ErrorCodeTimeout,
+		fmt.Errorf("Timed out waiting for %s '%s' to meet condition '%s'", resource.Kind, resource.Name, condition))
+}
+
+type snapshot struct {
+	resource interface{}
+}
+
+func (r *Resource) condition(text string) (func(interface{}) (bool, error), error) {
+	if text == DeleteCondition {
+		return func(current interface{}) (bool, error) {
+			return current == nil, nil
+		}, nil
+	}
+	if condition, ok := r.Conditions[text]; ok {
+		return condition, nil
+	}
+	condition, err := watch.ParseCondition(text)
+	if err != nil {
+		return nil, err
+	}
+	if condition == nil {
+		return nil, fmt.Errorf("A condition is required to wait for %s '%s'", r.Kind, r.Name)
+	}
+	return func(current interface{}) (bool, error) {
+		if current == nil {
+			return false, nil
+		}
+		return condition.Met(current)
+	}, nil
+}
@@ -0,0 +1,244 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--watch', '--interval' and '--until' command
+// line options of the list and describe commands.
+
+package watch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/output"
+)
+
+const (
+	FlagName         = "watch"
+	IntervalFlagName = "interval"
+	UntilFlagName    = "until"
+
+	DefaultInterval = 30 * time.Second
+)
+
+var (
+	watch    bool
+	interval time.Duration
+	until    string
+)
+
+// AddFlag adds the watch flags to the given command.
+func AddFlag(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(
+		&watch,
+		FlagName,
+		false,
+		"Keep polling and print the rows that change, until interrupted or the '--until' condition is met.",
+	)
+	flags.DurationVar(
+		&interval,
+		IntervalFlagName,
+		DefaultInterval,
+		"Time between polls in watch mode.",
+	)
+	flags.StringVar(
+		&until,
+		UntilFlagName,
+		"",
+		"Stop watching when the given field of the resource, or of all the resources of the list, has "+
+			"the given value. For example 'state=ready'. Fields use the JSON names of the '--output json' "+
+			"format, and nested fields are separated with dots.",
+	)
+}
+
+// Enabled returns true if the watch mode is enabled.
+func Enabled() bool {
+	return watch
+}
+
+func SetEnabled(value bool) {
+	watch = value
+}
+
+func SetInterval(value time.Duration) {
+	interval = value
+}
+
+func SetUntil(value string) {
+	until = value
+}
+
+// Validate checks that the watch flags are consistent with each other and with the output flag.
+func Validate() error {
+	if until != "" && !watch {
+		return fmt.Errorf("The '--%s' option requires '--%s'", UntilFlagName, FlagName)
+	}
+	if !watch {
+		return nil
+	}
+	if output.HasFlag() {
+		return fmt.Errorf("The '--%s' option can't be used with '--%s'", FlagName, output.FLAG_NAME)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("The '--%s' option must be a positive duration", IntervalFlagName)
+	}
+	_, err := ParseCondition(until)
+	if err != nil {
+		return fmt.Errorf("Invalid '--%s' option: %v", UntilFlagName, err)
+	}
+	return nil
+}
+
+// RenderFunc writes the current state of the resources to the writer, and returns them so that the
+// '--until' condition can be checked.
+type RenderFunc func(writer io.Writer) (interface{}, error)
+
+// Run calls the render function every interval and prints the lines of its result that changed
+// since the previous call, until the '--until' condition is met or the render function fails.
+func Run(render RenderFunc) error {
+	return run(os.Stdout, render, time.Sleep)
+}
+
+func run(stdout io.Writer, render RenderFunc, sleep func(time.Duration)) error {
+	condition, err := ParseCondition(until)
+	if err != nil {
+		return err
+	}
+	previous := map[string]string{}
+	for {
+		var buffer bytes.Buffer
+		resource, err := render(&buffer)
+		if err != nil {
+			return err
+		}
+		for _, line := range changedLines(previous, buffer.String()) {
+			fmt.Fprintln(stdout, line)
+		}
+
+		if condition != nil {
+			met, err := condition.Met(resource)
+			if err != nil {
+				return err
+			}
+			if met {
+				return nil
+			}
+		}
+		sleep(interval)
+	}
+}
+
+// changedLines returns the lines of the text that changed since the previous rendering, and
+// updates it. Lines are identified by the label before the colon of the descriptions, or by the
+// first column of the tables, so a line is printed again only when one of its values changes.
+// Changes in the alignment of the columns and blank lines are ignored.
+func changedLines(previous map[string]string, text string) []string {
+	var result []string
+	occurrences := map[string]int{}
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		key := fields[0]
+		if label, _, found := strings.Cut(line, ": "); found {
+			key = strings.TrimSpace(label)
+		} else if strings.HasSuffix(line, ":") {
+			key = strings.TrimSpace(line)
+		}
+		// Some labels, like the ones of the lists of the descriptions, are repeated:
+		occurrences[key]++
+		key = fmt.Sprintf("%s#%d", key, occurrences[key])
+
+		normalized := strings.Join(fields, " ")
+		if previous[key] != normalized {
+			result = append(result, strings.TrimRight(line, " "))
+			previous[key] = normalized
+		}
+	}
+	return result
+}
+
+// Condition is a field and the value that it must have, like 'state=ready'. Fields use the JSON
+// names of the '--output json' format, and nested fields are separated with dots.
+type Condition struct {
+	Field string
+	Value string
+	path  *output.JSONPath
+}
+
+// ParseCondition parses a condition like 'state=ready'. It returns nil if the text is empty.
+func ParseCondition(text string) (*Condition, error) {
+	if text == "" {
+		return nil, nil
+	}
+	field, value, found := strings.Cut(text, "=")
+	field = strings.TrimPrefix(strings.TrimSpace(field), ".")
+	if !found || field == "" {
+		return nil, fmt.Errorf("Invalid condition '%s', expected 'field=value'", text)
+	}
+	path, err := output.ParseJSONPath("{." + field + "}")
+	if err != nil {
+		return nil, fmt.Errorf("Invalid condition '%s': %v", text, err)
+	}
+	return &Condition{Field: field, Value: strings.TrimSpace(value), path: path}, nil
+}
+
+// String returns the condition as it was given.
+func (c *Condition) String() string {
+	return fmt.Sprintf("%s=%s", c.Field, c.Value)
+}
+
+// Met returns true if the field has the expected value in the resource or, for lists, in all of
+// the resources of the list. Empty lists never meet the condition.
+func (c *Condition) Met(resource interface{}) (bool, error) {
+	data, err := output.Marshal(resource)
+	if err != nil {
+		return false, err
+	}
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&decoded)
+	if err != nil {
+		return false, err
+	}
+	items, isList := decoded.([]interface{})
+	if !isList {
+		items = []interface{}{decoded}
+	}
+	if len(items) == 0 {
+		return false, nil
+	}
+	for _, item := range items {
+		value, err := c.path.Execute(item)
+		if err != nil {
+			return false, err
+		}
+		if value != c.Value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
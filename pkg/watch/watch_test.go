@@ -0,0 +1,117 @@
+package watch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/output"
+)
+
+var _ = Describe("Watch", func() {
+	AfterEach(func() {
+		SetEnabled(false)
+		SetInterval(DefaultInterval)
+		SetUntil("")
+		output.SetOutput("")
+	})
+
+	It("Adds the flags to the command", func() {
+		cmd := &cobra.Command{}
+		AddFlag(cmd)
+		Expect(cmd.Flag(FlagName)).NotTo(BeNil())
+		Expect(cmd.Flag(IntervalFlagName).DefValue).To(Equal("30s"))
+		Expect(cmd.Flag(UntilFlagName)).NotTo(BeNil())
+	})
+
+	DescribeTable("Validates the flags",
+		func(enabled bool, until string, format string, expected string) {
+			SetEnabled(enabled)
+			SetUntil(until)
+			output.SetOutput(format)
+			err := Validate()
+			if expected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+		Entry("Disabled", false, "", "", ""),
+		Entry("Enabled", true, "state=ready", "", ""),
+		Entry("Until without watch", false, "state=ready", "", "requires '--watch'"),
+		Entry("Watch with output", true, "", "json", "can't be used with '--output'"),
+		Entry("Invalid condition", true, "state", "", "expected 'field=value'"),
+	)
+
+	It("Prints only the lines that change", func() {
+		renders := []string{
+			"ID  NAME  STATE\n123  a  installing\n456  b  ready\n",
+			"ID  NAME  STATE\n123  a  installing\n456  b  ready\n",
+			"ID  NAME        STATE\n123  a           ready\n456  longer-name ready\n",
+		}
+		calls := 0
+		var stdout bytes.Buffer
+		SetInterval(time.Minute)
+		var sleeps []time.Duration
+		err := run(&stdout, func(writer io.Writer) (interface{}, error) {
+			if calls == len(renders) {
+				return nil, fmt.Errorf("done")
+			}
+			fmt.Fprint(writer, renders[calls])
+			calls++
+			return nil, nil
+		}, func(interval time.Duration) {
+			sleeps = append(sleeps, interval)
+		})
+		Expect(err).To(MatchError("done"))
+		Expect(stdout.String()).To(Equal("ID  NAME  STATE\n123  a  installing\n456  b  ready\n" +
+			"123  a           ready\n456  longer-name ready\n"))
+		Expect(sleeps).To(Equal([]time.Duration{time.Minute, time.Minute, time.Minute}))
+	})
+
+	It("Tells apart repeated labels of descriptions", func() {
+		previous := map[string]string{}
+		changedLines(previous, "Nodes:\n - Control plane: 3\n - Compute: 2\nState: installing\n")
+		Expect(changedLines(previous, "Nodes:\n - Control plane: 3\n - Compute: 3\nState: ready\n")).To(
+			Equal([]string{" - Compute: 3", "State: ready"}))
+	})
+
+	It("Stops when all the resources meet the condition", func() {
+		states := []cmv1.ClusterState{cmv1.ClusterStateInstalling, cmv1.ClusterStateReady}
+		SetUntil("state=ready")
+		for calls := 0; calls < 3; calls++ {
+			clusters := []*cmv1.Cluster{}
+			for i := 0; i < calls && i < len(states); i++ {
+				cluster, err := cmv1.NewCluster().ID(fmt.Sprint(i)).State(states[i]).Build()
+				Expect(err).NotTo(HaveOccurred())
+				clusters = append(clusters, cluster)
+			}
+			condition, err := ParseCondition(until)
+			Expect(err).NotTo(HaveOccurred())
+			met, err := condition.Met(clusters)
+			Expect(err).NotTo(HaveOccurred())
+			// An empty list or a list with a cluster that isn't ready doesn't meet the condition:
+			Expect(met).To(BeFalse())
+		}
+
+		cluster, err := cmv1.NewCluster().ID("123").State(cmv1.ClusterStateReady).
+			Nodes(cmv1.NewClusterNodes().Compute(3)).Build()
+		Expect(err).NotTo(HaveOccurred())
+		SetUntil("nodes.compute=3")
+		var stdout bytes.Buffer
+		err = run(&stdout, func(writer io.Writer) (interface{}, error) {
+			fmt.Fprintln(writer, "State: ready")
+			return cluster, nil
+		}, func(time.Duration) {
+			Fail("Shouldn't wait when the condition is met")
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout.String()).To(Equal("State: ready\n"))
+	})
+})